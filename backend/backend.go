@@ -0,0 +1,303 @@
+/*
+Package backend defines the interface of the engines which perform the basic
+linear algebra operations of gocrunch, such as dot products, matrix products
+and matrix factorizations, and selects the engine that is used.
+
+The default engine, Native, is written in pure Go. Other engines, such as
+bindings to an optimized BLAS library, implement the Backend interface and are
+made available with Register(). The engine used by gocrunch is then selected
+globally, without changing any call sites:
+
+	if err := backend.Use("blas"); err != nil {
+		log.Fatal(err)
+	}
+	p := mat.Dot(m, n) // now computed by the "blas" engine
+
+The functions of this package validate their arguments, and dispatch to the
+engine selected with Use() or SetCurrent(). To select the engine of a single
+call instead, pass it as the optional last argument:
+
+	blas, _ := backend.Lookup("blas")
+	d := backend.Dot(x, y, blas)
+
+Invalid arguments, such as vectors of different lengths, are treated as
+critical errors, and cause a panic with a message that names the offending
+function, as with the other packages in gocrunch.
+*/
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/backend error.\nIn backend.%s, the length of the passed slices does not match: %d and %d.\n",
+		"\ngocrunch/backend error.\nIn backend.%s, the dimensions of the %s do not match: %d by %d, and %d by %d.\n",
+		"\ngocrunch/backend error.\nIn backend.%s, a backend named %q is already registered.\n",
+		"\ngocrunch/backend error.\nIn backend.%s, expected at most 1 Backend, but received %d.\n",
+		"\ngocrunch/backend error.\nIn backend.%s, the Backend cannot be nil.\n",
+		"\ngocrunch/backend error.\nIn backend.%s, the matrix must be square, but it is %d by %d.\n",
+	}
+)
+
+// ErrUnknown is returned by Use when no backend with the passed name is
+// registered.
+var ErrUnknown = errors.New("backend: unknown backend")
+
+/*
+Backend is an engine for the basic linear algebra operations. Matrices are
+[][]float64 whose rows all have the same length. The methods of a Backend
+may assume that the dimensions of their arguments are valid, since the
+functions of this package check them before calling the methods.
+*/
+type Backend interface {
+	// Name returns the name the Backend is registered under.
+	Name() string
+	// Dot returns the dot product of x and y.
+	Dot(x, y []float64) float64
+	// Axpy stores alpha*x + y in y.
+	Axpy(alpha float64, x, y []float64)
+	// Scal stores alpha*x in x.
+	Scal(alpha float64, x []float64)
+	// Gemv stores alpha*op(a)*x + beta*y in y, where op(a) is a, or its
+	// transpose if trans is true.
+	Gemv(trans bool, alpha float64, a [][]float64, x []float64, beta float64, y []float64)
+	// Gemm stores alpha*op(a)*op(b) + beta*c in c, where op(a) is a, or its
+	// transpose if transA is true, and likewise for b.
+	Gemm(transA, transB bool, alpha float64, a, b [][]float64, beta float64, c [][]float64)
+	// Getrf overwrites the square a with its LU factorization with partial
+	// pivoting: the multipliers of the unit lower factor below the
+	// diagonal, and the upper factor on and above it. Row k was
+	// interchanged with row ipiv[k] before the elimination of column k.
+	// Getrf returns -1, or the first column with no pivot if a is singular,
+	// in which case the contents of a are unspecified.
+	Getrf(a [][]float64, ipiv []int) int
+	// Potrf overwrites the lower half of the square a with the lower
+	// factor l for which a = l*T(l), reading only that half, and leaving
+	// the upper half alone. Potrf returns -1, or the first row whose pivot
+	// is not positive if a is not positive definite, in which case the
+	// contents of the lower half are unspecified.
+	Potrf(a [][]float64) int
+}
+
+var (
+	registry   = map[string]Backend{}
+	registryMu sync.RWMutex
+	current    atomic.Pointer[Backend]
+)
+
+func init() {
+	var native Backend = Native{}
+	Register(native)
+	current.Store(&native)
+}
+
+/*
+Register makes the passed Backend available under its name, for use with
+Use() and Lookup(). It is meant to be called from the init function of the
+package implementing the Backend. Registering a nil Backend, or two
+backends with the same name, causes a panic.
+*/
+func Register(b Backend) {
+	if b == nil {
+		panic(fmt.Sprintf(errStrings[4], "Register()"))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[b.Name()]; ok {
+		panic(fmt.Sprintf(errStrings[2], "Register()", b.Name()))
+	}
+	registry[b.Name()] = b
+}
+
+// Lookup returns the Backend registered under the passed name, and whether
+// there is one.
+func Lookup(name string) (Backend, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	b, ok := registry[name]
+	return b, ok
+}
+
+// Names returns the names of the registered backends, in sorted order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+Use selects the Backend registered under the passed name for all of the
+following operations which do not name their own. If there is no such
+Backend, ErrUnknown is returned, and the current Backend is kept.
+*/
+func Use(name string) error {
+	b, ok := Lookup(name)
+	if !ok {
+		return fmt.Errorf("%w: %q, registered backends are %v", ErrUnknown, name, Names())
+	}
+	current.Store(&b)
+	return nil
+}
+
+// SetCurrent selects the passed Backend, which need not be registered, for
+// all of the following operations which do not name their own.
+func SetCurrent(b Backend) {
+	if b == nil {
+		panic(fmt.Sprintf(errStrings[4], "SetCurrent()"))
+	}
+	current.Store(&b)
+}
+
+// Current returns the Backend selected for the operations which do not
+// name their own.
+func Current() Backend {
+	return *current.Load()
+}
+
+func pick(fn string, b []Backend) Backend {
+	switch len(b) {
+	case 0:
+		return Current()
+	case 1:
+		if b[0] == nil {
+			panic(fmt.Sprintf(errStrings[4], fn))
+		}
+		return b[0]
+	default:
+		panic(fmt.Sprintf(errStrings[3], fn, len(b)))
+	}
+}
+
+/*
+Dot returns the dot product of x and y, which must have the same length,
+computed by the passed Backend, or the current one if none is passed.
+*/
+func Dot(x, y []float64, b ...Backend) float64 {
+	be := pick("Dot()", b)
+	if len(x) != len(y) {
+		panic(fmt.Sprintf(errStrings[0], "Dot()", len(x), len(y)))
+	}
+	return be.Dot(x, y)
+}
+
+/*
+Axpy stores alpha*x + y in y, which must have the length of x, using the
+passed Backend, or the current one if none is passed.
+*/
+func Axpy(alpha float64, x, y []float64, b ...Backend) {
+	be := pick("Axpy()", b)
+	if len(x) != len(y) {
+		panic(fmt.Sprintf(errStrings[0], "Axpy()", len(x), len(y)))
+	}
+	be.Axpy(alpha, x, y)
+}
+
+/*
+Scal stores alpha*x in x, using the passed Backend, or the current one if
+none is passed.
+*/
+func Scal(alpha float64, x []float64, b ...Backend) {
+	pick("Scal()", b).Scal(alpha, x)
+}
+
+/*
+Gemv stores alpha*op(a)*x + beta*y in y, where op(a) is a, or its transpose if
+trans is true, using the passed Backend, or the current one if none is
+passed. The number of columns of op(a) must be the length of x, and its
+number of rows the length of y.
+*/
+func Gemv(trans bool, alpha float64, a [][]float64, x []float64, beta float64, y []float64, b ...Backend) {
+	be := pick("Gemv()", b)
+	r, c := dims(a, trans)
+	if c != len(x) || r != len(y) {
+		panic(fmt.Sprintf(errStrings[1], "Gemv()", "matrix and vectors", r, c, len(x), len(y)))
+	}
+	be.Gemv(trans, alpha, a, x, beta, y)
+}
+
+/*
+Gemm stores alpha*op(a)*op(b) + beta*c in c, where op(a) is a, or its
+transpose if transA is true, and likewise for b, using the passed Backend, or
+the current one if none is passed. The number of columns of op(a) must be
+the number of rows of op(b), and c must have the number of rows of op(a) and
+the number of columns of op(b).
+*/
+func Gemm(transA, transB bool, alpha float64, a, bm [][]float64, beta float64, c [][]float64, b ...Backend) {
+	be := pick("Gemm()", b)
+	ar, ac := dims(a, transA)
+	br, bc := dims(bm, transB)
+	if ac != br {
+		panic(fmt.Sprintf(errStrings[1], "Gemm()", "factors", ar, ac, br, bc))
+	}
+	cr, cc := dims(c, false)
+	if cr != ar || cc != bc {
+		panic(fmt.Sprintf(errStrings[1], "Gemm()", "product and result", ar, bc, cr, cc))
+	}
+	be.Gemm(transA, transB, alpha, a, bm, beta, c)
+}
+
+// dims returns the number of rows and columns of a, or of its transpose if
+// trans is true.
+func dims(a [][]float64, trans bool) (int, int) {
+	r, c := len(a), 0
+	if r > 0 {
+		c = len(a[0])
+	}
+	if trans {
+		return c, r
+	}
+	return r, c
+}
+
+/*
+Getrf overwrites the square a with its LU factorization with partial
+pivoting, using the passed Backend, or the current one if none is passed.
+Below the diagonal, a holds the multipliers of the unit lower factor, and on
+and above it the upper factor. Row k was interchanged with row ipiv[k],
+which must have the length of a, before the elimination of column k.
+
+Getrf returns -1, or the first column with no pivot if a is singular, in
+which case the contents of a are unspecified.
+*/
+func Getrf(a [][]float64, ipiv []int, b ...Backend) int {
+	be := pick("Getrf()", b)
+	checkSquare("Getrf()", a)
+	if len(ipiv) != len(a) {
+		panic(fmt.Sprintf(errStrings[0], "Getrf()", len(a), len(ipiv)))
+	}
+	return be.Getrf(a, ipiv)
+}
+
+/*
+Potrf overwrites the lower half of the square a with the lower factor l of
+its Cholesky factorization, a = l*T(l), using the passed Backend, or the
+current one if none is passed. Only the lower half of a is read, and its
+upper half is not altered.
+
+Potrf returns -1, or the first row whose pivot is not positive if a is not
+positive definite, in which case the contents of the lower half are
+unspecified.
+*/
+func Potrf(a [][]float64, b ...Backend) int {
+	be := pick("Potrf()", b)
+	checkSquare("Potrf()", a)
+	return be.Potrf(a)
+}
+
+// checkSquare panics if a is not square.
+func checkSquare(fn string, a [][]float64) {
+	if r, c := dims(a, false); r != c {
+		panic(fmt.Sprintf(errStrings[5], fn, r, c))
+	}
+}
@@ -0,0 +1,102 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// counting is a Backend which counts the calls it receives, and forwards
+// them to Native.
+type counting struct {
+	Native
+	name  string
+	calls int
+}
+
+func (c *counting) Name() string { return c.name }
+
+func (c *counting) Dot(x, y []float64) float64 {
+	c.calls++
+	return c.Native.Dot(x, y)
+}
+
+func TestRegistry(t *testing.T) {
+	if Current().Name() != "native" {
+		t.Errorf("expected the native backend by default, got %s", Current().Name())
+	}
+	c := &counting{name: "counting"}
+	Register(c)
+	if b, ok := Lookup("counting"); !ok || b != Backend(c) {
+		t.Errorf("expected to find the registered backend, got %v", b)
+	}
+	if names := Names(); len(names) != 2 || names[0] != "counting" || names[1] != "native" {
+		t.Errorf("expected {counting, native}, got %v", names)
+	}
+	if err := Use("missing"); !errors.Is(err, ErrUnknown) {
+		t.Errorf("expected ErrUnknown, got %v", err)
+	}
+	if err := Use("counting"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	Dot([]float64{1.0}, []float64{2.0})
+	SetCurrent(Native{})
+	// The backend of a single call overrides the current one.
+	if d := Dot([]float64{1.0, 2.0}, []float64{3.0, 4.0}, c); d != 11.0 {
+		t.Errorf("expected 11.0, got %v", d)
+	}
+	Dot([]float64{1.0}, []float64{2.0})
+	if c.calls != 2 {
+		t.Errorf("expected 2 calls to the counting backend, got %d", c.calls)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[2], "Register()", "native")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Register(Native{})
+	}()
+	wg.Wait()
+}
+
+func TestChecks(t *testing.T) {
+	cases := []struct {
+		expectedErr string
+		f           func()
+	}{
+		{fmt.Sprintf(errStrings[0], "Dot()", 2, 1), func() { Dot([]float64{1, 2}, []float64{1}) }},
+		{fmt.Sprintf(errStrings[0], "Axpy()", 1, 2), func() { Axpy(1, []float64{1}, []float64{1, 2}) }},
+		{fmt.Sprintf(errStrings[1], "Gemv()", "matrix and vectors", 2, 3, 2, 2), func() {
+			Gemv(false, 1, [][]float64{{1, 2, 3}, {4, 5, 6}}, []float64{1, 2}, 0, []float64{0, 0})
+		}},
+		{fmt.Sprintf(errStrings[1], "Gemm()", "factors", 1, 2, 1, 2), func() {
+			Gemm(false, false, 1, [][]float64{{1, 2}}, [][]float64{{1, 2}}, 0, [][]float64{{0}})
+		}},
+		{fmt.Sprintf(errStrings[3], "Scal()", 2), func() { Scal(1, []float64{1}, Native{}, Native{}) }},
+		{fmt.Sprintf(errStrings[5], "Getrf()", 1, 2), func() { Getrf([][]float64{{1, 2}}, []int{0}) }},
+		{fmt.Sprintf(errStrings[0], "Getrf()", 2, 1), func() { Getrf([][]float64{{1, 2}, {3, 4}}, []int{0}) }},
+		{fmt.Sprintf(errStrings[5], "Potrf()", 2, 1), func() { Potrf([][]float64{{1}, {2}}) }},
+	}
+	var wg sync.WaitGroup
+	for _, c := range cases {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != c.expectedErr {
+					t.Errorf("Expected %s, got %v", c.expectedErr, r)
+				}
+				wg.Done()
+			}()
+			c.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,230 @@
+//go:build cblas
+
+package blas
+
+/*
+#cgo linux LDFLAGS: -lopenblas
+#cgo darwin CFLAGS: -DACCELERATE_NEW_LAPACK
+#cgo darwin LDFLAGS: -framework Accelerate
+#ifdef __APPLE__
+#include <Accelerate/Accelerate.h>
+typedef __LAPACK_int gc_lapack_int;
+#else
+#include <cblas.h>
+#include <lapacke.h>
+typedef lapack_int gc_lapack_int;
+#endif
+
+// The wrappers take plain ints, since the integer type of the CBLAS
+// interface differs between libraries.
+
+static double gc_ddot(int n, const double *x, const double *y) {
+	return cblas_ddot(n, x, 1, y, 1);
+}
+
+static void gc_daxpy(int n, double alpha, const double *x, double *y) {
+	cblas_daxpy(n, alpha, x, 1, y, 1);
+}
+
+static void gc_dscal(int n, double alpha, double *x) {
+	cblas_dscal(n, alpha, x, 1);
+}
+
+static void gc_dgemv(int trans, int m, int n, double alpha, const double *a,
+		const double *x, double beta, double *y) {
+	cblas_dgemv(CblasRowMajor, trans ? CblasTrans : CblasNoTrans, m, n,
+		alpha, a, n, x, 1, beta, y, 1);
+}
+
+static void gc_dgemm(int transA, int transB, int m, int n, int k,
+		double alpha, const double *a, int lda, const double *b, int ldb,
+		double beta, double *c, int ldc) {
+	cblas_dgemm(CblasRowMajor, transA ? CblasTrans : CblasNoTrans,
+		transB ? CblasTrans : CblasNoTrans, m, n, k, alpha, a, lda, b, ldb,
+		beta, c, ldc);
+}
+
+// The factorizations take column major matrices, which is the order of the
+// LAPACK routines of Accelerate, and return the info of LAPACK.
+
+static gc_lapack_int gc_dgetrf(gc_lapack_int n, double *a, gc_lapack_int *ipiv) {
+#ifdef __APPLE__
+	gc_lapack_int info;
+	dgetrf_(&n, &n, a, &n, ipiv, &info);
+	return info;
+#else
+	return LAPACKE_dgetrf(LAPACK_COL_MAJOR, n, n, a, n, ipiv);
+#endif
+}
+
+static gc_lapack_int gc_dpotrf(gc_lapack_int n, double *a) {
+#ifdef __APPLE__
+	gc_lapack_int info;
+	char uplo = 'L';
+	dpotrf_(&uplo, &n, a, &n, &info);
+	return info;
+#else
+	return LAPACKE_dpotrf(LAPACK_COL_MAJOR, 'L', n, a, n);
+#endif
+}
+*/
+import "C"
+
+import (
+	"github.com/NDari/gocrunch/backend"
+)
+
+func init() {
+	backend.Register(Backend{})
+}
+
+// Backend is the CBLAS Backend, registered under Name.
+type Backend struct{}
+
+// Name returns Name.
+func (Backend) Name() string {
+	return Name
+}
+
+// Dot returns the dot product of x and y.
+func (Backend) Dot(x, y []float64) float64 {
+	if len(x) == 0 {
+		return 0.0
+	}
+	return float64(C.gc_ddot(C.int(len(x)), ptr(x), ptr(y)))
+}
+
+// Axpy stores alpha*x + y in y.
+func (Backend) Axpy(alpha float64, x, y []float64) {
+	if len(x) == 0 {
+		return
+	}
+	C.gc_daxpy(C.int(len(x)), C.double(alpha), ptr(x), ptr(y))
+}
+
+// Scal stores alpha*x in x.
+func (Backend) Scal(alpha float64, x []float64) {
+	if len(x) == 0 {
+		return
+	}
+	C.gc_dscal(C.int(len(x)), C.double(alpha), ptr(x))
+}
+
+// Gemv stores alpha*op(a)*x + beta*y in y.
+func (Backend) Gemv(trans bool, alpha float64, a [][]float64, x []float64, beta float64, y []float64) {
+	if len(a) == 0 || len(a[0]) == 0 {
+		backend.Native{}.Gemv(trans, alpha, a, x, beta, y)
+		return
+	}
+	buf := pack(a)
+	C.gc_dgemv(flag(trans), C.int(len(a)), C.int(len(a[0])), C.double(alpha), ptr(buf),
+		ptr(x), C.double(beta), ptr(y))
+}
+
+// Gemm stores alpha*op(a)*op(b) + beta*c in c.
+func (Backend) Gemm(transA, transB bool, alpha float64, a, b [][]float64, beta float64, c [][]float64) {
+	if len(a) == 0 || len(a[0]) == 0 || len(c) == 0 || len(c[0]) == 0 {
+		backend.Native{}.Gemm(transA, transB, alpha, a, b, beta, c)
+		return
+	}
+	m, n := len(c), len(c[0])
+	k := len(a[0])
+	if transA {
+		k = len(a)
+	}
+	ab, bb, cb := pack(a), pack(b), pack(c)
+	C.gc_dgemm(flag(transA), flag(transB), C.int(m), C.int(n), C.int(k), C.double(alpha),
+		ptr(ab), C.int(len(a[0])), ptr(bb), C.int(len(b[0])), C.double(beta), ptr(cb), C.int(n))
+	for i := range c {
+		copy(c[i], cb[i*n:(i+1)*n])
+	}
+}
+
+// Getrf overwrites a with its LU factorization with partial pivoting.
+func (Backend) Getrf(a [][]float64, ipiv []int) int {
+	n := len(a)
+	if n == 0 {
+		return -1
+	}
+	buf := packCols(a)
+	piv := make([]C.gc_lapack_int, n)
+	info := C.gc_dgetrf(C.gc_lapack_int(n), ptr(buf), &piv[0])
+	unpackCols(a, buf, false)
+	// The pivots of LAPACK are 1-based.
+	for i, p := range piv {
+		ipiv[i] = int(p) - 1
+	}
+	return index(info)
+}
+
+// Potrf overwrites the lower half of a with its Cholesky factor.
+func (Backend) Potrf(a [][]float64) int {
+	n := len(a)
+	if n == 0 {
+		return -1
+	}
+	buf := packCols(a)
+	info := C.gc_dpotrf(C.gc_lapack_int(n), ptr(buf))
+	unpackCols(a, buf, true)
+	return index(info)
+}
+
+// pack returns the elements of a in a contiguous []float64, in row major
+// order.
+func pack(a [][]float64) []float64 {
+	cols := len(a[0])
+	buf := make([]float64, len(a)*cols)
+	for i := range a {
+		copy(buf[i*cols:], a[i])
+	}
+	return buf
+}
+
+// packCols returns the elements of the square a in a contiguous []float64,
+// in column major order.
+func packCols(a [][]float64) []float64 {
+	n := len(a)
+	buf := make([]float64, n*n)
+	for i := range a {
+		for j, x := range a[i] {
+			buf[j*n+i] = x
+		}
+	}
+	return buf
+}
+
+// unpackCols copies the column major buf back into the square a, or only
+// into its lower half if lower is true.
+func unpackCols(a [][]float64, buf []float64, lower bool) {
+	n := len(a)
+	for i := range a {
+		cols := n
+		if lower {
+			cols = i + 1
+		}
+		for j := 0; j < cols; j++ {
+			a[i][j] = buf[j*n+i]
+		}
+	}
+}
+
+// index returns the 0-based row or column reported by a positive LAPACK
+// info, or -1. The arguments are checked by the backend package, so the info
+// is never negative.
+func index(info C.gc_lapack_int) int {
+	if info > 0 {
+		return int(info) - 1
+	}
+	return -1
+}
+
+func ptr(v []float64) *C.double {
+	return (*C.double)(&v[0])
+}
+
+func flag(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
+}
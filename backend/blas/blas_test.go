@@ -0,0 +1,132 @@
+//go:build cblas
+
+package blas
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/NDari/gocrunch/backend"
+)
+
+func randMat(r, c int, src *rand.Rand) [][]float64 {
+	m := make([][]float64, r)
+	for i := range m {
+		m[i] = make([]float64, c)
+		for j := range m[i] {
+			m[i][j] = src.NormFloat64()
+		}
+	}
+	return m
+}
+
+func clone(m [][]float64) [][]float64 {
+	c := make([][]float64, len(m))
+	for i := range m {
+		c[i] = append([]float64(nil), m[i]...)
+	}
+	return c
+}
+
+func TestRegistered(t *testing.T) {
+	if b, ok := backend.Lookup(Name); !ok || b.Name() != Name {
+		t.Errorf("expected the %s backend to be registered", Name)
+	}
+}
+
+func TestMatchesNative(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	b, n := Backend{}, backend.Native{}
+	x, y := randMat(1, 7, src)[0], randMat(1, 7, src)[0]
+	if d, e := backend.Dot(x, y, b), backend.Dot(x, y, n); math.Abs(d-e) > 1e-12 {
+		t.Errorf("Dot: expected %v, got %v", e, d)
+	}
+	y1, y2 := append([]float64(nil), y...), append([]float64(nil), y...)
+	backend.Axpy(0.5, x, y1, b)
+	backend.Axpy(0.5, x, y2, n)
+	for i := range y1 {
+		if math.Abs(y1[i]-y2[i]) > 1e-12 {
+			t.Fatalf("Axpy: expected %v, got %v", y2, y1)
+		}
+	}
+	a := randMat(7, 4, src)
+	for _, trans := range []bool{false, true} {
+		in, out := randMat(1, 4, src)[0], randMat(1, 7, src)[0]
+		if trans {
+			in, out = randMat(1, 7, src)[0], randMat(1, 4, src)[0]
+		}
+		o1, o2 := append([]float64(nil), out...), append([]float64(nil), out...)
+		backend.Gemv(trans, 1.5, a, in, -0.5, o1, b)
+		backend.Gemv(trans, 1.5, a, in, -0.5, o2, n)
+		for i := range o1 {
+			if math.Abs(o1[i]-o2[i]) > 1e-12 {
+				t.Fatalf("Gemv, trans %v: expected %v, got %v", trans, o2, o1)
+			}
+		}
+	}
+	for _, tr := range [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+		am, bm := randMat(5, 3, src), randMat(3, 6, src)
+		if tr[0] {
+			am = randMat(3, 5, src)
+		}
+		if tr[1] {
+			bm = randMat(6, 3, src)
+		}
+		c := randMat(5, 6, src)
+		c1, c2 := clone(c), clone(c)
+		backend.Gemm(tr[0], tr[1], 2.0, am, bm, 0.5, c1, b)
+		backend.Gemm(tr[0], tr[1], 2.0, am, bm, 0.5, c2, n)
+		for i := range c1 {
+			for j := range c1[i] {
+				if math.Abs(c1[i][j]-c2[i][j]) > 1e-12 {
+					t.Fatalf("Gemm, transposes %v: at [%d][%d], expected %v, got %v", tr, i, j, c2[i][j], c1[i][j])
+				}
+			}
+		}
+	}
+}
+
+func TestFactorizationsMatchNative(t *testing.T) {
+	src := rand.New(rand.NewSource(2))
+	b, n := Backend{}, backend.Native{}
+	a := randMat(6, 6, src)
+	a1, a2 := clone(a), clone(a)
+	p1, p2 := make([]int, 6), make([]int, 6)
+	if k1, k2 := backend.Getrf(a1, p1, b), backend.Getrf(a2, p2, n); k1 != -1 || k2 != -1 {
+		t.Fatalf("Getrf: expected -1, got %d and %d", k1, k2)
+	}
+	for i := range a1 {
+		if p1[i] != p2[i] {
+			t.Fatalf("Getrf: expected the pivots %v, got %v", p2, p1)
+		}
+		for j := range a1[i] {
+			if math.Abs(a1[i][j]-a2[i][j]) > 1e-12 {
+				t.Fatalf("Getrf: at [%d][%d], expected %v, got %v", i, j, a2[i][j], a1[i][j])
+			}
+		}
+	}
+	if k := backend.Getrf([][]float64{{1.0, 2.0}, {2.0, 4.0}}, make([]int, 2), b); k != 1 {
+		t.Errorf("Getrf: expected the singular column 1, got %d", k)
+	}
+	// a*T(a) is positive definite.
+	s := make([][]float64, 6)
+	for i := range s {
+		s[i] = make([]float64, 6)
+	}
+	backend.Gemm(false, true, 1.0, a, a, 0.0, s, n)
+	s1, s2 := clone(s), clone(s)
+	if k1, k2 := backend.Potrf(s1, b), backend.Potrf(s2, n); k1 != -1 || k2 != -1 {
+		t.Fatalf("Potrf: expected -1, got %d and %d", k1, k2)
+	}
+	for i := range s1 {
+		for j := range s1[i] {
+			if math.Abs(s1[i][j]-s2[i][j]) > 1e-10 {
+				t.Fatalf("Potrf: at [%d][%d], expected %v, got %v", i, j, s2[i][j], s1[i][j])
+			}
+		}
+	}
+	if k := backend.Potrf([][]float64{{1.0, 2.0}, {2.0, 1.0}}, b); k != 1 {
+		t.Errorf("Potrf: expected the row 1, got %d", k)
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Package blas provides a Backend which routes the linear algebra operations of
+gocrunch through an optimized CBLAS and LAPACK library: OpenBLAS, with its
+LAPACKE interface, on linux, and the Accelerate framework on macOS. The
+products go through CBLAS, and the LU and Cholesky factorizations, such as
+those of mat.LU() and Symmetric.Cholesky(), through LAPACK.
+
+The Backend uses cgo and links against the system library, so it is only
+built with the cblas build tag:
+
+	go build -tags cblas ./...
+
+Importing the package then registers the Backend under the name "blas", and
+selecting it makes the rest of gocrunch use it:
+
+	import "github.com/NDari/gocrunch/backend/blas"
+
+	func main() {
+		backend.Use(blas.Name)
+		p := mat.Dot(m, n) // computed by OpenBLAS or Accelerate
+	}
+
+Without the build tag, importing the package registers nothing, and
+backend.Use(blas.Name) returns backend.ErrUnknown, so programs can fall back
+to the native Backend.
+
+Since the matrices of gocrunch are [][]float64, whose rows are not stored
+contiguously, matrix operations copy their arguments into contiguous buffers
+before calling the library, in column major order for LAPACK. The copies
+cost O(n^2) for operations which cost O(n^3), and so only matter for small
+matrices.
+*/
+package blas
+
+// Name is the name the Backend of this package is registered under.
+const Name = "blas"
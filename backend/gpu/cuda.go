@@ -0,0 +1,232 @@
+//go:build cuda
+
+package gpu
+
+/*
+#cgo LDFLAGS: -lcublas -lcudart
+#include <cuda_runtime.h>
+#include <cublas_v2.h>
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/NDari/gocrunch/backend"
+)
+
+func init() {
+	d, ok := newDevice()
+	if !ok {
+		return
+	}
+	backend.Register(&Backend{dev: d})
+}
+
+// Backend is the CUDA Backend, registered under Name.
+type Backend struct {
+	dev *device
+}
+
+// device holds the cuBLAS handle and the device buffers. The operations on
+// a device are serialized, since they share its buffers.
+type device struct {
+	mu     sync.Mutex
+	handle C.cublasHandle_t
+	bufs   [3]buffer
+}
+
+// buffer is a block of device memory holding up to size float64s.
+type buffer struct {
+	ptr  unsafe.Pointer
+	size int
+}
+
+func newDevice() (*device, bool) {
+	var count C.int
+	if C.cudaGetDeviceCount(&count) != C.cudaSuccess || count == 0 {
+		return nil, false
+	}
+	d := &device{}
+	if C.cublasCreate(&d.handle) != C.CUBLAS_STATUS_SUCCESS {
+		return nil, false
+	}
+	return d, true
+}
+
+// reserve makes buffer i hold at least n float64s, and returns it.
+func (d *device) reserve(i, n int) (*C.double, bool) {
+	b := &d.bufs[i]
+	if b.size < n {
+		if b.ptr != nil {
+			C.cudaFree(b.ptr)
+			b.ptr, b.size = nil, 0
+		}
+		if C.cudaMalloc(&b.ptr, C.size_t(n*8)) != C.cudaSuccess {
+			b.ptr = nil
+			return nil, false
+		}
+		b.size = n
+	}
+	return (*C.double)(b.ptr), true
+}
+
+// upload copies v to buffer i.
+func (d *device) upload(i int, v []float64) (*C.double, bool) {
+	p, ok := d.reserve(i, len(v))
+	if !ok {
+		return nil, false
+	}
+	err := C.cudaMemcpy(unsafe.Pointer(p), unsafe.Pointer(&v[0]), C.size_t(len(v)*8), C.cudaMemcpyHostToDevice)
+	return p, err == C.cudaSuccess
+}
+
+// download copies the first len(v) elements of p to v.
+func download(v []float64, p *C.double) bool {
+	err := C.cudaMemcpy(unsafe.Pointer(&v[0]), unsafe.Pointer(p), C.size_t(len(v)*8), C.cudaMemcpyDeviceToHost)
+	return err == C.cudaSuccess
+}
+
+// Name returns Name.
+func (*Backend) Name() string {
+	return Name
+}
+
+// Dot returns the dot product of x and y.
+func (b *Backend) Dot(x, y []float64) float64 {
+	if len(x) < VectorThreshold {
+		return backend.Native{}.Dot(x, y)
+	}
+	b.dev.mu.Lock()
+	defer b.dev.mu.Unlock()
+	px, ok1 := b.dev.upload(0, x)
+	py, ok2 := b.dev.upload(1, y)
+	var res C.double
+	if !ok1 || !ok2 || C.cublasDdot(b.dev.handle, C.int(len(x)), px, 1, py, 1, &res) != C.CUBLAS_STATUS_SUCCESS {
+		return backend.Native{}.Dot(x, y)
+	}
+	return float64(res)
+}
+
+// Axpy stores alpha*x + y in y.
+func (b *Backend) Axpy(alpha float64, x, y []float64) {
+	if len(x) < VectorThreshold {
+		backend.Native{}.Axpy(alpha, x, y)
+		return
+	}
+	b.dev.mu.Lock()
+	defer b.dev.mu.Unlock()
+	px, ok1 := b.dev.upload(0, x)
+	py, ok2 := b.dev.upload(1, y)
+	a := C.double(alpha)
+	if !ok1 || !ok2 || C.cublasDaxpy(b.dev.handle, C.int(len(x)), &a, px, 1, py, 1) != C.CUBLAS_STATUS_SUCCESS || !download(y, py) {
+		backend.Native{}.Axpy(alpha, x, y)
+	}
+}
+
+// Scal stores alpha*x in x.
+func (b *Backend) Scal(alpha float64, x []float64) {
+	if len(x) < VectorThreshold {
+		backend.Native{}.Scal(alpha, x)
+		return
+	}
+	b.dev.mu.Lock()
+	defer b.dev.mu.Unlock()
+	px, ok := b.dev.upload(0, x)
+	a := C.double(alpha)
+	if !ok || C.cublasDscal(b.dev.handle, C.int(len(x)), &a, px, 1) != C.CUBLAS_STATUS_SUCCESS || !download(x, px) {
+		backend.Native{}.Scal(alpha, x)
+	}
+}
+
+// Gemv stores alpha*op(a)*x + beta*y in y.
+func (b *Backend) Gemv(trans bool, alpha float64, a [][]float64, x []float64, beta float64, y []float64) {
+	if len(a) == 0 || len(a[0]) == 0 || len(a)*len(a[0]) < VectorThreshold {
+		backend.Native{}.Gemv(trans, alpha, a, x, beta, y)
+		return
+	}
+	r, c := len(a), len(a[0])
+	b.dev.mu.Lock()
+	defer b.dev.mu.Unlock()
+	pa, ok1 := b.dev.upload(0, pack(a))
+	px, ok2 := b.dev.upload(1, x)
+	py, ok3 := b.dev.upload(2, y)
+	// cuBLAS is column major, so it sees the row major a as its transpose.
+	op := C.cublasOperation_t(C.CUBLAS_OP_T)
+	if trans {
+		op = C.CUBLAS_OP_N
+	}
+	al, be := C.double(alpha), C.double(beta)
+	if !ok1 || !ok2 || !ok3 ||
+		C.cublasDgemv(b.dev.handle, op, C.int(c), C.int(r), &al, pa, C.int(c), px, 1, &be, py, 1) != C.CUBLAS_STATUS_SUCCESS ||
+		!download(y, py) {
+		backend.Native{}.Gemv(trans, alpha, a, x, beta, y)
+	}
+}
+
+// Gemm stores alpha*op(a)*op(b) + beta*c in c.
+func (b *Backend) Gemm(transA, transB bool, alpha float64, a, bm [][]float64, beta float64, c [][]float64) {
+	if len(a) == 0 || len(a[0]) == 0 || len(c) == 0 || len(c[0]) == 0 {
+		backend.Native{}.Gemm(transA, transB, alpha, a, bm, beta, c)
+		return
+	}
+	m, n := len(c), len(c[0])
+	k := len(a[0])
+	if transA {
+		k = len(a)
+	}
+	if m*n*k < GemmThreshold {
+		backend.Native{}.Gemm(transA, transB, alpha, a, bm, beta, c)
+		return
+	}
+	b.dev.mu.Lock()
+	defer b.dev.mu.Unlock()
+	pa, ok1 := b.dev.upload(0, pack(a))
+	pb, ok2 := b.dev.upload(1, pack(bm))
+	cb := pack(c)
+	pc, ok3 := b.dev.upload(2, cb)
+	// In column major order, the row major product c = op(a)*op(b) is
+	// c' = op(b)'*op(a)', and the stored matrices are seen transposed.
+	opA, opB := C.cublasOperation_t(C.CUBLAS_OP_N), C.cublasOperation_t(C.CUBLAS_OP_N)
+	if transA {
+		opA = C.CUBLAS_OP_T
+	}
+	if transB {
+		opB = C.CUBLAS_OP_T
+	}
+	al, be := C.double(alpha), C.double(beta)
+	if !ok1 || !ok2 || !ok3 ||
+		C.cublasDgemm(b.dev.handle, opB, opA, C.int(n), C.int(m), C.int(k), &al,
+			pb, C.int(len(bm[0])), pa, C.int(len(a[0])), &be, pc, C.int(n)) != C.CUBLAS_STATUS_SUCCESS ||
+		!download(cb, pc) {
+		backend.Native{}.Gemm(transA, transB, alpha, a, bm, beta, c)
+		return
+	}
+	for i := range c {
+		copy(c[i], cb[i*n:(i+1)*n])
+	}
+}
+
+// Getrf overwrites a with its LU factorization with partial pivoting. The
+// factorizations are computed on the host, by the native Backend.
+func (*Backend) Getrf(a [][]float64, ipiv []int) int {
+	return backend.Native{}.Getrf(a, ipiv)
+}
+
+// Potrf overwrites the lower half of a with its Cholesky factor, on the
+// host.
+func (*Backend) Potrf(a [][]float64) int {
+	return backend.Native{}.Potrf(a)
+}
+
+// pack returns the elements of a in a contiguous []float64, in row major
+// order.
+func pack(a [][]float64) []float64 {
+	cols := len(a[0])
+	buf := make([]float64, len(a)*cols)
+	for i := range a {
+		copy(buf[i*cols:], a[i])
+	}
+	return buf
+}
@@ -0,0 +1,97 @@
+//go:build cuda
+
+package gpu
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/NDari/gocrunch/backend"
+)
+
+func randMat(r, c int, src *rand.Rand) [][]float64 {
+	m := make([][]float64, r)
+	for i := range m {
+		m[i] = make([]float64, c)
+		for j := range m[i] {
+			m[i][j] = src.NormFloat64()
+		}
+	}
+	return m
+}
+
+func clone(m [][]float64) [][]float64 {
+	c := make([][]float64, len(m))
+	for i := range m {
+		c[i] = append([]float64(nil), m[i]...)
+	}
+	return c
+}
+
+func TestMatchesNative(t *testing.T) {
+	b, ok := backend.Lookup(Name)
+	if !ok {
+		t.Skip("no CUDA device is available")
+	}
+	// Offload every operation, so that the device paths are exercised.
+	defer func(g, v int) { GemmThreshold, VectorThreshold = g, v }(GemmThreshold, VectorThreshold)
+	GemmThreshold, VectorThreshold = 0, 0
+	src := rand.New(rand.NewSource(1))
+	n := backend.Native{}
+	x, y := randMat(1, 7, src)[0], randMat(1, 7, src)[0]
+	if d, e := backend.Dot(x, y, b), backend.Dot(x, y, n); math.Abs(d-e) > 1e-12 {
+		t.Errorf("Dot: expected %v, got %v", e, d)
+	}
+	y1, y2 := append([]float64(nil), y...), append([]float64(nil), y...)
+	backend.Axpy(0.5, x, y1, b)
+	backend.Axpy(0.5, x, y2, n)
+	for i := range y1 {
+		if math.Abs(y1[i]-y2[i]) > 1e-12 {
+			t.Fatalf("Axpy: expected %v, got %v", y2, y1)
+		}
+	}
+	x1, x2 := append([]float64(nil), x...), append([]float64(nil), x...)
+	backend.Scal(-2.0, x1, b)
+	backend.Scal(-2.0, x2, n)
+	for i := range x1 {
+		if math.Abs(x1[i]-x2[i]) > 1e-12 {
+			t.Fatalf("Scal: expected %v, got %v", x2, x1)
+		}
+	}
+	a := randMat(7, 4, src)
+	for _, trans := range []bool{false, true} {
+		in, out := randMat(1, 4, src)[0], randMat(1, 7, src)[0]
+		if trans {
+			in, out = randMat(1, 7, src)[0], randMat(1, 4, src)[0]
+		}
+		o1, o2 := append([]float64(nil), out...), append([]float64(nil), out...)
+		backend.Gemv(trans, 1.5, a, in, -0.5, o1, b)
+		backend.Gemv(trans, 1.5, a, in, -0.5, o2, n)
+		for i := range o1 {
+			if math.Abs(o1[i]-o2[i]) > 1e-12 {
+				t.Fatalf("Gemv, trans %v: expected %v, got %v", trans, o2, o1)
+			}
+		}
+	}
+	for _, tr := range [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+		am, bm := randMat(5, 3, src), randMat(3, 6, src)
+		if tr[0] {
+			am = randMat(3, 5, src)
+		}
+		if tr[1] {
+			bm = randMat(6, 3, src)
+		}
+		c := randMat(5, 6, src)
+		c1, c2 := clone(c), clone(c)
+		backend.Gemm(tr[0], tr[1], 2.0, am, bm, 0.5, c1, b)
+		backend.Gemm(tr[0], tr[1], 2.0, am, bm, 0.5, c2, n)
+		for i := range c1 {
+			for j := range c1[i] {
+				if math.Abs(c1[i][j]-c2[i][j]) > 1e-12 {
+					t.Fatalf("Gemm, transposes %v: at [%d][%d], expected %v, got %v", tr, i, j, c2[i][j], c1[i][j])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Package gpu provides an experimental Backend which offloads large linear
+algebra operations to an NVIDIA GPU through CUDA and cuBLAS.
+
+The Backend uses cgo and links against the CUDA runtime and cuBLAS, so it is
+only built with the cuda build tag:
+
+	go build -tags cuda ./...
+
+Importing the package then registers the Backend under the name "gpu", if a
+CUDA device is available, and selecting it makes the rest of gocrunch use it:
+
+	import "github.com/NDari/gocrunch/backend/gpu"
+
+	func main() {
+		if err := backend.Use(gpu.Name); err != nil {
+			// No GPU, so keep the native backend.
+		}
+		p := mat.Dot(m, n) // computed on the GPU if m and n are large
+	}
+
+The Backend copies the arguments of each operation to device memory, and the
+result back, reusing its device buffers between operations. Since these
+transfers dominate the cost of small operations, operations below the sizes
+set by GemmThreshold and VectorThreshold are computed by the native Backend
+instead. If a CUDA call fails, the operation is also computed by the native
+Backend, so that the results are always available. The LU and Cholesky
+factorizations are not offloaded, and are always computed by the native
+Backend.
+
+Element-wise functions such as vec.Foreach() call arbitrary Go functions, and
+cannot be offloaded. The additions and subtractions of large vectors by
+vec.Add() and vec.Sub() are offloaded through Axpy.
+*/
+package gpu
+
+// Name is the name the Backend of this package is registered under.
+const Name = "gpu"
+
+var (
+	// GemmThreshold is the number of multiplications, m*n*k, below which
+	// matrix products are computed on the host.
+	GemmThreshold = 1 << 24
+	// VectorThreshold is the number of elements below which vector and
+	// matrix-vector operations are computed on the host.
+	VectorThreshold = 1 << 20
+)
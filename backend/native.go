@@ -0,0 +1,152 @@
+package backend
+
+import "math"
+
+// Native is the pure Go Backend, registered under the name "native". It is
+// the current Backend unless another one is selected.
+type Native struct{}
+
+// Name returns "native".
+func (Native) Name() string {
+	return "native"
+}
+
+// Dot returns the dot product of x and y.
+func (Native) Dot(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+	return sum
+}
+
+// Axpy stores alpha*x + y in y.
+func (Native) Axpy(alpha float64, x, y []float64) {
+	for i := range x {
+		y[i] += alpha * x[i]
+	}
+}
+
+// Scal stores alpha*x in x.
+func (Native) Scal(alpha float64, x []float64) {
+	for i := range x {
+		x[i] *= alpha
+	}
+}
+
+// Gemv stores alpha*op(a)*x + beta*y in y.
+func (n Native) Gemv(trans bool, alpha float64, a [][]float64, x []float64, beta float64, y []float64) {
+	scale(beta, y)
+	if trans {
+		for k := range a {
+			n.Axpy(alpha*x[k], a[k], y)
+		}
+		return
+	}
+	for i := range a {
+		y[i] += alpha * n.Dot(a[i], x)
+	}
+}
+
+// Gemm stores alpha*op(a)*op(b) + beta*c in c.
+func (n Native) Gemm(transA, transB bool, alpha float64, a, b [][]float64, beta float64, c [][]float64) {
+	for i := range c {
+		scale(beta, c[i])
+	}
+	inner := len(b)
+	if transB && len(b) > 0 {
+		inner = len(b[0])
+	}
+	at := func(i, k int) float64 {
+		if transA {
+			return a[k][i]
+		}
+		return a[i][k]
+	}
+	for i := range c {
+		if transB {
+			// Each element is the dot product of a row of op(a) with a row
+			// of b.
+			for j := range c[i] {
+				sum := 0.0
+				for k := 0; k < inner; k++ {
+					sum += at(i, k) * b[j][k]
+				}
+				c[i][j] += alpha * sum
+			}
+			continue
+		}
+		// The i-k-j order reads the rows of b sequentially. The zero
+		// elements of a are not skipped, so that 0*Inf gives NaN.
+		for k := 0; k < inner; k++ {
+			n.Axpy(alpha*at(i, k), b[k], c[i])
+		}
+	}
+}
+
+// Getrf overwrites a with its LU factorization with partial pivoting.
+func (Native) Getrf(a [][]float64, ipiv []int) int {
+	n := len(a)
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i][k]) > math.Abs(a[p][k]) {
+				p = i
+			}
+		}
+		ipiv[k] = p
+		if a[p][k] == 0.0 {
+			return k
+		}
+		a[k], a[p] = a[p], a[k]
+		for i := k + 1; i < n; i++ {
+			l := a[i][k] / a[k][k]
+			a[i][k] = l
+			if l == 0.0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				a[i][j] -= l * a[k][j]
+			}
+		}
+	}
+	return -1
+}
+
+// Potrf overwrites the lower half of a with its Cholesky factor.
+func (Native) Potrf(a [][]float64) int {
+	for i, li := range a {
+		for j := 0; j <= i; j++ {
+			lj := a[j]
+			sum := li[j]
+			for k := 0; k < j; k++ {
+				sum -= li[k] * lj[k]
+			}
+			if j < i {
+				li[j] = sum / lj[j]
+				continue
+			}
+			if !(sum > 0.0) {
+				return i
+			}
+			li[i] = math.Sqrt(sum)
+		}
+	}
+	return -1
+}
+
+// scale stores beta*y in y, treating a beta of 0.0 as assigning 0.0, so that
+// NaNs already in y are not propagated.
+func scale(beta float64, y []float64) {
+	switch beta {
+	case 1.0:
+	case 0.0:
+		for i := range y {
+			y[i] = 0.0
+		}
+	default:
+		for i := range y {
+			y[i] *= beta
+		}
+	}
+}
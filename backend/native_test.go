@@ -0,0 +1,127 @@
+package backend
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randMat(r, c int, src *rand.Rand) [][]float64 {
+	m := make([][]float64, r)
+	for i := range m {
+		m[i] = make([]float64, c)
+		for j := range m[i] {
+			m[i][j] = src.NormFloat64()
+		}
+	}
+	return m
+}
+
+func transpose(m [][]float64) [][]float64 {
+	t := make([][]float64, len(m[0]))
+	for j := range t {
+		t[j] = make([]float64, len(m))
+		for i := range m {
+			t[j][i] = m[i][j]
+		}
+	}
+	return t
+}
+
+func TestNativeVectors(t *testing.T) {
+	x, y := []float64{1.0, 2.0, 3.0}, []float64{4.0, 5.0, 6.0}
+	if d := Dot(x, y); d != 32.0 {
+		t.Errorf("expected 32.0, got %v", d)
+	}
+	Axpy(2.0, x, y)
+	if y[0] != 6.0 || y[1] != 9.0 || y[2] != 12.0 {
+		t.Errorf("expected {6.0, 9.0, 12.0}, got %v", y)
+	}
+	Scal(0.5, y)
+	if y[0] != 3.0 || y[1] != 4.5 || y[2] != 6.0 {
+		t.Errorf("expected {3.0, 4.5, 6.0}, got %v", y)
+	}
+	a := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	out := []float64{1.0, 1.0}
+	Gemv(false, 2.0, a, x, 3.0, out)
+	if out[0] != 31.0 || out[1] != 67.0 {
+		t.Errorf("expected {31.0, 67.0}, got %v", out)
+	}
+	out = []float64{math.NaN(), 0.0, 0.0}
+	Gemv(true, 1.0, a, []float64{1.0, 1.0}, 0.0, out)
+	if out[0] != 5.0 || out[1] != 7.0 || out[2] != 9.0 {
+		t.Errorf("expected {5.0, 7.0, 9.0}, got %v", out)
+	}
+}
+
+func TestNativeGemm(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	a, b := randMat(4, 3, src), randMat(3, 5, src)
+	expected := make([][]float64, 4)
+	c0 := randMat(4, 5, src)
+	for i := range expected {
+		expected[i] = make([]float64, 5)
+		for j := range expected[i] {
+			for k := 0; k < 3; k++ {
+				expected[i][j] += a[i][k] * b[k][j]
+			}
+			expected[i][j] = 2.0*expected[i][j] - c0[i][j]
+		}
+	}
+	for _, tr := range [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+		aa, bb := a, b
+		if tr[0] {
+			aa = transpose(a)
+		}
+		if tr[1] {
+			bb = transpose(b)
+		}
+		c := make([][]float64, 4)
+		for i := range c {
+			c[i] = append([]float64(nil), c0[i]...)
+		}
+		Gemm(tr[0], tr[1], 2.0, aa, bb, -1.0, c)
+		for i := range c {
+			for j := range c[i] {
+				if math.Abs(c[i][j]-expected[i][j]) > 1e-12 {
+					t.Fatalf("transposes %v, at [%d][%d], expected %v, got %v", tr, i, j, expected[i][j], c[i][j])
+				}
+			}
+		}
+	}
+}
+
+func TestNativeFactorizations(t *testing.T) {
+	a := [][]float64{{1.0, 2.0, 0.0}, {4.0, 2.0, 2.0}, {2.0, 5.0, 1.0}}
+	ipiv := make([]int, 3)
+	if k := Getrf(a, ipiv); k != -1 {
+		t.Fatalf("expected -1, got %d", k)
+	}
+	expected := [][]float64{{4.0, 2.0, 2.0}, {0.5, 4.0, 0.0}, {0.25, 0.375, -0.5}}
+	for i := range a {
+		for j := range a[i] {
+			if math.Abs(a[i][j]-expected[i][j]) > 1e-15 {
+				t.Fatalf("at [%d][%d], expected %v, got %v", i, j, expected[i][j], a[i][j])
+			}
+		}
+	}
+	if ipiv[0] != 1 || ipiv[1] != 2 || ipiv[2] != 2 {
+		t.Errorf("expected the pivots {1, 2, 2}, got %v", ipiv)
+	}
+	if k := Getrf([][]float64{{1.0, 2.0}, {2.0, 4.0}}, make([]int, 2)); k != 1 {
+		t.Errorf("expected the singular column 1, got %d", k)
+	}
+	// The upper half is neither read nor altered.
+	s := [][]float64{{4.0, 9.0}, {2.0, 2.0}}
+	if k := Potrf(s); k != -1 {
+		t.Fatalf("expected -1, got %d", k)
+	}
+	if s[0][0] != 2.0 || s[0][1] != 9.0 || s[1][0] != 1.0 || s[1][1] != 1.0 {
+		t.Errorf("expected {{2.0, 9.0}, {1.0, 1.0}}, got %v", s)
+	}
+	for _, m := range [][][]float64{{{1.0, 2.0}, {2.0, 1.0}}, {{1.0, 0.0}, {0.0, math.NaN()}}} {
+		if k := Potrf(m); k != 1 {
+			t.Errorf("expected the row 1, got %d", k)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Package check implements the validation of []float64s, for code which must
+report bad input with errors rather than crash on it, such as a service
+validating data before passing it to the vec and mat packages, which panic
+on invalid arguments. For example:
+
+	if err := check.SameLen(x, y, w); err != nil {
+		return err
+	}
+	if err := check.AllFinite(w); err != nil {
+		return err
+	}
+	s := vec.Dot(vec.Mul(x, w), y) // cannot panic
+
+The errors match the errors of the errs package where there is one for
+their cause, so that errors.Is(err, errs.ErrShapeMismatch) holds for the
+error of SameLen, and errors.Is(err, errs.ErrEmpty) holds for the error of
+NonEmpty, and otherwise wrap the sentinel errors of this package.
+*/
+package check
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+var (
+	// ErrNotFinite is returned by AllFinite when an element is NaN or an
+	// infinity.
+	ErrNotFinite = errors.New("check: the value is not finite")
+	// ErrRange is returned by InRange when an element is outside of the
+	// range.
+	ErrRange = errors.New("check: the value is out of range")
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/check error.\nIn check.%s, the lower bound %v must not be greater than the upper bound %v.\n",
+	}
+)
+
+/*
+SameLen returns nil if the passed []float64s all have the same length, and
+otherwise an *errs.ShapeError holding the length of the first []float64
+whose length differs from that of vs[0], along with the length of vs[0].
+No []float64s, or a single one, always have the same length.
+*/
+func SameLen(vs ...[]float64) error {
+	for i := range vs {
+		if len(vs[i]) != len(vs[0]) {
+			return &errs.ShapeError{
+				Got:  []int{len(vs[i])},
+				Want: []int{len(vs[0])},
+				Err:  fmt.Errorf("check: slice %d has length %d, while slice 0 has length %d", i, len(vs[i]), len(vs[0])),
+			}
+		}
+	}
+	return nil
+}
+
+// NonEmpty returns nil if v has elements, and an *errs.EmptyError otherwise.
+func NonEmpty(v []float64) error {
+	if len(v) == 0 {
+		return &errs.EmptyError{Got: []int{0}, Err: errors.New("check: the slice is empty")}
+	}
+	return nil
+}
+
+// AllFinite returns nil if no element of v is NaN or an infinity, and an
+// error wrapping ErrNotFinite, naming the first one that is, otherwise.
+func AllFinite(v []float64) error {
+	for i, x := range v {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return fmt.Errorf("%w: the element at index %d is %v", ErrNotFinite, i, x)
+		}
+	}
+	return nil
+}
+
+/*
+InRange returns nil if every element of v is in the closed interval
+[lo, hi], and an error wrapping ErrRange, naming the first element which is
+not, otherwise. NaN is in no range. For example:
+
+	err := check.InRange(probabilities, 0.0, 1.0)
+
+lo must not be greater than hi, otherwise this function will panic.
+*/
+func InRange(v []float64, lo, hi float64) error {
+	if !(lo <= hi) {
+		panic(fmt.Sprintf(errStrings[0], "InRange()", lo, hi))
+	}
+	for i, x := range v {
+		if !(x >= lo && x <= hi) {
+			return fmt.Errorf("%w: the element at index %d is %v, outside of [%v, %v]", ErrRange, i, x, lo, hi)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+func TestSameLen(t *testing.T) {
+	if err := SameLen([]float64{1.0, 2.0}, []float64{3.0, 4.0}, make([]float64, 2)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := SameLen(); err != nil {
+		t.Errorf("unexpected error for no slices: %v", err)
+	}
+	err := SameLen([]float64{1.0, 2.0}, []float64{3.0, 4.0}, []float64{5.0})
+	var se *errs.ShapeError
+	if !errors.Is(err, errs.ErrShapeMismatch) || !errors.As(err, &se) {
+		t.Fatalf("expected an *errs.ShapeError, got %v", err)
+	}
+	if se.Got[0] != 1 || se.Want[0] != 2 {
+		t.Errorf("expected the lengths 1 and 2, got %v and %v", se.Got, se.Want)
+	}
+	if expected := "check: slice 2 has length 1, while slice 0 has length 2"; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestNonEmpty(t *testing.T) {
+	if err := NonEmpty([]float64{0.0}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := NonEmpty(nil); !errors.Is(err, errs.ErrEmpty) {
+		t.Errorf("expected errs.ErrEmpty, got %v", err)
+	}
+}
+
+func TestAllFinite(t *testing.T) {
+	if err := AllFinite([]float64{1.0, -math.MaxFloat64, 0.0}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, x := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		err := AllFinite([]float64{1.0, x})
+		if !errors.Is(err, ErrNotFinite) {
+			t.Errorf("expected ErrNotFinite for %v, got %v", x, err)
+		}
+		if expected := fmt.Sprintf("check: the value is not finite: the element at index 1 is %v", x); err.Error() != expected {
+			t.Errorf("expected %q, got %q", expected, err.Error())
+		}
+	}
+}
+
+func TestInRange(t *testing.T) {
+	if err := InRange([]float64{0.0, 0.5, 1.0}, 0.0, 1.0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := InRange([]float64{0.5, 1.5}, 0.0, 1.0); !errors.Is(err, ErrRange) {
+		t.Errorf("expected ErrRange, got %v", err)
+	}
+	if err := InRange([]float64{math.NaN()}, math.Inf(-1), math.Inf(1)); !errors.Is(err, ErrRange) {
+		t.Errorf("expected ErrRange for NaN, got %v", err)
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { InRange(nil, 1.0, 0.0) }, fmt.Sprintf(errStrings[0], "InRange()", 1.0, 0.0)},
+		{func() { InRange(nil, math.NaN(), 0.0) }, fmt.Sprintf(errStrings[0], "InRange()", math.NaN(), 0.0)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
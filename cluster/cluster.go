@@ -0,0 +1,324 @@
+/*
+Package cluster implements the clustering of the rows of a matrix, stored as a
+[][]float64, into groups of similar rows.
+
+cluster.KMeans() partitions the rows into k clusters, such that each row
+belongs to the cluster with the nearest centroid:
+
+	res, err := cluster.KMeans(X, 3, nil)
+	res.Labels    // the cluster of each row of X
+	res.Centroids // the center of each cluster
+
+cluster.KMeansCtx() does the same, but stops early when the passed
+context.Context is done.
+
+Invalid arguments, such as an empty matrix, are treated as critical errors,
+and cause a panic with a message that names the offending function, as with
+the other packages in gocrunch. Rows of different lengths, like in the metric
+package, are reported with an error wrapping metric.ErrLength.
+*/
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"github.com/NDari/gocrunch/check"
+	"github.com/NDari/gocrunch/mat"
+	"github.com/NDari/gocrunch/metric"
+	"github.com/NDari/gocrunch/parallel"
+	"github.com/NDari/gocrunch/progress"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/cluster error.\nIn cluster.%s, the matrix cannot be empty.\n",
+		"\ngocrunch/cluster error.\nIn cluster.%s, the number of clusters must be in [1, %d], received %d.\n",
+		"\ngocrunch/cluster error.\nIn cluster.%s, the %s must not be negative, received %d.\n",
+	}
+)
+
+// ErrMaxIter is returned when the centroids do not converge within the
+// allowed number of iterations. The returned Result holds the clustering
+// reached at that point.
+var ErrMaxIter = errors.New("cluster: maximum number of iterations reached")
+
+/*
+Settings configures cluster.KMeans(). The zero value of each field is
+replaced by the default mentioned in its description.
+*/
+type Settings struct {
+	// MaxIter is the maximum number of iterations of each run, 300 by
+	// default.
+	MaxIter int
+	// Tol stops the iterations when the sum of the squared distances that
+	// the centroids moved in an iteration falls to or below it, 1e-8 by
+	// default.
+	Tol float64
+	// Runs is the number of times the clustering is repeated with different
+	// initial centroids, keeping the clustering with the lowest inertia.
+	// It is 1 by default.
+	Runs int
+	// Rand is the source of randomness for choosing the initial centroids,
+	// rand.New(rand.NewSource(1)) by default, so that the clustering is
+	// reproducible.
+	Rand *rand.Rand
+	// Observer, when not nil, is passed the inertia of the assignment of
+	// the rows after each iteration of each run, and can stop the
+	// clustering. The iterations are counted from 1 in each run.
+	Observer progress.Observer
+}
+
+// Result holds the outcome of a clustering.
+type Result struct {
+	// Centroids holds the center of each cluster, as a k by dim matrix.
+	Centroids [][]float64
+	// Labels holds the index of the cluster of each row.
+	Labels []int
+	// Inertia is the sum of the squared euclidean distances between each
+	// row and the centroid of its cluster.
+	Inertia float64
+	// Iter is the number of iterations of the kept run.
+	Iter int
+}
+
+/*
+KMeans partitions the rows of X into k clusters with Lloyd's algorithm,
+seeding the centroids with the k-means++ method, which picks rows far from
+the centroids chosen so far. The assignment of the rows to the centroids is
+spread over up to GOMAXPROCS goroutines for large X.
+
+If a cluster loses all of its rows, its centroid is moved to the row that is
+farthest from its own centroid. KMeans returns ErrMaxIter if a run does not
+converge within the maximum number of iterations, and progress.ErrStopped if
+the Observer stops it, along with the best clustering found. A nil Settings
+uses the defaults of all its fields.
+
+X must not be empty, and k must be between 1 and the number of rows of X,
+otherwise this function will panic. X is not modified in this function.
+*/
+func KMeans(X [][]float64, k int, s *Settings) (*Result, error) {
+	return kmeans(context.Background(), "KMeans()", X, k, s)
+}
+
+/*
+KMeansCtx is KMeans(), stopping early if ctx is done before the clustering is
+complete, in which case it returns nil and ctx.Err(). The context is checked
+before each iteration of each run.
+*/
+func KMeansCtx(ctx context.Context, X [][]float64, k int, s *Settings) (*Result, error) {
+	return kmeans(ctx, "KMeansCtx()", X, k, s)
+}
+
+func kmeans(ctx context.Context, fn string, X [][]float64, k int, s *Settings) (*Result, error) {
+	if len(X) == 0 || len(X[0]) == 0 {
+		panic(fmt.Sprintf(errStrings[0], fn))
+	}
+	if k < 1 || k > len(X) {
+		panic(fmt.Sprintf(errStrings[1], fn, len(X), k))
+	}
+	set := withDefaults(fn, s)
+	if err := check.SameLen(X...); err != nil {
+		return nil, fmt.Errorf("%w: the rows of X: %w", metric.ErrLength, err)
+	}
+	var best *Result
+	var bestErr error
+	for r := 0; r < set.Runs; r++ {
+		res, err := lloyd(ctx, X, seed(X, k, set.Rand), set)
+		if err != nil && !errors.Is(err, ErrMaxIter) && !errors.Is(err, progress.ErrStopped) {
+			return nil, err
+		}
+		if best == nil || res.Inertia < best.Inertia {
+			best, bestErr = res, err
+		}
+		if errors.Is(err, progress.ErrStopped) {
+			return best, err
+		}
+	}
+	return best, bestErr
+}
+
+// lloyd runs Lloyd's algorithm from the passed initial centroids.
+func lloyd(ctx context.Context, X, centroids [][]float64, set Settings) (*Result, error) {
+	k, dim := len(centroids), len(X[0])
+	labels := make([]int, len(X))
+	dist := make([]float64, len(X))
+	counts := make([]int, k)
+	next := mat.New(k, dim)
+	for iter := 0; iter < set.MaxIter; iter++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		inertia := assign(X, centroids, labels, dist)
+		for j := range next {
+			counts[j] = 0
+			for d := range next[j] {
+				next[j][d] = 0.0
+			}
+		}
+		for i, l := range labels {
+			counts[l]++
+			for d := range X[i] {
+				next[l][d] += X[i][d]
+			}
+		}
+		for j := range next {
+			if counts[j] == 0 {
+				// Move the centroid of an empty cluster to the row that is
+				// farthest from its centroid, so that no cluster is lost.
+				far := 0
+				for i := range dist {
+					if dist[i] > dist[far] {
+						far = i
+					}
+				}
+				copy(next[j], X[far])
+				dist[far] = 0.0
+				continue
+			}
+			for d := range next[j] {
+				next[j][d] /= float64(counts[j])
+			}
+		}
+		shift := 0.0
+		for j := range next {
+			d, _ := metric.SqEuclidean(next[j], centroids[j])
+			shift += d
+		}
+		centroids, next = next, centroids
+		if shift <= set.Tol {
+			inertia := assign(X, centroids, labels, dist)
+			return &Result{Centroids: centroids, Labels: labels, Inertia: inertia, Iter: iter + 1}, nil
+		}
+		if !progress.Continue(set.Observer, iter+1, inertia) {
+			inertia := assign(X, centroids, labels, dist)
+			return &Result{Centroids: centroids, Labels: labels, Inertia: inertia, Iter: iter + 1}, progress.ErrStopped
+		}
+	}
+	inertia := assign(X, centroids, labels, dist)
+	return &Result{Centroids: centroids, Labels: labels, Inertia: inertia, Iter: set.MaxIter}, ErrMaxIter
+}
+
+// assign stores the index of the nearest centroid of each row in labels,
+// and the squared distance to it in dist, returning the sum of dist. The
+// rows are spread over several goroutines when the work of the distances
+// reaches parallel.Threshold().
+func assign(X, centroids [][]float64, labels []int, dist []float64) float64 {
+	chunks := 1
+	if len(X)*len(centroids)*len(X[0]) >= parallel.Threshold() {
+		chunks = runtime.GOMAXPROCS(0)
+	}
+	size := (len(X) + chunks - 1) / chunks
+	partial := make([]float64, chunks)
+	var wg sync.WaitGroup
+	for c := 0; c < chunks; c++ {
+		lo, hi := c*size, (c+1)*size
+		if hi > len(X) {
+			hi = len(X)
+		}
+		work := func(c, lo, hi int) {
+			for i := lo; i < hi; i++ {
+				labels[i], dist[i] = nearest(X[i], centroids)
+				partial[c] += dist[i]
+			}
+		}
+		if chunks == 1 {
+			work(c, lo, hi)
+			break
+		}
+		wg.Add(1)
+		go func(c, lo, hi int) {
+			defer wg.Done()
+			work(c, lo, hi)
+		}(c, lo, hi)
+	}
+	wg.Wait()
+	// Summing the partial sums in order keeps the inertia independent of
+	// the scheduling of the goroutines.
+	sum := 0.0
+	for _, p := range partial {
+		sum += p
+	}
+	return sum
+}
+
+func nearest(x []float64, centroids [][]float64) (int, float64) {
+	best, bestDist := 0, math.Inf(1)
+	for j := range centroids {
+		if d, _ := metric.SqEuclidean(x, centroids[j]); d < bestDist {
+			best, bestDist = j, d
+		}
+	}
+	return best, bestDist
+}
+
+// seed chooses k initial centroids among the rows of X with the k-means++
+// method: after a first row chosen uniformly, each row is chosen with a
+// probability proportional to its squared distance to the nearest centroid
+// chosen so far.
+func seed(X [][]float64, k int, r *rand.Rand) [][]float64 {
+	centroids := make([][]float64, 0, k)
+	centroids = append(centroids, mat.Row(X, r.Intn(len(X))))
+	dist := make([]float64, len(X))
+	for i := range X {
+		dist[i], _ = metric.SqEuclidean(X[i], centroids[0])
+	}
+	for len(centroids) < k {
+		total := 0.0
+		for _, d := range dist {
+			total += d
+		}
+		pick := 0
+		if total == 0.0 {
+			// All the rows coincide with a centroid, so any row will do.
+			pick = r.Intn(len(X))
+		} else {
+			target := r.Float64() * total
+			for pick = 0; pick < len(X)-1; pick++ {
+				target -= dist[pick]
+				if target < 0.0 {
+					break
+				}
+			}
+		}
+		c := mat.Row(X, pick)
+		centroids = append(centroids, c)
+		for i := range X {
+			if d, _ := metric.SqEuclidean(X[i], c); d < dist[i] {
+				dist[i] = d
+			}
+		}
+	}
+	return centroids
+}
+
+func withDefaults(fn string, s *Settings) Settings {
+	var set Settings
+	if s != nil {
+		set = *s
+	}
+	if set.MaxIter < 0 {
+		panic(fmt.Sprintf(errStrings[2], fn, "maximum number of iterations", set.MaxIter))
+	}
+	if set.Runs < 0 {
+		panic(fmt.Sprintf(errStrings[2], fn, "number of runs", set.Runs))
+	}
+	if set.MaxIter == 0 {
+		set.MaxIter = 300
+	}
+	if set.Tol == 0.0 {
+		set.Tol = 1e-8
+	}
+	if set.Runs == 0 {
+		set.Runs = 1
+	}
+	if set.Rand == nil {
+		set.Rand = rand.New(rand.NewSource(1))
+	}
+	return set
+}
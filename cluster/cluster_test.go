@@ -0,0 +1,144 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/metric"
+	"github.com/NDari/gocrunch/parallel"
+	"github.com/NDari/gocrunch/progress"
+)
+
+// blobs returns n rows scattered around each of the passed centers.
+func blobs(centers [][]float64, n int, spread float64, r *rand.Rand) [][]float64 {
+	var X [][]float64
+	for _, c := range centers {
+		for i := 0; i < n; i++ {
+			row := make([]float64, len(c))
+			for d := range c {
+				row[d] = c[d] + spread*r.NormFloat64()
+			}
+			X = append(X, row)
+		}
+	}
+	return X
+}
+
+func TestKMeans(t *testing.T) {
+	centers := [][]float64{{0.0, 0.0}, {10.0, 0.0}, {0.0, 10.0}}
+	// With a low threshold, the assignment of 3000 rows runs in parallel.
+	parallel.SetThreshold(1000)
+	defer parallel.SetThreshold(0)
+	for _, n := range []int{20, 1000} {
+		X := blobs(centers, n, 0.5, rand.New(rand.NewSource(2)))
+		res, err := KMeans(X, 3, &Settings{Runs: 3})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Each blob must be a cluster of its own, with a centroid near its
+		// center.
+		for b, c := range centers {
+			l := res.Labels[b*n]
+			for i := b * n; i < (b+1)*n; i++ {
+				if res.Labels[i] != l {
+					t.Fatalf("n = %d, row %d is not in the cluster of its blob", n, i)
+				}
+			}
+			if d, _ := metric.Euclidean(res.Centroids[l], c); d > 0.5 {
+				t.Errorf("n = %d, expected a centroid near %v, got %v", n, c, res.Centroids[l])
+			}
+		}
+		inertia := 0.0
+		for i := range X {
+			d, _ := metric.SqEuclidean(X[i], res.Centroids[res.Labels[i]])
+			inertia += d
+		}
+		if math.Abs(inertia-res.Inertia) > 1e-9*inertia {
+			t.Errorf("n = %d, expected an inertia of %v, got %v", n, inertia, res.Inertia)
+		}
+	}
+}
+
+func TestKMeansEdgeCases(t *testing.T) {
+	// With as many clusters as distinct rows, every row is a centroid.
+	X := [][]float64{{1.0}, {5.0}, {9.0}, {5.0}}
+	res, err := KMeans(X, 3, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Inertia != 0.0 {
+		t.Errorf("expected an inertia of 0.0, got %v", res.Inertia)
+	}
+	if res.Labels[1] != res.Labels[3] {
+		t.Errorf("expected equal rows in the same cluster, got %v", res.Labels)
+	}
+	one, _ := KMeans(X, 1, nil)
+	if math.Abs(one.Centroids[0][0]-5.0) > 1e-12 {
+		t.Errorf("expected a single centroid at the mean, got %v", one.Centroids)
+	}
+	if _, err := KMeans([][]float64{{1.0, 2.0}, {3.0}}, 1, nil); !errors.Is(err, metric.ErrLength) {
+		t.Errorf("expected metric.ErrLength, got %v", err)
+	}
+	blob := blobs([][]float64{{0.0, 0.0}, {5.0, 5.0}}, 50, 1.0, rand.New(rand.NewSource(5)))
+	if _, err := KMeans(blob, 4, &Settings{MaxIter: 1}); !errors.Is(err, ErrMaxIter) {
+		t.Errorf("expected ErrMaxIter, got %v", err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[1], "KMeans()", 4, 5)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		KMeans(X, 5, nil)
+	}()
+	wg.Wait()
+}
+
+func TestKMeansCtx(t *testing.T) {
+	X := blobs([][]float64{{0.0, 0.0}, {5.0, 5.0}}, 50, 1.0, rand.New(rand.NewSource(5)))
+	res, err := KMeansCtx(context.Background(), X, 2, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := KMeans(X, 2, nil)
+	if res.Inertia != want.Inertia || res.Iter != want.Iter {
+		t.Errorf("expected the result of KMeans(), got %v", res)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if res, err := KMeansCtx(ctx, X, 2, nil); err != context.Canceled || res != nil {
+		t.Errorf("expected nil and %v, got %v and %v", context.Canceled, res, err)
+	}
+}
+
+func TestKMeansObserver(t *testing.T) {
+	X := blobs([][]float64{{0.0, 0.0}, {5.0, 5.0}, {0.0, 5.0}}, 50, 1.5, rand.New(rand.NewSource(5)))
+	var values []float64
+	watch := progress.Func(func(r progress.Report) bool {
+		values = append(values, r.Value)
+		return true
+	})
+	if _, err := KMeans(X, 3, &Settings{Observer: watch}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i] > values[i-1]+1e-9 {
+			t.Errorf("expected the inertia not to increase, got %v", values)
+		}
+	}
+	stop := progress.Func(func(r progress.Report) bool { return false })
+	res, err := KMeans(X, 3, &Settings{Observer: stop, Runs: 3})
+	if err != progress.ErrStopped || res == nil || res.Iter != 1 {
+		t.Errorf("expected to stop after one iteration with progress.ErrStopped, got %v and %v", res, err)
+	}
+}
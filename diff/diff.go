@@ -0,0 +1,117 @@
+/*
+Package diff implements the numerical differentiation of functions, for
+example to provide the gradient of a function to optimize.Minimize() without
+deriving it by hand:
+
+	f := func(x []float64) float64 { return x[0]*x[0] + 3.0*x[0]*x[1] }
+	grad := diff.Gradient(f, []float64{1.0, 2.0}) // {8.0, 3.0}
+
+The derivatives are estimated with central differences, which are refined with
+Richardson extrapolation over a sequence of decreasing step sizes. This gives
+results which are typically accurate to about 1e-10 relative to the scale of
+the function, far better than a single finite difference.
+
+Invalid arguments, such as a nil function, are treated as critical errors, and
+cause a panic with a message that names the offending function, as with the
+other packages in gocrunch.
+*/
+package diff
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/diff error.\nIn diff.%s, the function cannot be nil.\n",
+		"\ngocrunch/diff error.\nIn diff.%s, the []float64 cannot be empty.\n",
+	}
+)
+
+const (
+	// shrink is the factor by which the step size is decreased between
+	// successive extrapolation levels.
+	shrink = 1.4
+	// levels is the maximum number of step sizes used.
+	levels = 10
+)
+
+/*
+Derivative returns the derivative of f at x, estimated with central
+differences and Richardson extrapolation.
+*/
+func Derivative(f func(float64) float64, x float64) float64 {
+	if f == nil {
+		panic(fmt.Sprintf(errStrings[0], "Derivative()"))
+	}
+	return richardson(f, x)
+}
+
+/*
+Gradient returns the gradient of f at x, with each partial derivative
+estimated with central differences and Richardson extrapolation. The passed x
+is not modified in this function.
+*/
+func Gradient(f func([]float64) float64, x []float64) []float64 {
+	if f == nil {
+		panic(fmt.Sprintf(errStrings[0], "Gradient()"))
+	}
+	if len(x) == 0 {
+		panic(fmt.Sprintf(errStrings[1], "Gradient()"))
+	}
+	p := make([]float64, len(x))
+	copy(p, x)
+	grad := make([]float64, len(x))
+	for i := range x {
+		partial := func(xi float64) float64 {
+			p[i] = xi
+			v := f(p)
+			p[i] = x[i]
+			return v
+		}
+		grad[i] = richardson(partial, x[i])
+	}
+	return grad
+}
+
+// richardson implements Ridders' method: a table of central differences
+// with decreasing step sizes is extrapolated to a zero step size, and the
+// estimate with the smallest error is returned.
+func richardson(f func(float64) float64, x float64) float64 {
+	// The initial step is relative to x, so that functions defined only
+	// on one side of the origin can be differentiated close to it.
+	h := 0.1 * math.Abs(x)
+	if h == 0.0 {
+		h = 0.1
+	}
+	var table [levels][levels]float64
+	table[0][0] = central(f, x, h)
+	best, bestErr := table[0][0], math.Inf(1)
+	for i := 1; i < levels; i++ {
+		h /= shrink
+		table[0][i] = central(f, x, h)
+		factor := shrink * shrink
+		for j := 1; j <= i; j++ {
+			table[j][i] = (table[j-1][i]*factor - table[j-1][i-1]) / (factor - 1.0)
+			factor *= shrink * shrink
+			e := math.Max(math.Abs(table[j][i]-table[j-1][i]), math.Abs(table[j][i]-table[j-1][i-1]))
+			if e <= bestErr {
+				best, bestErr = table[j][i], e
+			}
+		}
+		// A higher order estimate that is much worse than the best one so
+		// far means that rounding errors have taken over.
+		if math.Abs(table[i][i]-table[i-1][i-1]) >= 2.0*bestErr {
+			break
+		}
+	}
+	return best
+}
+
+func central(f func(float64) float64, x, h float64) float64 {
+	// Rounding x+h and x-h to representable values gives the step that is
+	// actually taken.
+	hi, lo := x+h, x-h
+	return (f(hi) - f(lo)) / (hi - lo)
+}
@@ -0,0 +1,76 @@
+package diff
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestDerivative(t *testing.T) {
+	cases := []struct {
+		f        func(float64) float64
+		x, deriv float64
+	}{
+		{math.Sin, 1.0, math.Cos(1.0)},
+		{math.Exp, 2.0, math.Exp(2.0)},
+		{func(x float64) float64 { return x * x * x }, -3.0, 27.0},
+		{math.Log, 1000.0, 1e-3},
+		{math.Sqrt, 1e-2, 0.5 / math.Sqrt(1e-2)},
+	}
+	for i, c := range cases {
+		d := Derivative(c.f, c.x)
+		if math.Abs(d-c.deriv) > 1e-9*math.Max(1.0, math.Abs(c.deriv)) {
+			t.Errorf("case %d: expected %v, got %v", i, c.deriv, d)
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[0], "Derivative()")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Derivative(nil, 1.0)
+	}()
+	wg.Wait()
+}
+
+func TestGradient(t *testing.T) {
+	rosenbrock := func(x []float64) float64 {
+		a, b := 1.0-x[0], x[1]-x[0]*x[0]
+		return a*a + 100.0*b*b
+	}
+	x := []float64{-1.2, 1.0}
+	grad := Gradient(rosenbrock, x)
+	expected := []float64{
+		-2.0*(1.0-x[0]) - 400.0*x[0]*(x[1]-x[0]*x[0]),
+		200.0 * (x[1] - x[0]*x[0]),
+	}
+	for i := range grad {
+		if math.Abs(grad[i]-expected[i]) > 1e-7 {
+			t.Errorf("at index %d, expected %v, got %v", i, expected[i], grad[i])
+		}
+	}
+	if x[0] != -1.2 || x[1] != 1.0 {
+		t.Errorf("the passed []float64 was modified: %v", x)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[1], "Gradient()")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Gradient(rosenbrock, []float64{})
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,172 @@
+/*
+Package errs defines the errors shared by the packages of gocrunch, so that
+callers can branch on the cause of a failure, whichever package reported it.
+
+The causes are the sentinel errors of this package, which are matched with
+errors.Is(), while the details, such as the offending dimensions, are held by
+the error types of this package, which are extracted with errors.As(). For
+example:
+
+	_, err := mat.VStack(a, b)
+	if errors.Is(err, errs.ErrShapeMismatch) {
+		var se *errs.ShapeError
+		errors.As(err, &se)
+		fmt.Println(se.Got, se.Want) // the shape of b, and the shape expected
+	}
+
+The errors returned by the other packages also keep wrapping the sentinel
+errors of those packages, such as mat.ErrShape and metric.ErrLength, and keep
+their messages, so that existing checks keep working.
+*/
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrShapeMismatch is the cause of the errors reported when the shapes
+	// of the arguments of a function do not fit together.
+	ErrShapeMismatch = errors.New("gocrunch: the shapes do not match")
+	// ErrSingular is the cause of the errors reported when a matrix which
+	// must be inverted, or a system of equations which must be solved, is
+	// singular.
+	ErrSingular = errors.New("gocrunch: the matrix is singular")
+	// ErrDivideByZero is the cause of the errors reported when dividing by
+	// a zero.
+	ErrDivideByZero = errors.New("gocrunch: division by zero")
+	// ErrEmpty is the cause of the errors reported when an argument which
+	// must have elements is empty.
+	ErrEmpty = errors.New("gocrunch: the argument is empty")
+)
+
+/*
+ShapeError is reported when the shapes of the arguments of a function do not
+fit together. It matches ErrShapeMismatch with errors.Is().
+*/
+type ShapeError struct {
+	// Got is the offending shape, such as {3} for a []float64 of length 3,
+	// or {2, 3} for a 2 by 3 [][]float64.
+	Got []int
+	// Want is the shape Got should have had to fit the other arguments. A
+	// dimension of -1 is not constrained.
+	Want []int
+	// Err is the error reported by the package of the function, which gives
+	// the message of the ShapeError, and which it wraps.
+	Err error
+}
+
+func (e *ShapeError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v: got %v, want %v", ErrShapeMismatch, e.Got, e.Want)
+}
+
+// Is reports whether target is ErrShapeMismatch.
+func (e *ShapeError) Is(target error) bool {
+	return target == ErrShapeMismatch
+}
+
+// Unwrap returns the error reported by the package of the function.
+func (e *ShapeError) Unwrap() error {
+	return e.Err
+}
+
+/*
+SingularError is reported when a matrix is found to be singular. It matches
+ErrSingular with errors.Is().
+*/
+type SingularError struct {
+	// Index is the row or column at which the matrix was found to be
+	// singular, such as the column without a pivot, or -1 if it is not
+	// known, as with the iterative solvers of linsolve.
+	Index int
+	// Err is the error reported by the package of the function, which gives
+	// the message of the SingularError, and which it wraps.
+	Err error
+}
+
+func (e *SingularError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	if e.Index < 0 {
+		return ErrSingular.Error()
+	}
+	return fmt.Sprintf("%v at index %d", ErrSingular, e.Index)
+}
+
+// Is reports whether target is ErrSingular.
+func (e *SingularError) Is(target error) bool {
+	return target == ErrSingular
+}
+
+// Unwrap returns the error reported by the package of the function.
+func (e *SingularError) Unwrap() error {
+	return e.Err
+}
+
+/*
+DivideByZeroError is reported when dividing by a zero. It matches
+ErrDivideByZero with errors.Is().
+*/
+type DivideByZeroError struct {
+	// Index is the index of the zero in the divisor, or -1 if the divisor
+	// is a scalar.
+	Index int
+	// Err is the error reported by the package of the function, which gives
+	// the message of the DivideByZeroError, and which it wraps.
+	Err error
+}
+
+func (e *DivideByZeroError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	if e.Index < 0 {
+		return ErrDivideByZero.Error()
+	}
+	return fmt.Sprintf("%v at index %d", ErrDivideByZero, e.Index)
+}
+
+// Is reports whether target is ErrDivideByZero.
+func (e *DivideByZeroError) Is(target error) bool {
+	return target == ErrDivideByZero
+}
+
+// Unwrap returns the error reported by the package of the function.
+func (e *DivideByZeroError) Unwrap() error {
+	return e.Err
+}
+
+/*
+EmptyError is reported when an argument which must have elements is empty.
+It matches ErrEmpty with errors.Is().
+*/
+type EmptyError struct {
+	// Got is the shape of the empty argument, such as {0} for a []float64,
+	// or {3, 0} for a [][]float64 of 3 empty rows.
+	Got []int
+	// Err is the error reported by the package of the function, which gives
+	// the message of the EmptyError, and which it wraps.
+	Err error
+}
+
+func (e *EmptyError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v: got %v", ErrEmpty, e.Got)
+}
+
+// Is reports whether target is ErrEmpty.
+func (e *EmptyError) Is(target error) bool {
+	return target == ErrEmpty
+}
+
+// Unwrap returns the error reported by the package of the function.
+func (e *EmptyError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,53 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestTypes(t *testing.T) {
+	cause := errors.New("pkg: the cause")
+	tests := []struct {
+		err      error
+		sentinel error
+		message  string
+	}{
+		{&ShapeError{Got: []int{2, 3}, Want: []int{3, 3}}, ErrShapeMismatch, "gocrunch: the shapes do not match: got [2 3], want [3 3]"},
+		{&SingularError{Index: 2}, ErrSingular, "gocrunch: the matrix is singular at index 2"},
+		{&SingularError{Index: -1}, ErrSingular, "gocrunch: the matrix is singular"},
+		{&DivideByZeroError{Index: -1}, ErrDivideByZero, "gocrunch: division by zero"},
+		{&DivideByZeroError{Index: 4}, ErrDivideByZero, "gocrunch: division by zero at index 4"},
+		{&EmptyError{Got: []int{0}}, ErrEmpty, "gocrunch: the argument is empty: got [0]"},
+	}
+	for _, test := range tests {
+		if !errors.Is(test.err, test.sentinel) {
+			t.Errorf("expected %v to match %v", test.err, test.sentinel)
+		}
+		if test.err.Error() != test.message {
+			t.Errorf("expected %q, got %q", test.message, test.err.Error())
+		}
+		for _, other := range []error{ErrShapeMismatch, ErrSingular, ErrDivideByZero, ErrEmpty} {
+			if other != test.sentinel && errors.Is(test.err, other) {
+				t.Errorf("expected %v not to match %v", test.err, other)
+			}
+		}
+	}
+	// The error of the package gives the message, and is wrapped.
+	err := fmt.Errorf("in Foo: %w", &SingularError{Index: 1, Err: fmt.Errorf("%w: column 1", cause)})
+	if !errors.Is(err, ErrSingular) || !errors.Is(err, cause) {
+		t.Errorf("expected %v to match both ErrSingular and the cause", err)
+	}
+	if err.Error() != "in Foo: pkg: the cause: column 1" {
+		t.Errorf("expected the message of the cause, got %q", err.Error())
+	}
+	var se *SingularError
+	if !errors.As(err, &se) || se.Index != 1 {
+		t.Errorf("expected a *SingularError at index 1, got %v", se)
+	}
+	var sh *ShapeError
+	if !errors.As(fmt.Errorf("wrapped: %w", &ShapeError{Got: []int{2}, Want: []int{3}}), &sh) || !slices.Equal(sh.Got, []int{2}) {
+		t.Errorf("expected the shape {2}, got %v", sh.Got)
+	}
+}
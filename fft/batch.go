@@ -0,0 +1,54 @@
+package fft
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+/*
+FFTBatch returns the discrete Fourier transform of each of the passed
+[]complex128, as computed by fft.FFT(). The signals may have different
+lengths, and are transformed in parallel on up to GOMAXPROCS goroutines. The
+passed signals are not modified in this function.
+*/
+func FFTBatch(xs [][]complex128) [][]complex128 {
+	res, _ := FFTBatchCtx(context.Background(), xs)
+	return res
+}
+
+/*
+FFTBatchCtx is FFTBatch(), stopping early if ctx is done before all of the
+signals are transformed, in which case it returns nil and ctx.Err(). The
+context is checked before each signal is transformed, so that the transforms
+are returned, with no error, if they all completed before ctx was done.
+*/
+func FFTBatchCtx(ctx context.Context, xs [][]complex128) ([][]complex128, error) {
+	res := make([][]complex128, len(xs))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(xs) {
+		workers = len(xs)
+	}
+	var next, done atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(xs) || ctx.Err() != nil {
+					return
+				}
+				res[i] = FFT(xs[i])
+				done.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if int(done.Load()) < len(xs) {
+		return nil, ctx.Err()
+	}
+	return res, nil
+}
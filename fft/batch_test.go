@@ -0,0 +1,59 @@
+package fft
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFFTBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	xs := make([][]complex128, 20)
+	for i := range xs {
+		xs[i] = make([]complex128, 1+r.Intn(100))
+		for j := range xs[i] {
+			xs[i][j] = complex(r.NormFloat64(), r.NormFloat64())
+		}
+	}
+	res := FFTBatch(xs)
+	if len(res) != len(xs) {
+		t.Fatalf("expected %d transforms, got %d", len(xs), len(res))
+	}
+	for i := range xs {
+		want := FFT(xs[i])
+		for j := range want {
+			if res[i][j] != want[j] {
+				t.Fatalf("signal %d: expected %v, got %v", i, want, res[i])
+			}
+		}
+	}
+	if res := FFTBatch(nil); len(res) != 0 {
+		t.Errorf("expected no transforms, got %v", res)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if res, err := FFTBatchCtx(ctx, xs); err != context.Canceled || res != nil {
+		t.Errorf("expected nil and %v, got %v and %v", context.Canceled, res, err)
+	}
+	// The context is done once every signal has been transformed.
+	late := &lateContext{Context: context.Background(), after: int64(len(xs))}
+	if res, err := FFTBatchCtx(late, xs); err != nil || len(res) != len(xs) || res[len(xs)-1] == nil {
+		t.Errorf("expected the %d transforms and no error, got %d and %v", len(xs), len(res), err)
+	}
+}
+
+// lateContext is a context which is done after its Err method has been
+// called after times.
+type lateContext struct {
+	context.Context
+	after int64
+	calls atomic.Int64
+}
+
+func (c *lateContext) Err() error {
+	if c.calls.Add(1) > c.after {
+		return context.Canceled
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+/*
+Package fft implements the discrete Fourier transform of one dimensional
+slices of complex128 and float64, along with its inverse.
+
+The transforms in this package work for any length. Lengths which are a
+power of 2 use an iterative radix-2 algorithm, other lengths are split into
+their prime factors using a mixed-radix algorithm, and large prime lengths
+are handled with Bluestein's algorithm, so that all lengths take
+O(n log n) time.
+
+The forward transform of x, of length n, is defined as
+
+	X[k] = sum over j of x[j] * exp(-2*pi*i*j*k/n)
+
+and the inverse transform includes the 1/n normalization, such that
+fft.IFFT(fft.FFT(x)) returns x, up to rounding errors.
+
+For real input, fft.RFFT() computes only the non-negative frequency terms,
+since the rest follow from symmetry, in about half of the time of the full
+complex transform. Use fft.FFTFreq() and fft.RFFTFreq() to find the frequency
+of each of the returned terms. Many signals are transformed in parallel with
+fft.FFTBatch(), or with fft.FFTBatchCtx(), which stops early when the passed
+context.Context is done.
+
+As with the other packages in gocrunch, invalid input is treated as a critical
+error, and causes a panic with a message that names the offending function.
+*/
+package fft
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/fft error.\nIn fft.%s, the length of the spectrum is %d, but a signal of length %d needs %d terms.\n",
+		"\ngocrunch/fft error.\nIn fft.%s, the %s must be greater than 0, received %v.\n",
+	}
+)
+
+// bluesteinThreshold is the size of the prime factors above which Bluestein's
+// algorithm is used instead of splitting the transform by that factor.
+const bluesteinThreshold = 32
+
+/*
+FFT returns the discrete Fourier transform of the passed []complex128. The
+passed []complex128 is not modified in this function.
+*/
+func FFT(x []complex128) []complex128 {
+	c := make([]complex128, len(x))
+	copy(c, x)
+	transform(c)
+	return c
+}
+
+/*
+IFFT returns the inverse discrete Fourier transform of the passed
+[]complex128, including the 1/n normalization. The passed []complex128 is not
+modified in this function.
+*/
+func IFFT(x []complex128) []complex128 {
+	n := len(x)
+	c := make([]complex128, n)
+	for i := range x {
+		c[i] = conj(x[i])
+	}
+	transform(c)
+	for i := range c {
+		c[i] = conj(c[i]) / complex(float64(n), 0.0)
+	}
+	return c
+}
+
+/*
+RFFT returns the discrete Fourier transform of the passed []float64. Since the
+transform of real input is conjugate symmetric, only the n/2+1 terms for the
+non-negative frequencies are returned, where n is the length of the passed
+[]float64. For example:
+
+	x := []float64{1.0, 0.0, -1.0, 0.0}
+	X := fft.RFFT(x) // X is {0, 2, 0}
+
+The passed []float64 is not modified in this function.
+*/
+func RFFT(x []float64) []complex128 {
+	n := len(x)
+	if n == 0 {
+		return []complex128{}
+	}
+	if n%2 != 0 {
+		c := make([]complex128, n)
+		for i := range x {
+			c[i] = complex(x[i], 0.0)
+		}
+		transform(c)
+		return c[:n/2+1]
+	}
+	// Pack the even and odd elements into the real and imaginary parts of a
+	// transform of half the length, and untangle the result.
+	h := n / 2
+	z := make([]complex128, h)
+	for i := range z {
+		z[i] = complex(x[2*i], x[2*i+1])
+	}
+	transform(z)
+	X := make([]complex128, h+1)
+	for k := 0; k <= h; k++ {
+		zk := z[k%h]
+		zr := conj(z[(h-k)%h])
+		even := (zk + zr) / 2.0
+		odd := (zk - zr) / complex(0.0, 2.0)
+		X[k] = even + twiddle(k, n)*odd
+	}
+	return X
+}
+
+/*
+IRFFT returns the []float64 of length n whose transform, as computed by
+fft.RFFT(), is the passed []complex128. This is the inverse of fft.RFFT(), and
+the length n of the original signal must be given, since signals of length
+2m and 2m+1 both have m+1 terms. The imaginary parts of the first term, and
+of the last term when n is even, are ignored.
+
+The passed []complex128 must have n/2+1 terms, otherwise this function will
+panic. The passed []complex128 is not modified in this function.
+*/
+func IRFFT(X []complex128, n int) []float64 {
+	if n <= 0 {
+		panic(fmt.Sprintf(errStrings[1], "IRFFT()", "length of the signal", n))
+	}
+	if len(X) != n/2+1 {
+		panic(fmt.Sprintf(errStrings[0], "IRFFT()", len(X), n, n/2+1))
+	}
+	x := make([]float64, n)
+	if n%2 != 0 {
+		full := make([]complex128, n)
+		copy(full, X)
+		for k := len(X); k < n; k++ {
+			full[k] = conj(X[n-k])
+		}
+		c := IFFT(full)
+		for i := range x {
+			x[i] = real(c[i])
+		}
+		return x
+	}
+	h := n / 2
+	z := make([]complex128, h)
+	for k := range z {
+		xk := X[k]
+		xr := conj(X[h-k])
+		even := (xk + xr) / 2.0
+		odd := (xk - xr) / (2.0 * twiddle(k, n))
+		z[k] = even + complex(0.0, 1.0)*odd
+	}
+	z = IFFT(z)
+	for i := range z {
+		x[2*i] = real(z[i])
+		x[2*i+1] = imag(z[i])
+	}
+	return x
+}
+
+/*
+FFTFreq returns the frequency of each of the terms returned by fft.FFT() for
+a signal of length n, sampled with a spacing of d. The frequencies are in
+cycles per unit of d, with the non-negative frequencies first, followed by
+the negative frequencies. For example:
+
+	f := fft.FFTFreq(4, 0.5) // f is {0.0, 0.5, -1.0, -0.5}
+
+n and d must be greater than 0, otherwise this function will panic.
+*/
+func FFTFreq(n int, d float64) []float64 {
+	checkFreqArgs("FFTFreq()", n, d)
+	f := make([]float64, n)
+	for i := range f {
+		k := i
+		if i > (n-1)/2 {
+			k = i - n
+		}
+		f[i] = float64(k) / (float64(n) * d)
+	}
+	return f
+}
+
+/*
+RFFTFreq returns the frequency of each of the n/2+1 terms returned by
+fft.RFFT() for a signal of length n, sampled with a spacing of d. For example:
+
+	f := fft.RFFTFreq(4, 0.5) // f is {0.0, 0.5, 1.0}
+
+n and d must be greater than 0, otherwise this function will panic.
+*/
+func RFFTFreq(n int, d float64) []float64 {
+	checkFreqArgs("RFFTFreq()", n, d)
+	f := make([]float64, n/2+1)
+	for i := range f {
+		f[i] = float64(i) / (float64(n) * d)
+	}
+	return f
+}
+
+func checkFreqArgs(fn string, n int, d float64) {
+	if n <= 0 {
+		panic(fmt.Sprintf(errStrings[1], fn, "length", n))
+	}
+	if !(d > 0.0) {
+		panic(fmt.Sprintf(errStrings[1], fn, "sample spacing", d))
+	}
+}
+
+// transform computes the forward transform of x in place.
+func transform(x []complex128) {
+	n := len(x)
+	switch {
+	case n <= 1:
+	case n&(n-1) == 0:
+		radix2(x)
+	default:
+		p := smallestFactor(n)
+		switch {
+		case p > bluesteinThreshold:
+			bluestein(x)
+		case p < n:
+			mixedRadix(x, p)
+		default:
+			dft(x)
+		}
+	}
+}
+
+// radix2 computes the forward transform of x in place, where the length of x
+// is a power of 2.
+func radix2(x []complex128) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		step := n / size
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				t := twiddle(k*step, n) * x[start+k+half]
+				u := x[start+k]
+				x[start+k] = u + t
+				x[start+k+half] = u - t
+			}
+		}
+	}
+}
+
+// mixedRadix computes the forward transform of x in place by splitting it
+// into p interleaved sub-sequences, where p divides the length of x.
+func mixedRadix(x []complex128, p int) {
+	n := len(x)
+	m := n / p
+	subs := make([][]complex128, p)
+	for r := range subs {
+		subs[r] = make([]complex128, m)
+		for j := range subs[r] {
+			subs[r][j] = x[j*p+r]
+		}
+		transform(subs[r])
+	}
+	for k := 0; k < n; k++ {
+		sum := complex(0.0, 0.0)
+		for r := 0; r < p; r++ {
+			sum += twiddle(r*k, n) * subs[r][k%m]
+		}
+		x[k] = sum
+	}
+}
+
+// dft computes the forward transform of x in place from the definition.
+func dft(x []complex128) {
+	n := len(x)
+	c := make([]complex128, n)
+	for k := range c {
+		for j := range x {
+			c[k] += twiddle(j*k, n) * x[j]
+		}
+	}
+	copy(x, c)
+}
+
+// bluestein computes the forward transform of x in place for any length, by
+// expressing it as a convolution which is computed with power of 2 transforms.
+func bluestein(x []complex128) {
+	n := len(x)
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+	// chirp[k] is exp(-pi*i*k*k/n). k*k is reduced modulo 2n to keep the
+	// angle small and accurate.
+	chirp := make([]complex128, n)
+	for k := range chirp {
+		chirp[k] = twiddle((k*k)%(2*n), 2*n)
+	}
+	a := make([]complex128, m)
+	b := make([]complex128, m)
+	for k := 0; k < n; k++ {
+		a[k] = x[k] * chirp[k]
+		b[k] = conj(chirp[k])
+		if k > 0 {
+			b[m-k] = b[k]
+		}
+	}
+	radix2(a)
+	radix2(b)
+	for i := range a {
+		a[i] = conj(a[i] * b[i])
+	}
+	radix2(a)
+	for k := 0; k < n; k++ {
+		x[k] = chirp[k] * conj(a[k]) / complex(float64(m), 0.0)
+	}
+}
+
+// twiddle returns exp(-2*pi*i*k/n).
+func twiddle(k, n int) complex128 {
+	s, c := math.Sincos(-2.0 * math.Pi * float64(k%n) / float64(n))
+	return complex(c, s)
+}
+
+// smallestFactor returns the smallest prime factor of n.
+func smallestFactor(n int) int {
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
+
+func conj(c complex128) complex128 {
+	return complex(real(c), -imag(c))
+}
@@ -0,0 +1,162 @@
+package fft
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// naive computes the forward transform of x from the definition.
+func naive(x []complex128) []complex128 {
+	n := len(x)
+	c := make([]complex128, n)
+	for k := range c {
+		for j := range x {
+			c[k] += x[j] * cmplx.Exp(complex(0.0, -2.0*math.Pi*float64(j*k)/float64(n)))
+		}
+	}
+	return c
+}
+
+func randComplex(n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(rand.Float64()-0.5, rand.Float64()-0.5)
+	}
+	return x
+}
+
+func TestFFT(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 5, 6, 8, 12, 31, 37, 64, 97, 100, 210, 1517} {
+		x := randComplex(n)
+		X := FFT(x)
+		expected := naive(x)
+		for k := range X {
+			if cmplx.Abs(X[k]-expected[k]) > 1e-9 {
+				t.Errorf("n = %d, at index %d, expected %v, got %v", n, k, expected[k], X[k])
+				break
+			}
+		}
+		y := IFFT(X)
+		for i := range y {
+			if cmplx.Abs(y[i]-x[i]) > 1e-12 {
+				t.Errorf("n = %d, the round trip at index %d gave %v instead of %v", n, i, y[i], x[i])
+				break
+			}
+		}
+	}
+}
+
+func TestRFFT(t *testing.T) {
+	x := []float64{1.0, 0.0, -1.0, 0.0}
+	X := RFFT(x)
+	expected := []complex128{0.0, 2.0, 0.0}
+	for k := range X {
+		if cmplx.Abs(X[k]-expected[k]) > 1e-12 {
+			t.Errorf("at index %d, expected %v, got %v", k, expected[k], X[k])
+		}
+	}
+	for _, n := range []int{1, 2, 5, 8, 9, 38, 100} {
+		x := make([]float64, n)
+		c := make([]complex128, n)
+		for i := range x {
+			x[i] = rand.Float64()
+			c[i] = complex(x[i], 0.0)
+		}
+		X := RFFT(x)
+		full := naive(c)
+		if len(X) != n/2+1 {
+			t.Errorf("n = %d, expected %d terms, got %d", n, n/2+1, len(X))
+		}
+		for k := range X {
+			if cmplx.Abs(X[k]-full[k]) > 1e-9 {
+				t.Errorf("n = %d, at index %d, expected %v, got %v", n, k, full[k], X[k])
+				break
+			}
+		}
+		y := IRFFT(X, n)
+		for i := range y {
+			if math.Abs(y[i]-x[i]) > 1e-12 {
+				t.Errorf("n = %d, the round trip at index %d gave %f instead of %f", n, i, y[i], x[i])
+				break
+			}
+		}
+	}
+}
+
+func TestIRFFTErrors(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[0], "IRFFT()", 2, 4, 3)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		IRFFT(make([]complex128, 2), 4)
+	}()
+	wg.Wait()
+}
+
+func TestFFTFreq(t *testing.T) {
+	f := FFTFreq(4, 0.5)
+	expected := []float64{0.0, 0.5, -1.0, -0.5}
+	for i := range f {
+		if f[i] != expected[i] {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], f[i])
+		}
+	}
+	f = FFTFreq(5, 1.0)
+	expected = []float64{0.0, 0.2, 0.4, -0.4, -0.2}
+	for i := range f {
+		if math.Abs(f[i]-expected[i]) > 1e-15 {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], f[i])
+		}
+	}
+	f = RFFTFreq(4, 0.5)
+	expected = []float64{0.0, 0.5, 1.0}
+	for i := range f {
+		if f[i] != expected[i] {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], f[i])
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[1], "FFTFreq()", "sample spacing", 0.0)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		FFTFreq(4, 0.0)
+	}()
+	wg.Wait()
+}
+
+func BenchmarkFFT(b *testing.B) {
+	x := randComplex(4096)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FFT(x)
+	}
+}
+
+func BenchmarkRFFT(b *testing.B) {
+	x := make([]float64, 4096)
+	for i := range x {
+		x[i] = rand.Float64()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RFFT(x)
+	}
+}
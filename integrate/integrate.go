@@ -0,0 +1,211 @@
+/*
+Package integrate implements the numerical integration of functions of one
+variable.
+
+Where vec.Trapz() integrates data that has already been sampled, the
+functions in this package choose where to evaluate the integrand themselves,
+refining the parts of the interval where it is hardest to integrate, until the
+requested accuracy is reached:
+
+	f := func(x float64) float64 { return math.Exp(-x * x) }
+	v, est, err := integrate.Quad(f, 0, math.Inf(1), nil) // v is Sqrt(Pi)/2
+
+Along with the estimated integral, the functions return an estimate of its
+absolute error, and an error value when the requested accuracy could not be
+reached.
+*/
+package integrate
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrMaxSubdivisions is returned when the requested accuracy is not reached
+// after the maximum number of subdivisions of the interval. The returned
+// estimate and error estimate are still the best available.
+var ErrMaxSubdivisions = errors.New("integrate: maximum number of subdivisions reached")
+
+/*
+QuadSettings configures integrate.Quad(). The zero value of each field is
+replaced by the default mentioned in its description.
+*/
+type QuadSettings struct {
+	// AbsTol and RelTol are the requested absolute and relative accuracy.
+	// Subdivision stops when the error estimate is below either of them,
+	// relative to the value of the integral for RelTol. Both are 1e-10 by
+	// default.
+	AbsTol, RelTol float64
+	// MaxSubdivisions is the maximum number of times an interval is split,
+	// 200 by default.
+	MaxSubdivisions int
+}
+
+/*
+Quad returns the integral of f from a to b, along with an estimate of the
+absolute error of the result. The integral is computed with the adaptive
+15-point Gauss-Kronrod rule: the interval with the largest error estimate is
+bisected repeatedly, until the total error estimate reaches the tolerances in
+the passed QuadSettings.
+
+Either or both limits may be infinite, in which case the integral is computed
+over a finite interval after a change of variables. If b is less than a, the
+negated integral from b to a is returned.
+
+If the requested accuracy is not reached within the maximum number of
+subdivisions, ErrMaxSubdivisions is returned along with the best estimates. A
+nil QuadSettings uses the defaults of all its fields.
+*/
+func Quad(f func(float64) float64, a, b float64, s *QuadSettings) (float64, float64, error) {
+	set := quadDefaults(s)
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.NaN(), math.NaN(), fmt.Errorf("integrate: the limits %g and %g must not be NaN", a, b)
+	}
+	if a == b {
+		return 0.0, 0.0, nil
+	}
+	if b < a {
+		v, e, err := Quad(f, b, a, s)
+		return -v, e, err
+	}
+	g, lo, hi := transform(f, a, b)
+	first := kronrod(g, lo, hi)
+	intervals := &intervalHeap{first}
+	total, totalErr := first.value, first.err
+	for i := 0; i < set.MaxSubdivisions; i++ {
+		if totalErr <= set.AbsTol || totalErr <= set.RelTol*math.Abs(total) {
+			return total, totalErr, nil
+		}
+		worst := heap.Pop(intervals).(interval)
+		mid := worst.lo + 0.5*(worst.hi-worst.lo)
+		left, right := kronrod(g, worst.lo, mid), kronrod(g, mid, worst.hi)
+		total += left.value + right.value - worst.value
+		totalErr += left.err + right.err - worst.err
+		heap.Push(intervals, left)
+		heap.Push(intervals, right)
+	}
+	// Recompute the totals to avoid the rounding errors of the updates.
+	total, totalErr = 0.0, 0.0
+	for _, iv := range *intervals {
+		total += iv.value
+		totalErr += iv.err
+	}
+	if totalErr <= set.AbsTol || totalErr <= set.RelTol*math.Abs(total) {
+		return total, totalErr, nil
+	}
+	return total, totalErr, fmt.Errorf("%w: the error estimate is %g after %d subdivisions", ErrMaxSubdivisions, totalErr, set.MaxSubdivisions)
+}
+
+// transform returns an integrand and finite limits whose integral is the
+// integral of f from a to b.
+func transform(f func(float64) float64, a, b float64) (func(float64) float64, float64, float64) {
+	switch {
+	case math.IsInf(a, -1) && math.IsInf(b, 1):
+		// x = t / (1 - t^2), for t in (-1, 1).
+		return func(t float64) float64 {
+			d := 1.0 - t*t
+			return f(t/d) * (1.0 + t*t) / (d * d)
+		}, -1.0, 1.0
+	case math.IsInf(b, 1):
+		// x = a + t / (1 - t), for t in [0, 1).
+		return func(t float64) float64 {
+			d := 1.0 - t
+			return f(a+t/d) / (d * d)
+		}, 0.0, 1.0
+	case math.IsInf(a, -1):
+		// x = b - t / (1 - t), for t in [0, 1).
+		return func(t float64) float64 {
+			d := 1.0 - t
+			return f(b-t/d) / (d * d)
+		}, 0.0, 1.0
+	}
+	return f, a, b
+}
+
+// The nodes and weights of the 15-point Kronrod rule, and the weights of the
+// embedded 7-point Gauss rule, which uses the odd numbered Kronrod nodes.
+var (
+	xgk = [8]float64{
+		0.991455371120812639206854697526329,
+		0.949107912342758524526189684047851,
+		0.864864423359769072789712788640926,
+		0.741531185599394439863864773280788,
+		0.586087235467691130294144845693013,
+		0.405845151377397166906606412076961,
+		0.207784955007898467600689403773245,
+		0.000000000000000000000000000000000,
+	}
+	wgk = [8]float64{
+		0.022935322010529224963732008058970,
+		0.063092092629978553290700663189204,
+		0.104790010322250183839876322541518,
+		0.140653259715525918745189590510238,
+		0.169004726639267902826583426598550,
+		0.190350578064785409913256402421014,
+		0.204432940075298892414161999234649,
+		0.209482141084727828012999174891714,
+	}
+	wg = [4]float64{
+		0.129484966168869693270611432679082,
+		0.279705391489276667901467771423780,
+		0.381830050505118944950369775488975,
+		0.417959183673469387755102040816327,
+	}
+)
+
+type interval struct {
+	lo, hi, value, err float64
+}
+
+// kronrod applies the 15-point Gauss-Kronrod rule to g over [lo, hi]. The
+// error is estimated from the difference with the 7-point Gauss rule. The
+// nodes are all interior, so g is never evaluated at lo or hi.
+func kronrod(g func(float64) float64, lo, hi float64) interval {
+	center := 0.5 * (lo + hi)
+	half := 0.5 * (hi - lo)
+	fc := g(center)
+	resK := fc * wgk[7]
+	resG := fc * wg[3]
+	for j := 0; j < 7; j++ {
+		dx := half * xgk[j]
+		sum := g(center-dx) + g(center+dx)
+		resK += wgk[j] * sum
+		if j%2 == 1 {
+			resG += wg[j/2] * sum
+		}
+	}
+	return interval{lo: lo, hi: hi, value: resK * half, err: math.Abs((resK - resG) * half)}
+}
+
+// intervalHeap is a max-heap of intervals ordered by their error estimate.
+type intervalHeap []interval
+
+func (h intervalHeap) Len() int            { return len(h) }
+func (h intervalHeap) Less(i, j int) bool  { return h[i].err > h[j].err }
+func (h intervalHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *intervalHeap) Push(x interface{}) { *h = append(*h, x.(interval)) }
+func (h *intervalHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func quadDefaults(s *QuadSettings) QuadSettings {
+	var set QuadSettings
+	if s != nil {
+		set = *s
+	}
+	if set.AbsTol == 0.0 {
+		set.AbsTol = 1e-10
+	}
+	if set.RelTol == 0.0 {
+		set.RelTol = 1e-10
+	}
+	if set.MaxSubdivisions <= 0 {
+		set.MaxSubdivisions = 200
+	}
+	return set
+}
@@ -0,0 +1,47 @@
+package integrate
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestQuad(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(float64) float64
+		a, b     float64
+		expected float64
+	}{
+		{"polynomial", func(x float64) float64 { return x*x*x - x }, 0.0, 2.0, 2.0},
+		{"sine", math.Sin, 0.0, math.Pi, 2.0},
+		{"reversed", math.Sin, math.Pi, 0.0, -2.0},
+		{"sqrt singularity", func(x float64) float64 { return 1.0 / math.Sqrt(x) }, 0.0, 1.0, 2.0},
+		{"log singularity", math.Log, 0.0, 1.0, -1.0},
+		{"oscillatory", func(x float64) float64 { return math.Cos(50.0 * x) }, 0.0, 1.0, math.Sin(50.0) / 50.0},
+		{"half line", func(x float64) float64 { return math.Exp(-x * x) }, 0.0, math.Inf(1), math.Sqrt(math.Pi) / 2.0},
+		{"left half line", math.Exp, math.Inf(-1), 0.0, 1.0},
+		{"whole line", func(x float64) float64 { return 1.0 / (1.0 + x*x) }, math.Inf(-1), math.Inf(1), math.Pi},
+		{"empty", math.Exp, 1.0, 1.0, 0.0},
+	}
+	for _, test := range tests {
+		v, est, err := Quad(test.f, test.a, test.b, nil)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if math.Abs(v-test.expected) > 1e-8 {
+			t.Errorf("%s: expected %.12f, got %.12f", test.name, test.expected, v)
+		}
+		if math.Abs(v-test.expected) > est+1e-12 {
+			t.Errorf("%s: the error estimate %e is below the actual error %e", test.name, est, math.Abs(v-test.expected))
+		}
+	}
+}
+
+func TestQuadMaxSubdivisions(t *testing.T) {
+	f := func(x float64) float64 { return math.Sin(1.0 / x) }
+	_, _, err := Quad(f, 0.0, 1.0, &QuadSettings{MaxSubdivisions: 3, AbsTol: 1e-14, RelTol: 1e-14})
+	if !errors.Is(err, ErrMaxSubdivisions) {
+		t.Errorf("expected ErrMaxSubdivisions, got %v", err)
+	}
+}
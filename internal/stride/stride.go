@@ -0,0 +1,44 @@
+/*
+Package stride implements the numpy slicing rules shared by the strided
+views of the vec and mat packages.
+*/
+package stride
+
+import "math"
+
+/*
+Span returns the first index and the number of elements picked by start,
+stop and step among n elements, following numpy. Negative starts and stops
+count from the end, and bounds past either end are clamped to it, so that
+math.MinInt and math.MaxInt stand for the omitted bounds of numpy. step must
+not be 0.
+*/
+func Span(n, start, stop, step int) (int, int) {
+	lo, hi := 0, n
+	if step < 0 {
+		lo, hi = -1, n-1
+	}
+	clamp := func(k int) int {
+		if k < 0 {
+			k += n
+		}
+		return max(lo, min(k, hi))
+	}
+	start, stop = clamp(start), clamp(stop)
+	if step > 0 {
+		if stop <= start {
+			return 0, 0
+		}
+		// The first element is always picked, and the form avoids the
+		// overflow of stop - start + step for a large step.
+		return start, (stop-start-1)/step + 1
+	}
+	if start <= stop {
+		return 0, 0
+	}
+	if step == math.MinInt {
+		// -step overflows, but is larger than any span.
+		return start, 1
+	}
+	return start, (start-stop-1)/(-step) + 1
+}
@@ -0,0 +1,32 @@
+package stride
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpan(t *testing.T) {
+	tests := []struct {
+		n, start, stop, step int
+		off, count           int
+	}{
+		{6, 1, 5, 2, 1, 2},
+		{6, -1, 0, -2, 5, 3},
+		{6, math.MaxInt, math.MinInt, -1, 5, 6},
+		{6, math.MinInt, math.MaxInt, 4, 0, 2},
+		{6, 4, 2, 1, 0, 0},
+		{6, 2, 4, -1, 0, 0},
+		{0, 0, math.MaxInt, 1, 0, 0},
+		{3, 0, 3, math.MaxInt, 0, 1},
+		{3, math.MinInt, math.MaxInt, math.MaxInt - 1, 0, 1},
+		{3, 2, math.MinInt, math.MinInt, 2, 1},
+		{3, -1, math.MinInt, math.MinInt + 1, 2, 1},
+	}
+	for _, test := range tests {
+		off, count := Span(test.n, test.start, test.stop, test.step)
+		if off != test.off || count != test.count {
+			t.Errorf("Span(%d, %d, %d, %d): expected %d and %d, got %d and %d",
+				test.n, test.start, test.stop, test.step, test.off, test.count, off, count)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package interp
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+Bezier returns the value at the parameter t of the Bezier curve with the
+passed control points, evaluated with de Casteljau's algorithm. The curve
+starts at the first control point for a t of 0.0, and ends at the last one
+for a t of 1.0. For curves in more than one dimension, call Bezier with the
+coordinates of the control points along each dimension in turn.
+
+At least one control point must be passed, otherwise this function will
+panic. The passed []float64 is not modified in this function.
+*/
+func Bezier(ctrl []float64, t float64) float64 {
+	if len(ctrl) == 0 {
+		panic(fmt.Sprintf(errStrings[6], "Bezier()"))
+	}
+	return deCasteljau(make([]float64, len(ctrl)), ctrl, t)
+}
+
+/*
+BezierVec returns the value of the Bezier curve with the passed control
+points at each of the parameters in ts. See Bezier() for details.
+*/
+func BezierVec(ctrl, ts []float64) []float64 {
+	if len(ctrl) == 0 {
+		panic(fmt.Sprintf(errStrings[6], "BezierVec()"))
+	}
+	work := make([]float64, len(ctrl))
+	v := make([]float64, len(ts))
+	for i := range ts {
+		v[i] = deCasteljau(work, ctrl, ts[i])
+	}
+	return v
+}
+
+func deCasteljau(work, ctrl []float64, t float64) float64 {
+	copy(work, ctrl)
+	for n := len(work) - 1; n > 0; n-- {
+		for i := 0; i < n; i++ {
+			work[i] = (1.0-t)*work[i] + t*work[i+1]
+		}
+	}
+	return work[0]
+}
+
+/*
+BSpline is a spline curve defined by its degree, a nondecreasing sequence of
+knots, and the coefficients of its basis functions, which are the control
+points of the curve.
+
+A BSpline with n control points of degree k has n + k + 1 knots, and is
+defined on the interval from knots[k] to knots[n]. Outside of this interval,
+it is extrapolated using the polynomial piece of the first or last span.
+*/
+type BSpline struct {
+	knots, ctrl []float64
+	degree      int
+}
+
+/*
+NewBSpline returns the BSpline of the passed degree with the passed knots and
+control points. Repeating the first and last knots degree + 1 times gives a
+curve that starts at the first control point and ends at the last one.
+
+The degree must not be negative, at least one control point must be passed,
+there must be len(ctrl) + degree + 1 knots, and the knots must not be
+decreasing, otherwise this function will panic. The passed slices are copied,
+and not modified in this function.
+*/
+func NewBSpline(knots, ctrl []float64, degree int) *BSpline {
+	if degree < 0 {
+		panic(fmt.Sprintf(errStrings[7], "NewBSpline()", degree))
+	}
+	if len(ctrl) == 0 {
+		panic(fmt.Sprintf(errStrings[6], "NewBSpline()"))
+	}
+	if len(knots) != len(ctrl)+degree+1 {
+		panic(fmt.Sprintf(errStrings[8], "NewBSpline()", len(ctrl), degree, len(ctrl)+degree+1, len(knots)))
+	}
+	for i := 1; i < len(knots); i++ {
+		if knots[i] < knots[i-1] {
+			panic(fmt.Sprintf(errStrings[9], "NewBSpline()", i))
+		}
+	}
+	return &BSpline{
+		knots:  append([]float64(nil), knots...),
+		ctrl:   append([]float64(nil), ctrl...),
+		degree: degree,
+	}
+}
+
+/*
+Eval returns the value of the BSpline at the parameter t, evaluated with de
+Boor's algorithm.
+*/
+func (b *BSpline) Eval(t float64) float64 {
+	return b.deBoor(make([]float64, b.degree+1), t)
+}
+
+/*
+EvalVec returns the value of the BSpline at each of the parameters in ts. The
+passed []float64 is not modified in this function.
+*/
+func (b *BSpline) EvalVec(ts []float64) []float64 {
+	work := make([]float64, b.degree+1)
+	v := make([]float64, len(ts))
+	for i := range ts {
+		v[i] = b.deBoor(work, ts[i])
+	}
+	return v
+}
+
+// span returns the index s of the knot span [knots[s], knots[s+1]) that
+// contains t, limited to the spans on which the BSpline is defined.
+func (b *BSpline) span(t float64) int {
+	k, n := b.degree, len(b.ctrl)
+	s := sort.Search(len(b.knots), func(i int) bool { return b.knots[i] > t }) - 1
+	if s < k {
+		s = k
+	}
+	// Skip back over empty spans at the end, so that the last knot of the
+	// interval belongs to the last nonempty span.
+	for s > n-1 {
+		s--
+	}
+	for s > k && b.knots[s] == b.knots[s+1] {
+		s--
+	}
+	return s
+}
+
+func (b *BSpline) deBoor(work []float64, t float64) float64 {
+	k := b.degree
+	s := b.span(t)
+	copy(work, b.ctrl[s-k:s+1])
+	for r := 1; r <= k; r++ {
+		for j := k; j >= r; j-- {
+			i := s - k + j
+			den := b.knots[i+k+1-r] - b.knots[i]
+			alpha := 0.0
+			if den != 0.0 {
+				alpha = (t - b.knots[i]) / den
+			}
+			work[j] = (1.0-alpha)*work[j-1] + alpha*work[j]
+		}
+	}
+	return work[k]
+}
@@ -0,0 +1,99 @@
+package interp
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestBezier(t *testing.T) {
+	ctrl := []float64{0.0, 2.0, -1.0, 3.0}
+	// The cubic Bernstein form of the curve.
+	f := func(t float64) float64 {
+		u := 1.0 - t
+		return u*u*u*ctrl[0] + 3.0*u*u*t*ctrl[1] + 3.0*u*t*t*ctrl[2] + t*t*t*ctrl[3]
+	}
+	ts := []float64{0.0, 0.25, 0.5, 0.9, 1.0}
+	v := BezierVec(ctrl, ts)
+	for i := range ts {
+		if math.Abs(v[i]-f(ts[i])) > 1e-12 {
+			t.Errorf("at %f, expected %f, got %f", ts[i], f(ts[i]), v[i])
+		}
+	}
+	if b := Bezier([]float64{4.0}, 0.7); b != 4.0 {
+		t.Errorf("expected a constant curve, got %f", b)
+	}
+	if ctrl[1] != 2.0 {
+		t.Errorf("the control points were modified: %v", ctrl)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[6], "Bezier()")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Bezier([]float64{}, 0.5)
+	}()
+	wg.Wait()
+}
+
+func TestBSpline(t *testing.T) {
+	// With clamped knots and a single span, a BSpline is a Bezier curve.
+	ctrl := []float64{0.0, 2.0, -1.0, 3.0}
+	b := NewBSpline([]float64{0, 0, 0, 0, 1, 1, 1, 1}, ctrl, 3)
+	ts := []float64{0.0, 0.25, 0.5, 0.9, 1.0}
+	v, bz := b.EvalVec(ts), BezierVec(ctrl, ts)
+	for i := range ts {
+		if math.Abs(v[i]-bz[i]) > 1e-12 {
+			t.Errorf("at %f, expected %f, got %f", ts[i], bz[i], v[i])
+		}
+	}
+	// A linear BSpline interpolates its control points at the knots.
+	lin := NewBSpline([]float64{0, 0, 1, 2, 3, 3}, []float64{1.0, 3.0, 2.0, 5.0}, 1)
+	for i, e := range map[float64]float64{0.0: 1.0, 0.5: 2.0, 1.0: 3.0, 2.5: 3.5, 3.0: 5.0} {
+		if got := lin.Eval(i); math.Abs(got-e) > 1e-12 {
+			t.Errorf("at %f, expected %f, got %f", i, e, got)
+		}
+	}
+	// The uniform cubic basis functions sum to one.
+	knots := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	ones := NewBSpline(knots, []float64{1, 1, 1, 1, 1, 1}, 3)
+	for _, p := range []float64{3.0, 3.7, 4.5, 6.0} {
+		if got := ones.Eval(p); math.Abs(got-1.0) > 1e-12 {
+			t.Errorf("at %f, expected 1.0, got %f", p, got)
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[8], "NewBSpline()", 4, 3, 8, 7)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		NewBSpline([]float64{0, 0, 0, 1, 1, 1, 1}, ctrl, 3)
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[9], "NewBSpline()", 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		NewBSpline([]float64{0, 1, 0.5, 2}, []float64{1, 2}, 1)
+	}()
+	wg.Wait()
+}
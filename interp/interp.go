@@ -0,0 +1,248 @@
+/*
+Package interp implements smooth interpolation of functions that are only
+known at a set of sample points, stored as one dimensional slices of float64.
+
+The main type of this package is the Spline, a piecewise cubic polynomial that
+passes through all of the samples, and whose first and second derivatives are
+continuous. A Spline is fitted once, and can then be evaluated, along with its
+derivatives, at any point:
+
+	x := []float64{0.0, 1.0, 2.0, 3.0}
+	y := []float64{0.0, 1.0, 0.0, 1.0}
+	s := interp.NewNatural(x, y)
+	s.Eval(1.5)          // the interpolated value at 1.5
+	s.Derivative(1.5, 1) // the slope at 1.5
+
+For noisy samples, NewSmoothing fits a Spline that trades closeness to the
+samples for smoothness. The package also evaluates Bezier curves and
+BSplines, given their control points.
+
+As with the other packages in gocrunch, invalid input such as sample
+points that are not increasing is treated as a critical error, and causes
+a panic with a message that names the offending function.
+*/
+package interp
+
+import (
+	"fmt"
+	"sort"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/interp error.\nIn interp.%s, the length of x is %d, while the length of y is %d. They must match.\n",
+		"\ngocrunch/interp error.\nIn interp.%s, at least %d sample points are needed, but received %d.\n",
+		"\ngocrunch/interp error.\nIn interp.%s, the sample points must be strictly increasing, but x[%d] is not.\n",
+		"\ngocrunch/interp error.\nIn interp.%s, the order of the derivative must not be negative, received %d.\n",
+		"\ngocrunch/interp error.\nIn interp.%s, the smoothing parameter must not be negative, received %g.\n",
+		"\ngocrunch/interp error.\nIn interp.%s, the weights must be positive, but element %d is %g.\n",
+		"\ngocrunch/interp error.\nIn interp.%s, at least one control point is needed.\n",
+		"\ngocrunch/interp error.\nIn interp.%s, the degree must not be negative, received %d.\n",
+		"\ngocrunch/interp error.\nIn interp.%s, %d control points of degree %d need %d knots, but received %d.\n",
+		"\ngocrunch/interp error.\nIn interp.%s, the knots must not be decreasing, but knots[%d] is.\n",
+	}
+)
+
+/*
+Spline is a cubic spline fitted to a set of samples. Between each pair of
+neighboring sample points, it is a cubic polynomial, and the polynomials of
+neighboring intervals share their value, slope and curvature at the sample
+point between them.
+
+Outside of the range of the sample points, the Spline is extrapolated using
+the polynomial of the first or last interval.
+*/
+type Spline struct {
+	x []float64
+	// The coefficients of the polynomial on interval i, in powers of
+	// (t - x[i]).
+	a, b, c, d []float64
+}
+
+/*
+NewNatural fits a natural cubic spline to the samples y taken at the points x.
+A natural spline has zero curvature at the first and last sample points.
+
+x and y must have equal lengths of at least 2, and the elements of x must be
+strictly increasing, otherwise this function will panic. The passed slices
+are copied, and not modified in this function.
+*/
+func NewNatural(x, y []float64) *Spline {
+	validate("NewNatural()", x, y)
+	return fit(x, y, false, 0.0, 0.0)
+}
+
+/*
+NewClamped fits a clamped cubic spline to the samples y taken at the points x.
+A clamped spline has the slope d0 at the first sample point, and the slope dn
+at the last sample point.
+
+x and y must have equal lengths of at least 2, and the elements of x must be
+strictly increasing, otherwise this function will panic. The passed slices
+are copied, and not modified in this function.
+*/
+func NewClamped(x, y []float64, d0, dn float64) *Spline {
+	validate("NewClamped()", x, y)
+	return fit(x, y, true, d0, dn)
+}
+
+/*
+Eval returns the value of the Spline at the point t.
+*/
+func (s *Spline) Eval(t float64) float64 {
+	return s.Derivative(t, 0)
+}
+
+/*
+EvalVec returns the value of the Spline at each of the points in the passed
+[]float64. The passed []float64 is not modified in this function.
+*/
+func (s *Spline) EvalVec(ts []float64) []float64 {
+	return s.DerivativeVec(ts, 0)
+}
+
+/*
+Derivative returns the derivative of the given order of the Spline at the
+point t. An order of 0 is the value of the Spline itself, and since the
+Spline is made of cubic polynomials, all derivatives of order 4 or higher are
+0.0. At the interior sample points, the third derivative is taken from the
+interval to the right of the point, and at the last one, from the interval
+to its left.
+
+order must not be negative, otherwise this function will panic.
+*/
+func (s *Spline) Derivative(t float64, order int) float64 {
+	if order < 0 {
+		panic(fmt.Sprintf(errStrings[3], "Derivative()", order))
+	}
+	i := s.interval(t)
+	h := t - s.x[i]
+	a, b, c, d := s.a[i], s.b[i], s.c[i], s.d[i]
+	switch order {
+	case 0:
+		return a + h*(b+h*(c+h*d))
+	case 1:
+		return b + h*(2.0*c+h*3.0*d)
+	case 2:
+		return 2.0*c + 6.0*d*h
+	case 3:
+		return 6.0 * d
+	default:
+		return 0.0
+	}
+}
+
+/*
+DerivativeVec returns the derivative of the given order of the Spline at each
+of the points in the passed []float64. See Derivative() for details. The
+passed []float64 is not modified in this function.
+*/
+func (s *Spline) DerivativeVec(ts []float64, order int) []float64 {
+	if order < 0 {
+		panic(fmt.Sprintf(errStrings[3], "DerivativeVec()", order))
+	}
+	v := make([]float64, len(ts))
+	for i := range ts {
+		v[i] = s.Derivative(ts[i], order)
+	}
+	return v
+}
+
+// interval returns the index of the polynomial piece used at the point t,
+// which is the piece to the right of t if t is an interior sample point.
+func (s *Spline) interval(t float64) int {
+	i := sort.Search(len(s.x), func(j int) bool { return s.x[j] > t }) - 1
+	if i < 0 {
+		return 0
+	}
+	if i > len(s.a)-1 {
+		return len(s.a) - 1
+	}
+	return i
+}
+
+func validate(fn string, x, y []float64) {
+	if len(x) != len(y) {
+		panic(fmt.Sprintf(errStrings[0], fn, len(x), len(y)))
+	}
+	if len(x) < 2 {
+		panic(fmt.Sprintf(errStrings[1], fn, 2, len(x)))
+	}
+	for i := 1; i < len(x); i++ {
+		if !(x[i] > x[i-1]) {
+			panic(fmt.Sprintf(errStrings[2], fn, i))
+		}
+	}
+}
+
+// fit computes the coefficients of the spline by solving the tridiagonal
+// system for the second derivatives m at each sample point.
+func fit(x, y []float64, clamped bool, d0, dn float64) *Spline {
+	n := len(x)
+	h := make([]float64, n-1)
+	slope := make([]float64, n-1)
+	for i := range h {
+		h[i] = x[i+1] - x[i]
+		slope[i] = (y[i+1] - y[i]) / h[i]
+	}
+	// sub, diag and super are the three diagonals of the system, and rhs
+	// its right hand side.
+	sub := make([]float64, n)
+	diag := make([]float64, n)
+	super := make([]float64, n)
+	rhs := make([]float64, n)
+	if clamped {
+		diag[0], super[0] = 2.0*h[0], h[0]
+		rhs[0] = 6.0 * (slope[0] - d0)
+		sub[n-1], diag[n-1] = h[n-2], 2.0*h[n-2]
+		rhs[n-1] = 6.0 * (dn - slope[n-2])
+	} else {
+		diag[0], diag[n-1] = 1.0, 1.0
+	}
+	for i := 1; i < n-1; i++ {
+		sub[i] = h[i-1]
+		diag[i] = 2.0 * (h[i-1] + h[i])
+		super[i] = h[i]
+		rhs[i] = 6.0 * (slope[i] - slope[i-1])
+	}
+	m := solveTridiagonal(sub, diag, super, rhs)
+	return fromCurvature(x, y, m)
+}
+
+// fromCurvature returns the spline through the values y at the points x,
+// whose second derivatives at the points are m.
+func fromCurvature(x, y, m []float64) *Spline {
+	n := len(x)
+	s := &Spline{
+		x: append([]float64(nil), x...),
+		a: make([]float64, n-1),
+		b: make([]float64, n-1),
+		c: make([]float64, n-1),
+		d: make([]float64, n-1),
+	}
+	for i := 0; i < n-1; i++ {
+		h := x[i+1] - x[i]
+		s.a[i] = y[i]
+		s.b[i] = (y[i+1]-y[i])/h - h*(2.0*m[i]+m[i+1])/6.0
+		s.c[i] = m[i] / 2.0
+		s.d[i] = (m[i+1] - m[i]) / (6.0 * h)
+	}
+	return s
+}
+
+// solveTridiagonal solves a tridiagonal system with the Thomas algorithm.
+// sub[0] and super[len-1] are ignored. The passed slices are overwritten.
+func solveTridiagonal(sub, diag, super, rhs []float64) []float64 {
+	n := len(diag)
+	for i := 1; i < n; i++ {
+		w := sub[i] / diag[i-1]
+		diag[i] -= w * super[i-1]
+		rhs[i] -= w * rhs[i-1]
+	}
+	x := make([]float64, n)
+	x[n-1] = rhs[n-1] / diag[n-1]
+	for i := n - 2; i >= 0; i-- {
+		x[i] = (rhs[i] - super[i]*x[i+1]) / diag[i]
+	}
+	return x
+}
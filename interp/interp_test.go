@@ -0,0 +1,108 @@
+package interp
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestNewNatural(t *testing.T) {
+	x := []float64{0.0, 1.0, 2.0, 3.0}
+	y := []float64{0.0, 1.0, 0.0, 1.0}
+	s := NewNatural(x, y)
+	for i := range x {
+		if v := s.Eval(x[i]); math.Abs(v-y[i]) > 1e-12 {
+			t.Errorf("at sample %d, expected %f, got %f", i, y[i], v)
+		}
+	}
+	if d := s.Derivative(0.0, 2); math.Abs(d) > 1e-12 {
+		t.Errorf("expected zero curvature at the first point, got %f", d)
+	}
+	if d := s.Derivative(3.0, 2); math.Abs(d) > 1e-12 {
+		t.Errorf("expected zero curvature at the last point, got %f", d)
+	}
+	// The spline must be smooth across the interior points.
+	eps := 1e-9
+	for _, p := range []float64{1.0, 2.0} {
+		for order := 0; order < 3; order++ {
+			l, r := s.Derivative(p-eps, order), s.Derivative(p+eps, order)
+			if math.Abs(l-r) > 1e-6 {
+				t.Errorf("derivative %d is discontinuous at %f: %f and %f", order, p, l, r)
+			}
+		}
+	}
+	// The third derivative jumps at the interior points, where it is taken
+	// from the interval to the right.
+	for _, p := range []float64{1.0, 2.0} {
+		if d, r := s.Derivative(p, 3), s.Derivative(p+eps, 3); d != r || d == s.Derivative(p-eps, 3) {
+			t.Errorf("expected the third derivative %f at %f from the right, got %f", r, p, d)
+		}
+	}
+	if d, l := s.Derivative(3.0, 3), s.Derivative(3.0-eps, 3); d != l {
+		t.Errorf("expected the third derivative %f at the last point from the left, got %f", l, d)
+	}
+	two := NewNatural([]float64{0.0, 2.0}, []float64{1.0, 5.0})
+	if v := two.Eval(0.5); math.Abs(v-2.0) > 1e-12 {
+		t.Errorf("expected a straight line through 2 points, got %f at 0.5", v)
+	}
+}
+
+func TestNewClamped(t *testing.T) {
+	// A cubic is reproduced exactly by a clamped spline with the right slopes.
+	f := func(x float64) float64 { return x*x*x - 2.0*x }
+	df := func(x float64) float64 { return 3.0*x*x - 2.0 }
+	x := []float64{-1.0, 0.0, 0.5, 2.0, 3.0}
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = f(x[i])
+	}
+	s := NewClamped(x, y, df(x[0]), df(x[len(x)-1]))
+	ts := []float64{-0.5, 0.25, 1.0, 2.5}
+	v := s.EvalVec(ts)
+	d := s.DerivativeVec(ts, 1)
+	for i := range ts {
+		if math.Abs(v[i]-f(ts[i])) > 1e-10 {
+			t.Errorf("at %f, expected %f, got %f", ts[i], f(ts[i]), v[i])
+		}
+		if math.Abs(d[i]-df(ts[i])) > 1e-10 {
+			t.Errorf("at %f, expected slope %f, got %f", ts[i], df(ts[i]), d[i])
+		}
+	}
+	if d := s.Derivative(1.0, 3); math.Abs(d-6.0) > 1e-9 {
+		t.Errorf("expected third derivative 6.0, got %f", d)
+	}
+	if d := s.Derivative(1.0, 4); d != 0.0 {
+		t.Errorf("expected fourth derivative 0.0, got %f", d)
+	}
+}
+
+func TestSplineErrors(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[2], "NewNatural()", 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		NewNatural([]float64{0.0, 1.0, 0.5}, []float64{0.0, 1.0, 2.0})
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[1], "NewClamped()", 2, 1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		NewClamped([]float64{0.0}, []float64{0.0}, 0.0, 0.0)
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,131 @@
+package interp
+
+import (
+	"fmt"
+)
+
+/*
+NewSmoothing fits a cubic smoothing spline to the samples y taken at the
+points x. Rather than passing through every sample, a smoothing spline g
+minimizes the penalized sum of squares
+
+	sum(w[i] * (y[i] - g(x[i]))^2) + lambda * integral(g''(t)^2 dt)
+
+so that lambda trades closeness to noisy samples for smoothness. A lambda of
+0.0 gives the natural interpolating spline, while a very large lambda
+approaches the weighted least squares straight line through the samples. Since
+the penalty has the units of y^2 / x^3, suitable values of lambda depend on
+the scale of the data.
+
+w holds the weight of each sample, and a nil w gives every sample a weight of
+1.0. x and y must have equal lengths of at least 2, the elements of x must be
+strictly increasing, lambda must not be negative, and w must either be nil or
+hold a positive weight for each sample, otherwise this function will panic.
+The passed slices are not modified in this function.
+*/
+func NewSmoothing(x, y []float64, lambda float64, w []float64) *Spline {
+	validate("NewSmoothing()", x, y)
+	if lambda < 0.0 {
+		panic(fmt.Sprintf(errStrings[4], "NewSmoothing()", lambda))
+	}
+	n := len(x)
+	if w == nil {
+		w = make([]float64, n)
+		for i := range w {
+			w[i] = 1.0
+		}
+	} else if len(w) != n {
+		panic(fmt.Sprintf(errStrings[0], "NewSmoothing()", len(x), len(w)))
+	}
+	for i := range w {
+		if !(w[i] > 0.0) {
+			panic(fmt.Sprintf(errStrings[5], "NewSmoothing()", i, w[i]))
+		}
+	}
+	m := make([]float64, n)
+	if n == 2 {
+		return fromCurvature(x, y, m)
+	}
+	// Following the Reinsch algorithm, the second derivatives gamma at the
+	// interior points solve (R + lambda*Q'*W^-1*Q) gamma = Q'y, where Q is
+	// the n by n-2 second difference matrix with the three nonzero elements
+	// q0, q1 and q2 in each column, and R is tridiagonal.
+	h := make([]float64, n-1)
+	for i := range h {
+		h[i] = x[i+1] - x[i]
+	}
+	k := n - 2
+	q0, q1, q2 := make([]float64, k), make([]float64, k), make([]float64, k)
+	for j := 0; j < k; j++ {
+		q0[j] = 1.0 / h[j]
+		q1[j] = -1.0/h[j] - 1.0/h[j+1]
+		q2[j] = 1.0 / h[j+1]
+	}
+	diag, off1, off2 := make([]float64, k), make([]float64, k), make([]float64, k)
+	rhs := make([]float64, k)
+	for j := 0; j < k; j++ {
+		diag[j] = (h[j]+h[j+1])/3.0 +
+			lambda*(q0[j]*q0[j]/w[j]+q1[j]*q1[j]/w[j+1]+q2[j]*q2[j]/w[j+2])
+		if j+1 < k {
+			off1[j] = h[j+1]/6.0 +
+				lambda*(q1[j]*q0[j+1]/w[j+1]+q2[j]*q1[j+1]/w[j+2])
+		}
+		if j+2 < k {
+			off2[j] = lambda * q2[j] * q0[j+2] / w[j+2]
+		}
+		rhs[j] = q0[j]*y[j] + q1[j]*y[j+1] + q2[j]*y[j+2]
+	}
+	gamma := solvePentadiagonal(diag, off1, off2, rhs)
+	copy(m[1:], gamma)
+	// The smoothed values are g = y - lambda*W^-1*Q*gamma.
+	g := make([]float64, n)
+	copy(g, y)
+	for j := 0; j < k; j++ {
+		g[j] -= lambda * q0[j] * gamma[j] / w[j]
+		g[j+1] -= lambda * q1[j] * gamma[j] / w[j+1]
+		g[j+2] -= lambda * q2[j] * gamma[j] / w[j+2]
+	}
+	return fromCurvature(x, g, m)
+}
+
+// solvePentadiagonal solves the symmetric positive definite system whose
+// diagonal is diag, and whose first and second superdiagonals are off1 and
+// off2, using an LDL' factorization. The passed slices are overwritten.
+func solvePentadiagonal(diag, off1, off2, rhs []float64) []float64 {
+	n := len(diag)
+	// After the factorization, diag holds D, and off1 and off2 the first
+	// and second subdiagonals of L.
+	for i := 0; i < n; i++ {
+		if i >= 1 {
+			diag[i] -= off1[i-1] * off1[i-1] * diag[i-1]
+		}
+		if i >= 2 {
+			diag[i] -= off2[i-2] * off2[i-2] * diag[i-2]
+		}
+		if i+1 < n {
+			if i >= 1 {
+				off1[i] -= off2[i-1] * off1[i-1] * diag[i-1]
+			}
+			off1[i] /= diag[i]
+		}
+		if i+2 < n {
+			off2[i] /= diag[i]
+		}
+	}
+	for i := 1; i < n; i++ {
+		rhs[i] -= off1[i-1] * rhs[i-1]
+		if i >= 2 {
+			rhs[i] -= off2[i-2] * rhs[i-2]
+		}
+	}
+	for i := range rhs {
+		rhs[i] /= diag[i]
+	}
+	for i := n - 2; i >= 0; i-- {
+		rhs[i] -= off1[i] * rhs[i+1]
+		if i+2 < n {
+			rhs[i] -= off2[i] * rhs[i+2]
+		}
+	}
+	return rhs
+}
@@ -0,0 +1,89 @@
+package interp
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestNewSmoothing(t *testing.T) {
+	x := []float64{0.0, 0.5, 1.5, 2.0, 3.0, 4.5}
+	y := []float64{1.0, -1.0, 2.0, 0.5, 0.0, 3.0}
+	// Without smoothing, the natural interpolating spline is recovered.
+	s, n := NewSmoothing(x, y, 0.0, nil), NewNatural(x, y)
+	for _, p := range []float64{0.0, 0.3, 1.0, 2.7, 4.5} {
+		if a, b := s.Eval(p), n.Eval(p); math.Abs(a-b) > 1e-10 {
+			t.Errorf("at %f, expected %f, got %f", p, b, a)
+		}
+	}
+	// With strong smoothing, the least squares line is approached.
+	s = NewSmoothing(x, y, 1e9, nil)
+	mx, my := 0.0, 0.0
+	for i := range x {
+		mx += x[i] / float64(len(x))
+		my += y[i] / float64(len(x))
+	}
+	sxy, sxx := 0.0, 0.0
+	for i := range x {
+		sxy += (x[i] - mx) * (y[i] - my)
+		sxx += (x[i] - mx) * (x[i] - mx)
+	}
+	slope := sxy / sxx
+	for _, p := range []float64{0.0, 2.2, 4.5} {
+		line := my + slope*(p-mx)
+		if v := s.Eval(p); math.Abs(v-line) > 1e-5 {
+			t.Errorf("at %f, expected %f, got %f", p, line, v)
+		}
+	}
+	// Smoothing samples of a sine with alternating noise gets closer to
+	// the sine than the samples are.
+	m := 41
+	xs, ys := make([]float64, m), make([]float64, m)
+	for i := range xs {
+		xs[i] = float64(i) * 0.1
+		ys[i] = math.Sin(xs[i]) + 0.1*math.Pow(-1.0, float64(i))
+	}
+	s = NewSmoothing(xs, ys, 1e-3, nil)
+	rms := 0.0
+	for i := range xs {
+		d := s.Eval(xs[i]) - math.Sin(xs[i])
+		rms += d * d / float64(m)
+	}
+	if rms = math.Sqrt(rms); rms > 0.03 {
+		t.Errorf("expected the smoothed values to be near the sine, but the RMS error is %f", rms)
+	}
+	// A heavily weighted sample is approached more closely.
+	w := []float64{1.0, 1.0, 1000.0, 1.0, 1.0, 1.0}
+	light, heavy := NewSmoothing(x, y, 1.0, nil), NewSmoothing(x, y, 1.0, w)
+	if math.Abs(heavy.Eval(x[2])-y[2]) >= math.Abs(light.Eval(x[2])-y[2]) {
+		t.Errorf("expected the weighted sample to be approached more closely")
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[4], "NewSmoothing()", -1.0)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		NewSmoothing(x, y, -1.0, nil)
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[5], "NewSmoothing()", 1, 0.0)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		NewSmoothing(x, y, 1.0, []float64{1.0, 0.0, 1.0, 1.0, 1.0, 1.0})
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,130 @@
+package linsolve
+
+import (
+	"math"
+
+	"github.com/NDari/gocrunch/backend"
+	"github.com/NDari/gocrunch/errs"
+	"github.com/NDari/gocrunch/progress"
+)
+
+/*
+GMRES solves a * x = b with the restarted generalized minimal residual
+method, for any nonsingular Operator a, including those which are not
+symmetric. Each iteration takes one product with a, and picks the x with the
+smallest residual in the space spanned by the previous iterations. Since
+this space grows by one vector per iteration, GMRES starts over from its
+latest x every Restart iterations, trading the convergence speed for
+bounded memory.
+
+The preconditioner is applied on the right, solving a * m * u = b for x = m
+* u, so that the reported residuals are those of the original system.
+
+A nil Settings uses the defaults of all its fields. GMRES returns ErrMaxIter
+if the tolerance is not reached within the maximum number of iterations,
+ErrBreakdown if a is found to be singular, and progress.ErrStopped if the
+Observer stops it, along with the last solution reached. The passed
+arguments are not mutated in this function.
+*/
+func GMRES(a Operator, b []float64, s *Settings) (*Result, error) {
+	set := withDefaults("GMRES()", a, b, s)
+	n, m := len(b), set.Restart
+	x, r := start(a, b, set)
+	bnorm := norm(b)
+	if bnorm == 0.0 {
+		return &Result{X: make([]float64, n)}, nil
+	}
+	// v holds the orthonormal basis of the Krylov space, and z the basis
+	// with the preconditioner applied. h is the Hessenberg matrix of the
+	// Arnoldi process, reduced to upper triangular form by the Givens
+	// rotations of cs and sn, which are also applied to g.
+	v := make([][]float64, m+1)
+	z := make([][]float64, m)
+	h := make([][]float64, m+1)
+	for i := range h {
+		h[i] = make([]float64, m)
+	}
+	cs, sn := make([]float64, m), make([]float64, m)
+	g := make([]float64, m+1)
+	w := make([]float64, n)
+	iter := 0
+	res := norm(r) / bnorm
+	for {
+		beta := res * bnorm
+		if res <= set.Tol {
+			return &Result{X: x, Residual: res, Iter: iter}, nil
+		}
+		if iter == set.MaxIter {
+			return &Result{X: x, Residual: res, Iter: iter}, ErrMaxIter
+		}
+		v[0] = make([]float64, n)
+		for i := range r {
+			v[0][i] = r[i] / beta
+		}
+		for i := range g {
+			g[i] = 0.0
+		}
+		g[0] = beta
+		k, stop, breakdown := 0, false, false
+		for k < m && iter < set.MaxIter {
+			z[k] = precondition(set.Precond, v[k])
+			a.Apply(w, z[k])
+			for i := 0; i <= k; i++ {
+				h[i][k] = backend.Dot(w, v[i])
+				backend.Axpy(-h[i][k], v[i], w)
+			}
+			hk := norm(w)
+			if hk != 0.0 {
+				v[k+1] = make([]float64, n)
+				for i := range w {
+					v[k+1][i] = w[i] / hk
+				}
+			}
+			for i := 0; i < k; i++ {
+				h[i][k], h[i+1][k] = cs[i]*h[i][k]+sn[i]*h[i+1][k], -sn[i]*h[i][k]+cs[i]*h[i+1][k]
+			}
+			d := math.Hypot(h[k][k], hk)
+			if d == 0.0 {
+				breakdown = true
+				break
+			}
+			cs[k], sn[k] = h[k][k]/d, hk/d
+			h[k][k] = d
+			g[k], g[k+1] = cs[k]*g[k], -sn[k]*g[k]
+			k++
+			iter++
+			res = math.Abs(g[k]) / bnorm
+			if !progress.Continue(set.Observer, iter, res) {
+				stop = true
+				break
+			}
+			// A zero hk means that the Krylov space holds the solution.
+			if res <= set.Tol || hk == 0.0 {
+				break
+			}
+		}
+		// x += z * y, for the y minimizing the residual, which solves the
+		// triangular system h * y = g.
+		y := make([]float64, k)
+		for i := k - 1; i >= 0; i-- {
+			sum := g[i]
+			for j := i + 1; j < k; j++ {
+				sum -= h[i][j] * y[j]
+			}
+			y[i] = sum / h[i][i]
+		}
+		for i := range y {
+			backend.Axpy(y[i], z[i], x)
+		}
+		// The residual is recomputed from x, since the rotated residual
+		// drifts from the true residual through rounding.
+		residual(a, b, x, r)
+		res = norm(r) / bnorm
+		if stop {
+			return &Result{X: x, Residual: res, Iter: iter}, progress.ErrStopped
+		}
+		if breakdown && res > set.Tol {
+			return &Result{X: x, Residual: res, Iter: iter}, &errs.SingularError{Index: -1, Err: ErrBreakdown}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package linsolve
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+	"github.com/NDari/gocrunch/mat"
+	"github.com/NDari/gocrunch/progress"
+)
+
+func TestGMRES(t *testing.T) {
+	// A nonsymmetric convection-diffusion operator.
+	n := 60
+	m := mat.New(n)
+	diag := make([]float64, n)
+	for i := range m {
+		diag[i] = 2.0 + float64(i%4)
+		m[i][i] = diag[i]
+		if i > 0 {
+			m[i][i-1] = -1.3
+		}
+		if i < n-1 {
+			m[i][i+1] = -0.7
+		}
+	}
+	a := Dense(m)
+	b := make([]float64, n)
+	for i := range b {
+		b[i] = math.Cos(float64(i) / 3.0)
+	}
+	res, err := GMRES(a, b, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSolution(t, "GMRES", a, b, res.X, 1e-10)
+	if math.Abs(res.Residual) > 1e-10 {
+		t.Errorf("expected a residual below 1e-10, got %v", res.Residual)
+	}
+	// A short restart still converges, and the preconditioner helps.
+	short, err := GMRES(a, b, &Settings{Restart: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSolution(t, "GMRES(5)", a, b, short.X, 1e-10)
+	pre, err := GMRES(a, b, &Settings{Restart: 5, Precond: Jacobi(diag)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSolution(t, "GMRES(5) with Jacobi", a, b, pre.X, 1e-10)
+	if pre.Iter > short.Iter {
+		t.Errorf("expected at most %d iterations with the preconditioner, got %d", short.Iter, pre.Iter)
+	}
+	// Without restarts, GMRES solves a system of size n in at most n
+	// iterations.
+	small := [][]float64{{1.0, 2.0, 0.0}, {0.0, 1.0, 3.0}, {4.0, 0.0, 1.0}}
+	res, err = GMRES(Dense(small), []float64{1.0, 2.0, 3.0}, nil)
+	if err != nil || res.Iter > 3 {
+		t.Errorf("expected at most 3 iterations, got %d and %v", res.Iter, err)
+	}
+	checkSolution(t, "GMRES small", Dense(small), []float64{1.0, 2.0, 3.0}, res.X, 1e-12)
+	if _, err := GMRES(a, b, &Settings{MaxIter: 4}); !errors.Is(err, ErrMaxIter) {
+		t.Errorf("expected ErrMaxIter, got %v", err)
+	}
+	_, err = GMRES(Dense([][]float64{{0.0, 0.0}, {0.0, 0.0}}), []float64{1.0, 1.0}, nil)
+	var se *errs.SingularError
+	if !errors.Is(err, ErrBreakdown) || !errors.Is(err, errs.ErrSingular) || !errors.As(err, &se) {
+		t.Errorf("expected ErrBreakdown in an *errs.SingularError, got %v", err)
+	}
+	res, err = GMRES(a, b, &Settings{Observer: progress.Func(func(r progress.Report) bool {
+		return r.Iter < 3
+	})})
+	if !errors.Is(err, progress.ErrStopped) || res.Iter != 3 {
+		t.Errorf("expected to stop after 3 iterations, got %d and %v", res.Iter, err)
+	}
+	checkSolution(t, "GMRES stopped", a, b, res.X, res.Residual*(1.0+1e-12))
+}
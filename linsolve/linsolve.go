@@ -0,0 +1,346 @@
+/*
+Package linsolve implements iterative solvers for large systems of linear
+equations, a * x = b, such as those of discretized PDEs, for which a direct
+factorization would take too much time or memory.
+
+The solvers never read the elements of a, and only need its product with
+vectors, through an Operator. An Operator can wrap a [][]float64, a sparse
+matrix made of vec.Sparse rows, any type with its own product, or a closure
+computing the product without storing a at all:
+
+	// The second differences on a grid of n points.
+	a := linsolve.Func(func(dst, x []float64) {
+		for i := range x {
+			dst[i] = -2.0 * x[i]
+			if i > 0 {
+				dst[i] += x[i-1]
+			}
+			if i < len(x)-1 {
+				dst[i] += x[i+1]
+			}
+		}
+	})
+	res, err := linsolve.CG(a, b, nil)
+
+linsolve.CG() solves symmetric positive definite systems, and
+linsolve.GMRES() any nonsingular system. Both accept a preconditioner, which
+is also an Operator, and report the relative residual of each iteration to
+the progress.Observer of their Settings.
+
+Failing to converge is reported by an error value, along with the last
+solution reached. Invalid arguments, such as an empty right hand side, are
+treated as critical errors, and cause a panic with a message that names the
+offending function, as with the other packages in gocrunch.
+*/
+package linsolve
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/backend"
+	"github.com/NDari/gocrunch/mat"
+	"github.com/NDari/gocrunch/progress"
+	"github.com/NDari/gocrunch/vec"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/linsolve error.\nIn linsolve.%s, the right hand side cannot be empty.\n",
+		"\ngocrunch/linsolve error.\nIn linsolve.%s, the %s has length %d, while the right hand side has length %d.\n",
+		"\ngocrunch/linsolve error.\nIn linsolve.%s, the %s must not be negative, received %v.\n",
+		"\ngocrunch/linsolve error.\nIn linsolve.%s, the [][]float64 must be square, but it is %d by %d.\n",
+		"\ngocrunch/linsolve error.\nIn linsolve.%s, the diagonal is 0.0 at index %d.\n",
+		"\ngocrunch/linsolve error.\nIn linsolve.%s, the Operator cannot be nil.\n",
+		"\ngocrunch/linsolve error.\nIn linsolve.%s, the sparse matrix cannot be empty.\n",
+		"\ngocrunch/linsolve error.\nIn linsolve.%s, the sparse matrix must be square, but row %d of its %d rows has length %d.\n",
+	}
+)
+
+var (
+	// ErrMaxIter is returned when a solver does not reach its tolerance
+	// within the allowed number of iterations. The returned result holds
+	// the last solution reached.
+	ErrMaxIter = errors.New("linsolve: maximum number of iterations reached")
+	// ErrNotPositiveDefinite is returned by CG() when the Operator, or its
+	// preconditioner, is found not to be positive definite.
+	ErrNotPositiveDefinite = errors.New("linsolve: the operator is not positive definite")
+	// ErrBreakdown is returned by GMRES() when the Operator is found to be
+	// singular, so that the iterations cannot improve the solution. It is
+	// wrapped by an *errs.SingularError, which matches errs.ErrSingular.
+	ErrBreakdown = errors.New("linsolve: the iterations broke down")
+)
+
+// Operator is a linear operator on vectors.
+type Operator interface {
+	// Apply stores the product of the operator and x in dst, which has the
+	// length of x and does not share its elements with it.
+	Apply(dst, x []float64)
+}
+
+// Func adapts a function computing the product of an operator and x to an
+// Operator.
+type Func func(dst, x []float64)
+
+// Apply calls f(dst, x).
+func (f Func) Apply(dst, x []float64) {
+	f(dst, x)
+}
+
+/*
+Dense returns the Operator of the product with a square [][]float64, through
+mat.MulVecTo(). The [][]float64 is not copied, so changes to it change the
+Operator. It must be square and not empty, otherwise this function will
+panic.
+*/
+func Dense(m [][]float64) Operator {
+	if len(m) == 0 || len(m[0]) != len(m) {
+		c := 0
+		if len(m) > 0 {
+			c = len(m[0])
+		}
+		panic(fmt.Sprintf(errStrings[3], "Dense()", len(m), c))
+	}
+	return Func(func(dst, x []float64) {
+		mat.MulVecTo(dst, m, x)
+	})
+}
+
+/*
+Sparse returns the Operator of the product with the square sparse matrix
+whose rows are the passed vec.Sparse, through vec.Sparse.Dot(), so that a
+product takes a time proportional to the number of stored elements. For
+example, for the second differences on a grid of n points:
+
+	rows := make([]vec.Sparse, n)
+	for i := range rows {
+		switch i {
+		case 0:
+			rows[i] = vec.NewSparse(n, []int{0, 1}, []float64{2.0, -1.0})
+		case n - 1:
+			rows[i] = vec.NewSparse(n, []int{n - 2, n - 1}, []float64{-1.0, 2.0})
+		default:
+			rows[i] = vec.NewSparse(n, []int{i - 1, i, i + 1}, []float64{-1.0, 2.0, -1.0})
+		}
+	}
+	res, err := linsolve.CG(linsolve.Sparse(rows), b, nil)
+
+The []vec.Sparse is not copied, so changes to it change the Operator. It
+must not be empty, and each row must have the length of the number of rows,
+otherwise this function will panic.
+*/
+func Sparse(rows []vec.Sparse) Operator {
+	if len(rows) == 0 {
+		panic(fmt.Sprintf(errStrings[6], "Sparse()"))
+	}
+	for i, r := range rows {
+		if r.Len() != len(rows) {
+			panic(fmt.Sprintf(errStrings[7], "Sparse()", i, len(rows), r.Len()))
+		}
+	}
+	return Func(func(dst, x []float64) {
+		for i, r := range rows {
+			dst[i] = r.Dot(x)
+		}
+	})
+}
+
+/*
+Jacobi returns the Jacobi preconditioner of an operator with the passed
+diagonal, which divides each element of a vector by the element of the
+diagonal. It is cheap, and effective when the diagonal dominates the
+operator, or varies over orders of magnitude:
+
+	res, err := linsolve.CG(a, b, &linsolve.Settings{Precond: linsolve.Jacobi(diag)})
+
+The diagonal must not have zeros, otherwise this function will panic. It is
+copied.
+*/
+func Jacobi(diag []float64) Operator {
+	inv := make([]float64, len(diag))
+	for i, d := range diag {
+		if d == 0.0 {
+			panic(fmt.Sprintf(errStrings[4], "Jacobi()", i))
+		}
+		inv[i] = 1.0 / d
+	}
+	return Func(func(dst, x []float64) {
+		for i := range x {
+			dst[i] = x[i] * inv[i]
+		}
+	})
+}
+
+/*
+Settings configures the solvers of this package. The zero value of each
+field is replaced by the default mentioned in its description.
+*/
+type Settings struct {
+	// Tol is the relative residual, the norm of b - a*x over that of b, at
+	// which the solvers stop, 1e-10 by default.
+	Tol float64
+	// MaxIter is the maximum number of iterations, which are products with
+	// the Operator, 10 times the length of b by default.
+	MaxIter int
+	// Restart is the number of iterations of GMRES() after which it
+	// restarts, bounding its memory to Restart vectors, 30 or the length of
+	// b by default, whichever is smaller.
+	Restart int
+	// Precond, when not nil, is applied to the residuals, and approximates
+	// the inverse of the Operator. The closer it is to the inverse, the
+	// fewer iterations the solvers take. For CG(), it must be symmetric
+	// positive definite.
+	Precond Operator
+	// X0, when not nil, is the starting point of the iterations, which are
+	// started from zeros by default.
+	X0 []float64
+	// Observer, when not nil, is passed the relative residual after each
+	// iteration, and can stop the solver.
+	Observer progress.Observer
+}
+
+// Result holds the outcome of a solver.
+type Result struct {
+	// X is the last solution reached, which is not always the one with the
+	// smallest residual.
+	X []float64
+	// Residual is the relative residual of X, the norm of b - a*X over
+	// that of b.
+	Residual float64
+	// Iter is the number of iterations that were performed.
+	Iter int
+}
+
+/*
+CG solves a * x = b with the preconditioned conjugate gradient method, for a
+symmetric positive definite Operator a. Each iteration takes one product
+with a, and the method converges in at most len(b) iterations in exact
+arithmetic, and usually in far fewer for a well conditioned, or well
+preconditioned, a.
+
+A nil Settings uses the defaults of all its fields. CG returns ErrMaxIter if
+the tolerance is not reached within the maximum number of iterations,
+ErrNotPositiveDefinite if a is found not to be positive definite, and
+progress.ErrStopped if the Observer stops it, along with the last solution
+reached. The passed arguments are not mutated in this function.
+*/
+func CG(a Operator, b []float64, s *Settings) (*Result, error) {
+	set := withDefaults("CG()", a, b, s)
+	n := len(b)
+	x, r := start(a, b, set)
+	bnorm := norm(b)
+	if bnorm == 0.0 {
+		// The solution of a nonsingular system is 0.
+		return &Result{X: make([]float64, n)}, nil
+	}
+	res := norm(r) / bnorm
+	if res <= set.Tol {
+		return &Result{X: x, Residual: res}, nil
+	}
+	z := precondition(set.Precond, r)
+	p := make([]float64, n)
+	copy(p, z)
+	q := make([]float64, n)
+	rz := backend.Dot(r, z)
+	for iter := 1; iter <= set.MaxIter; iter++ {
+		a.Apply(q, p)
+		pq := backend.Dot(p, q)
+		if !(pq > 0.0) {
+			return &Result{X: x, Residual: res, Iter: iter - 1}, fmt.Errorf("%w: the curvature of iteration %d is %v", ErrNotPositiveDefinite, iter, pq)
+		}
+		alpha := rz / pq
+		backend.Axpy(alpha, p, x)
+		backend.Axpy(-alpha, q, r)
+		res = norm(r) / bnorm
+		if res <= set.Tol {
+			return &Result{X: x, Residual: res, Iter: iter}, nil
+		}
+		if !progress.Continue(set.Observer, iter, res) {
+			return &Result{X: x, Residual: res, Iter: iter}, progress.ErrStopped
+		}
+		z = precondition(set.Precond, r)
+		rzNew := backend.Dot(r, z)
+		if !(rzNew > 0.0) {
+			return &Result{X: x, Residual: res, Iter: iter}, fmt.Errorf("%w: the preconditioned residual of iteration %d has a norm of %v", ErrNotPositiveDefinite, iter, rzNew)
+		}
+		beta := rzNew / rz
+		rz = rzNew
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+	return &Result{X: x, Residual: res, Iter: set.MaxIter}, ErrMaxIter
+}
+
+func withDefaults(fn string, a Operator, b []float64, s *Settings) Settings {
+	if a == nil {
+		panic(fmt.Sprintf(errStrings[5], fn))
+	}
+	if len(b) == 0 {
+		panic(fmt.Sprintf(errStrings[0], fn))
+	}
+	var set Settings
+	if s != nil {
+		set = *s
+	}
+	if set.Tol < 0.0 {
+		panic(fmt.Sprintf(errStrings[2], fn, "tolerance", set.Tol))
+	}
+	if set.MaxIter < 0 {
+		panic(fmt.Sprintf(errStrings[2], fn, "maximum number of iterations", set.MaxIter))
+	}
+	if set.Restart < 0 {
+		panic(fmt.Sprintf(errStrings[2], fn, "restart", set.Restart))
+	}
+	if set.X0 != nil && len(set.X0) != len(b) {
+		panic(fmt.Sprintf(errStrings[1], fn, "starting point", len(set.X0), len(b)))
+	}
+	if set.Tol == 0.0 {
+		set.Tol = 1e-10
+	}
+	if set.MaxIter == 0 {
+		set.MaxIter = 10 * len(b)
+	}
+	if set.Restart == 0 {
+		set.Restart = min(30, len(b))
+	}
+	return set
+}
+
+// start returns the starting point of the iterations, and its residual.
+func start(a Operator, b []float64, set Settings) (x, r []float64) {
+	n := len(b)
+	x = make([]float64, n)
+	r = make([]float64, n)
+	copy(r, b)
+	if set.X0 != nil {
+		copy(x, set.X0)
+		residual(a, b, x, r)
+	}
+	return x, r
+}
+
+// residual stores b - a*x in r.
+func residual(a Operator, b, x, r []float64) {
+	a.Apply(r, x)
+	for i := range r {
+		r[i] = b[i] - r[i]
+	}
+}
+
+// precondition returns m applied to r, or a copy of r if m is nil.
+func precondition(m Operator, r []float64) []float64 {
+	z := make([]float64, len(r))
+	if m == nil {
+		copy(z, r)
+		return z
+	}
+	m.Apply(z, r)
+	return z
+}
+
+// norm returns the euclidean norm of v.
+func norm(v []float64) float64 {
+	return math.Sqrt(backend.Dot(v, v))
+}
@@ -0,0 +1,176 @@
+package linsolve
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/mat"
+	"github.com/NDari/gocrunch/progress"
+	"github.com/NDari/gocrunch/vec"
+)
+
+// laplacian is the operator of the negated second differences on a grid of
+// points, which is symmetric positive definite.
+var laplacian = Func(func(dst, x []float64) {
+	for i := range x {
+		dst[i] = 2.0 * x[i]
+		if i > 0 {
+			dst[i] -= x[i-1]
+		}
+		if i < len(x)-1 {
+			dst[i] -= x[i+1]
+		}
+	}
+})
+
+func checkSolution(t *testing.T, name string, a Operator, b, x []float64, tol float64) {
+	t.Helper()
+	r := make([]float64, len(b))
+	residual(a, b, x, r)
+	if norm(r)/norm(b) > tol {
+		t.Errorf("%s: expected a relative residual below %v, got %v", name, tol, norm(r)/norm(b))
+	}
+}
+
+func TestCG(t *testing.T) {
+	n := 50
+	b := make([]float64, n)
+	for i := range b {
+		b[i] = math.Sin(float64(i))
+	}
+	res, err := CG(laplacian, b, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSolution(t, "CG", laplacian, b, res.X, 1e-10)
+	if res.Iter > n {
+		t.Errorf("expected at most %d iterations, got %d", n, res.Iter)
+	}
+	// A badly scaled system converges faster with the Jacobi
+	// preconditioner.
+	m := mat.New(n)
+	diag := make([]float64, n)
+	for i := range m {
+		diag[i] = math.Pow(10.0, float64(i%6))
+		m[i][i] = diag[i]
+		if i > 0 {
+			m[i][i-1], m[i-1][i] = 0.5, 0.5
+		}
+	}
+	plain, err := CG(Dense(m), b, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pre, err := CG(Dense(m), b, &Settings{Precond: Jacobi(diag)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSolution(t, "CG with Jacobi", Dense(m), b, pre.X, 1e-10)
+	if pre.Iter >= plain.Iter {
+		t.Errorf("expected fewer than %d iterations with the preconditioner, got %d", plain.Iter, pre.Iter)
+	}
+	// Starting from the solution takes no iterations.
+	if res, err := CG(laplacian, b, &Settings{X0: res.X}); err != nil || res.Iter != 0 {
+		t.Errorf("expected no iterations from the solution, got %d and %v", res.Iter, err)
+	}
+	if res, err := CG(laplacian, make([]float64, n), nil); err != nil || mat.Sum([][]float64{res.X}) != 0.0 {
+		t.Errorf("expected a zero solution for a zero right hand side, got %v and %v", res.X, err)
+	}
+	if _, err := CG(Dense([][]float64{{1.0, 0.0}, {0.0, -1.0}}), []float64{1.0, 1.0}, nil); !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("expected ErrNotPositiveDefinite, got %v", err)
+	}
+	if _, err := CG(laplacian, b, &Settings{MaxIter: 3}); !errors.Is(err, ErrMaxIter) {
+		t.Errorf("expected ErrMaxIter, got %v", err)
+	}
+	var reports []float64
+	res, err = CG(laplacian, b, &Settings{Observer: progress.Func(func(r progress.Report) bool {
+		reports = append(reports, r.Value)
+		return r.Iter < 5
+	})})
+	if !errors.Is(err, progress.ErrStopped) || res.Iter != 5 || len(reports) != 5 || reports[4] != res.Residual {
+		t.Errorf("expected to stop after 5 iterations, got %d iterations, %v and %v", res.Iter, reports, err)
+	}
+}
+
+func TestSparse(t *testing.T) {
+	n := 200
+	lap, conv := make([]vec.Sparse, n), make([]vec.Sparse, n)
+	for i := range lap {
+		idx := []int{i}
+		if i > 0 {
+			idx = []int{i - 1, i}
+		}
+		if i < n-1 {
+			idx = append(idx, i+1)
+		}
+		lv, cv := make([]float64, len(idx)), make([]float64, len(idx))
+		for k, j := range idx {
+			lv[k], cv[k] = -1.0, -1.5
+			if j == i {
+				lv[k], cv[k] = 2.0, 4.0
+			} else if j > i {
+				cv[k] = -0.5
+			}
+		}
+		lap[i], conv[i] = vec.NewSparse(n, idx, lv), vec.NewSparse(n, idx, cv)
+	}
+	b := make([]float64, n)
+	for i := range b {
+		b[i] = math.Cos(float64(i))
+	}
+	// The sparse rows are the same operator as laplacian.
+	a := Sparse(lap)
+	x, y := make([]float64, n), make([]float64, n)
+	a.Apply(x, b)
+	laplacian.Apply(y, b)
+	for i := range x {
+		if x[i] != y[i] {
+			t.Fatalf("at %d, expected %v, got %v", i, y[i], x[i])
+		}
+	}
+	res, err := CG(a, b, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSolution(t, "CG", a, b, res.X, 1e-10)
+	// The convection-diffusion rows are not symmetric.
+	res, err = GMRES(Sparse(conv), b, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSolution(t, "GMRES", Sparse(conv), b, res.X, 1e-10)
+}
+
+func TestSettings(t *testing.T) {
+	b := []float64{1.0, 2.0}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		f        func()
+		expected string
+	}{
+		{func() { CG(nil, b, nil) }, fmt.Sprintf(errStrings[5], "CG()")},
+		{func() { GMRES(laplacian, nil, nil) }, fmt.Sprintf(errStrings[0], "GMRES()")},
+		{func() { CG(laplacian, b, &Settings{Tol: -1.0}) }, fmt.Sprintf(errStrings[2], "CG()", "tolerance", -1.0)},
+		{func() { GMRES(laplacian, b, &Settings{Restart: -1}) }, fmt.Sprintf(errStrings[2], "GMRES()", "restart", -1)},
+		{func() { CG(laplacian, b, &Settings{X0: []float64{1.0}}) }, fmt.Sprintf(errStrings[1], "CG()", "starting point", 1, 2)},
+		{func() { Dense([][]float64{{1.0, 2.0}}) }, fmt.Sprintf(errStrings[3], "Dense()", 1, 2)},
+		{func() { Sparse(nil) }, fmt.Sprintf(errStrings[6], "Sparse()")},
+		{func() { Sparse([]vec.Sparse{vec.NewSparse(2, nil, nil)}) }, fmt.Sprintf(errStrings[7], "Sparse()", 0, 1, 2)},
+		{func() { Jacobi([]float64{1.0, 0.0}) }, fmt.Sprintf(errStrings[4], "Jacobi()", 1)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
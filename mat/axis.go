@@ -0,0 +1,192 @@
+package mat
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Axis picks whether a function of this package acts on the rows or on the
+// columns of a [][]float64.
+type Axis int
+
+const (
+	// AxisRow acts on each row of a [][]float64.
+	AxisRow Axis = iota
+	// AxisCol acts on each column of a [][]float64.
+	AxisCol
+)
+
+/*
+RowView returns a row of a [][]float64, without copying it. For example:
+
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}}
+	r := mat.RowView(m, -1) // r is {3.0, 4.0}
+	r[0] = 5.0              // m is now {{1.0, 2.0}, {5.0, 4.0}}
+
+As with mat.Row(), negative indices count from the last row. The returned
+[]float64 shares its elements with m, so that setting an element of it sets
+the element of m. Since the elements of a column are not contiguous, there
+is no view of a column; mat.Col() returns a copy.
+*/
+func RowView(m [][]float64, x int) []float64 {
+	return m[rowIndex("RowView()", m, x)]
+}
+
+/*
+SetRow sets the elements of a row of a [][]float64 to those of a []float64,
+and returns the [][]float64. For example:
+
+	m := mat.New(2, 3)
+	mat.SetRow(m, 0, []float64{1.0, 2.0, 3.0}) // m is {{1.0, 2.0, 3.0}, {0.0, 0.0, 0.0}}
+
+As with mat.Row(), negative indices count from the last row. The length of
+the []float64 must be the number of columns of m, otherwise this function
+will panic. The elements are copied, so m does not share its elements with
+the []float64 afterwards.
+
+Unlike most functions of this package, SetRow mutates the passed
+[][]float64.
+*/
+func SetRow(m [][]float64, x int, v []float64) [][]float64 {
+	x = rowIndex("SetRow()", m, x)
+	if len(v) != len(m[x]) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the []float64 has %d elements, while the row has %d.\n"
+		s = fmt.Sprintf(s, "SetRow()", len(v), len(m[x]))
+		debug.PrintStack()
+		panic(s)
+	}
+	copy(m[x], v)
+	return m
+}
+
+/*
+SetCol sets the elements of a column of a [][]float64 to those of a
+[]float64, and returns the [][]float64. For example:
+
+	m := mat.New(2, 3)
+	mat.SetCol(m, -1, []float64{1.0, 2.0}) // m is {{0.0, 0.0, 1.0}, {0.0, 0.0, 2.0}}
+
+As with mat.Col(), negative indices count from the last column. The length
+of the []float64 must be the number of rows of m, otherwise this function
+will panic.
+
+Unlike most functions of this package, SetCol mutates the passed
+[][]float64.
+*/
+func SetCol(m [][]float64, x int, v []float64) [][]float64 {
+	x = colIndex("SetCol()", m, x)
+	if len(v) != len(m) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the []float64 has %d elements, while the column has %d.\n"
+		s = fmt.Sprintf(s, "SetCol()", len(v), len(m))
+		debug.PrintStack()
+		panic(s)
+	}
+	for i := range m {
+		m[i][x] = v[i]
+	}
+	return m
+}
+
+/*
+ApplyAxis applies a function to each row, for an axis of mat.AxisRow, or to
+each column, for an axis of mat.AxisCol, of a [][]float64, and returns the
+[][]float64 of the results. The result of f for a row becomes a row of the
+result, and the result of f for a column becomes a column of it. For
+example, to center each column of m on its mean:
+
+	c := mat.ApplyAxis(m, func(v []float64) []float64 {
+		return vec.Sub(v, vec.Sum(v)/float64(len(v)))
+	}, mat.AxisCol)
+
+f receives a copy of each row or column, which it may modify and return.
+All the results of f must have the same length, which need not be the length
+of its argument, otherwise this function will panic.
+
+The original [][]float64 is not mutated in this function.
+*/
+func ApplyAxis(m [][]float64, f func([]float64) []float64, axis Axis) [][]float64 {
+	switch axis {
+	case AxisRow:
+		res := make([][]float64, len(m))
+		for i := range m {
+			res[i] = f(Row(m, i))
+			checkApplyLen(res[i], res[0])
+		}
+		return res
+	case AxisCol:
+		cols := make([][]float64, rowLen(m))
+		for j := range cols {
+			cols[j] = f(Col(m, j))
+			checkApplyLen(cols[j], cols[0])
+		}
+		if len(cols) == 0 {
+			return [][]float64{}
+		}
+		res := make([][]float64, len(cols[0]))
+		for i := range res {
+			res[i] = make([]float64, len(cols))
+			for j := range cols {
+				res[i][j] = cols[j][i]
+			}
+		}
+		return res
+	default:
+		checkAxis("ApplyAxis()", axis)
+		return nil
+	}
+}
+
+func checkAxis(fn string, axis Axis) {
+	if axis != AxisRow && axis != AxisCol {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the axis %d is unknown. It must be mat.AxisRow or mat.AxisCol.\n"
+		s = fmt.Sprintf(s, fn, axis)
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+func checkApplyLen(v, first []float64) {
+	if len(v) != len(first) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the function returned %d elements, after returning %d.\n"
+		s = fmt.Sprintf(s, "ApplyAxis()", len(v), len(first))
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+// rowIndex returns the row of m picked by x, which may be negative, or
+// panics if x is outside of m.
+func rowIndex(fn string, m [][]float64, x int) int {
+	if x >= len(m) || x < -len(m) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the requested row %d is outside of bounds [-%d, %d)\n"
+		s = fmt.Sprintf(s, fn, x, len(m), len(m))
+		debug.PrintStack()
+		panic(s)
+	}
+	if x < 0 {
+		x += len(m)
+	}
+	return x
+}
+
+// colIndex returns the column of m picked by x, which may be negative, or
+// panics if x is outside of m.
+func colIndex(fn string, m [][]float64, x int) int {
+	n := rowLen(m)
+	if x >= n || x < -n {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the requested column %d is outside of bounds [-%d, %d)\n"
+		s = fmt.Sprintf(s, fn, x, n, n)
+		debug.PrintStack()
+		panic(s)
+	}
+	if x < 0 {
+		x += n
+	}
+	return x
+}
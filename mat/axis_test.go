@@ -0,0 +1,157 @@
+package mat
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestRowView(t *testing.T) {
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}}
+	r := RowView(m, -1)
+	if r[0] != 3.0 || r[1] != 4.0 {
+		t.Errorf("expected {3.0, 4.0}, got %v", r)
+	}
+	r[0] = 5.0
+	if m[1][0] != 5.0 {
+		t.Errorf("expected the view to share the elements of m")
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		expected := fmt.Sprintf("In mat.%s the requested row %d is outside of bounds [-%d, %d)\n", "RowView()", 2, 2, 2)
+		defer func() {
+			if r := recover(); r != expected {
+				t.Errorf("Expected %s, got %v", expected, r)
+			}
+			wg.Done()
+		}()
+		RowView(m, 2)
+	}()
+	wg.Wait()
+}
+
+func TestSetRow(t *testing.T) {
+	m := New(2, 3)
+	v := []float64{1.0, 2.0, 3.0}
+	SetRow(m, 0, v)
+	SetRow(m, -1, v)
+	if !Equal(m, [][]float64{{1.0, 2.0, 3.0}, {1.0, 2.0, 3.0}}) {
+		t.Errorf("expected the rows to be set, got %v", m)
+	}
+	v[0] = 10.0
+	if m[0][0] != 1.0 {
+		t.Errorf("expected the row to be copied")
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		x        int
+		v        []float64
+		expected string
+	}{
+		{-3, v, fmt.Sprintf("In mat.%s the requested row %d is outside of bounds [-%d, %d)\n", "SetRow()", -3, 2, 2)},
+		{0, v[:2], fmt.Sprintf("In mat.%s the []float64 has %d elements, while the row has %d.\n", "SetRow()", 2, 3)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			SetRow(m, test.x, test.v)
+		}()
+		wg.Wait()
+	}
+}
+
+func TestSetCol(t *testing.T) {
+	m := New(2, 3)
+	SetCol(m, -1, []float64{1.0, 2.0})
+	SetCol(m, 0, []float64{3.0, 4.0})
+	if !Equal(m, [][]float64{{3.0, 0.0, 1.0}, {4.0, 0.0, 2.0}}) {
+		t.Errorf("expected the columns to be set, got %v", m)
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		x        int
+		v        []float64
+		expected string
+	}{
+		{3, []float64{1.0, 2.0}, fmt.Sprintf("In mat.%s the requested column %d is outside of bounds [-%d, %d)\n", "SetCol()", 3, 3, 3)},
+		{0, []float64{1.0}, fmt.Sprintf("In mat.%s the []float64 has %d elements, while the column has %d.\n", "SetCol()", 1, 2)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			SetCol(m, test.x, test.v)
+		}()
+		wg.Wait()
+	}
+}
+
+func TestApplyAxis(t *testing.T) {
+	m := [][]float64{{1.0, 2.0}, {3.0, 6.0}}
+	center := func(v []float64) []float64 {
+		mean := 0.0
+		for _, x := range v {
+			mean += x
+		}
+		mean /= float64(len(v))
+		for i := range v {
+			v[i] -= mean
+		}
+		return v
+	}
+	c := ApplyAxis(m, center, AxisCol)
+	if !Equal(c, [][]float64{{-1.0, -2.0}, {1.0, 2.0}}) {
+		t.Errorf("expected {{-1.0, -2.0}, {1.0, 2.0}}, got %v", c)
+	}
+	r := ApplyAxis(m, center, AxisRow)
+	if !Equal(r, [][]float64{{-0.5, 0.5}, {-1.5, 1.5}}) {
+		t.Errorf("expected {{-0.5, 0.5}, {-1.5, 1.5}}, got %v", r)
+	}
+	if !Equal(m, [][]float64{{1.0, 2.0}, {3.0, 6.0}}) {
+		t.Errorf("expected m not to be mutated, got %v", m)
+	}
+	sum := func(v []float64) []float64 { return []float64{v[0] + v[1]} }
+	if s := ApplyAxis(m, sum, AxisCol); !Equal(s, [][]float64{{4.0, 8.0}}) {
+		t.Errorf("expected {{4.0, 8.0}}, got %v", s)
+	}
+	if s := ApplyAxis(m, sum, AxisRow); !Equal(s, [][]float64{{3.0}, {9.0}}) {
+		t.Errorf("expected {{3.0}, {9.0}}, got %v", s)
+	}
+	ragged := func(v []float64) []float64 {
+		if v[0] == 1.0 {
+			return v[:1]
+		}
+		return v
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		f        func([]float64) []float64
+		axis     Axis
+		expected string
+	}{
+		{sum, Axis(2), fmt.Sprintf("In mat.%s the axis %d is unknown. It must be mat.AxisRow or mat.AxisCol.\n", "ApplyAxis()", 2)},
+		{ragged, AxisRow, fmt.Sprintf("In mat.%s the function returned %d elements, after returning %d.\n", "ApplyAxis()", 2, 1)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			ApplyAxis(m, test.f, test.axis)
+		}()
+		wg.Wait()
+	}
+}
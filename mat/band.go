@@ -0,0 +1,334 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+/*
+Band is a square banded matrix, whose elements are 0.0 except for the kl
+diagonals below the main diagonal, the main diagonal, and the ku diagonals
+above it. Only the n*(kl+ku+1) elements of the band are stored, so that an n
+by n tridiagonal matrix, with kl and ku of 1, takes 3*n float64s rather than
+n*n.
+*/
+type Band struct {
+	kl, ku int
+	// rows[i][j-i+kl] holds the element at row i and column j, for the
+	// columns j of the band.
+	rows [][]float64
+}
+
+/*
+NewBand returns an n by n Band of zeros, with kl diagonals below the main
+diagonal and ku diagonals above it. The elements of the band are then set
+with Set(). For example, the tridiagonal matrix of the second differences
+on a grid of n points is given by:
+
+	b := mat.NewBand(n, 1, 1)
+	for i := 0; i < n; i++ {
+		b.Set(i, i, -2.0)
+		if i > 0 {
+			b.Set(i, i-1, 1.0)
+			b.Set(i-1, i, 1.0)
+		}
+	}
+
+n must be greater than 0, and kl and ku must be in [0, n), otherwise this
+function will panic.
+*/
+func NewBand(n, kl, ku int) *Band {
+	if n <= 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the size must be greater than 0, but received %d.\n"
+		s = fmt.Sprintf(s, "NewBand()", n)
+		debug.PrintStack()
+		panic(s)
+	}
+	if kl < 0 || kl >= n || ku < 0 || ku >= n {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the bandwidths %d and %d must be in [0, %d).\n"
+		s = fmt.Sprintf(s, "NewBand()", kl, ku, n)
+		debug.PrintStack()
+		panic(s)
+	}
+	return &Band{kl: kl, ku: ku, rows: packRows(n, kl+ku+1)}
+}
+
+/*
+NewTridiagonal returns the n by n Band with the passed diagonals, where diag
+is the main diagonal of n elements, and lower and upper are the n-1 elements
+below and above it. For example:
+
+	b := mat.NewTridiagonal([]float64{1.0}, []float64{2.0, 3.0}, []float64{4.0})
+	// b is {{2.0, 4.0}, {1.0, 3.0}}
+
+diag must not be empty, and lower and upper must have one element less than
+diag, otherwise this function will panic. The elements are copied.
+*/
+func NewTridiagonal(lower, diag, upper []float64) *Band {
+	if len(diag) == 0 || len(lower) != len(diag)-1 || len(upper) != len(diag)-1 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the diagonals have %d, %d and %d elements. The main diagonal\n"
+		s += "must not be empty, and the others must have one element less.\n"
+		s = fmt.Sprintf(s, "NewTridiagonal()", len(lower), len(diag), len(upper))
+		debug.PrintStack()
+		panic(s)
+	}
+	n := len(diag)
+	k := min(1, n-1)
+	b := &Band{kl: k, ku: k, rows: packRows(n, 2*k+1)}
+	for i := range diag {
+		b.rows[i][b.kl] = diag[i]
+		if i > 0 {
+			b.rows[i][0] = lower[i-1]
+		}
+		if i < n-1 {
+			b.rows[i][b.kl+1] = upper[i]
+		}
+	}
+	return b
+}
+
+// packRows returns n rows of width elements, which share one allocation.
+func packRows(n, width int) [][]float64 {
+	rows := make([][]float64, n)
+	data := make([]float64, n*width)
+	for i := range rows {
+		rows[i] = data[i*width : (i+1)*width : (i+1)*width]
+	}
+	return rows
+}
+
+// Size returns the number of rows, and columns, of b.
+func (b *Band) Size() int {
+	return len(b.rows)
+}
+
+// Bandwidth returns the number of diagonals of b below, and above, its main
+// diagonal.
+func (b *Band) Bandwidth() (kl, ku int) {
+	return b.kl, b.ku
+}
+
+// inBand reports whether the element at row i and column j is in the band.
+func (b *Band) inBand(i, j int) bool {
+	return j-i <= b.ku && i-j <= b.kl
+}
+
+/*
+At returns the element of b at row i and column j, which is 0.0 outside of
+the band. The indices must be in [0, b.Size()), otherwise this function will
+panic.
+*/
+func (b *Band) At(i, j int) float64 {
+	checkAt("Band.At()", i, j, len(b.rows))
+	if !b.inBand(i, j) {
+		return 0.0
+	}
+	return b.rows[i][j-i+b.kl]
+}
+
+/*
+Set sets the element of b at row i and column j to v. The element must be in
+the band, otherwise this function will panic.
+*/
+func (b *Band) Set(i, j int, v float64) {
+	checkAt("Band.Set()", i, j, len(b.rows))
+	if !b.inBand(i, j) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the element (%d, %d) is outside of the band, with %d diagonals\n"
+		s += "below the main diagonal and %d above it.\n"
+		s = fmt.Sprintf(s, "Band.Set()", i, j, b.kl, b.ku)
+		debug.PrintStack()
+		panic(s)
+	}
+	b.rows[i][j-i+b.kl] = v
+}
+
+/*
+Dense returns b as a [][]float64, with zeros outside of the band.
+*/
+func (b *Band) Dense() [][]float64 {
+	n := len(b.rows)
+	m := New(n)
+	for i, r := range b.rows {
+		for j := max(0, i-b.kl); j <= min(n-1, i+b.ku); j++ {
+			m[i][j] = r[j-i+b.kl]
+		}
+	}
+	return m
+}
+
+/*
+MulVec returns the product b * v, where v is taken as a column vector, in
+about n*(kl+ku+1) operations. The length of v must be b.Size(), otherwise
+this function will panic. v is not mutated in this function.
+*/
+func (b *Band) MulVec(v []float64) []float64 {
+	checkLen("Band.MulVec()", v, len(b.rows))
+	n := len(b.rows)
+	res := make([]float64, n)
+	for i, r := range b.rows {
+		for j := max(0, i-b.kl); j <= min(n-1, i+b.ku); j++ {
+			res[i] += r[j-i+b.kl] * v[j]
+		}
+	}
+	return res
+}
+
+/*
+BandLU is the LU factorization of a Band with partial pivoting, which solves
+systems of the Band for many right hand sides, such as those of the time
+steps of a discretized PDE.
+*/
+type BandLU struct {
+	kl, ku int
+	// rows[i][j-i+kl] holds the element at row i and column j of the
+	// factors, for j in [i-kl, i+kl+ku]. The upper factor takes the columns
+	// from i, and the multipliers of the lower factor those before i.
+	rows [][]float64
+	piv  []int
+}
+
+/*
+LU returns the LU factorization of b, with partial pivoting. The row
+interchanges widen the upper factor to kl+ku diagonals, so the
+factorization takes about n*kl*(kl+ku) operations and n*(2*kl+ku+1)
+float64s, rather than the n*n*n operations and n*n float64s of a general
+factorization. For example:
+
+	lu, err := b.LU()
+	for t := 0; t < steps; t++ {
+		u = lu.Solve(u)
+	}
+
+LU returns an error wrapping ErrSingular if b is singular. b is not mutated
+in this function.
+*/
+func (b *Band) LU() (*BandLU, error) {
+	n, kl, ku := len(b.rows), b.kl, b.ku
+	lu := &BandLU{kl: kl, ku: ku, rows: packRows(n, 2*kl+ku+1), piv: make([]int, n)}
+	for i, r := range b.rows {
+		copy(lu.rows[i], r)
+	}
+	w := lu.rows
+	for k := 0; k < n; k++ {
+		last := min(n-1, k+kl)
+		p := k
+		for i := k + 1; i <= last; i++ {
+			if math.Abs(w[i][k-i+kl]) > math.Abs(w[p][k-p+kl]) {
+				p = i
+			}
+		}
+		lu.piv[k] = p
+		if w[p][k-p+kl] == 0.0 {
+			return nil, &errs.SingularError{Index: k, Err: fmt.Errorf("%w: no pivot in column %d", ErrSingular, k)}
+		}
+		end := min(n-1, k+kl+ku)
+		if p != k {
+			for j := k; j <= end; j++ {
+				w[k][j-k+kl], w[p][j-p+kl] = w[p][j-p+kl], w[k][j-k+kl]
+			}
+		}
+		pivot := w[k][kl]
+		for i := k + 1; i <= last; i++ {
+			f := w[i][k-i+kl] / pivot
+			w[i][k-i+kl] = f
+			if f == 0.0 {
+				continue
+			}
+			for j := k + 1; j <= end; j++ {
+				w[i][j-i+kl] -= f * w[k][j-k+kl]
+			}
+		}
+	}
+	return lu, nil
+}
+
+/*
+Solve returns the x for which b * x = rhs, where b is the factored Band. The
+length of rhs must be the size of b, otherwise this function will panic. rhs
+is not mutated in this function.
+*/
+func (lu *BandLU) Solve(rhs []float64) []float64 {
+	checkLen("BandLU.Solve()", rhs, len(lu.rows))
+	x := make([]float64, len(rhs))
+	copy(x, rhs)
+	lu.solve(x)
+	return x
+}
+
+// solve overwrites x with the solution of b * x = x.
+func (lu *BandLU) solve(x []float64) {
+	n, kl, w := len(lu.rows), lu.kl, lu.rows
+	for k := 0; k < n; k++ {
+		if p := lu.piv[k]; p != k {
+			x[k], x[p] = x[p], x[k]
+		}
+		for i := k + 1; i <= min(n-1, k+kl); i++ {
+			x[i] -= w[i][k-i+kl] * x[k]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := x[i]
+		for j := i + 1; j <= min(n-1, i+kl+lu.ku); j++ {
+			s -= w[i][j-i+kl] * x[j]
+		}
+		x[i] = s / w[i][kl]
+	}
+}
+
+/*
+SolveMat returns the x for which b * x = rhs, where b is the factored Band,
+and each column of rhs is a right hand side. rhs must have as many rows as
+b, and must not be empty or jagged, otherwise this function will panic. rhs
+is not mutated in this function.
+*/
+func (lu *BandLU) SolveMat(rhs [][]float64) [][]float64 {
+	checkRHS("BandLU.SolveMat()", rhs, len(lu.rows))
+	x, _ := solveCols(rhs, func(x []float64) error {
+		lu.solve(x)
+		return nil
+	})
+	return x
+}
+
+/*
+Solve returns the x for which b * x = rhs, through the LU factorization of
+b. For example, for the tridiagonal system of a cubic spline:
+
+	b := mat.NewTridiagonal(lower, diag, upper)
+	x, err := b.Solve(rhs)
+
+To solve several systems of the same Band, factor it once with LU() instead.
+Solve returns an error wrapping ErrSingular if b is singular. The length of
+rhs must be b.Size(), otherwise this function will panic. The passed
+arguments are not mutated in this function.
+*/
+func (b *Band) Solve(rhs []float64) ([]float64, error) {
+	checkLen("Band.Solve()", rhs, len(b.rows))
+	lu, err := b.LU()
+	if err != nil {
+		return nil, err
+	}
+	return lu.Solve(rhs), nil
+}
+
+/*
+SolveMat returns the x for which b * x = rhs, where each column of rhs is a
+right hand side, as with Solve(). b is factored once for all the columns.
+rhs must have b.Size() rows, and must not be empty or jagged, otherwise this
+function will panic. rhs is not mutated in this function.
+*/
+func (b *Band) SolveMat(rhs [][]float64) ([][]float64, error) {
+	checkRHS("Band.SolveMat()", rhs, len(b.rows))
+	lu, err := b.LU()
+	if err != nil {
+		return nil, err
+	}
+	return lu.SolveMat(rhs), nil
+}
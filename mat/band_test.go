@@ -0,0 +1,111 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestNewBand(t *testing.T) {
+	b := NewBand(4, 1, 2)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if j-i <= 2 && i-j <= 1 {
+				b.Set(i, j, float64(10*i+j+1))
+			}
+		}
+	}
+	e := [][]float64{{1.0, 2.0, 3.0, 0.0}, {11.0, 12.0, 13.0, 14.0}, {0.0, 22.0, 23.0, 24.0}, {0.0, 0.0, 33.0, 34.0}}
+	if !Equal(b.Dense(), e) {
+		t.Errorf("expected %v, got %v", e, b.Dense())
+	}
+	if kl, ku := b.Bandwidth(); b.Size() != 4 || kl != 1 || ku != 2 {
+		t.Errorf("expected a size of 4 and bandwidths of 1 and 2")
+	}
+	if b.At(3, 0) != 0.0 || b.At(1, 3) != 14.0 {
+		t.Errorf("expected At() to return the elements of the band")
+	}
+	v := []float64{1.0, -1.0, 2.0, 0.5}
+	p, q := b.MulVec(v), MulVec(e, v)
+	for i := range p {
+		if p[i] != q[i] {
+			t.Errorf("expected %v, got %v", q, p)
+			break
+		}
+	}
+	tri := NewTridiagonal([]float64{1.0}, []float64{2.0, 3.0}, []float64{4.0})
+	if !Equal(tri.Dense(), [][]float64{{2.0, 4.0}, {1.0, 3.0}}) {
+		t.Errorf("expected {{2.0, 4.0}, {1.0, 3.0}}, got %v", tri.Dense())
+	}
+	if one := NewTridiagonal(nil, []float64{5.0}, nil); !Equal(one.Dense(), [][]float64{{5.0}}) {
+		t.Errorf("expected {{5.0}}, got %v", one.Dense())
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		f        func()
+		expected string
+	}{
+		{func() { NewBand(0, 0, 0) }, fmt.Sprintf("In mat.%s the size must be greater than 0, but received %d.\n", "NewBand()", 0)},
+		{func() { NewBand(3, 1, 3) }, fmt.Sprintf("In mat.%s the bandwidths %d and %d must be in [0, %d).\n", "NewBand()", 1, 3, 3)},
+		{func() { b.Set(3, 0, 1.0) }, fmt.Sprintf("In mat.%s the element (%d, %d) is outside of the band, with %d diagonals\nbelow the main diagonal and %d above it.\n", "Band.Set()", 3, 0, 1, 2)},
+		{func() { b.At(4, 0) }, fmt.Sprintf("In mat.%s the element (%d, %d) is outside of bounds [0, %d).\n", "Band.At()", 4, 0, 4)},
+		{func() { NewTridiagonal(nil, []float64{1.0, 2.0}, []float64{1.0}) }, fmt.Sprintf("In mat.%s the diagonals have %d, %d and %d elements. The main diagonal\nmust not be empty, and the others must have one element less.\n", "NewTridiagonal()", 0, 2, 1)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestBandSolve(t *testing.T) {
+	// The small diagonal forces row interchanges, which widen the upper
+	// factor.
+	n := 8
+	b := NewBand(n, 2, 1)
+	for i := 0; i < n; i++ {
+		for j := max(0, i-2); j <= min(n-1, i+1); j++ {
+			b.Set(i, j, float64((3*i+5*j)%7)-3.0)
+		}
+		b.Set(i, i, 1e-3*float64(i+1))
+	}
+	rhs := make([]float64, n)
+	for i := range rhs {
+		rhs[i] = float64(i) - 2.5
+	}
+	x, err := b.Solve(rhs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	y := MulVec(b.Dense(), x)
+	for i := range rhs {
+		if math.Abs(y[i]-rhs[i]) > 1e-9 {
+			t.Errorf("expected b * x = %v, got %v", rhs, y)
+			break
+		}
+	}
+	lu, err := NewTridiagonal([]float64{1.0, 1.0}, []float64{-2.0, -2.0, -2.0}, []float64{1.0, 1.0}).LU()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	x = lu.Solve([]float64{-1.0, 0.0, -1.0})
+	for i := range x {
+		if math.Abs(x[i]-1.0) > 1e-12 {
+			t.Errorf("expected {1.0, 1.0, 1.0}, got %v", x)
+			break
+		}
+	}
+	_, err = NewTridiagonal([]float64{1.0}, []float64{1.0, 1.0}, []float64{1.0}).Solve([]float64{1.0, 2.0})
+	if !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+}
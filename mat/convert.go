@@ -0,0 +1,80 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NDari/gocrunch/vec"
+)
+
+// ErrRange is returned by the conversions of this package when an element
+// is NaN, or outside of the range of the type it is converted to.
+var ErrRange = errors.New("mat: the value is outside of the range of the type")
+
+/*
+FromInts returns a [][]float64 holding the elements of a [][]int, as
+vec.FromInts() does for each row. Integers of magnitude up to 2^53 are
+converted exactly, and larger ones are rounded to the nearest float64. m is
+not mutated in this function.
+*/
+func FromInts(m [][]int) [][]float64 {
+	res := make([][]float64, len(m))
+	for i := range m {
+		res[i] = make([]float64, len(m[i]))
+		for j, x := range m[i] {
+			res[i][j] = float64(x)
+		}
+	}
+	return res
+}
+
+/*
+FromFloat32 returns a [][]float64 holding the elements of a [][]float32,
+which are converted exactly. m is not mutated in this function.
+*/
+func FromFloat32(m [][]float32) [][]float64 {
+	res := make([][]float64, len(m))
+	for i := range m {
+		res[i] = make([]float64, len(m[i]))
+		for j, x := range m[i] {
+			res[i][j] = float64(x)
+		}
+	}
+	return res
+}
+
+/*
+ToFloat32 returns a [][]float32 holding the elements of a [][]float64,
+rounded to the nearest float32, as vec.ToFloat32() does for each row. m is
+not mutated in this function.
+*/
+func ToFloat32(m [][]float64) [][]float32 {
+	res := make([][]float32, len(m))
+	for i := range m {
+		res[i] = make([]float32, len(m[i]))
+		for j, x := range m[i] {
+			res[i][j] = float32(x)
+		}
+	}
+	return res
+}
+
+/*
+ToInts returns a [][]int holding the elements of a [][]float64, rounded to
+the nearest integer, with halves rounded away from zero, as vec.ToInts()
+does for each row. ToInts returns an error wrapping both ErrRange and the
+error of vec.ToInts() for the first row holding an element which is NaN, or
+rounds to an integer outside of the range of an int. m is not mutated in
+this function.
+*/
+func ToInts(m [][]float64) ([][]int, error) {
+	res := make([][]int, len(m))
+	for i := range m {
+		row, err := vec.ToInts(m[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: in row %d, %w", ErrRange, i, err)
+		}
+		res[i] = row
+	}
+	return res, nil
+}
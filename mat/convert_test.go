@@ -0,0 +1,35 @@
+package mat
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/NDari/gocrunch/vec"
+)
+
+func TestConvert(t *testing.T) {
+	if m := FromInts([][]int{{1, 2}, {-3, 4}}); !Equal(m, [][]float64{{1.0, 2.0}, {-3.0, 4.0}}) {
+		t.Errorf("expected {{1.0, 2.0}, {-3.0, 4.0}}, got %v", m)
+	}
+	if m := FromFloat32([][]float32{{0.5}, {-1.25}}); !Equal(m, [][]float64{{0.5}, {-1.25}}) {
+		t.Errorf("expected {{0.5}, {-1.25}}, got %v", m)
+	}
+	f := ToFloat32([][]float64{{0.1, 2.0}, {1e300, 3.0}})
+	if f[0][0] != float32(0.1) || f[0][1] != 2.0 || !math.IsInf(float64(f[1][0]), 1) {
+		t.Errorf("expected {{0.1, 2.0}, {+Inf, 3.0}}, got %v", f)
+	}
+	n, err := ToInts([][]float64{{1.5, -1.5}, {2.4, 0.0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n[0][0] != 2 || n[0][1] != -2 || n[1][0] != 2 || n[1][1] != 0 {
+		t.Errorf("expected {{2, -2}, {2, 0}}, got %v", n)
+	}
+	if n, err = ToInts([][]float64{{1.0}, {math.NaN()}}); !errors.Is(err, ErrRange) || !errors.Is(err, vec.ErrRange) || n != nil {
+		t.Errorf("expected ErrRange, got %v and %v", n, err)
+	}
+	if _, err = ToInts([][]float64{{1e20}}); !errors.Is(err, ErrRange) {
+		t.Errorf("expected ErrRange, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package mat
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/debug"
+)
+
+/*
+Trace returns the sum of the elements on the main diagonal of a square
+[][]float64. For example:
+
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}}
+	t := mat.Trace(m) // t is 5.0
+
+The passed [][]float64 must be square and not empty, otherwise this function
+will panic. It is not mutated in this function.
+*/
+func Trace(m [][]float64) float64 {
+	if len(m) == 0 || len(m) != len(m[0]) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 must be square, but it is %d by %d.\n"
+		s = fmt.Sprintf(s, "Trace()", len(m), rowLen(m))
+		debug.PrintStack()
+		panic(s)
+	}
+	t := 0.0
+	for i := range m {
+		t += m[i][i]
+	}
+	return t
+}
+
+/*
+Rank returns the numerical rank of a [][]float64, which is the number of its
+singular values that are greater than a tolerance. The tolerance can be
+passed as an optional argument:
+
+	r := mat.Rank(m)       // the default tolerance
+	r = mat.Rank(m, 1e-8)  // singular values up to 1e-8 are taken as 0.0
+
+By default, the tolerance is max(r, c) * eps * s, where r and c are the
+dimensions of m, eps is the spacing of float64s at 1.0, and s is the largest
+singular value, which accounts for the rounding errors of the
+decomposition.
+
+The passed [][]float64 must not be empty, and at most one tolerance can be
+passed, otherwise this function will panic. The passed [][]float64 is not
+mutated in this function.
+*/
+func Rank(m [][]float64, tol ...float64) int {
+	if len(tol) > 1 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s expected at most 1 tolerance, but received %d.\n"
+		s = fmt.Sprintf(s, "Rank()", len(tol))
+		debug.PrintStack()
+		panic(s)
+	}
+	_, sv, _, _ := svd(context.Background(), "Rank()", m)
+	t := float64(max(len(m), len(m[0]))) * 0x1p-52 * sv[0]
+	if len(tol) == 1 {
+		t = tol[0]
+	}
+	r := 0
+	for _, x := range sv {
+		if x > t {
+			r++
+		}
+	}
+	return r
+}
+
+/*
+Cond returns the condition number of a [][]float64 in the 2-norm, the ratio
+of its largest singular value to its smallest. The condition number bounds
+how much the relative error of the right hand side of a linear system is
+amplified in its solution, so about log10(mat.Cond(m)) digits of accuracy
+are lost when solving a system with m. A matrix whose smallest singular value
+is 0.0 has a condition number of +Inf.
+
+The passed [][]float64 must not be empty, otherwise this function will
+panic. It is not mutated in this function.
+*/
+func Cond(m [][]float64) float64 {
+	_, sv, _, _ := svd(context.Background(), "Cond()", m)
+	small := sv[len(sv)-1]
+	if small == 0.0 {
+		return math.Inf(1)
+	}
+	return sv[0] / small
+}
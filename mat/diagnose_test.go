@@ -0,0 +1,71 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	if tr := Trace([][]float64{{1.0, 2.0}, {3.0, 4.0}}); tr != 5.0 {
+		t.Errorf("expected 5.0, got %v", tr)
+	}
+	if tr := Trace(I(7)); tr != 7.0 {
+		t.Errorf("expected 7.0, got %v", tr)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf("In mat.%s the [][]float64 must be square, but it is %d by %d.\n", "Trace()", 2, 3)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Trace(New(2, 3))
+	}()
+	wg.Wait()
+}
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		m        [][]float64
+		expected int
+	}{
+		{I(4), 4},
+		{[][]float64{{1.0, 2.0, 3.0}, {2.0, 4.0, 6.0}}, 1},
+		{[][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}, 2},
+		{[][]float64{{0.0, 0.0}, {0.0, 0.0}}, 0},
+		{Rand(5, 3), 3},
+	}
+	for _, test := range tests {
+		if r := Rank(test.m); r != test.expected {
+			t.Errorf("Rank(%v): expected %d, got %d", test.m, test.expected, r)
+		}
+	}
+	m := [][]float64{{1.0, 0.0}, {0.0, 1e-6}}
+	if r := Rank(m); r != 2 {
+		t.Errorf("expected a rank of 2 with the default tolerance, got %d", r)
+	}
+	if r := Rank(m, 1e-5); r != 1 {
+		t.Errorf("expected a rank of 1 with a tolerance of 1e-5, got %d", r)
+	}
+}
+
+func TestCond(t *testing.T) {
+	if c := Cond(I(3)); math.Abs(c-1.0) > 1e-15 {
+		t.Errorf("expected 1.0, got %v", c)
+	}
+	if c := Cond([][]float64{{2.0, 0.0}, {0.0, -0.5}}); math.Abs(c-4.0) > 1e-14 {
+		t.Errorf("expected 4.0, got %v", c)
+	}
+	if c := Cond([][]float64{{1.0, 1.0}, {1.0, 1.0}}); c < 1e15 {
+		t.Errorf("expected a very large condition number for a singular matrix, got %v", c)
+	}
+	if c := Cond([][]float64{{0.0, 0.0}, {0.0, 0.0}}); !math.IsInf(c, 1) {
+		t.Errorf("expected +Inf, got %v", c)
+	}
+}
@@ -0,0 +1,91 @@
+package mat
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/NDari/gocrunch/backend"
+)
+
+// dotBlock is the number of rows of the product computed between checks of
+// the context in DotCtx().
+const dotBlock = 64
+
+/*
+DotCtx is Dot(), computing the product in blocks of rows and stopping early
+if ctx is done before the product is complete, in which case it returns nil
+and ctx.Err(). This bounds the time spent on large products, for example when
+serving a request with a deadline:
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	p, err := mat.DotCtx(ctx, m, n)
+
+The original [][]float64s are not mutated in this function.
+*/
+func DotCtx(ctx context.Context, m, n [][]float64) ([][]float64, error) {
+	if len(m[0]) != len(n) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the number of elements in the first row of the first argument is %d,\n"
+		s += "while the len of the second argument is %d. They must match.\n"
+		s = fmt.Sprintf(s, "DotCtx()", len(m[0]), len(n))
+		debug.PrintStack()
+		panic(s)
+	}
+	res := New(len(m), len(n[0]))
+	for lo := 0; lo < len(m); lo += dotBlock {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		hi := lo + dotBlock
+		if hi > len(m) {
+			hi = len(m)
+		}
+		backend.Gemm(false, false, 1.0, m[lo:hi], n, 0.0, res[lo:hi])
+	}
+	return res, nil
+}
+
+/*
+Gemm stores alpha*a*b + beta*c in c, and returns c, accumulating the product
+of a and b into an existing [][]float64 in a single pass, without allocating.
+For example, to add the product of a and b to c:
+
+	mat.Gemm(1.0, a, b, 1.0, c)
+
+With a beta of 0.0, the previous elements of c are ignored, even if they are
+NaN. The product is computed by the current engine of the backend package.
+
+The number of columns of a must be the number of rows of b, and c must have
+the number of rows of a and the number of columns of b, otherwise this
+function will panic. c must not share its elements with a or b. a and b are
+not mutated in this function.
+*/
+func Gemm(alpha float64, a, b [][]float64, beta float64, c [][]float64) [][]float64 {
+	if len(a) == 0 || len(a[0]) == 0 || len(b) == 0 || len(b[0]) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64s cannot be empty.\n"
+		s = fmt.Sprintf(s, "Gemm()")
+		debug.PrintStack()
+		panic(s)
+	}
+	if len(a[0]) != len(b) || len(c) != len(a) || len(c[0]) != len(b[0]) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s a is %d by %d, b is %d by %d, and c is %d by %d.\n"
+		s += "The product of a and b must have the shape of c.\n"
+		s = fmt.Sprintf(s, "Gemm()", len(a), len(a[0]), len(b), len(b[0]), len(c), rowLen(c))
+		debug.PrintStack()
+		panic(s)
+	}
+	backend.Gemm(false, false, alpha, a, b, beta, c)
+	return c
+}
+
+// rowLen returns the length of the first row of m, or 0 if m is empty.
+func rowLen(m [][]float64) int {
+	if len(m) == 0 {
+		return 0
+	}
+	return len(m[0])
+}
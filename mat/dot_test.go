@@ -0,0 +1,57 @@
+package mat
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestDotCtx(t *testing.T) {
+	m := Rand(150, 20)
+	n := Rand(20, 30)
+	p, err := DotCtx(context.Background(), m, n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(p, Dot(m, n)) {
+		t.Errorf("expected the product of Dot()")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p, err = DotCtx(ctx, m, n)
+	if err != context.Canceled || p != nil {
+		t.Errorf("expected nil and %v, got %v and %v", context.Canceled, p, err)
+	}
+}
+
+func TestGemm(t *testing.T) {
+	a := [][]float64{{1.0, 2.0}, {3.0, 4.0}}
+	b := [][]float64{{1.0, 0.0, 1.0}, {0.0, 1.0, 1.0}}
+	c := [][]float64{{1.0, 1.0, 1.0}, {2.0, 2.0, 2.0}}
+	res := Gemm(2.0, a, b, -1.0, c)
+	expected := [][]float64{{1.0, 3.0, 5.0}, {4.0, 6.0, 12.0}}
+	if !Equal(c, expected) || &res[0][0] != &c[0][0] {
+		t.Errorf("expected %v in place, got %v", expected, c)
+	}
+	nan := [][]float64{{math.NaN(), math.NaN(), math.NaN()}, {math.NaN(), math.NaN(), math.NaN()}}
+	if !Equal(Gemm(1.0, a, b, 0.0, nan), Dot(a, b)) {
+		t.Errorf("expected a beta of 0.0 to ignore the elements of c")
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf("In mat.%s a is %d by %d, b is %d by %d, and c is %d by %d.\n"+
+				"The product of a and b must have the shape of c.\n", "Gemm()", 2, 2, 2, 3, 2, 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Gemm(1.0, a, b, 1.0, New(2, 2))
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,259 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime/debug"
+)
+
+// ErrMaxIter is returned when an iteration does not converge within its
+// maximum number of iterations.
+var ErrMaxIter = errors.New("mat: maximum number of iterations reached")
+
+// padeTheta holds the largest 1-norms for which the Pade approximants of
+// degree 3, 5, 7, 9 and 13 of the exponential are accurate to the precision
+// of float64s, from Higham, "The scaling and squaring method for the matrix
+// exponential revisited", 2005.
+var padeTheta = []struct {
+	degree int
+	theta  float64
+}{
+	{3, 1.495585217958292e-2},
+	{5, 2.539398330063230e-1},
+	{7, 9.504178996162932e-1},
+	{9, 2.097847961257068e0},
+	{13, 5.371920351148152e0},
+}
+
+// padeCoef holds the coefficients of the Pade approximants of the
+// exponential, by degree.
+var padeCoef = map[int][]float64{
+	3: {120.0, 60.0, 12.0, 1.0},
+	5: {30240.0, 15120.0, 3360.0, 420.0, 30.0, 1.0},
+	7: {17297280.0, 8648640.0, 1995840.0, 277200.0, 25200.0, 1512.0, 56.0, 1.0},
+	9: {17643225600.0, 8821612800.0, 2075673600.0, 302702400.0, 30270240.0,
+		2162160.0, 110880.0, 3960.0, 90.0, 1.0},
+	13: {64764752532480000.0, 32382376266240000.0, 7771770303897600.0,
+		1187353796428800.0, 129060195264000.0, 10559470521600.0, 670442572800.0,
+		33522128640.0, 1323241920.0, 40840800.0, 960960.0, 16380.0, 182.0, 1.0},
+}
+
+/*
+Expm returns the matrix exponential of a square [][]float64,
+
+	exp(m) = I + m + m*m/2! + m*m*m/3! + ...
+
+which is, for example, the state transition matrix exp(a*t) of the linear
+system dx/dt = a*x over a time t:
+
+	phi := mat.Expm(mat.Mul(a, t))
+	x1 := mat.MulVec(phi, x0)
+
+The exponential is computed by scaling and squaring, with the Pade
+approximant of the lowest degree which is accurate for the 1-norm of m, as
+described by Higham in "The scaling and squaring method for the matrix
+exponential revisited", 2005. This takes a few tens of matrix products, and
+is meant for small dense [][]float64s.
+
+The passed [][]float64 must be square and not empty, otherwise this function
+will panic. It is not mutated in this function.
+*/
+func Expm(m [][]float64) [][]float64 {
+	checkSquare("Expm()", m)
+	norm := Norm(m, NormOne)
+	for _, p := range padeTheta[:len(padeTheta)-1] {
+		if norm <= p.theta {
+			u, v := padeUV(m, p.degree)
+			return padeSolve("Expm()", u, v)
+		}
+	}
+	// Scale m by 2^-s to bring its norm under the threshold of degree 13,
+	// and square the approximant s times.
+	s := 0
+	if theta := padeTheta[len(padeTheta)-1].theta; norm > theta {
+		s = int(math.Ceil(math.Log2(norm / theta)))
+	}
+	a := Mul(m, math.Ldexp(1.0, -s))
+	u, v := padeUV(a, 13)
+	r := padeSolve("Expm()", u, v)
+	for ; s > 0; s-- {
+		r = Dot(r, r)
+	}
+	return r
+}
+
+// padeUV returns the odd and even parts, u and v, of the numerator of the
+// Pade approximant of the given degree of exp(a), which is v + u, with a
+// denominator of v - u.
+func padeUV(a [][]float64, degree int) (u, v [][]float64) {
+	b := padeCoef[degree]
+	n := len(a)
+	a2 := Dot(a, a)
+	if degree < 13 {
+		// u = a * (b[1]*I + b[3]*a2 + ...), v = b[0]*I + b[2]*a2 + ...
+		odd, v := scaledI(n, b[1]), scaledI(n, b[0])
+		pow := I(n)
+		for k := 2; k <= degree; k += 2 {
+			pow = Dot(pow, a2)
+			addScaled(odd, b[k+1], pow)
+			addScaled(v, b[k], pow)
+		}
+		return Dot(a, odd), v
+	}
+	a4 := Dot(a2, a2)
+	a6 := Dot(a4, a2)
+	w := New(n)
+	addScaled(w, b[13], a6)
+	addScaled(w, b[11], a4)
+	addScaled(w, b[9], a2)
+	odd := Dot(a6, w)
+	addScaled(odd, b[7], a6)
+	addScaled(odd, b[5], a4)
+	addScaled(odd, b[3], a2)
+	addScaled(odd, b[1], I(n))
+	z := New(n)
+	addScaled(z, b[12], a6)
+	addScaled(z, b[10], a4)
+	addScaled(z, b[8], a2)
+	v = Dot(a6, z)
+	addScaled(v, b[6], a6)
+	addScaled(v, b[4], a4)
+	addScaled(v, b[2], a2)
+	addScaled(v, b[0], I(n))
+	return Dot(a, odd), v
+}
+
+// padeSolve returns the Pade approximant (v - u)^-1 * (v + u), for the
+// function fn. Since the norm of u is bounded, v - u is always well
+// conditioned, and a singular v - u is a bug in the choice of the degree.
+func padeSolve(fn string, u, v [][]float64) [][]float64 {
+	f, err := factorLU(Sub(v, u))
+	if err != nil {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the denominator of the Pade approximant is singular:\n%v\n"
+		s = fmt.Sprintf(s, fn, err)
+		debug.PrintStack()
+		panic(s)
+	}
+	return f.SolveMat(Add(v, u))
+}
+
+/*
+Logm returns the principal matrix logarithm of a square [][]float64, the
+[][]float64 l whose eigenvalues have imaginary parts in (-pi, pi), and for
+which mat.Expm(l) is m. For example, the generator a of a linear system can
+be recovered from its state transition matrix phi over a time t:
+
+	l, err := mat.Logm(phi)
+	a := mat.Div(l, t)
+
+The logarithm is computed by inverse scaling and squaring: square roots of
+m are taken with the Denman-Beavers iteration until the result is close to
+I, the logarithm of the result is approximated by a Pade approximant of
+degree 8, and is then scaled back by 2 to the number of square roots taken.
+
+The principal logarithm is real only when m has no eigenvalues on the
+closed negative real axis. Logm returns an error wrapping ErrSingular if m
+is singular, and an error wrapping ErrSingular or ErrMaxIter if the square
+roots break down or do not converge, which happens when m has negative real
+eigenvalues. The passed
+[][]float64 must be square and not empty, otherwise this function will
+panic. It is not mutated in this function.
+*/
+func Logm(m [][]float64) ([][]float64, error) {
+	checkSquare("Logm()", m)
+	n := len(m)
+	x := Clone(m)
+	k := 0
+	for Norm(Sub(x, I(n)), NormOne) > 0.25 {
+		if k == maxSqrtm {
+			return nil, fmt.Errorf("%w: %d square roots are still not close to I", ErrMaxIter, k)
+		}
+		var err error
+		if x, err = sqrtm(x); err != nil {
+			return nil, err
+		}
+		k++
+	}
+	// log(I + e) = sum of w[j] * e * (I + t[j]*e)^-1, which is the Gauss
+	// Legendre quadrature of the integral of e * (I + t*e)^-1 over [0, 1],
+	// and the diagonal Pade approximant of degree 8. Its error for a norm
+	// of e up to 0.25 is below the precision of float64s.
+	e := Sub(x, I(n))
+	l := New(n)
+	for j, t := range gaussNodes {
+		q := I(n)
+		addScaled(q, t, e)
+		f, err := factorLU(q)
+		if err != nil {
+			return nil, err
+		}
+		addScaled(l, gaussWeights[j], f.SolveMat(e))
+	}
+	return Mul(l, math.Ldexp(1.0, k)), nil
+}
+
+// maxSqrtm is the number of square roots after which Logm() stops.
+const maxSqrtm = 64
+
+// maxDenmanBeavers is the number of iterations of the Denman-Beavers
+// iteration in sqrtm() after which it stops.
+const maxDenmanBeavers = 100
+
+// sqrtm returns the principal square root of x, with the Denman-Beavers
+// iteration, y = (y + z^-1)/2, z = (z + y^-1)/2, from y = x and z = I, under
+// which y converges to the square root of x, and z to its inverse.
+func sqrtm(x [][]float64) ([][]float64, error) {
+	n := len(x)
+	y, z := Clone(x), I(n)
+	for it := 0; it < maxDenmanBeavers; it++ {
+		fy, err := factorLU(y)
+		if err != nil {
+			return nil, err
+		}
+		fz, err := factorLU(z)
+		if err != nil {
+			return nil, err
+		}
+		ny := Mul(Add(y, fz.SolveMat(I(n))), 0.5)
+		z = Mul(Add(z, fy.SolveMat(I(n))), 0.5)
+		diff := Norm(Sub(ny, y), NormOne)
+		y = ny
+		if diff <= 1e-14*Norm(y, NormOne) {
+			return y, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: the square root did not converge in %d iterations", ErrMaxIter, maxDenmanBeavers)
+}
+
+// gaussNodes and gaussWeights are the nodes and weights of the Gauss
+// Legendre quadrature of 8 points over [0, 1].
+var (
+	gaussNodes = []float64{
+		0.019855071751231856, 0.10166676129318664, 0.2372337950418355, 0.4082826787521751,
+		0.5917173212478249, 0.7627662049581645, 0.8983332387068134, 0.9801449282487681,
+	}
+	gaussWeights = []float64{
+		0.05061426814518815, 0.11119051722668725, 0.15685332293894365, 0.1813418916891810,
+		0.1813418916891810, 0.15685332293894365, 0.11119051722668725, 0.05061426814518815,
+	}
+)
+
+// scaledI returns an n by n identity scaled by alpha.
+func scaledI(n int, alpha float64) [][]float64 {
+	m := New(n)
+	for i := range m {
+		m[i][i] = alpha
+	}
+	return m
+}
+
+// addScaled stores dst + alpha*x in dst.
+func addScaled(dst [][]float64, alpha float64, x [][]float64) {
+	for i := range dst {
+		for j := range dst[i] {
+			dst[i][j] += alpha * x[i][j]
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestExpm(t *testing.T) {
+	near := func(a, b [][]float64, tol float64) bool {
+		for i := range a {
+			for j := range a[i] {
+				if math.Abs(a[i][j]-b[i][j]) > tol*(1.0+math.Abs(b[i][j])) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	for _, test := range []struct {
+		m, expected [][]float64
+	}{
+		{New(2), I(2)},
+		{[][]float64{{1e-3, 0.0}, {0.0, -2e-3}}, [][]float64{{math.Exp(1e-3), 0.0}, {0.0, math.Exp(-2e-3)}}},
+		{[][]float64{{0.0, 1.0}, {0.0, 0.0}}, [][]float64{{1.0, 1.0}, {0.0, 1.0}}},
+		{[][]float64{{0.0, -1.5}, {1.5, 0.0}}, [][]float64{{math.Cos(1.5), -math.Sin(1.5)}, {math.Sin(1.5), math.Cos(1.5)}}},
+		{[][]float64{{2.0, 0.0, 0.0}, {0.0, 3.0, 0.0}, {0.0, 0.0, 4.0}}, [][]float64{{math.Exp(2.0), 0.0, 0.0}, {0.0, math.Exp(3.0), 0.0}, {0.0, 0.0, math.Exp(4.0)}}},
+		// The example of Moler and Van Loan, which needs scaling and squaring.
+		{[][]float64{{-49.0, 24.0}, {-64.0, 31.0}}, [][]float64{
+			{-2.0*math.Exp(-1.0) + 3.0*math.Exp(-17.0), 1.5*math.Exp(-1.0) - 1.5*math.Exp(-17.0)},
+			{-4.0*math.Exp(-1.0) + 4.0*math.Exp(-17.0), 3.0*math.Exp(-1.0) - 2.0*math.Exp(-17.0)},
+		}},
+	} {
+		if e := Expm(test.m); !near(e, test.expected, 1e-12) {
+			t.Errorf("expected the exponential of %v to be %v, got %v", test.m, test.expected, e)
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		expected := fmt.Sprintf("In mat.%s the [][]float64 must be square, but row %d has %d elements,\nwhile there are %d rows.\n", "Expm()", 0, 3, 2)
+		defer func() {
+			if r := recover(); r != expected {
+				t.Errorf("Expected %s, got %v", expected, r)
+			}
+			wg.Done()
+		}()
+		Expm(New(2, 3))
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		u := I(2)
+		_, err := factorLU(Sub(u, u))
+		expected := fmt.Sprintf("In mat.%s the denominator of the Pade approximant is singular:\n%v\n", "Expm()", err)
+		defer func() {
+			if r := recover(); r != expected {
+				t.Errorf("Expected %s, got %v", expected, r)
+			}
+			wg.Done()
+		}()
+		padeSolve("Expm()", u, u)
+	}()
+	wg.Wait()
+}
+
+func TestLogm(t *testing.T) {
+	for _, a := range [][][]float64{
+		{{0.0, 0.0}, {0.0, 0.0}},
+		{{0.1, 0.2}, {-0.3, 0.05}},
+		{{1.0, 2.0, 0.0}, {-0.5, 0.3, 1.0}, {0.2, 0.0, -1.2}},
+		{{0.0, -2.5}, {2.5, 0.0}},
+	} {
+		l, err := Logm(Expm(a))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		for i := range a {
+			for j := range a[i] {
+				if math.Abs(l[i][j]-a[i][j]) > 1e-10 {
+					t.Errorf("expected the logarithm to be %v, got %v", a, l)
+				}
+			}
+		}
+	}
+	if _, err := Logm([][]float64{{1.0, 2.0}, {2.0, 4.0}}); err == nil {
+		t.Errorf("expected an error for a singular matrix")
+	}
+	if _, err := Logm([][]float64{{-2.0, 0.0}, {0.0, 1.0}}); err == nil {
+		t.Errorf("expected an error for a negative eigenvalue")
+	}
+}
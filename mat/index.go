@@ -0,0 +1,178 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+// ErrIndex is returned by the indexing functions of this package when an
+// index is outside of the [][]float64 it picks rows or columns from.
+var ErrIndex = errors.New("mat: index out of range")
+
+/*
+GatherRows returns a new [][]float64 holding copies of the rows of m at the
+passed indices, in the order of the indices. The indices may repeat, and may
+be negative, counting from the end, as with mat.RowView(). For example, to
+shuffle the rows of a data set:
+
+	shuffled, err := mat.GatherRows(m, rand.Perm(len(m)))
+
+GatherRows returns an error wrapping ErrIndex if an index is outside of
+[-len(m), len(m)), and one wrapping ErrShape if m is jagged. m is not
+mutated in this function.
+*/
+func GatherRows(m [][]float64, idx []int) ([][]float64, error) {
+	if _, err := shape(m); err != nil {
+		return nil, err
+	}
+	res := make([][]float64, len(idx))
+	for i, k := range idx {
+		j, err := pick(k, len(m), "row")
+		if err != nil {
+			return nil, fmt.Errorf("%w: index %d is %v", ErrIndex, i, err)
+		}
+		res[i] = make([]float64, len(m[j]))
+		copy(res[i], m[j])
+	}
+	return res, nil
+}
+
+/*
+GatherCols returns a new [][]float64 whose columns are copies of the columns
+of m at the passed indices, in the order of the indices, which may repeat
+and may be negative, as with mat.GatherRows(). For example:
+
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}}
+	n, err := mat.GatherCols(m, []int{2, 0}) // n is {{3.0, 1.0}, {6.0, 4.0}}
+
+GatherCols returns an error wrapping ErrIndex if an index is outside of the
+columns of m, and one wrapping ErrShape if m is empty or jagged. m is not
+mutated in this function.
+*/
+func GatherCols(m [][]float64, idx []int) ([][]float64, error) {
+	c, err := shape(m)
+	if err != nil {
+		return nil, err
+	}
+	if len(m) == 0 {
+		return nil, &errs.EmptyError{Got: []int{0}, Err: fmt.Errorf("%w: the [][]float64 is empty", ErrShape)}
+	}
+	cols := make([]int, len(idx))
+	for i, k := range idx {
+		if cols[i], err = pick(k, c, "column"); err != nil {
+			return nil, fmt.Errorf("%w: index %d is %v", ErrIndex, i, err)
+		}
+	}
+	res := make([][]float64, len(m))
+	for i, r := range m {
+		res[i] = make([]float64, len(cols))
+		for j, k := range cols {
+			res[i][j] = r[k]
+		}
+	}
+	return res, nil
+}
+
+/*
+ScatterRows copies the rows of a [][]float64 into the rows of m at the
+passed indices, in order, so that the last row is kept for an index that
+repeats. It is the inverse of mat.GatherRows():
+
+	rows, err := mat.GatherRows(m, idx)
+	// ... update rows ...
+	err = mat.ScatterRows(m, idx, rows)
+
+ScatterRows returns an error wrapping ErrShape if rows does not have one row
+per index, of the length of the rows of m, or if m is jagged, and one
+wrapping ErrIndex if an index is outside of [-len(m), len(m)). All the
+indices and shapes are checked before any row is copied, so m is left intact
+when an error is returned. rows is not mutated in this function.
+*/
+func ScatterRows(m [][]float64, idx []int, rows [][]float64) error {
+	c, err := shape(m)
+	if err != nil {
+		return err
+	}
+	if len(rows) != len(idx) {
+		return &errs.ShapeError{
+			Got:  []int{len(rows), -1},
+			Want: []int{len(idx), c},
+			Err:  fmt.Errorf("%w: %d indices and %d rows", ErrShape, len(idx), len(rows)),
+		}
+	}
+	dst := make([]int, len(idx))
+	for i, k := range idx {
+		if dst[i], err = pick(k, len(m), "row"); err != nil {
+			return fmt.Errorf("%w: index %d is %v", ErrIndex, i, err)
+		}
+		if len(rows[i]) != c {
+			return &errs.ShapeError{
+				Got:  []int{len(rows[i])},
+				Want: []int{c},
+				Err:  fmt.Errorf("%w: row %d has %d elements, while the rows of the [][]float64 have %d", ErrShape, i, len(rows[i]), c),
+			}
+		}
+	}
+	for i, j := range dst {
+		copy(m[j], rows[i])
+	}
+	return nil
+}
+
+/*
+ScatterCols copies the columns of a [][]float64 into the columns of m at the
+passed indices, in order, so that the last column is kept for an index that
+repeats. It is the inverse of mat.GatherCols(), and cols must have the
+number of rows of m, and one column per index.
+
+ScatterCols returns an error wrapping ErrShape if cols does not have that
+shape, or if m or cols are empty or jagged, and one wrapping ErrIndex if an
+index is outside of the columns of m. All the indices and shapes are checked
+before any element is copied, so m is left intact when an error is returned.
+cols is not mutated in this function.
+*/
+func ScatterCols(m [][]float64, idx []int, cols [][]float64) error {
+	c, err := shape(m)
+	if err != nil {
+		return err
+	}
+	if len(m) == 0 {
+		return &errs.EmptyError{Got: []int{0}, Err: fmt.Errorf("%w: the [][]float64 is empty", ErrShape)}
+	}
+	cc, err := shape(cols)
+	if err != nil {
+		return err
+	}
+	if len(cols) != len(m) || cc != len(idx) {
+		return &errs.ShapeError{
+			Got:  []int{len(cols), cc},
+			Want: []int{len(m), len(idx)},
+			Err:  fmt.Errorf("%w: the columns are %d by %d, while there are %d rows and %d indices", ErrShape, len(cols), cc, len(m), len(idx)),
+		}
+	}
+	dst := make([]int, len(idx))
+	for i, k := range idx {
+		if dst[i], err = pick(k, c, "column"); err != nil {
+			return fmt.Errorf("%w: index %d is %v", ErrIndex, i, err)
+		}
+	}
+	for i, r := range m {
+		for j, k := range dst {
+			r[k] = cols[i][j]
+		}
+	}
+	return nil
+}
+
+// pick returns the row or column of n picked by k, which may be negative.
+func pick(k, n int, what string) (int, error) {
+	if k >= n || k < -n {
+		return 0, fmt.Errorf("the %s %d, outside of [-%d, %d)", what, k, n, n)
+	}
+	if k < 0 {
+		k += n
+	}
+	return k, nil
+}
@@ -0,0 +1,100 @@
+package mat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGatherRows(t *testing.T) {
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}
+	n, err := GatherRows(m, []int{2, -3, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(n, [][]float64{{5.0, 6.0}, {1.0, 2.0}, {5.0, 6.0}}) {
+		t.Errorf("expected {{5.0, 6.0}, {1.0, 2.0}, {5.0, 6.0}}, got %v", n)
+	}
+	n[0][0] = 0.0
+	if m[2][0] != 5.0 {
+		t.Errorf("expected the rows to be copied")
+	}
+	if _, err = GatherRows(m, []int{3}); !errors.Is(err, ErrIndex) {
+		t.Errorf("expected ErrIndex, got %v", err)
+	}
+	if _, err = GatherRows([][]float64{{1.0}, {2.0, 3.0}}, []int{0}); !errors.Is(err, ErrShape) {
+		t.Errorf("expected ErrShape, got %v", err)
+	}
+}
+
+func TestGatherCols(t *testing.T) {
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}}
+	n, err := GatherCols(m, []int{2, 0, -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(n, [][]float64{{3.0, 1.0, 3.0}, {6.0, 4.0, 6.0}}) {
+		t.Errorf("expected {{3.0, 1.0, 3.0}, {6.0, 4.0, 6.0}}, got %v", n)
+	}
+	if _, err = GatherCols(m, []int{-4}); !errors.Is(err, ErrIndex) {
+		t.Errorf("expected ErrIndex, got %v", err)
+	}
+	if _, err = GatherCols(nil, []int{0}); !errors.Is(err, ErrShape) {
+		t.Errorf("expected ErrShape, got %v", err)
+	}
+}
+
+func TestScatterRows(t *testing.T) {
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}
+	if err := ScatterRows(m, []int{-1, 0}, [][]float64{{7.0, 8.0}, {9.0, 10.0}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(m, [][]float64{{9.0, 10.0}, {3.0, 4.0}, {7.0, 8.0}}) {
+		t.Errorf("expected {{9.0, 10.0}, {3.0, 4.0}, {7.0, 8.0}}, got %v", m)
+	}
+	expected := Clone(m)
+	tests := []struct {
+		idx  []int
+		rows [][]float64
+		err  error
+	}{
+		{[]int{0, 3}, [][]float64{{0.0, 0.0}, {0.0, 0.0}}, ErrIndex},
+		{[]int{0, 1}, [][]float64{{0.0, 0.0}, {0.0}}, ErrShape},
+		{[]int{0}, [][]float64{{0.0, 0.0}, {0.0, 0.0}}, ErrShape},
+	}
+	for _, test := range tests {
+		if err := ScatterRows(m, test.idx, test.rows); !errors.Is(err, test.err) {
+			t.Errorf("ScatterRows(%v, %v): expected %v, got %v", test.idx, test.rows, test.err, err)
+		}
+		if !Equal(m, expected) {
+			t.Errorf("expected m to be left intact on error, got %v", m)
+		}
+	}
+}
+
+func TestScatterCols(t *testing.T) {
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}}
+	if err := ScatterCols(m, []int{2, 0}, [][]float64{{7.0, 8.0}, {9.0, 10.0}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(m, [][]float64{{8.0, 2.0, 7.0}, {10.0, 5.0, 9.0}}) {
+		t.Errorf("expected {{8.0, 2.0, 7.0}, {10.0, 5.0, 9.0}}, got %v", m)
+	}
+	cols, err := GatherCols(m, []int{1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cols[0][0], cols[1][0] = -1.0, -2.0
+	if err = ScatterCols(m, []int{1}, cols); err != nil || m[0][1] != -1.0 || m[1][1] != -2.0 {
+		t.Errorf("expected the gathered column to be scattered back, got %v and %v", m, err)
+	}
+	expected := Clone(m)
+	if err = ScatterCols(m, []int{0, 5}, [][]float64{{0.0, 0.0}, {0.0, 0.0}}); !errors.Is(err, ErrIndex) {
+		t.Errorf("expected ErrIndex, got %v", err)
+	}
+	if err = ScatterCols(m, []int{0}, [][]float64{{0.0, 0.0}, {0.0, 0.0}}); !errors.Is(err, ErrShape) {
+		t.Errorf("expected ErrShape, got %v", err)
+	}
+	if !Equal(m, expected) {
+		t.Errorf("expected m to be left intact on error, got %v", m)
+	}
+}
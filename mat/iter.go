@@ -0,0 +1,79 @@
+package mat
+
+import (
+	"fmt"
+	"iter"
+	"runtime/debug"
+)
+
+/*
+Values returns an iterator over the elements of a [][]float64, row after
+row, for use in range-over-func loops, as vec.Values() does for a
+[]float64. For example:
+
+	for x := range mat.Values(m) {
+		fmt.Println(x)
+	}
+
+The iterator reads m as it goes, so changes to m during the iteration are
+seen by it.
+*/
+func Values(m [][]float64) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for i := range m {
+			for j := range m[i] {
+				if !yield(m[i][j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+/*
+Slices returns an iterator over the rows or the columns of a [][]float64,
+along with their indices, without allocating a []float64 for each of them.
+For example, to find the norm of each column:
+
+	for j, c := range mat.Slices(m, mat.AxisCol) {
+		norms[j] = vec.Norm(c)
+	}
+
+The rows are views of m, as with mat.RowView(), so that setting an element
+of a row sets the element of m. Since the elements of a column are not
+contiguous, each column is copied into a single []float64 which is reused
+for all the columns, and is only valid until the next iteration. Use
+vec.Clone() to keep a column, and mat.SetCol() to change one. The axis must
+be mat.AxisRow or mat.AxisCol, and m must not be jagged when iterating over
+the columns, otherwise this function will panic.
+*/
+func Slices(m [][]float64, axis Axis) iter.Seq2[int, []float64] {
+	checkAxis("Slices()", axis)
+	if axis == AxisRow {
+		return func(yield func(int, []float64) bool) {
+			for i := range m {
+				if !yield(i, m[i]) {
+					return
+				}
+			}
+		}
+	}
+	if _, err := shape(m); err != nil {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 cannot be jagged when iterating over its columns.\n"
+		s = fmt.Sprintf(s, "Slices()")
+		debug.PrintStack()
+		panic(s)
+	}
+	return func(yield func(int, []float64) bool) {
+		c := make([]float64, len(m))
+		for j := 0; j < rowLen(m); j++ {
+			for i := range m {
+				c[i] = m[i][j]
+			}
+			if !yield(j, c) {
+				return
+			}
+		}
+	}
+}
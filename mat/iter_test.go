@@ -0,0 +1,90 @@
+package mat
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestValues(t *testing.T) {
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}}
+	if v := slices.Collect(Values(m)); !Equal([][]float64{v}, [][]float64{{1.0, 2.0, 3.0, 4.0}}) {
+		t.Errorf("expected {1.0, 2.0, 3.0, 4.0}, got %v", v)
+	}
+	count := 0
+	for range Values(m) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("expected to stop after 3 values, got %d", count)
+	}
+}
+
+func TestSlices(t *testing.T) {
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}}
+	for i, r := range Slices(m, AxisRow) {
+		if &r[0] != &m[i][0] {
+			t.Errorf("expected row %d to be a view of m", i)
+		}
+		r[0] *= 10.0
+	}
+	if m[0][0] != 10.0 || m[1][0] != 40.0 {
+		t.Errorf("expected the rows to be changed through the views, got %v", m)
+	}
+	var cols [][]float64
+	var buf []float64
+	for j, c := range Slices(m, AxisCol) {
+		if j == 0 {
+			buf = c
+		} else if &c[0] != &buf[0] {
+			t.Errorf("expected the column buffer to be reused")
+		}
+		cols = append(cols, append([]float64{}, c...))
+	}
+	if !Equal(cols, T(m)) {
+		t.Errorf("expected the columns %v, got %v", T(m), cols)
+	}
+	count := 0
+	for range Slices(m, AxisCol) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected to stop after 1 column, got %d", count)
+	}
+	for range Slices(nil, AxisCol) {
+		t.Errorf("expected no columns for an empty [][]float64")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { Slices(m, Axis(2)) },
+			fmt.Sprintf("In mat.%s the axis %d is unknown. It must be mat.AxisRow or mat.AxisCol.\n", "Slices()", 2),
+		},
+		{
+			func() { Slices([][]float64{{1.0}, {1.0, 2.0}}, AxisCol) },
+			fmt.Sprintf("In mat.%s the [][]float64 cannot be jagged when iterating over its columns.\n", "Slices()"),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,50 @@
+package mat
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+/*
+Kron returns the Kronecker product of two [][]float64. If a is r by c, and b
+is p by q, the product is the rp by cq [][]float64 made of r by c blocks,
+where the block at [i][j] is b multiplied by a[i][j]. For example:
+
+	a := [][]float64{{1.0, 2.0}}
+	b := [][]float64{{1.0}, {10.0}}
+	k := mat.Kron(a, b) // k is {{1.0, 2.0}, {10.0, 20.0}}
+
+Each row of the product is filled one block at a time from a row of b, so
+that both are read sequentially, and the rows of the product share a single
+allocation.
+
+The passed [][]float64s must not be empty, otherwise this function will
+panic. They are not mutated in this function.
+*/
+func Kron(a, b [][]float64) [][]float64 {
+	if len(a) == 0 || len(a[0]) == 0 || len(b) == 0 || len(b[0]) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64s cannot be empty.\n"
+		s = fmt.Sprintf(s, "Kron()")
+		debug.PrintStack()
+		panic(s)
+	}
+	r, c, p, q := len(a), len(a[0]), len(b), len(b[0])
+	cols := c * q
+	data := make([]float64, r*p*cols)
+	res := make([][]float64, r*p)
+	for i := range a {
+		for k := range b {
+			lo, hi := (i*p+k)*cols, (i*p+k+1)*cols
+			row := data[lo:hi:hi]
+			for j, x := range a[i] {
+				block := row[j*q : (j+1)*q]
+				for l, y := range b[k] {
+					block[l] = x * y
+				}
+			}
+			res[i*p+k] = row
+		}
+	}
+	return res
+}
@@ -0,0 +1,36 @@
+package mat
+
+import (
+	"testing"
+)
+
+func TestKron(t *testing.T) {
+	a := [][]float64{{1.0, 2.0}}
+	b := [][]float64{{1.0}, {10.0}}
+	if k := Kron(a, b); !Equal(k, [][]float64{{1.0, 2.0}, {10.0, 20.0}}) {
+		t.Errorf("expected {{1.0, 2.0}, {10.0, 20.0}}, got %v", k)
+	}
+	a = Rand(3, 2)
+	b = Rand(4, 5)
+	k := Kron(a, b)
+	if len(k) != 12 || len(k[0]) != 10 {
+		t.Fatalf("expected a 12 by 10 product, got %d by %d", len(k), len(k[0]))
+	}
+	for i := range k {
+		for j := range k[i] {
+			e := a[i/4][j/5] * b[i%4][j%5]
+			if k[i][j] != e {
+				t.Errorf("at [%d][%d], expected %v, got %v", i, j, e, k[i][j])
+			}
+		}
+	}
+	// The product of identities is an identity.
+	if !Equal(Kron(I(2), I(3)), I(6)) {
+		t.Errorf("expected the 6 by 6 identity")
+	}
+	// Appending to a row must not overwrite the next one.
+	k[0] = append(k[0], 1.0)
+	if k[1][0] != a[0][0]*b[1][0] {
+		t.Errorf("the rows of the product share their capacity")
+	}
+}
@@ -0,0 +1,159 @@
+package mat
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/NDari/gocrunch/backend"
+	"github.com/NDari/gocrunch/errs"
+)
+
+/*
+DenseLU is the LU factorization with partial pivoting of a square
+[][]float64, which solves systems of the [][]float64 for any number of right
+hand sides, at about n*n operations per right hand side once factored.
+*/
+type DenseLU struct {
+	// a holds the upper factor on and above its diagonal, and the
+	// multipliers of the unit lower factor below it. Row k was
+	// interchanged with row piv[k] before the elimination of column k.
+	a   [][]float64
+	piv []int
+}
+
+/*
+LU returns the LU factorization of a square [][]float64, with partial
+pivoting, which takes about 2*n*n*n/3 operations, and is computed by the
+current Backend of the backend package. For example, to solve the
+systems of the same m for right hand sides known one at a time:
+
+	lu, err := mat.LU(m)
+	for _, b := range rhs {
+		x := lu.Solve(b)
+	}
+
+LU returns an error wrapping ErrSingular if m is singular. The passed
+[][]float64 must be square and not empty, otherwise this function will
+panic. It is not mutated in this function.
+*/
+func LU(m [][]float64) (*DenseLU, error) {
+	checkSquare("LU()", m)
+	return factorLU(m)
+}
+
+func factorLU(m [][]float64) (*DenseLU, error) {
+	f := &DenseLU{a: Clone(m), piv: make([]int, len(m))}
+	if k := backend.Getrf(f.a, f.piv); k >= 0 {
+		return nil, &errs.SingularError{Index: k, Err: fmt.Errorf("%w: no pivot in column %d", ErrSingular, k)}
+	}
+	return f, nil
+}
+
+/*
+Solve returns the x for which m * x = b, where m is the factored
+[][]float64. The length of b must be the size of m, otherwise this function
+will panic. b is not mutated in this function.
+*/
+func (f *DenseLU) Solve(b []float64) []float64 {
+	checkLen("DenseLU.Solve()", b, len(f.a))
+	x := make([]float64, len(b))
+	copy(x, b)
+	f.solve(x)
+	return x
+}
+
+/*
+SolveMat returns the x for which m * x = b, where m is the factored
+[][]float64, and each column of b is a right hand side. b must have as many
+rows as m, and must not be empty or jagged, otherwise this function will
+panic. b is not mutated in this function.
+*/
+func (f *DenseLU) SolveMat(b [][]float64) [][]float64 {
+	checkRHS("DenseLU.SolveMat()", b, len(f.a))
+	x, _ := solveCols(b, func(x []float64) error {
+		f.solve(x)
+		return nil
+	})
+	return x
+}
+
+// solve overwrites x with the solution of m * x = x.
+func (f *DenseLU) solve(x []float64) {
+	a := f.a
+	for k, p := range f.piv {
+		x[k], x[p] = x[p], x[k]
+	}
+	for i := range a {
+		for j := 0; j < i; j++ {
+			x[i] -= a[i][j] * x[j]
+		}
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := i + 1; j < len(a); j++ {
+			x[i] -= a[i][j] * x[j]
+		}
+		x[i] /= a[i][i]
+	}
+}
+
+/*
+Solve returns the x for which a * x = b, where each column of b is a right
+hand side, and the same column of x its solution. For example:
+
+	a := [][]float64{{2.0, 1.0}, {1.0, 3.0}}
+	b := [][]float64{{3.0, 1.0}, {4.0, 2.0}}
+	x, err := mat.Solve(a, b) // x is {{1.0, 0.2}, {1.0, 0.6}}
+
+a is factored once, and the factorization is reused for all the columns of
+b, which takes about 2*n*n*n/3 + 2*n*n*k operations for k right hand sides,
+rather than k factorizations. To solve for right hand sides which are not
+all known at once, factor a with mat.LU() instead.
+
+Solve returns an error wrapping ErrSingular if a is singular. a must be
+square and not empty, and b must have as many rows as a and must not be
+empty or jagged, otherwise this function will panic. The passed
+[][]float64s are not mutated in this function.
+*/
+func Solve(a, b [][]float64) ([][]float64, error) {
+	checkSquare("Solve()", a)
+	checkRHS("Solve()", b, len(a))
+	f, err := factorLU(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.SolveMat(b), nil
+}
+
+func checkRHS(fn string, b [][]float64, n int) {
+	if len(b) == 0 || len(b[0]) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the right hand sides cannot be empty.\n"
+		s = fmt.Sprintf(s, fn)
+		debug.PrintStack()
+		panic(s)
+	}
+	if _, err := shape(b); err != nil || len(b) != n {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the right hand sides must be a [][]float64 of %d rows,\n"
+		s += "but have %d rows of %d to %d elements.\n"
+		lo, hi := len(b[0]), len(b[0])
+		for i := range b {
+			lo, hi = min(lo, len(b[i])), max(hi, len(b[i]))
+		}
+		s = fmt.Sprintf(s, fn, n, len(b), lo, hi)
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+// solveCols returns the [][]float64 whose columns are those of b,
+// overwritten by solve, stopping at the first error of solve.
+func solveCols(b [][]float64, solve func(x []float64) error) ([][]float64, error) {
+	x := T(b)
+	for _, c := range x {
+		if err := solve(c); err != nil {
+			return nil, err
+		}
+	}
+	return T(x), nil
+}
@@ -0,0 +1,103 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+func TestLU(t *testing.T) {
+	a := [][]float64{{0.0, 2.0, 1.0}, {1.0, -1.0, 3.0}, {4.0, 1.0, -2.0}}
+	lu, err := LU(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b := []float64{1.0, 2.0, 3.0}
+	x := lu.Solve(b)
+	if y := MulVec(a, x); !nearMat([][]float64{y}, [][]float64{b}, 1e-12) {
+		t.Errorf("expected a * x = %v, got %v", b, y)
+	}
+	if _, err := LU([][]float64{{1.0, 2.0}, {2.0, 4.0}}); !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+	_, err = Solve([][]float64{{1.0, 2.0}, {2.0, 4.0}}, [][]float64{{1.0}, {1.0}})
+	var se *errs.SingularError
+	if !errors.Is(err, errs.ErrSingular) || !errors.As(err, &se) || se.Index != 1 {
+		t.Errorf("expected an *errs.SingularError in column 1, got %v", err)
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		f        func()
+		expected string
+	}{
+		{func() { LU([][]float64{{1.0, 2.0}}) }, fmt.Sprintf("In mat.%s the [][]float64 must be square, but row %d has %d elements,\nwhile there are %d rows.\n", "LU()", 0, 2, 1)},
+		{func() { lu.Solve(b[:2]) }, fmt.Sprintf("In mat.%s the []float64 has %d elements, while the matrix has %d rows.\n", "DenseLU.Solve()", 2, 3)},
+		{func() { lu.SolveMat([][]float64{{1.0}, {2.0}, {3.0, 4.0}}) }, fmt.Sprintf("In mat.%s the right hand sides must be a [][]float64 of %d rows,\nbut have %d rows of %d to %d elements.\n", "DenseLU.SolveMat()", 3, 3, 1, 2)},
+		{func() { lu.SolveMat([][]float64{}) }, fmt.Sprintf("In mat.%s the right hand sides cannot be empty.\n", "DenseLU.SolveMat()")},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestSolve(t *testing.T) {
+	x, err := Solve([][]float64{{2.0, 1.0}, {1.0, 3.0}}, [][]float64{{3.0, 1.0}, {4.0, 2.0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !nearMat(x, [][]float64{{1.0, 0.2}, {1.0, 0.6}}, 1e-15) {
+		t.Errorf("expected {{1.0, 0.2}, {1.0, 0.6}}, got %v", x)
+	}
+	a := [][]float64{{4.0, -2.0, 1.0}, {3.0, 6.0, -4.0}, {2.0, 1.0, 8.0}}
+	if inv, err := Solve(a, I(3)); err != nil || !nearMat(Dot(a, inv), I(3), 1e-15) {
+		t.Errorf("expected the inverse of a, got %v and %v", inv, err)
+	}
+	if _, err := Solve([][]float64{{0.0, 0.0}, {0.0, 1.0}}, I(2)); !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+}
+
+func TestSolveMatStructured(t *testing.T) {
+	a := [][]float64{{4.0, 1.0, 2.0}, {1.0, 5.0, 3.0}, {2.0, 3.0, 6.0}}
+	b := [][]float64{{1.0, 0.0}, {-2.0, 1.0}, {3.0, 2.0}}
+	check := func(name string, m, x [][]float64, err error) {
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			return
+		}
+		p := Dot(m, x)
+		for i := range b {
+			for j := range b[i] {
+				if math.Abs(p[i][j]-b[i][j]) > 1e-12 {
+					t.Errorf("%s: expected m * x = %v, got %v", name, b, p)
+					return
+				}
+			}
+		}
+	}
+	s := NewSymmetric(a, HalfLower)
+	x, err := s.SolveMat(b)
+	check("Symmetric", a, x, err)
+	tr := NewTriangular(a, HalfUpper)
+	x, err = tr.SolveMat(b)
+	check("Triangular", tr.Dense(), x, err)
+	band := NewTridiagonal([]float64{1.0, 1.0}, []float64{4.0, 5.0, 6.0}, []float64{2.0, 3.0})
+	x, err = band.SolveMat(b)
+	check("Band", band.Dense(), x, err)
+	if _, err := NewTriangular(New(3), HalfLower).SolveMat(b); !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package mat
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+/*
+Mask returns a [][]bool of the shape of m, which is true where the passed
+function returns true for the element of m. It builds the masks of
+mat.MaskedSelect() and mat.MaskedSet() from a condition. For example:
+
+	negative := func(x float64) bool {
+		return x < 0.0
+	}
+	m := [][]float64{{1.0, -2.0}, {-3.0, 4.0}}
+	mask := mat.Mask(m, negative) // mask is {{false, true}, {true, false}}
+
+The passed [][]float64 is not mutated in this function.
+*/
+func Mask(m [][]float64, f func(float64) bool) [][]bool {
+	mask := make([][]bool, len(m))
+	for i := range m {
+		mask[i] = make([]bool, len(m[i]))
+		for j := range m[i] {
+			mask[i][j] = f(m[i][j])
+		}
+	}
+	return mask
+}
+
+/*
+MaskedSelect returns a new []float64 containing the elements of the passed
+[][]float64 for which the corresponding entry of mask is true, row after
+row, as vec.MaskedSelect() does for a []float64. For example:
+
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}}
+	mask := [][]bool{{true, false}, {false, true}}
+	v := mat.MaskedSelect(m, mask) // v is {1.0, 4.0}
+
+The mask must have the shape of m, otherwise this function will panic. The
+passed [][]float64 is not mutated in this function.
+*/
+func MaskedSelect(m [][]float64, mask [][]bool) []float64 {
+	checkMask("MaskedSelect()", m, mask)
+	v := []float64{}
+	for i := range m {
+		for j := range m[i] {
+			if mask[i][j] {
+				v = append(v, m[i][j])
+			}
+		}
+	}
+	return v
+}
+
+/*
+MaskedSet sets the elements of the passed [][]float64 for which the
+corresponding entry of mask is true to val, and returns the [][]float64. For
+example, to clip the negative elements of m to 0.0:
+
+	mat.MaskedSet(m, mat.Mask(m, negative), 0.0)
+
+The mask must have the shape of m, otherwise this function will panic.
+*/
+func MaskedSet(m [][]float64, mask [][]bool, val float64) [][]float64 {
+	checkMask("MaskedSet()", m, mask)
+	for i := range m {
+		for j := range m[i] {
+			if mask[i][j] {
+				m[i][j] = val
+			}
+		}
+	}
+	return m
+}
+
+func checkMask(fn string, m [][]float64, mask [][]bool) {
+	if len(mask) != len(m) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the mask has %d rows, while the [][]float64 has %d rows.\n"
+		s = fmt.Sprintf(s, fn, len(mask), len(m))
+		debug.PrintStack()
+		panic(s)
+	}
+	for i := range m {
+		if len(mask[i]) != len(m[i]) {
+			fmt.Println("\ngocrunch/mat error.")
+			s := "In mat.%s row %d of the mask has %d elements, while the row of the [][]float64 has %d.\n"
+			s = fmt.Sprintf(s, fn, i, len(mask[i]), len(m[i]))
+			debug.PrintStack()
+			panic(s)
+		}
+	}
+}
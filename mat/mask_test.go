@@ -0,0 +1,62 @@
+package mat
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestMask(t *testing.T) {
+	negative := func(x float64) bool {
+		return x < 0.0
+	}
+	m := [][]float64{{1.0, -2.0}, {-3.0, 4.0}}
+	mask := Mask(m, negative)
+	if len(mask) != 2 || mask[0][0] || !mask[0][1] || !mask[1][0] || mask[1][1] {
+		t.Errorf("expected {{false, true}, {true, false}}, got %v", mask)
+	}
+	if v := MaskedSelect(m, mask); len(v) != 2 || v[0] != -2.0 || v[1] != -3.0 {
+		t.Errorf("expected {-2.0, -3.0}, got %v", v)
+	}
+	if v := MaskedSelect(m, [][]bool{{false, false}, {false, false}}); len(v) != 0 {
+		t.Errorf("expected an empty []float64, got %v", v)
+	}
+	if res := MaskedSet(m, mask, 0.0); &res[0][0] != &m[0][0] {
+		t.Errorf("expected MaskedSet() to return m")
+	}
+	if !Equal(m, [][]float64{{1.0, 0.0}, {0.0, 4.0}}) {
+		t.Errorf("expected {{1.0, 0.0}, {0.0, 4.0}}, got %v", m)
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { MaskedSelect(m, [][]bool{{true, true}}) },
+			fmt.Sprintf("In mat.%s the mask has %d rows, while the [][]float64 has %d rows.\n", "MaskedSelect()", 1, 2),
+		},
+		{
+			func() { MaskedSet(m, [][]bool{{true, true}, {true}}, 1.0) },
+			fmt.Sprintf("In mat.%s row %d of the mask has %d elements, while the row of the [][]float64 has %d.\n",
+				"MaskedSet()", 1, 1, 2),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+	if !Equal(m, [][]float64{{1.0, 0.0}, {0.0, 4.0}}) {
+		t.Errorf("expected m to be left intact by a mismatched mask, got %v", m)
+	}
+}
@@ -34,6 +34,8 @@ import (
 	"os"
 	"runtime/debug"
 	"strconv"
+
+	"github.com/NDari/gocrunch/backend"
 )
 
 /*
@@ -774,15 +776,12 @@ is defined in the usual manner, where every value at row x, and column y is
 placed at row y, and column x. The number of rows and column of the transpose
 of a slice are equal to the number of columns and rows of the original slice,
 respectively. This method creates a new [][]float64, and the original is
-left intact. The passed [][]float64 is assumed to be non-jagged.
+left intact. The passed [][]float64 is assumed to be non-jagged. The
+transpose is copied in blocks, as with TransposeTo().
 */
 func T(m [][]float64) [][]float64 {
 	n := New(len(m[0]), len(m))
-	for i := range m {
-		for j := range m[i] {
-			n[j][i] = m[i][j]
-		}
-	}
+	transposeBlocks(n, m)
 	return n
 }
 
@@ -1120,6 +1119,8 @@ non-jagged, meaning that each row has the same number of entries as any
 other row in both [][]float64, and each column has the same number of entries
 as any other column in both [][]float64s passed to this function.
 
+The product is computed by the current engine of the backend package.
+
 The original [][]float64s is not mutated in this function.
 */
 func Dot(m, n [][]float64) [][]float64 {
@@ -1132,13 +1133,7 @@ func Dot(m, n [][]float64) [][]float64 {
 		panic(s)
 	}
 	res := New(len(m), len(n[0]))
-	for i := range m {
-		for j := range n[0] {
-			for k := range m[i] {
-				res[i][j] += m[i][k] * n[k][j]
-			}
-		}
-	}
+	backend.Gemm(false, false, 1.0, m, n, 0.0, res)
 	return res
 }
 
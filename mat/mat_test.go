@@ -2,6 +2,7 @@ package mat
 
 import (
 	"log"
+	"math"
 	"os"
 	"testing"
 )
@@ -723,6 +724,11 @@ func TestDot(t *testing.T) {
 	if !Equal(o, m) {
 		t.Errorf("expected equal, got not equal")
 	}
+	// 0*Inf is NaN, even though the other product is finite.
+	o = Dot([][]float64{{0.0, 1.0}}, [][]float64{{math.Inf(1)}, {2.0}})
+	if !math.IsNaN(o[0][0]) {
+		t.Errorf("expected NaN, got %v", o[0][0])
+	}
 }
 
 func BenchmarkDot(b *testing.B) {
@@ -0,0 +1,204 @@
+package mat
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+
+	"github.com/NDari/gocrunch/backend"
+	"github.com/NDari/gocrunch/parallel"
+)
+
+const (
+	// dotTile is the number of rows and columns of the tiles of the product
+	// in DotParallel(), and of the blocks of the inner dimension, chosen so
+	// that the three blocks of a tile fit in the cache of a core.
+	dotTile = 128
+	// strassenMin is the default threshold of DotStrassen(), below which
+	// the seven products of Strassen's method cost more than they save.
+	strassenMin = 512
+)
+
+/*
+DotParallel returns the product m * n, as with Dot(), splitting the product
+into square tiles which are spread over up to GOMAXPROCS goroutines once the
+product is large enough, as decided by parallel.Threshold(). Each tile is
+accumulated over blocks of the inner dimension, so that the blocks it reads
+stay in the cache, and each element of the product is computed by a single
+goroutine in a fixed order, so that the result is the same from one call to
+the next, whatever the number of goroutines. For example:
+
+	p := mat.DotParallel(m, n) // the same as mat.Dot(m, n)
+
+The number of columns of m must be the number of rows of n, and neither can
+be empty, otherwise this function will panic. The passed arguments are not
+mutated in this function.
+*/
+func DotParallel(m, n [][]float64) [][]float64 {
+	checkDot("DotParallel()", m, n)
+	res := New(len(m), len(n[0]))
+	tiledGemm(res, m, n)
+	return res
+}
+
+/*
+DotStrassen returns the product m * n, computed with Strassen's method, which
+replaces the eight products of the quadrants of m and n by seven, and
+recurses on the quadrants. Each level of recursion saves an eighth of the
+multiplications, at the cost of additions and temporary memory, which pays
+off for very large matrices. The recursion stops once a dimension of the
+quadrants is less than the threshold, and the remaining products are
+computed by DotParallel(). A threshold of 0 uses the default of 512.
+Dimensions that are odd at a level of recursion are padded with zeros. For
+example:
+
+	p := mat.DotStrassen(m, n, 0)
+
+The rounding errors of Strassen's method are larger than those of Dot(), and
+the elements of the result are only as accurate as the largest products of
+the elements of m and n, rather than elementwise. The number of columns of m
+must be the number of rows of n, neither can be empty, and the threshold
+must be 0 or at least 2, otherwise this function will panic. The passed
+arguments are not mutated in this function.
+*/
+func DotStrassen(m, n [][]float64, threshold int) [][]float64 {
+	checkDot("DotStrassen()", m, n)
+	if threshold < 0 || threshold == 1 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the threshold must be 0, or at least 2, but it is %d.\n"
+		s = fmt.Sprintf(s, "DotStrassen()", threshold)
+		debug.PrintStack()
+		panic(s)
+	}
+	if threshold == 0 {
+		threshold = strassenMin
+	}
+	res := New(len(m), len(n[0]))
+	strassen(res, m, n, threshold)
+	return res
+}
+
+func checkDot(fn string, m, n [][]float64) {
+	if len(m) == 0 || len(m[0]) == 0 || len(n) == 0 || len(n[0]) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64s cannot be empty.\n"
+		s = fmt.Sprintf(s, fn)
+		debug.PrintStack()
+		panic(s)
+	}
+	if len(m[0]) != len(n) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the number of elements in the first row of the first argument is %d,\n"
+		s += "while the len of the second argument is %d. They must match.\n"
+		s = fmt.Sprintf(s, fn, len(m[0]), len(n))
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+// tiledGemm stores a * b in c, one tile of c at a time, with the tiles
+// handed out to the goroutines in turn.
+func tiledGemm(c, a, b [][]float64) {
+	r, k, n := len(a), len(b), len(b[0])
+	tr, tc := (r+dotTile-1)/dotTile, (n+dotTile-1)/dotTile
+	tile := func(t int) {
+		r0, c0 := (t/tc)*dotTile, (t%tc)*dotTile
+		r1, c1 := min(r0+dotTile, r), min(c0+dotTile, n)
+		ct := Slice(c, r0, r1, c0, c1)
+		for k0 := 0; k0 < k; k0 += dotTile {
+			k1 := min(k0+dotTile, k)
+			beta := 1.0
+			if k0 == 0 {
+				beta = 0.0
+			}
+			backend.Gemm(false, false, 1.0, Slice(a, r0, r1, k0, k1), Slice(b, k0, k1, c0, c1), beta, ct)
+		}
+	}
+	workers := min(runtime.GOMAXPROCS(0), tr*tc)
+	if workers < 2 || r*k*n < parallel.Threshold() {
+		for t := 0; t < tr*tc; t++ {
+			tile(t)
+		}
+		return
+	}
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for t := int(next.Add(1)) - 1; t < tr*tc; t = int(next.Add(1)) - 1 {
+				tile(t)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// strassen stores a * b in c, recursing on the quadrants until one of the
+// dimensions is less than the threshold.
+func strassen(c, a, b [][]float64, threshold int) {
+	r, k, n := len(a), len(b), len(b[0])
+	if min(r, k, n) < threshold {
+		tiledGemm(c, a, b)
+		return
+	}
+	if r%2 != 0 || k%2 != 0 || n%2 != 0 {
+		// Zeros added to the odd dimensions do not change the product.
+		pa, pb := padded(a, r+r%2, k+k%2), padded(b, k+k%2, n+n%2)
+		pc := New(r+r%2, n+n%2)
+		strassen(pc, pa, pb, threshold)
+		for i := range c {
+			copy(c[i], pc[i])
+		}
+		return
+	}
+	hr, hk, hn := r/2, k/2, n/2
+	a11, a12 := Slice(a, 0, hr, 0, hk), Slice(a, 0, hr, hk, k)
+	a21, a22 := Slice(a, hr, r, 0, hk), Slice(a, hr, r, hk, k)
+	b11, b12 := Slice(b, 0, hk, 0, hn), Slice(b, 0, hk, hn, n)
+	b21, b22 := Slice(b, hk, k, 0, hn), Slice(b, hk, k, hn, n)
+	sa, sb := New(hr, hk), New(hk, hn)
+	p := make([][][]float64, 7)
+	for i := range p {
+		p[i] = New(hr, hn)
+	}
+	strassen(p[0], combine(sa, a11, a22, 1.0), combine(sb, b11, b22, 1.0), threshold)
+	strassen(p[1], combine(sa, a21, a22, 1.0), b11, threshold)
+	strassen(p[2], a11, combine(sb, b12, b22, -1.0), threshold)
+	strassen(p[3], a22, combine(sb, b21, b11, -1.0), threshold)
+	strassen(p[4], combine(sa, a11, a12, 1.0), b22, threshold)
+	strassen(p[5], combine(sa, a21, a11, -1.0), combine(sb, b11, b12, 1.0), threshold)
+	strassen(p[6], combine(sa, a12, a22, -1.0), combine(sb, b21, b22, 1.0), threshold)
+	for i := 0; i < hr; i++ {
+		c11, c12 := c[i][:hn], c[i][hn:]
+		c21, c22 := c[hr+i][:hn], c[hr+i][hn:]
+		for j := 0; j < hn; j++ {
+			c11[j] = p[0][i][j] + p[3][i][j] - p[4][i][j] + p[6][i][j]
+			c12[j] = p[2][i][j] + p[4][i][j]
+			c21[j] = p[1][i][j] + p[3][i][j]
+			c22[j] = p[0][i][j] - p[1][i][j] + p[2][i][j] + p[5][i][j]
+		}
+	}
+}
+
+// combine stores x + sign*y in dst, and returns dst.
+func combine(dst, x, y [][]float64, sign float64) [][]float64 {
+	for i := range dst {
+		for j := range dst[i] {
+			dst[i][j] = x[i][j] + sign*y[i][j]
+		}
+	}
+	return dst
+}
+
+// padded returns a copy of m with r rows and c columns, padded with zeros.
+func padded(m [][]float64, r, c int) [][]float64 {
+	p := New(r, c)
+	for i := range m {
+		copy(p[i], m[i])
+	}
+	return p
+}
@@ -0,0 +1,89 @@
+package mat
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/parallel"
+)
+
+func TestDotParallel(t *testing.T) {
+	// With a low threshold, the tiles are spread over the goroutines when
+	// GOMAXPROCS is above 1, and the ragged tiles at the edges are covered.
+	parallel.SetThreshold(1)
+	defer parallel.SetThreshold(0)
+	m, n := Rand(300, 200), Rand(200, 260)
+	mc, nc := Clone(m), Clone(n)
+	p := DotParallel(m, n)
+	if !nearMat(p, Dot(m, n), 1e-12) {
+		t.Errorf("expected the product of Dot()")
+	}
+	if !Equal(m, mc) || !Equal(n, nc) {
+		t.Errorf("the original [][]float64s were modified")
+	}
+	if q := DotParallel(m, n); !Equal(p, q) {
+		t.Errorf("expected the same product on each call")
+	}
+	small := DotParallel([][]float64{{1.0, 2.0}}, [][]float64{{3.0}, {4.0}})
+	if !Equal(small, [][]float64{{11.0}}) {
+		t.Errorf("expected {{11.0}}, got %v", small)
+	}
+}
+
+func TestDotStrassen(t *testing.T) {
+	tests := []struct {
+		r, k, c, threshold int
+	}{
+		{4, 4, 4, 2},
+		{7, 5, 9, 2},
+		{70, 50, 90, 16},
+		{33, 64, 17, 8},
+		{10, 10, 10, 0},
+	}
+	for _, test := range tests {
+		m, n := Rand(test.r, test.k), Rand(test.k, test.c)
+		mc, nc := Clone(m), Clone(n)
+		p := DotStrassen(m, n, test.threshold)
+		if !nearMat(p, Dot(m, n), 1e-11) {
+			t.Errorf("%d by %d times %d by %d with threshold %d: expected the product of Dot()",
+				test.r, test.k, test.k, test.c, test.threshold)
+		}
+		if !Equal(m, mc) || !Equal(n, nc) {
+			t.Errorf("the original [][]float64s were modified")
+		}
+	}
+	panics := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { DotStrassen(New(2, 2), New(2, 2), 1) },
+			fmt.Sprintf("In mat.%s the threshold must be 0, or at least 2, but it is %d.\n", "DotStrassen()", 1),
+		},
+		{
+			func() { DotStrassen(New(2, 3), New(2, 2), 0) },
+			fmt.Sprintf("In mat.%s the number of elements in the first row of the first argument is %d,\n"+
+				"while the len of the second argument is %d. They must match.\n", "DotStrassen()", 3, 2),
+		},
+		{
+			func() { DotParallel(nil, New(2, 2)) },
+			fmt.Sprintf("In mat.%s the [][]float64s cannot be empty.\n", "DotParallel()"),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range panics {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
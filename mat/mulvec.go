@@ -0,0 +1,155 @@
+package mat
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+
+	"github.com/NDari/gocrunch/backend"
+	"github.com/NDari/gocrunch/parallel"
+)
+
+/*
+MulVec returns the product of a [][]float64 and a []float64, m * v, where v is
+taken as a column vector. Each element of the result is the dot product of a
+row of m with v. For example:
+
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}
+	v := []float64{1.0, -1.0}
+	p := mat.MulVec(m, v) // p is {-1.0, -1.0, -1.0}
+
+The product is computed by the current engine of the backend package, in
+blocks of rows spread over up to GOMAXPROCS goroutines for large m.
+
+The length of v must be the number of columns of m, otherwise this function
+will panic. The passed arguments are not mutated in this function.
+*/
+func MulVec(m [][]float64, v []float64) []float64 {
+	checkMulVec("MulVec()", m, v, false)
+	return mulVec(make([]float64, len(m)), m, v, false)
+}
+
+/*
+MulVecTo stores the result of mat.MulVec(m, v) in dst, and returns dst,
+without allocating. dst must have a length equal to the number of rows of m,
+and must not share its elements with v, otherwise this function will panic
+or return wrong results.
+*/
+func MulVecTo(dst []float64, m [][]float64, v []float64) []float64 {
+	checkMulVec("MulVecTo()", m, v, false)
+	checkMulVecDst("MulVecTo()", dst, len(m))
+	return mulVec(dst, m, v, false)
+}
+
+/*
+MulVecT returns the product of the transpose of a [][]float64 and a []float64,
+T(m) * v, without forming the transpose. Each element of the result is the
+dot product of a column of m with v. For example:
+
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}
+	v := []float64{1.0, 0.0, -1.0}
+	p := mat.MulVecT(m, v) // p is {-4.0, -4.0}
+
+The rows of m are split into blocks as with mat.MulVec(), and the partial
+products of the blocks are summed in order, so that the result is the same
+from one call to the next.
+
+The length of v must be the number of rows of m, otherwise this function
+will panic. The passed arguments are not mutated in this function.
+*/
+func MulVecT(m [][]float64, v []float64) []float64 {
+	checkMulVec("MulVecT()", m, v, true)
+	return mulVec(make([]float64, len(m[0])), m, v, true)
+}
+
+/*
+MulVecTTo stores the result of mat.MulVecT(m, v) in dst, and returns dst. dst
+must have a length equal to the number of columns of m, and must not share
+its elements with v, otherwise this function will panic or return wrong
+results.
+*/
+func MulVecTTo(dst []float64, m [][]float64, v []float64) []float64 {
+	checkMulVec("MulVecTTo()", m, v, true)
+	checkMulVecDst("MulVecTTo()", dst, len(m[0]))
+	return mulVec(dst, m, v, true)
+}
+
+func checkMulVec(fn string, m [][]float64, v []float64, trans bool) {
+	if len(m) == 0 || len(m[0]) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 cannot be empty.\n"
+		s = fmt.Sprintf(s, fn)
+		debug.PrintStack()
+		panic(s)
+	}
+	want, dim := len(m[0]), "columns"
+	if trans {
+		want, dim = len(m), "rows"
+	}
+	if len(v) != want {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the []float64 has %d elements, while the [][]float64 has %d %s.\n"
+		s += "They must match.\n"
+		s = fmt.Sprintf(s, fn, len(v), want, dim)
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+func checkMulVecDst(fn string, dst []float64, n int) {
+	if len(dst) != n {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the destination has %d elements, while the product has %d.\n"
+		s = fmt.Sprintf(s, fn, len(dst), n)
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+// mulVec stores op(m) * v in dst, splitting the rows of m into blocks which
+// are multiplied on separate goroutines when the product is large enough.
+func mulVec(dst []float64, m [][]float64, v []float64, trans bool) []float64 {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(m) {
+		workers = len(m)
+	}
+	if workers < 2 || len(m)*len(m[0]) < parallel.Threshold() {
+		backend.Gemv(trans, 1.0, m, v, 0.0, dst)
+		return dst
+	}
+	size := (len(m) + workers - 1) / workers
+	var partial [][]float64
+	if trans {
+		partial = New(workers, len(dst))
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo, hi := w*size, (w+1)*size
+		if hi > len(m) {
+			hi = len(m)
+		}
+		if lo >= hi {
+			break
+		}
+		wg.Add(1)
+		go func(w, lo, hi int) {
+			defer wg.Done()
+			if trans {
+				backend.Gemv(true, 1.0, m[lo:hi], v[lo:hi], 0.0, partial[w])
+			} else {
+				backend.Gemv(false, 1.0, m[lo:hi], v, 0.0, dst[lo:hi])
+			}
+		}(w, lo, hi)
+	}
+	wg.Wait()
+	if trans {
+		copy(dst, partial[0])
+		for _, p := range partial[1:] {
+			for j := range dst {
+				dst[j] += p[j]
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,77 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/parallel"
+)
+
+func TestMulVec(t *testing.T) {
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}
+	p := MulVec(m, []float64{1.0, -1.0})
+	if len(p) != 3 || p[0] != -1.0 || p[1] != -1.0 || p[2] != -1.0 {
+		t.Errorf("expected {-1.0, -1.0, -1.0}, got %v", p)
+	}
+	p = MulVecT(m, []float64{1.0, 0.0, -1.0})
+	if len(p) != 2 || p[0] != -4.0 || p[1] != -4.0 {
+		t.Errorf("expected {-4.0, -4.0}, got %v", p)
+	}
+	// With a low threshold, the products of 200 rows are split into blocks
+	// when GOMAXPROCS is above 1.
+	parallel.SetThreshold(1)
+	defer parallel.SetThreshold(0)
+	m = Rand(200, 30)
+	v, w := Rand(1, 30)[0], Rand(1, 200)[0]
+	dst := make([]float64, 200)
+	if &MulVecTo(dst, m, v)[0] != &dst[0] {
+		t.Errorf("expected MulVecTo() to return dst")
+	}
+	dstT := MulVecTTo(make([]float64, 30), m, w)
+	for i := range m {
+		e := 0.0
+		for j := range v {
+			e += m[i][j] * v[j]
+		}
+		if math.Abs(dst[i]-e) > 1e-12 {
+			t.Errorf("at index %d, expected %v, got %v", i, e, dst[i])
+		}
+	}
+	for j := range dstT {
+		e := 0.0
+		for i := range w {
+			e += m[i][j] * w[i]
+		}
+		if math.Abs(dstT[j]-e) > 1e-12 {
+			t.Errorf("at index %d of the transposed product, expected %v, got %v", j, e, dstT[j])
+		}
+	}
+}
+
+func TestMulVecPanics(t *testing.T) {
+	m := New(3, 2)
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		call     func()
+		expected string
+	}{
+		{func() { MulVec(m, make([]float64, 3)) }, fmt.Sprintf("In mat.%s the []float64 has %d elements, while the [][]float64 has %d %s.\nThey must match.\n", "MulVec()", 3, 2, "columns")},
+		{func() { MulVecT(m, make([]float64, 2)) }, fmt.Sprintf("In mat.%s the []float64 has %d elements, while the [][]float64 has %d %s.\nThey must match.\n", "MulVecT()", 2, 3, "rows")},
+		{func() { MulVecTo(make([]float64, 2), m, make([]float64, 2)) }, fmt.Sprintf("In mat.%s the destination has %d elements, while the product has %d.\n", "MulVecTo()", 2, 3)},
+		{func() { MulVecTTo(make([]float64, 3), m, make([]float64, 3)) }, fmt.Sprintf("In mat.%s the destination has %d elements, while the product has %d.\n", "MulVecTTo()", 3, 2)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.call()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,93 @@
+package mat
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+/*
+MustSolve is like mat.Solve(), but panics instead of returning an error,
+like most functions of this package. It suits scripts and tests, where a
+singular system is a bug to crash on rather than a failure to handle:
+
+	x := mat.MustSolve(a, b)
+
+Code which must survive bad input, such as a service, should call
+mat.Solve() instead.
+*/
+func MustSolve(a, b [][]float64) [][]float64 {
+	x, err := Solve(a, b)
+	must("MustSolve()", "Solve()", err)
+	return x
+}
+
+// MustLU is like mat.LU(), but panics instead of returning an error.
+func MustLU(m [][]float64) *DenseLU {
+	lu, err := LU(m)
+	must("MustLU()", "LU()", err)
+	return lu
+}
+
+// MustLogm is like mat.Logm(), but panics instead of returning an error.
+func MustLogm(m [][]float64) [][]float64 {
+	l, err := Logm(m)
+	must("MustLogm()", "Logm()", err)
+	return l
+}
+
+// MustHStack is like mat.HStack(), but panics instead of returning an error.
+func MustHStack(ms ...[][]float64) [][]float64 {
+	m, err := HStack(ms...)
+	must("MustHStack()", "HStack()", err)
+	return m
+}
+
+// MustVStack is like mat.VStack(), but panics instead of returning an error.
+func MustVStack(ms ...[][]float64) [][]float64 {
+	m, err := VStack(ms...)
+	must("MustVStack()", "VStack()", err)
+	return m
+}
+
+// MustBlock is like mat.Block(), but panics instead of returning an error.
+func MustBlock(blocks [][][][]float64) [][]float64 {
+	m, err := Block(blocks)
+	must("MustBlock()", "Block()", err)
+	return m
+}
+
+// MustGatherRows is like mat.GatherRows(), but panics instead of returning
+// an error.
+func MustGatherRows(m [][]float64, idx []int) [][]float64 {
+	rows, err := GatherRows(m, idx)
+	must("MustGatherRows()", "GatherRows()", err)
+	return rows
+}
+
+// MustGatherCols is like mat.GatherCols(), but panics instead of returning
+// an error.
+func MustGatherCols(m [][]float64, idx []int) [][]float64 {
+	cols, err := GatherCols(m, idx)
+	must("MustGatherCols()", "GatherCols()", err)
+	return cols
+}
+
+// MustToInts is like mat.ToInts(), but panics instead of returning an error.
+func MustToInts(m [][]float64) [][]int {
+	n, err := ToInts(m)
+	must("MustToInts()", "ToInts()", err)
+	return n
+}
+
+// must panics with a message naming the function fn, and the error err
+// returned by the function it wraps, if err is not nil.
+func must(fn, wrapped string, err error) {
+	if err == nil {
+		return
+	}
+	fmt.Println("\ngocrunch/mat error.")
+	s := "In mat.%s the call to mat.%s failed:\n%v\n"
+	s = fmt.Sprintf(s, fn, wrapped, err)
+	debug.PrintStack()
+	panic(s)
+}
@@ -0,0 +1,64 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestMust(t *testing.T) {
+	a := [][]float64{{2.0, 0.0}, {0.0, 4.0}}
+	if x := MustSolve(a, [][]float64{{2.0}, {2.0}}); !Equal(x, [][]float64{{1.0}, {0.5}}) {
+		t.Errorf("expected {{1.0}, {0.5}}, got %v", x)
+	}
+	if lu := MustLU(a); lu == nil {
+		t.Errorf("expected a factorization, got nil")
+	}
+	if m := MustVStack(a, [][]float64{{1.0, 1.0}}); len(m) != 3 {
+		t.Errorf("expected 3 rows, got %v", m)
+	}
+	if m := MustHStack(a, a); len(m[0]) != 4 {
+		t.Errorf("expected 4 columns, got %v", m)
+	}
+	if m := MustGatherCols(a, []int{1}); !Equal(m, [][]float64{{0.0}, {4.0}}) {
+		t.Errorf("expected {{0.0}, {4.0}}, got %v", m)
+	}
+	singular := [][]float64{{1.0, 2.0}, {2.0, 4.0}}
+	_, errSolve := Solve(singular, [][]float64{{1.0}, {1.0}})
+	_, errStack := VStack(a, [][]float64{{1.0}})
+	_, errRows := GatherRows(a, []int{2})
+	_, errInts := ToInts([][]float64{{1e300}})
+	for _, err := range []error{errSolve, errStack, errRows, errInts} {
+		if err == nil {
+			t.Fatalf("expected errors from the wrapped functions")
+		}
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { MustSolve(singular, [][]float64{{1.0}, {1.0}}) }, fmt.Sprintf("In mat.%s the call to mat.%s failed:\n%v\n", "MustSolve()", "Solve()", errSolve)},
+		{func() { MustLU(singular) }, fmt.Sprintf("In mat.%s the call to mat.%s failed:\n%v\n", "MustLU()", "LU()", errSolve)},
+		{func() { MustVStack(a, [][]float64{{1.0}}) }, fmt.Sprintf("In mat.%s the call to mat.%s failed:\n%v\n", "MustVStack()", "VStack()", errStack)},
+		{func() { MustGatherRows(a, []int{2}) }, fmt.Sprintf("In mat.%s the call to mat.%s failed:\n%v\n", "MustGatherRows()", "GatherRows()", errRows)},
+		{func() { MustToInts([][]float64{{1e300}}) }, fmt.Sprintf("In mat.%s the call to mat.%s failed:\n%v\n", "MustToInts()", "ToInts()", errInts)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+	if !errors.Is(errSolve, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", errSolve)
+	}
+}
@@ -0,0 +1,150 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime/debug"
+)
+
+// NormType selects the matrix norm computed by mat.Norm().
+type NormType int
+
+const (
+	// NormFrobenius is the square root of the sum of the squares of all
+	// the elements.
+	NormFrobenius NormType = iota
+	// NormOne is the largest sum of the absolute values of a column.
+	NormOne
+	// NormInf is the largest sum of the absolute values of a row.
+	NormInf
+	// NormSpectral is the largest singular value, the largest factor by
+	// which the matrix stretches a vector.
+	NormSpectral
+)
+
+const (
+	// spectralTol is the relative change of the estimate of the spectral
+	// norm between two iterations at which the power iteration stops.
+	spectralTol = 1e-12
+	// spectralIter is the maximum number of power iterations.
+	spectralIter = 1000
+)
+
+/*
+Norm returns the norm of a [][]float64 selected by the passed NormType. For
+example:
+
+	m := [][]float64{{1.0, -2.0}, {3.0, 4.0}}
+	mat.Norm(m, mat.NormOne) // 6.0, the sum of the second column
+	mat.Norm(m, mat.NormInf) // 7.0, the sum of the second row
+
+The spectral norm is estimated with the power iteration on T(m) * m, which
+only needs products of m with vectors, rather than a full SVD. The iteration
+stops once the estimate changes by less than 1e-12 relative to its value, and
+converges slowly when the two largest singular values are close, in which
+case it is stopped after 1000 iterations. Use mat.SVD() when the exact value
+is needed.
+
+The passed [][]float64 must not be empty, and the NormType must be one of the
+constants of this package, otherwise this function will panic. The passed
+[][]float64 is not mutated in this function.
+*/
+func Norm(m [][]float64, which NormType) float64 {
+	if len(m) == 0 || len(m[0]) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 cannot be empty.\n"
+		s = fmt.Sprintf(s, "Norm()")
+		debug.PrintStack()
+		panic(s)
+	}
+	switch which {
+	case NormFrobenius:
+		return frobenius(m)
+	case NormOne:
+		sums := make([]float64, len(m[0]))
+		for i := range m {
+			for j, x := range m[i] {
+				sums[j] += math.Abs(x)
+			}
+		}
+		return maxOf(sums)
+	case NormInf:
+		sums := make([]float64, len(m))
+		for i := range m {
+			for _, x := range m[i] {
+				sums[i] += math.Abs(x)
+			}
+		}
+		return maxOf(sums)
+	case NormSpectral:
+		return spectral(m)
+	default:
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s unknown norm %d.\n"
+		s = fmt.Sprintf(s, "Norm()", which)
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+// frobenius returns the Frobenius norm of m, scaling the elements by the
+// largest one to avoid overflow and underflow.
+func frobenius(m [][]float64) float64 {
+	scale := 0.0
+	for i := range m {
+		for _, x := range m[i] {
+			scale = math.Max(scale, math.Abs(x))
+		}
+	}
+	if scale == 0.0 || math.IsInf(scale, 1) {
+		return scale
+	}
+	sum := 0.0
+	for i := range m {
+		for _, x := range m[i] {
+			sum += (x / scale) * (x / scale)
+		}
+	}
+	return scale * math.Sqrt(sum)
+}
+
+// spectral estimates the largest singular value of m with the power
+// iteration on T(m) * m.
+func spectral(m [][]float64) float64 {
+	// A random start is almost surely not orthogonal to the leading right
+	// singular vector, unlike a fixed vector such as all ones. The seed is
+	// fixed, so that the estimate is reproducible.
+	r := rand.New(rand.NewSource(1))
+	x := make([]float64, len(m[0]))
+	for j := range x {
+		x[j] = r.NormFloat64()
+	}
+	y := make([]float64, len(m))
+	sigma := 0.0
+	for iter := 0; iter < spectralIter; iter++ {
+		n := frobenius([][]float64{x})
+		if n == 0.0 {
+			return 0.0
+		}
+		for j := range x {
+			x[j] /= n
+		}
+		mulVec(y, m, x, false)
+		next := frobenius([][]float64{y})
+		mulVec(x, m, y, true)
+		if math.Abs(next-sigma) <= spectralTol*next {
+			return next
+		}
+		sigma = next
+	}
+	return sigma
+}
+
+func maxOf(v []float64) float64 {
+	res := v[0]
+	for _, x := range v[1:] {
+		res = math.Max(res, x)
+	}
+	return res
+}
@@ -0,0 +1,56 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestNorm(t *testing.T) {
+	m := [][]float64{{1.0, -2.0}, {3.0, 4.0}}
+	tests := []struct {
+		which    NormType
+		expected float64
+	}{
+		{NormFrobenius, math.Sqrt(30.0)},
+		{NormOne, 6.0},
+		{NormInf, 7.0},
+		{NormSpectral, math.Sqrt(15.0 + math.Sqrt(125.0))},
+	}
+	for _, test := range tests {
+		if n := Norm(m, test.which); math.Abs(n-test.expected) > 1e-12*test.expected {
+			t.Errorf("norm %d: expected %v, got %v", test.which, test.expected, n)
+		}
+	}
+	// A matrix whose leading right singular vector is orthogonal to a
+	// vector of ones.
+	if n := Norm([][]float64{{1.0, -1.0}}, NormSpectral); math.Abs(n-math.Sqrt2) > 1e-12 {
+		t.Errorf("expected %v, got %v", math.Sqrt2, n)
+	}
+	r := Rand(30, 20)
+	_, s, _ := SVD(r)
+	if n := Norm(r, NormSpectral); math.Abs(n-s[0]) > 1e-8*s[0] {
+		t.Errorf("expected the largest singular value %v, got %v", s[0], n)
+	}
+	if n := Norm(New(3, 2), NormSpectral); n != 0.0 {
+		t.Errorf("expected 0.0 for a zero matrix, got %v", n)
+	}
+	if n := Norm([][]float64{{1e200, 1e200}}, NormFrobenius); math.Abs(n-math.Sqrt2*1e200) > 1e186 {
+		t.Errorf("expected %v, got %v", math.Sqrt2*1e200, n)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf("In mat.%s unknown norm %d.\n", "Norm()", 9)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Norm(m, NormType(9))
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,62 @@
+package mat
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+/*
+PInv returns the Moore-Penrose pseudo-inverse of a r by c [][]float64, a c
+by r [][]float64 computed from the SVD of m as
+
+	pinv(m) = v * diag(1/s) * T(u)
+
+where the singular values up to rcond times the largest singular value are
+taken as 0.0, and their reciprocals replaced by 0.0. This makes the
+pseudo-inverse of a rank deficient, or nearly rank deficient, [][]float64
+well defined, and
+
+	x := mat.MulVec(mat.PInv(a, 0.0), b)
+
+the least squares solution of a * x = b with the smallest norm, even when
+the columns of a are not independent. An rcond of 0.0 picks the default
+max(r, c) * eps, where eps is the spacing of float64s at 1.0, as with the
+default tolerance of mat.Rank(). Larger values of rcond discard more of the
+small singular values, trading accuracy for stability against noise in m.
+
+The passed [][]float64 must not be empty, and rcond must not be negative or
+NaN, otherwise this function will panic. The passed [][]float64 is not
+mutated in this function.
+*/
+func PInv(m [][]float64, rcond float64) [][]float64 {
+	if !(rcond >= 0.0) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the rcond cannot be negative or NaN, but received %v.\n"
+		s = fmt.Sprintf(s, "PInv()", rcond)
+		debug.PrintStack()
+		panic(s)
+	}
+	u, sv, v, _ := svd(context.Background(), "PInv()", m)
+	if rcond == 0.0 {
+		rcond = float64(max(len(m), len(m[0]))) * 0x1p-52
+	}
+	cut := rcond * sv[0]
+	res := New(len(m[0]), len(m))
+	for k, x := range sv {
+		if x <= cut {
+			// The singular values are decreasing.
+			break
+		}
+		for i := range res {
+			f := v[i][k] / x
+			if f == 0.0 {
+				continue
+			}
+			for j := range res[i] {
+				res[i][j] += f * u[j][k]
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,77 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestPInv(t *testing.T) {
+	near := func(a, b [][]float64) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			for j := range a[i] {
+				if math.Abs(a[i][j]-b[i][j]) > 1e-12 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	m := [][]float64{{4.0, 7.0}, {2.0, 6.0}}
+	if p := PInv(m, 0.0); !near(Dot(p, m), I(2)) {
+		t.Errorf("expected the inverse of an invertible matrix, got %v", p)
+	}
+	// A rank 1 matrix, whose pseudo-inverse satisfies the Penrose conditions.
+	r := [][]float64{{1.0, 2.0}, {2.0, 4.0}, {3.0, 6.0}}
+	p := PInv(r, 0.0)
+	if len(p) != 2 || len(p[0]) != 3 {
+		t.Fatalf("expected a 2 by 3 pseudo-inverse, got %v", p)
+	}
+	if !near(Dot(Dot(r, p), r), r) || !near(Dot(Dot(p, r), p), p) {
+		t.Errorf("expected r * p * r = r and p * r * p = p, got %v", p)
+	}
+	rp, pr := Dot(r, p), Dot(p, r)
+	if !near(rp, T(rp)) || !near(pr, T(pr)) {
+		t.Errorf("expected r * p and p * r to be symmetric, got %v", p)
+	}
+	// The least squares solution with the smallest norm is along {1.0, 2.0}.
+	x := MulVec(p, []float64{1.0, 2.0, 3.0})
+	if math.Abs(x[0]-0.2) > 1e-12 || math.Abs(x[1]-0.4) > 1e-12 {
+		t.Errorf("expected {0.2, 0.4}, got %v", x)
+	}
+	// A large rcond discards the small singular value of a nearly singular
+	// matrix.
+	n := [][]float64{{1.0, 0.0}, {0.0, 1e-10}}
+	if p := PInv(n, 1e-8); !near(p, [][]float64{{1.0, 0.0}, {0.0, 0.0}}) {
+		t.Errorf("expected {{1.0, 0.0}, {0.0, 0.0}}, got %v", p)
+	}
+	if p := PInv([][]float64{{0.0, 0.0}}, 0.0); !near(p, [][]float64{{0.0}, {0.0}}) {
+		t.Errorf("expected {{0.0}, {0.0}}, got %v", p)
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		m        [][]float64
+		rcond    float64
+		expected string
+	}{
+		{m, -1.0, fmt.Sprintf("In mat.%s the rcond cannot be negative or NaN, but received %v.\n", "PInv()", -1.0)},
+		{m, math.NaN(), fmt.Sprintf("In mat.%s the rcond cannot be negative or NaN, but received %v.\n", "PInv()", math.NaN())},
+		{[][]float64{}, 0.0, fmt.Sprintf("In mat.%s the [][]float64 cannot be empty.\n", "PInv()")},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			PInv(test.m, test.rcond)
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,116 @@
+package mat
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+/*
+FromVec returns a [][]float64 of the passed number of rows and columns whose
+rows are consecutive slices of v, so that no element is copied, and setting
+an element of the result sets the element of v, and the other way around.
+For example:
+
+	v := []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0}
+	m := mat.FromVec(v, 2, 3) // m is {{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}}
+	w := mat.Ravel(m)         // w is v itself
+
+Each row keeps the capacity of v up to its end, which lets mat.Ravel()
+recover v, so appending to a row overwrites the rows after it. Use
+mat.FromVecCopy() for rows which are independent of v.
+
+The rows and columns must be greater than 0, and their product must be the
+length of v, otherwise this function will panic.
+*/
+func FromVec(v []float64, rows, cols int) [][]float64 {
+	checkReshape("FromVec()", v, rows, cols)
+	m := make([][]float64, rows)
+	for i := range m {
+		m[i] = v[i*cols : (i+1)*cols]
+	}
+	return m
+}
+
+/*
+FromVecCopy is FromVec(), with the elements of v copied into a new backing
+[]float64, so that the result and v can be changed independently. The rows
+and columns must be greater than 0, and their product must be the length of
+v, otherwise this function will panic. v is not mutated in this function.
+*/
+func FromVecCopy(v []float64, rows, cols int) [][]float64 {
+	checkReshape("FromVecCopy()", v, rows, cols)
+	data := make([]float64, len(v))
+	copy(data, v)
+	return FromVec(data, rows, cols)
+}
+
+/*
+Ravel returns the elements of a [][]float64, row after row, as the single
+[]float64 which backs its rows, without copying them. This is only possible
+when the rows are consecutive slices of one []float64, in order, as with the
+[][]float64s of mat.FromVec(). Setting an element of the result sets the
+element of m, and the other way around.
+
+The passed [][]float64 must not be empty or jagged, and its rows must be
+consecutive slices of one []float64, otherwise this function will panic. Use
+mat.RavelCopy() for any other [][]float64.
+*/
+func Ravel(m [][]float64) []float64 {
+	r, c := checkRavel("Ravel()", m)
+	if cap(m[0]) >= r*c {
+		full := m[0][:r*c]
+		shared := true
+		for i := range m {
+			if &m[i][0] != &full[i*c] {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			return full
+		}
+	}
+	fmt.Println("\ngocrunch/mat error.")
+	s := "In mat.%s the rows of the [][]float64 are not consecutive slices of one []float64.\n"
+	s += "Use mat.RavelCopy() to copy them instead.\n"
+	s = fmt.Sprintf(s, "Ravel()")
+	debug.PrintStack()
+	panic(s)
+}
+
+/*
+RavelCopy returns a copy of the elements of a [][]float64, row after row, in
+a single []float64, as mat.Ravel() does without copying. The passed
+[][]float64 must not be empty or jagged, otherwise this function will panic.
+m is not mutated in this function.
+*/
+func RavelCopy(m [][]float64) []float64 {
+	r, c := checkRavel("RavelCopy()", m)
+	v := make([]float64, 0, r*c)
+	for i := range m {
+		v = append(v, m[i]...)
+	}
+	return v
+}
+
+func checkReshape(fn string, v []float64, rows, cols int) {
+	if rows <= 0 || cols <= 0 || rows*cols != len(v) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the []float64 has %d elements, which cannot be reshaped to %d by %d.\n"
+		s = fmt.Sprintf(s, fn, len(v), rows, cols)
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+func checkRavel(fn string, m [][]float64) (int, int) {
+	c, err := shape(m)
+	if err != nil || c == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 cannot be empty or jagged.\n"
+		s = fmt.Sprintf(s, fn)
+		debug.PrintStack()
+		panic(s)
+	}
+	return len(m), c
+}
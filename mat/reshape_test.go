@@ -0,0 +1,113 @@
+package mat
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestFromVec(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0}
+	m := FromVec(v, 2, 3)
+	if !Equal(m, [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}}) {
+		t.Errorf("expected {{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}}, got %v", m)
+	}
+	m[1][0] = 10.0
+	if v[3] != 10.0 {
+		t.Errorf("expected the [][]float64 to share the elements of v")
+	}
+	c := FromVecCopy(v, 3, 2)
+	if !Equal(c, [][]float64{{1.0, 2.0}, {3.0, 10.0}, {5.0, 6.0}}) {
+		t.Errorf("expected {{1.0, 2.0}, {3.0, 10.0}, {5.0, 6.0}}, got %v", c)
+	}
+	c[0][0] = -1.0
+	if v[0] != 1.0 {
+		t.Errorf("expected the copy not to share the elements of v")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { FromVec(v, 4, 2) },
+			fmt.Sprintf("In mat.%s the []float64 has %d elements, which cannot be reshaped to %d by %d.\n",
+				"FromVec()", 6, 4, 2),
+		},
+		{
+			func() { FromVecCopy(v, -2, -3) },
+			fmt.Sprintf("In mat.%s the []float64 has %d elements, which cannot be reshaped to %d by %d.\n",
+				"FromVecCopy()", 6, -2, -3),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestRavel(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0}
+	w := Ravel(FromVec(v, 3, 2))
+	if len(w) != 6 || &w[0] != &v[0] {
+		t.Errorf("expected Ravel() to return v itself")
+	}
+	if w = Ravel(FromVec(v, 1, 6)); len(w) != 6 || &w[0] != &v[0] {
+		t.Errorf("expected Ravel() to return v itself")
+	}
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}}
+	r := RavelCopy(m)
+	if !Equal([][]float64{r}, [][]float64{{1.0, 2.0, 3.0, 4.0}}) {
+		t.Errorf("expected {1.0, 2.0, 3.0, 4.0}, got %v", r)
+	}
+	r[0] = 5.0
+	if m[0][0] != 1.0 {
+		t.Errorf("the original [][]float64 was modified")
+	}
+	// Swapping two rows keeps them in one []float64, but out of order.
+	swapped := FromVec(v, 3, 2)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	notShared := fmt.Sprintf("In mat.%s the rows of the [][]float64 are not consecutive slices of one []float64.\n"+
+		"Use mat.RavelCopy() to copy them instead.\n", "Ravel()")
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { Ravel(m) }, notShared},
+		{func() { Ravel(swapped) }, notShared},
+		{func() { Ravel(Slice(FromVec(v, 3, 2), 0, 3, 0, 1)) }, notShared},
+		{
+			func() { RavelCopy([][]float64{{1.0}, {2.0, 3.0}}) },
+			fmt.Sprintf("In mat.%s the [][]float64 cannot be empty or jagged.\n", "RavelCopy()"),
+		},
+		{
+			func() { Ravel(nil) },
+			fmt.Sprintf("In mat.%s the [][]float64 cannot be empty or jagged.\n", "Ravel()"),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,143 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+// ErrShape is returned when the shapes of [][]float64s do not fit together,
+// such as the parts of a stack with different numbers of rows. It is wrapped
+// by an *errs.ShapeError, or an *errs.EmptyError, holding the shapes.
+var ErrShape = errors.New("mat: the shapes of the matrices do not fit together")
+
+/*
+HStack returns the [][]float64 made of the passed [][]float64s placed side
+by side, from left to right. For example:
+
+	a := [][]float64{{1.0}, {2.0}}
+	b := [][]float64{{3.0, 4.0}, {5.0, 6.0}}
+	m, err := mat.HStack(a, b) // m is {{1.0, 3.0, 4.0}, {2.0, 5.0, 6.0}}
+
+Unlike most functions of this package, HStack returns an error wrapping
+ErrShape, rather than panicking, if the parts do not all have the same
+number of rows, or one of them is jagged. The passed [][]float64s are not
+mutated in this function.
+*/
+func HStack(ms ...[][]float64) ([][]float64, error) {
+	if len(ms) == 0 {
+		return [][]float64{}, nil
+	}
+	cols := 0
+	for k, m := range ms {
+		c, err := shape(m)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", k, err)
+		}
+		if len(m) != len(ms[0]) {
+			return nil, &errs.ShapeError{
+				Got:  []int{len(m), c},
+				Want: []int{len(ms[0]), -1},
+				Err:  fmt.Errorf("%w: part %d has %d rows, while part 0 has %d", ErrShape, k, len(m), len(ms[0])),
+			}
+		}
+		cols += c
+	}
+	res := make([][]float64, len(ms[0]))
+	for i := range res {
+		res[i] = make([]float64, 0, cols)
+		for _, m := range ms {
+			res[i] = append(res[i], m[i]...)
+		}
+	}
+	return res, nil
+}
+
+/*
+VStack returns the [][]float64 made of the rows of the passed [][]float64s,
+stacked from top to bottom. For example:
+
+	a := [][]float64{{1.0, 2.0}}
+	b := [][]float64{{3.0, 4.0}, {5.0, 6.0}}
+	m, err := mat.VStack(a, b) // m is {{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}
+
+VStack returns an error wrapping ErrShape if the parts do not all have the
+same number of columns, or one of them is jagged. Empty parts are skipped.
+The rows of the result are copies, and the passed [][]float64s are not
+mutated in this function.
+*/
+func VStack(ms ...[][]float64) ([][]float64, error) {
+	var res [][]float64
+	cols := -1
+	for k, m := range ms {
+		if len(m) == 0 {
+			continue
+		}
+		c, err := shape(m)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", k, err)
+		}
+		if cols >= 0 && c != cols {
+			return nil, &errs.ShapeError{
+				Got:  []int{len(m), c},
+				Want: []int{-1, cols},
+				Err:  fmt.Errorf("%w: part %d has %d columns, while the previous parts have %d", ErrShape, k, c, cols),
+			}
+		}
+		cols = c
+		for i := range m {
+			res = append(res, append([]float64(nil), m[i]...))
+		}
+	}
+	if res == nil {
+		return [][]float64{}, nil
+	}
+	return res, nil
+}
+
+/*
+Block assembles a [][]float64 from a grid of blocks, where blocks[i][j] is
+the block in the i-th row and j-th column of the grid. For example, to build
+the block matrix {{A, B}, {C, D}}:
+
+	m, err := mat.Block([][][][]float64{{a, b}, {c, d}})
+
+The blocks in each row of the grid must have the same number of rows, and
+the rows of the grid must have the same total number of columns, otherwise
+an error wrapping ErrShape is returned, naming the offending block. The
+passed blocks are not mutated in this function.
+*/
+func Block(blocks [][][][]float64) ([][]float64, error) {
+	rows := make([][][]float64, len(blocks))
+	for i := range blocks {
+		r, err := HStack(blocks[i]...)
+		if err != nil {
+			return nil, fmt.Errorf("row %d of the blocks: %w", i, err)
+		}
+		rows[i] = r
+	}
+	res, err := VStack(rows...)
+	if err != nil {
+		return nil, fmt.Errorf("rows of the blocks: %w", err)
+	}
+	return res, nil
+}
+
+// shape returns the number of columns of m, or an *errs.ShapeError wrapping
+// ErrShape if m is jagged.
+func shape(m [][]float64) (int, error) {
+	if len(m) == 0 {
+		return 0, nil
+	}
+	for i := range m {
+		if len(m[i]) != len(m[0]) {
+			return 0, &errs.ShapeError{
+				Got:  []int{len(m[i])},
+				Want: []int{len(m[0])},
+				Err:  fmt.Errorf("%w: row %d has %d columns, while row 0 has %d", ErrShape, i, len(m[i]), len(m[0])),
+			}
+		}
+	}
+	return len(m[0]), nil
+}
@@ -0,0 +1,73 @@
+package mat
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+func TestHStack(t *testing.T) {
+	a := [][]float64{{1.0}, {2.0}}
+	b := [][]float64{{3.0, 4.0}, {5.0, 6.0}}
+	m, err := HStack(a, b)
+	if err != nil || !Equal(m, [][]float64{{1.0, 3.0, 4.0}, {2.0, 5.0, 6.0}}) {
+		t.Errorf("expected {{1.0, 3.0, 4.0}, {2.0, 5.0, 6.0}}, got %v and %v", m, err)
+	}
+	m[0][0] = 9.0
+	if a[0][0] != 1.0 {
+		t.Errorf("the original [][]float64 was modified")
+	}
+	if _, err := HStack(a, [][]float64{{1.0}}); !errors.Is(err, ErrShape) {
+		t.Errorf("expected ErrShape, got %v", err)
+	}
+	if _, err := HStack(a, [][]float64{{1.0}, {1.0, 2.0}}); !errors.Is(err, ErrShape) {
+		t.Errorf("expected ErrShape for a jagged part, got %v", err)
+	}
+	if m, err := HStack(); err != nil || len(m) != 0 {
+		t.Errorf("expected an empty [][]float64, got %v and %v", m, err)
+	}
+}
+
+func TestVStack(t *testing.T) {
+	a := [][]float64{{1.0, 2.0}}
+	b := [][]float64{{3.0, 4.0}, {5.0, 6.0}}
+	m, err := VStack(a, nil, b)
+	if err != nil || !Equal(m, [][]float64{{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}) {
+		t.Errorf("expected {{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}}, got %v and %v", m, err)
+	}
+	m[0][0] = 9.0
+	if a[0][0] != 1.0 {
+		t.Errorf("the original [][]float64 was modified")
+	}
+	if _, err := VStack(a, [][]float64{{1.0}}); !errors.Is(err, ErrShape) {
+		t.Errorf("expected ErrShape, got %v", err)
+	}
+	_, err = VStack(a, [][]float64{{1.0, 2.0, 3.0}})
+	var se *errs.ShapeError
+	if !errors.Is(err, errs.ErrShapeMismatch) || !errors.As(err, &se) {
+		t.Fatalf("expected an *errs.ShapeError, got %v", err)
+	}
+	if !slices.Equal(se.Got, []int{1, 3}) || !slices.Equal(se.Want, []int{-1, 2}) {
+		t.Errorf("expected {1, 3} and {-1, 2}, got %v and %v", se.Got, se.Want)
+	}
+}
+
+func TestBlock(t *testing.T) {
+	a := [][]float64{{1.0}}
+	b := [][]float64{{2.0, 3.0}}
+	c := [][]float64{{4.0}, {5.0}}
+	d := [][]float64{{6.0, 7.0}, {8.0, 9.0}}
+	m, err := Block([][][][]float64{{a, b}, {c, d}})
+	expected := [][]float64{{1.0, 2.0, 3.0}, {4.0, 6.0, 7.0}, {5.0, 8.0, 9.0}}
+	if err != nil || !Equal(m, expected) {
+		t.Errorf("expected %v, got %v and %v", expected, m, err)
+	}
+	if _, err := Block([][][][]float64{{a, c}}); !errors.Is(err, ErrShape) {
+		t.Errorf("expected ErrShape for blocks with different rows, got %v", err)
+	}
+	if _, err := Block([][][][]float64{{a, b}, {c}}); !errors.Is(err, ErrShape) {
+		t.Errorf("expected ErrShape for block rows with different columns, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package mat
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/NDari/gocrunch/internal/stride"
+)
+
+/*
+Strided is a view of every rstep-th row and cstep-th column of a
+[][]float64, as with the m[r0:r1:rstep, c0:c1:cstep] slices of numpy. It
+shares its elements with the [][]float64, so that setting an element of the
+view sets the element of the [][]float64, and the other way around, and no
+element is copied when the view is created. For a step of 1 in both
+directions, mat.Slice() returns the same view as a [][]float64.
+*/
+type Strided struct {
+	m [][]float64
+	// The element (i, j) of the view is m[r0+i*rstep][c0+j*cstep].
+	r0, rstep, rows int
+	c0, cstep, cols int
+}
+
+/*
+SliceStep returns the Strided view of the rows of m from r0 to r1, every
+rstep rows, and of their columns from c0 to c1, every cstep columns, with
+the semantics of numpy, as vec.Slice() does for a []float64. The starts are
+included, and the stops excluded. Negative starts and stops count from the
+end, bounds past either end are clamped to it, and negative steps walk
+backward, with math.MinInt and math.MaxInt standing for the omitted bounds.
+For example:
+
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	s := mat.SliceStep(m, 0, 3, 2, math.MaxInt, math.MinInt, -1)
+	n := s.Dense() // n is {{3.0, 2.0, 1.0}, {9.0, 8.0, 7.0}}
+
+The steps must not be 0, and m must not be jagged, otherwise this function
+will panic.
+*/
+func SliceStep(m [][]float64, r0, r1, rstep, c0, c1, cstep int) *Strided {
+	if rstep == 0 || cstep == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the steps cannot be 0, but they are %d and %d.\n"
+		s = fmt.Sprintf(s, "SliceStep()", rstep, cstep)
+		debug.PrintStack()
+		panic(s)
+	}
+	c, err := shape(m)
+	if err != nil {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 cannot be jagged.\n"
+		s = fmt.Sprintf(s, "SliceStep()")
+		debug.PrintStack()
+		panic(s)
+	}
+	s := &Strided{m: m, rstep: rstep, cstep: cstep}
+	s.r0, s.rows = stride.Span(len(m), r0, r1, rstep)
+	s.c0, s.cols = stride.Span(c, c0, c1, cstep)
+	if s.rows == 0 || s.cols == 0 {
+		s.rows, s.cols = 0, 0
+	}
+	return s
+}
+
+// Dims returns the number of rows and columns of s, which are both 0 if
+// either range of s is empty.
+func (s *Strided) Dims() (int, int) {
+	return s.rows, s.cols
+}
+
+/*
+At returns the element of s at row i and column j. The indices must be in
+the bounds of s.Dims(), otherwise this function will panic.
+*/
+func (s *Strided) At(i, j int) float64 {
+	s.check("Strided.At()", i, j)
+	return s.m[s.r0+i*s.rstep][s.c0+j*s.cstep]
+}
+
+/*
+Set sets the element of s at row i and column j, and so the element of the
+underlying [][]float64, to x. The indices must be in the bounds of
+s.Dims(), otherwise this function will panic.
+*/
+func (s *Strided) Set(i, j int, x float64) {
+	s.check("Strided.Set()", i, j)
+	s.m[s.r0+i*s.rstep][s.c0+j*s.cstep] = x
+}
+
+/*
+Dense returns a copy of the elements of s, in a new [][]float64, which is
+empty if s is.
+*/
+func (s *Strided) Dense() [][]float64 {
+	res := make([][]float64, s.rows)
+	for i := range res {
+		row := s.m[s.r0+i*s.rstep]
+		res[i] = make([]float64, s.cols)
+		for j := range res[i] {
+			res[i][j] = row[s.c0+j*s.cstep]
+		}
+	}
+	return res
+}
+
+func (s *Strided) check(fn string, i, j int) {
+	if i < 0 || i >= s.rows || j < 0 || j >= s.cols {
+		fmt.Println("\ngocrunch/mat error.")
+		msg := "In mat.%s the element (%d, %d) is outside of the %d by %d view.\n"
+		msg = fmt.Sprintf(msg, fn, i, j, s.rows, s.cols)
+		debug.PrintStack()
+		panic(msg)
+	}
+}
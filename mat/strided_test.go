@@ -0,0 +1,70 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSliceStep(t *testing.T) {
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	tests := []struct {
+		r0, r1, rstep, c0, c1, cstep int
+		expected                     [][]float64
+	}{
+		{0, 3, 2, math.MaxInt, math.MinInt, -1, [][]float64{{3.0, 2.0, 1.0}, {9.0, 8.0, 7.0}}},
+		{-1, math.MinInt, -1, 0, 3, 2, [][]float64{{7.0, 9.0}, {4.0, 6.0}, {1.0, 3.0}}},
+		{1, 2, 1, 1, 3, 1, [][]float64{{5.0, 6.0}}},
+		{2, 0, 1, 0, 3, 1, [][]float64{}},
+		{0, 3, math.MaxInt, math.MaxInt, math.MinInt, math.MinInt, [][]float64{{3.0}}},
+	}
+	for _, test := range tests {
+		s := SliceStep(m, test.r0, test.r1, test.rstep, test.c0, test.c1, test.cstep)
+		r, c := s.Dims()
+		if d := s.Dense(); !Equal(d, test.expected) || r != len(test.expected) || (r > 0 && c != len(test.expected[0])) {
+			t.Errorf("expected %v, got %v", test.expected, d)
+		}
+	}
+	s := SliceStep(m, 0, 3, 2, 0, 3, 2)
+	s.Set(1, 1, 90.0)
+	if m[2][2] != 90.0 || s.At(1, 1) != 90.0 {
+		t.Errorf("expected the view to share the elements of m")
+	}
+	panics := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { SliceStep(m, 0, 3, 1, 0, 3, 0) },
+			fmt.Sprintf("In mat.%s the steps cannot be 0, but they are %d and %d.\n", "SliceStep()", 1, 0),
+		},
+		{
+			func() { SliceStep([][]float64{{1.0}, {1.0, 2.0}}, 0, 2, 1, 0, 1, 1) },
+			fmt.Sprintf("In mat.%s the [][]float64 cannot be jagged.\n", "SliceStep()"),
+		},
+		{
+			func() { s.At(2, 0) },
+			fmt.Sprintf("In mat.%s the element (%d, %d) is outside of the %d by %d view.\n", "Strided.At()", 2, 0, 2, 2),
+		},
+		{
+			func() { s.Set(0, -1, 0.0) },
+			fmt.Sprintf("In mat.%s the element (%d, %d) is outside of the %d by %d view.\n", "Strided.Set()", 0, -1, 2, 2),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range panics {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
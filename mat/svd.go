@@ -0,0 +1,131 @@
+package mat
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"sort"
+)
+
+// maxSweeps is the number of sweeps of the Jacobi rotations after which
+// SVD() stops, even if some columns are not yet orthogonal.
+const maxSweeps = 64
+
+/*
+SVD computes the thin singular value decomposition of a r by c [][]float64,
+returning u, s and v such that
+
+	m = u * diag(s) * T(v)
+
+where k = min(r, c), u is a r by k [][]float64 with orthonormal columns, s
+holds the k singular values in decreasing order, and v is a c by k
+[][]float64 with orthonormal columns. The columns of u which belong to a
+singular value of 0.0 are left as zeros. For example:
+
+	m := [][]float64{{3.0, 0.0}, {0.0, -2.0}}
+	u, s, v := mat.SVD(m) // s is {3.0, 2.0}
+
+The decomposition is computed with one-sided Jacobi rotations, which give
+singular values that are accurate even when they are very small.
+
+The passed [][]float64 must not be empty, otherwise this function will
+panic. It is not mutated in this function.
+*/
+func SVD(m [][]float64) (u [][]float64, s []float64, v [][]float64) {
+	u, s, v, _ = svd(context.Background(), "SVD()", m)
+	return u, s, v
+}
+
+/*
+SVDCtx is SVD(), stopping early if ctx is done before the decomposition is
+complete, in which case it returns nil values and ctx.Err().
+*/
+func SVDCtx(ctx context.Context, m [][]float64) (u [][]float64, s []float64, v [][]float64, err error) {
+	return svd(ctx, "SVDCtx()", m)
+}
+
+func svd(ctx context.Context, fn string, m [][]float64) ([][]float64, []float64, [][]float64, error) {
+	if len(m) == 0 || len(m[0]) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 cannot be empty.\n"
+		s = fmt.Sprintf(s, fn)
+		debug.PrintStack()
+		panic(s)
+	}
+	// The rotations act on the columns of a, which are stored as the rows of
+	// w to keep them contiguous. A wide matrix is decomposed through its
+	// transpose, whose factors are those of m, swapped.
+	w, wide := T(m), false
+	if len(m) < len(m[0]) {
+		w, wide = Clone(m), true
+	}
+	n, k := len(w[0]), len(w)
+	vt := I(k)
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		rotated := false
+		for i := 0; i < k-1; i++ {
+			if err := ctx.Err(); err != nil {
+				return nil, nil, nil, err
+			}
+			for j := i + 1; j < k; j++ {
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				for l := 0; l < n; l++ {
+					alpha += w[i][l] * w[i][l]
+					beta += w[j][l] * w[j][l]
+					gamma += w[i][l] * w[j][l]
+				}
+				if gamma == 0.0 || math.Abs(gamma) <= 1e-15*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				zeta := (beta - alpha) / (2.0 * gamma)
+				t := math.Copysign(1.0, zeta) / (math.Abs(zeta) + math.Sqrt(1.0+zeta*zeta))
+				c := 1.0 / math.Sqrt(1.0+t*t)
+				s := c * t
+				rotate(w[i], w[j], c, s)
+				rotate(vt[i], vt[j], c, s)
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+	sv := make([]float64, k)
+	for i := range w {
+		for _, x := range w[i] {
+			sv[i] += x * x
+		}
+		sv[i] = math.Sqrt(sv[i])
+		if sv[i] != 0.0 {
+			for l := range w[i] {
+				w[i][l] /= sv[i]
+			}
+		}
+	}
+	order := make([]int, k)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return sv[order[a]] > sv[order[b]] })
+	s := make([]float64, k)
+	left, right := New(k, n), New(k, k)
+	for i, o := range order {
+		s[i] = sv[o]
+		copy(left[i], w[o])
+		copy(right[i], vt[o])
+	}
+	if wide {
+		return T(right), s, T(left), nil
+	}
+	return T(left), s, T(right), nil
+}
+
+// rotate applies the Jacobi rotation by c and s to the pair x, y.
+func rotate(x, y []float64, c, s float64) {
+	for l := range x {
+		a, b := x[l], y[l]
+		x[l] = c*a - s*b
+		y[l] = s*a + c*b
+	}
+}
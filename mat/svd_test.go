@@ -0,0 +1,78 @@
+package mat
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestSVD(t *testing.T) {
+	// A rank deficient matrix, whose third column is twice the second minus
+	// the first.
+	deficient := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {5.0, 7.0, 9.0}, {1.0, 0.0, -1.0}}
+	for _, m := range [][][]float64{Rand(6, 4), Rand(3, 7), deficient, {{3.0, 0.0}, {0.0, -2.0}}} {
+		r, c := len(m), len(m[0])
+		k := r
+		if c < k {
+			k = c
+		}
+		u, s, v := SVD(m)
+		if len(u) != r || len(u[0]) != k || len(s) != k || len(v) != c || len(v[0]) != k {
+			t.Fatalf("unexpected shapes %dx%d, %d and %dx%d", len(u), len(u[0]), len(s), len(v), len(v[0]))
+		}
+		for i := 1; i < k; i++ {
+			if s[i] > s[i-1] || s[i] < 0.0 {
+				t.Errorf("singular values %v are not decreasing", s)
+			}
+		}
+		for i := range m {
+			for j := range m[i] {
+				sum := 0.0
+				for l := 0; l < k; l++ {
+					sum += u[i][l] * s[l] * v[j][l]
+				}
+				if math.Abs(sum-m[i][j]) > 1e-12 {
+					t.Errorf("at [%d][%d], expected %v, got %v", i, j, m[i][j], sum)
+				}
+			}
+		}
+		vtv := Dot(T(v), v)
+		for i := range vtv {
+			for j := range vtv[i] {
+				want := 0.0
+				if i == j {
+					want = 1.0
+				}
+				if math.Abs(vtv[i][j]-want) > 1e-12 {
+					t.Errorf("the columns of v are not orthonormal: %v", vtv)
+				}
+			}
+		}
+	}
+	_, s, _ := SVD(deficient)
+	if s[2] > 1e-12 {
+		t.Errorf("expected a zero singular value, got %v", s)
+	}
+	_, s, _ = SVD([][]float64{{3.0, 0.0}, {0.0, -2.0}})
+	if math.Abs(s[0]-3.0) > 1e-15 || math.Abs(s[1]-2.0) > 1e-15 {
+		t.Errorf("expected {3.0, 2.0}, got %v", s)
+	}
+}
+
+func TestSVDCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	u, s, v, err := SVDCtx(ctx, Rand(10, 10))
+	if err != context.Canceled || u != nil || s != nil || v != nil {
+		t.Errorf("expected nil values and %v, got %v", context.Canceled, err)
+	}
+	m := Rand(5, 5)
+	u, s, v, err = SVDCtx(context.Background(), m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	u2, s2, v2 := SVD(m)
+	if !Equal(u, u2) || !Equal(v, v2) || len(s) != len(s2) {
+		t.Errorf("expected the result of SVD()")
+	}
+}
@@ -0,0 +1,225 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NDari/gocrunch/backend"
+	"github.com/NDari/gocrunch/errs"
+)
+
+// ErrNotPositiveDefinite is returned by Symmetric.Cholesky() when the
+// matrix is not positive definite.
+var ErrNotPositiveDefinite = errors.New("mat: the matrix is not positive definite")
+
+/*
+Symmetric is a square symmetric matrix, which stores only the n*(n+1)/2
+elements of its lower half, about half of the memory of the equivalent
+[][]float64.
+*/
+type Symmetric struct {
+	// l holds the lower half, the upper half being its transpose.
+	l *Triangular
+}
+
+/*
+NewSymmetric returns the Symmetric made of one half of a square [][]float64,
+mirrored onto the other half, whose elements are ignored. For example:
+
+	m := [][]float64{{2.0, 1.0}, {9.0, 4.0}}
+	s := mat.NewSymmetric(m, mat.HalfUpper) // s is {{2.0, 1.0}, {1.0, 4.0}}
+
+The passed [][]float64 must be square and not empty, otherwise this function
+will panic. The elements are copied, and m is not mutated in this function.
+*/
+func NewSymmetric(m [][]float64, half Half) *Symmetric {
+	checkSquare("NewSymmetric()", m)
+	checkHalf("NewSymmetric()", half)
+	l := newTriangular(len(m), HalfLower)
+	for i, r := range l.rows {
+		for j := range r {
+			if half == HalfLower {
+				r[j] = m[i][j]
+			} else {
+				r[j] = m[j][i]
+			}
+		}
+	}
+	return &Symmetric{l: l}
+}
+
+// Size returns the number of rows, and columns, of s.
+func (s *Symmetric) Size() int {
+	return len(s.l.rows)
+}
+
+/*
+At returns the element of s at row i and column j, which is also the
+element at row j and column i. The indices must be in [0, s.Size()),
+otherwise this function will panic.
+*/
+func (s *Symmetric) At(i, j int) float64 {
+	checkAt("Symmetric.At()", i, j, len(s.l.rows))
+	if j > i {
+		i, j = j, i
+	}
+	return s.l.rows[i][j]
+}
+
+/*
+Dense returns s as a [][]float64, with both of its halves.
+*/
+func (s *Symmetric) Dense() [][]float64 {
+	m := New(len(s.l.rows))
+	for i, r := range s.l.rows {
+		for j, x := range r {
+			m[i][j], m[j][i] = x, x
+		}
+	}
+	return m
+}
+
+/*
+MulVec returns the product s * v, where v is taken as a column vector,
+reading each stored element of s once. The length of v must be s.Size(),
+otherwise this function will panic. v is not mutated in this function.
+*/
+func (s *Symmetric) MulVec(v []float64) []float64 {
+	checkLen("Symmetric.MulVec()", v, len(s.l.rows))
+	res := make([]float64, len(v))
+	for i, r := range s.l.rows {
+		for j, x := range r[:i] {
+			res[i] += x * v[j]
+			res[j] += x * v[i]
+		}
+		res[i] += r[i] * v[i]
+	}
+	return res
+}
+
+/*
+Cholesky returns the lower Triangular l for which s = l * T(l), which
+exists when s is positive definite. For example:
+
+	s := mat.NewSymmetric([][]float64{{4.0, 2.0}, {2.0, 2.0}}, mat.HalfLower)
+	l, err := s.Cholesky() // l is {{2.0, 0.0}, {1.0, 1.0}}
+
+The factorization takes about n*n*n/6 multiplications, half of those of an
+LU factorization, and is computed by the current Backend of the backend
+package. Cholesky returns an error wrapping ErrNotPositiveDefinite
+if s is not positive definite.
+*/
+func (s *Symmetric) Cholesky() (*Triangular, error) {
+	a := s.Dense()
+	if i := backend.Potrf(a); i >= 0 {
+		return nil, fmt.Errorf("%w: the pivot of row %d is not positive", ErrNotPositiveDefinite, i)
+	}
+	l := newTriangular(len(a), HalfLower)
+	for i, r := range l.rows {
+		copy(r, a[i])
+	}
+	return l, nil
+}
+
+/*
+LDL returns the lower Triangular l, with ones on its diagonal, and the
+diagonal d for which s = l * diag(d) * T(l). Unlike Cholesky(), LDL does not
+take square roots, and also factors some symmetric matrices which are not
+positive definite. For example:
+
+	s := mat.NewSymmetric([][]float64{{1.0, 2.0}, {2.0, 1.0}}, mat.HalfLower)
+	l, d, err := s.LDL() // l is {{1.0, 0.0}, {2.0, 1.0}}, d is {1.0, -3.0}
+
+The factorization is computed without pivoting, and LDL returns an error
+wrapping ErrSingular if a pivot is 0.0, which happens for all singular s,
+and for some s which are not singular but need pivoting.
+*/
+func (s *Symmetric) LDL() (*Triangular, []float64, error) {
+	n := len(s.l.rows)
+	l := newTriangular(n, HalfLower)
+	d := make([]float64, n)
+	for i, a := range s.l.rows {
+		li := l.rows[i]
+		for j := 0; j <= i; j++ {
+			lj := l.rows[j]
+			sum := a[j]
+			for k := 0; k < j; k++ {
+				sum -= li[k] * lj[k] * d[k]
+			}
+			if j < i {
+				li[j] = sum / d[j]
+				continue
+			}
+			if sum == 0.0 {
+				return nil, nil, &errs.SingularError{Index: i, Err: fmt.Errorf("%w: the pivot of row %d is 0.0", ErrSingular, i)}
+			}
+			li[i], d[i] = 1.0, sum
+		}
+	}
+	return l, d, nil
+}
+
+/*
+Solve returns the x for which s * x = b. The system is solved with the
+Cholesky factorization of s, or with its LDL factorization if s is not
+positive definite. For example:
+
+	s := mat.NewSymmetric([][]float64{{4.0, 2.0}, {2.0, 2.0}}, mat.HalfLower)
+	x, err := s.Solve([]float64{6.0, 4.0}) // x is {1.0, 1.0}
+
+Solve returns an error wrapping ErrSingular if neither factorization
+exists. The length of b must be s.Size(), otherwise this function will
+panic. b is not mutated in this function.
+*/
+func (s *Symmetric) Solve(b []float64) ([]float64, error) {
+	checkLen("Symmetric.Solve()", b, len(s.l.rows))
+	solve, err := s.solver()
+	if err != nil {
+		return nil, err
+	}
+	x := make([]float64, len(b))
+	copy(x, b)
+	solve(x)
+	return x, nil
+}
+
+/*
+SolveMat returns the x for which s * x = b, where each column of b is a
+right hand side, as with Solve(). s is factored once for all the columns.
+b must have s.Size() rows, and must not be empty or jagged, otherwise this
+function will panic. b is not mutated in this function.
+*/
+func (s *Symmetric) SolveMat(b [][]float64) ([][]float64, error) {
+	checkRHS("Symmetric.SolveMat()", b, len(s.l.rows))
+	solve, err := s.solver()
+	if err != nil {
+		return nil, err
+	}
+	return solveCols(b, func(x []float64) error {
+		solve(x)
+		return nil
+	})
+}
+
+// solver factors s, and returns the function which overwrites x with the
+// solution of s * x = x.
+func (s *Symmetric) solver() (func(x []float64), error) {
+	if l, err := s.Cholesky(); err == nil {
+		// The diagonal of l is positive, so the substitutions cannot fail.
+		return func(x []float64) {
+			l.solve(x, false)
+			l.solve(x, true)
+		}, nil
+	}
+	l, d, err := s.LDL()
+	if err != nil {
+		return nil, err
+	}
+	return func(x []float64) {
+		l.solve(x, false)
+		for i := range x {
+			x[i] /= d[i]
+		}
+		l.solve(x, true)
+	}, nil
+}
@@ -0,0 +1,120 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestNewSymmetric(t *testing.T) {
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	l := NewSymmetric(m, HalfLower)
+	if !Equal(l.Dense(), [][]float64{{1.0, 4.0, 7.0}, {4.0, 5.0, 8.0}, {7.0, 8.0, 9.0}}) {
+		t.Errorf("expected the lower half of m mirrored, got %v", l.Dense())
+	}
+	u := NewSymmetric(m, HalfUpper)
+	if !Equal(u.Dense(), [][]float64{{1.0, 2.0, 3.0}, {2.0, 5.0, 6.0}, {3.0, 6.0, 9.0}}) {
+		t.Errorf("expected the upper half of m mirrored, got %v", u.Dense())
+	}
+	if u.Size() != 3 || u.At(0, 2) != 3.0 || u.At(2, 0) != 3.0 {
+		t.Errorf("expected a size of 3 and At() to be symmetric")
+	}
+	v := []float64{1.0, -1.0, 2.0}
+	p, q := u.MulVec(v), MulVec(u.Dense(), v)
+	for i := range p {
+		if p[i] != q[i] {
+			t.Errorf("expected %v, got %v", q, p)
+			break
+		}
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		f        func()
+		expected string
+	}{
+		{func() { NewSymmetric([][]float64{}, HalfLower) }, fmt.Sprintf("In mat.%s the [][]float64 cannot be empty.\n", "NewSymmetric()")},
+		{func() { u.At(-1, 0) }, fmt.Sprintf("In mat.%s the element (%d, %d) is outside of bounds [0, %d).\n", "Symmetric.At()", -1, 0, 3)},
+		{func() { u.MulVec(v[:2]) }, fmt.Sprintf("In mat.%s the []float64 has %d elements, while the matrix has %d rows.\n", "Symmetric.MulVec()", 2, 3)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	s := NewSymmetric([][]float64{{4.0, 2.0}, {2.0, 2.0}}, HalfLower)
+	l, err := s.Cholesky()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(l.Dense(), [][]float64{{2.0, 0.0}, {1.0, 1.0}}) {
+		t.Errorf("expected {{2.0, 0.0}, {1.0, 1.0}}, got %v", l.Dense())
+	}
+	a := [][]float64{{4.0, 1.0, 2.0}, {1.0, 5.0, 3.0}, {2.0, 3.0, 6.0}}
+	l, err = NewSymmetric(a, HalfLower).Cholesky()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := Dot(l.Dense(), T(l.Dense()))
+	for i := range a {
+		for j := range a[i] {
+			if math.Abs(p[i][j]-a[i][j]) > 1e-12 {
+				t.Errorf("expected l * T(l) = %v, got %v", a, p)
+			}
+		}
+	}
+	_, err = NewSymmetric([][]float64{{1.0, 2.0}, {2.0, 1.0}}, HalfLower).Cholesky()
+	if !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("expected ErrNotPositiveDefinite, got %v", err)
+	}
+}
+
+func TestLDL(t *testing.T) {
+	l, d, err := NewSymmetric([][]float64{{1.0, 2.0}, {2.0, 1.0}}, HalfLower).LDL()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(l.Dense(), [][]float64{{1.0, 0.0}, {2.0, 1.0}}) || d[0] != 1.0 || d[1] != -3.0 {
+		t.Errorf("expected {{1.0, 0.0}, {2.0, 1.0}} and {1.0, -3.0}, got %v and %v", l.Dense(), d)
+	}
+	_, _, err = NewSymmetric([][]float64{{1.0, 1.0}, {1.0, 1.0}}, HalfLower).LDL()
+	if !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+}
+
+func TestSymmetricSolve(t *testing.T) {
+	b := []float64{1.0, -2.0, 3.0}
+	for _, a := range [][][]float64{
+		{{4.0, 1.0, 2.0}, {1.0, 5.0, 3.0}, {2.0, 3.0, 6.0}},
+		{{1.0, 2.0, 0.0}, {2.0, 1.0, 3.0}, {0.0, 3.0, -2.0}},
+	} {
+		x, err := NewSymmetric(a, HalfUpper).Solve(b)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		y := MulVec(a, x)
+		for i := range b {
+			if math.Abs(y[i]-b[i]) > 1e-12 {
+				t.Errorf("expected a * x = %v, got %v", b, y)
+				break
+			}
+		}
+	}
+	_, err := NewSymmetric([][]float64{{1.0, 1.0}, {1.0, 1.0}}, HalfLower).Solve(b[:2])
+	if !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+
+	"github.com/NDari/gocrunch/backend"
+)
+
+// EulerOrder is the order of the axes of the three rotations of a set of
+// Euler angles.
+type EulerOrder int
+
+// The orders of the rotations of mat.Euler(), named by their axes from the
+// first rotation to the last.
+const (
+	EulerXYZ EulerOrder = iota
+	EulerXZY
+	EulerYXZ
+	EulerYZX
+	EulerZXY
+	EulerZYX
+)
+
+// eulerAxes holds the axes of the rotations of each EulerOrder.
+var eulerAxes = [][3]int{
+	EulerXYZ: {0, 1, 2},
+	EulerXZY: {0, 2, 1},
+	EulerYXZ: {1, 0, 2},
+	EulerYZX: {1, 2, 0},
+	EulerZXY: {2, 0, 1},
+	EulerZYX: {2, 1, 0},
+}
+
+/*
+Rotation2D returns the 2 by 2 [][]float64 which rotates the points of the
+plane by an angle theta, in radians, counterclockwise about the origin:
+
+	r := mat.Rotation2D(math.Pi / 2.0) // r is {{0.0, -1.0}, {1.0, 0.0}}
+*/
+func Rotation2D(theta float64) [][]float64 {
+	s, c := math.Sincos(theta)
+	return [][]float64{{c, -s}, {s, c}}
+}
+
+/*
+AxisAngle returns the 3 by 3 [][]float64 which rotates the points of space
+by an angle theta, in radians, about an axis through the origin, following
+the right hand rule. For example, a rotation by a third of a turn about the
+diagonal maps the x axis onto the y axis:
+
+	r := mat.AxisAngle([]float64{1.0, 1.0, 1.0}, 2.0*math.Pi/3.0)
+
+The axis need not be a unit vector, but must have 3 elements and must not be
+zero, otherwise this function will panic. It is not mutated in this
+function.
+*/
+func AxisAngle(axis []float64, theta float64) [][]float64 {
+	if len(axis) != 3 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the axis must have 3 elements, but has %d.\n"
+		s = fmt.Sprintf(s, "AxisAngle()", len(axis))
+		debug.PrintStack()
+		panic(s)
+	}
+	n := math.Hypot(math.Hypot(axis[0], axis[1]), axis[2])
+	if n == 0.0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the axis cannot be zero.\n"
+		s = fmt.Sprintf(s, "AxisAngle()")
+		debug.PrintStack()
+		panic(s)
+	}
+	x, y, z := axis[0]/n, axis[1]/n, axis[2]/n
+	s, c := math.Sincos(theta)
+	// The formula of Rodrigues, c*I + s*[k]x + (1-c)*k*T(k).
+	t := 1.0 - c
+	return [][]float64{
+		{c + t*x*x, t*x*y - s*z, t*x*z + s*y},
+		{t*y*x + s*z, c + t*y*y, t*y*z - s*x},
+		{t*z*x - s*y, t*z*y + s*x, c + t*z*z},
+	}
+}
+
+/*
+Euler returns the 3 by 3 [][]float64 of the rotations by the angles a, b and
+c, in radians, about the axes given by order. The rotations are intrinsic,
+each being about the axes as moved by the previous rotations, so that
+
+	r := mat.Euler(mat.EulerZYX, yaw, pitch, roll)
+
+is the rotation of the yaw about z, followed by the pitch about the new y
+axis, and the roll about the newest x axis, as used in aerospace. The
+result is the product of the rotations about the fixed axes, in order:
+Rz(yaw) * Ry(pitch) * Rx(roll).
+
+order must be one of the EulerOrder constants of this package, otherwise
+this function will panic.
+*/
+func Euler(order EulerOrder, a, b, c float64) [][]float64 {
+	if order < 0 || int(order) >= len(eulerAxes) {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the order %d is unknown.\n"
+		s = fmt.Sprintf(s, "Euler()", order)
+		debug.PrintStack()
+		panic(s)
+	}
+	ax := eulerAxes[order]
+	return Dot(Dot(rotationAbout(ax[0], a), rotationAbout(ax[1], b)), rotationAbout(ax[2], c))
+}
+
+// rotationAbout returns the 3 by 3 rotation by theta about the x, y or z
+// axis, for an axis of 0, 1 or 2.
+func rotationAbout(axis int, theta float64) [][]float64 {
+	s, c := math.Sincos(theta)
+	r := I(3)
+	i, j := (axis+1)%3, (axis+2)%3
+	r[i][i], r[i][j] = c, -s
+	r[j][i], r[j][j] = s, c
+	return r
+}
+
+/*
+Affine returns the n+1 by n+1 [][]float64 of the affine transform x -> l*x +
+t of n dimensional points, in homogeneous coordinates:
+
+	| l  t |
+	| 0  1 |
+
+The affine transforms are composed by their products, so that applying
+mat.Dot(a, b) is applying b, then a. For example, a rotation of the plane
+about the point {1.0, 2.0}, rather than the origin, is given by:
+
+	r := mat.Affine(mat.Rotation2D(theta), []float64{0.0, 0.0})
+	m := mat.Dot(mat.Translation([]float64{1.0, 2.0}), mat.Dot(r, mat.Translation([]float64{-1.0, -2.0})))
+
+l must be square and not empty, and t must have as many elements as l has
+rows, otherwise this function will panic. The passed arguments are not
+mutated in this function.
+*/
+func Affine(l [][]float64, t []float64) [][]float64 {
+	checkSquare("Affine()", l)
+	n := len(l)
+	if len(t) != n {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the translation has %d elements, while the linear part is %d by %d.\n"
+		s = fmt.Sprintf(s, "Affine()", len(t), n, n)
+		debug.PrintStack()
+		panic(s)
+	}
+	m := New(n + 1)
+	for i := range l {
+		copy(m[i], l[i])
+		m[i][n] = t[i]
+	}
+	m[n][n] = 1.0
+	return m
+}
+
+/*
+Translation returns the n+1 by n+1 [][]float64 of the affine transform which
+translates n dimensional points by t, in homogeneous coordinates. t must
+not be empty, otherwise this function will panic.
+*/
+func Translation(t []float64) [][]float64 {
+	if len(t) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the translation cannot be empty.\n"
+		s = fmt.Sprintf(s, "Translation()")
+		debug.PrintStack()
+		panic(s)
+	}
+	return Affine(I(len(t)), t)
+}
+
+/*
+TransformPoints applies a transform to a set of n dimensional points, stored
+one per row of a [][]float64, and returns the transformed points in the same
+layout. The transform is either a n by n linear transform, such as a
+rotation, or a n+1 by n+1 affine transform in homogeneous coordinates, such
+as those of mat.Affine(), whose last row is taken to be {0, ..., 0, 1}. For
+example:
+
+	points := [][]float64{{1.0, 0.0}, {0.0, 1.0}}
+	q := mat.TransformPoints(mat.Rotation2D(math.Pi/2.0), points) // q is {{0.0, 1.0}, {-1.0, 0.0}}
+
+All the points are transformed in a single product, by the current engine of
+the backend package.
+
+The transform must be square, and the points must not be empty and must
+have n elements each, otherwise this function will panic. The passed
+arguments are not mutated in this function.
+*/
+func TransformPoints(m, points [][]float64) [][]float64 {
+	checkSquare("TransformPoints()", m)
+	if len(points) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the points cannot be empty.\n"
+		s = fmt.Sprintf(s, "TransformPoints()")
+		debug.PrintStack()
+		panic(s)
+	}
+	n := len(points[0])
+	for i := range points {
+		if n == 0 || len(points[i]) != n || (len(m) != n && len(m) != n+1) {
+			fmt.Println("\ngocrunch/mat error.")
+			s := "In mat.%s the point %d has %d elements, which does not fit a %d by %d transform.\n"
+			s = fmt.Sprintf(s, "TransformPoints()", i, len(points[i]), len(m), len(m))
+			debug.PrintStack()
+			panic(s)
+		}
+	}
+	// The points are rows, so the result is points * T(l) + t.
+	l := m
+	res := New(len(points), n)
+	if len(m) == n+1 {
+		l = Slice(m, 0, n, 0, n)
+		for i := range res {
+			for j := range res[i] {
+				res[i][j] = m[j][n]
+			}
+		}
+	}
+	backend.Gemm(false, true, 1.0, points, l, 1.0, res)
+	return res
+}
@@ -0,0 +1,160 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func nearMat(a, b [][]float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if math.Abs(a[i][j]-b[i][j]) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestRotation2D(t *testing.T) {
+	if r := Rotation2D(math.Pi / 2.0); !nearMat(r, [][]float64{{0.0, -1.0}, {1.0, 0.0}}, 1e-15) {
+		t.Errorf("expected {{0.0, -1.0}, {1.0, 0.0}}, got %v", r)
+	}
+}
+
+func TestAxisAngle(t *testing.T) {
+	r := AxisAngle([]float64{1.0, 1.0, 1.0}, 2.0*math.Pi/3.0)
+	if p := MulVec(r, []float64{1.0, 0.0, 0.0}); !nearMat([][]float64{p}, [][]float64{{0.0, 1.0, 0.0}}, 1e-15) {
+		t.Errorf("expected the x axis to map onto the y axis, got %v", p)
+	}
+	if !nearMat(Dot(r, T(r)), I(3), 1e-15) {
+		t.Errorf("expected an orthogonal matrix, got %v", r)
+	}
+	if !nearMat(AxisAngle([]float64{0.0, 0.0, 2.0}, 0.3), rotationAbout(2, 0.3), 1e-15) {
+		t.Errorf("expected a rotation about z")
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		axis     []float64
+		expected string
+	}{
+		{[]float64{1.0, 0.0}, fmt.Sprintf("In mat.%s the axis must have 3 elements, but has %d.\n", "AxisAngle()", 2)},
+		{[]float64{0.0, 0.0, 0.0}, fmt.Sprintf("In mat.%s the axis cannot be zero.\n", "AxisAngle()")},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			AxisAngle(test.axis, 1.0)
+		}()
+		wg.Wait()
+	}
+}
+
+func TestEuler(t *testing.T) {
+	yaw, pitch, roll := 0.3, -0.2, 0.7
+	r := Euler(EulerZYX, yaw, pitch, roll)
+	e := Dot(Dot(AxisAngle([]float64{0.0, 0.0, 1.0}, yaw), AxisAngle([]float64{0.0, 1.0, 0.0}, pitch)), AxisAngle([]float64{1.0, 0.0, 0.0}, roll))
+	if !nearMat(r, e, 1e-15) {
+		t.Errorf("expected %v, got %v", e, r)
+	}
+	// A quarter turn about x, then about the new y, which is the old z.
+	r = Euler(EulerXYZ, math.Pi/2.0, math.Pi/2.0, 0.0)
+	if p := MulVec(r, []float64{1.0, 0.0, 0.0}); !nearMat([][]float64{p}, [][]float64{{0.0, 1.0, 0.0}}, 1e-15) {
+		t.Errorf("expected {0.0, 1.0, 0.0}, got %v", p)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		expected := fmt.Sprintf("In mat.%s the order %d is unknown.\n", "Euler()", 6)
+		defer func() {
+			if r := recover(); r != expected {
+				t.Errorf("Expected %s, got %v", expected, r)
+			}
+			wg.Done()
+		}()
+		Euler(EulerOrder(6), 0.0, 0.0, 0.0)
+	}()
+	wg.Wait()
+}
+
+func TestAffine(t *testing.T) {
+	a := Affine([][]float64{{1.0, 2.0}, {3.0, 4.0}}, []float64{5.0, 6.0})
+	if !Equal(a, [][]float64{{1.0, 2.0, 5.0}, {3.0, 4.0, 6.0}, {0.0, 0.0, 1.0}}) {
+		t.Errorf("expected {{1.0, 2.0, 5.0}, {3.0, 4.0, 6.0}, {0.0, 0.0, 1.0}}, got %v", a)
+	}
+	if tr := Translation([]float64{1.0, 2.0}); !Equal(tr, [][]float64{{1.0, 0.0, 1.0}, {0.0, 1.0, 2.0}, {0.0, 0.0, 1.0}}) {
+		t.Errorf("expected a translation by {1.0, 2.0}, got %v", tr)
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		f        func()
+		expected string
+	}{
+		{func() { Affine(I(2), []float64{1.0}) }, fmt.Sprintf("In mat.%s the translation has %d elements, while the linear part is %d by %d.\n", "Affine()", 1, 2, 2)},
+		{func() { Translation(nil) }, fmt.Sprintf("In mat.%s the translation cannot be empty.\n", "Translation()")},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestTransformPoints(t *testing.T) {
+	points := [][]float64{{1.0, 0.0}, {0.0, 1.0}, {2.0, 2.0}}
+	q := TransformPoints(Rotation2D(math.Pi/2.0), points)
+	if !nearMat(q, [][]float64{{0.0, 1.0}, {-1.0, 0.0}, {-2.0, 2.0}}, 1e-15) {
+		t.Errorf("expected the points rotated by a quarter turn, got %v", q)
+	}
+	// The rotation about {1.0, 2.0} leaves that point in place.
+	r := Affine(Rotation2D(math.Pi/2.0), []float64{0.0, 0.0})
+	m := Dot(Translation([]float64{1.0, 2.0}), Dot(r, Translation([]float64{-1.0, -2.0})))
+	q = TransformPoints(m, [][]float64{{1.0, 2.0}, {2.0, 2.0}})
+	if !nearMat(q, [][]float64{{1.0, 2.0}, {1.0, 3.0}}, 1e-15) {
+		t.Errorf("expected {{1.0, 2.0}, {1.0, 3.0}}, got %v", q)
+	}
+	if points[2][0] != 2.0 {
+		t.Errorf("expected the points not to be mutated")
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		m, points [][]float64
+		expected  string
+	}{
+		{I(2), [][]float64{}, fmt.Sprintf("In mat.%s the points cannot be empty.\n", "TransformPoints()")},
+		{I(2), [][]float64{{1.0, 2.0}, {1.0}}, fmt.Sprintf("In mat.%s the point %d has %d elements, which does not fit a %d by %d transform.\n", "TransformPoints()", 1, 1, 2, 2)},
+		{I(4), points, fmt.Sprintf("In mat.%s the point %d has %d elements, which does not fit a %d by %d transform.\n", "TransformPoints()", 0, 2, 4, 4)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			TransformPoints(test.m, test.points)
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,99 @@
+package mat
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// transposeBlock is the number of rows and columns of the blocks in which
+// transposes are copied, so that the rows and columns of a pair of blocks
+// stay in the cache while they are read and written.
+const transposeBlock = 32
+
+/*
+TransposeInPlace replaces a square [][]float64 by its transpose, and returns
+it, by swapping the elements above the diagonal with those below it, without
+allocating. For example:
+
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}}
+	mat.TransposeInPlace(m) // m is {{1.0, 3.0}, {2.0, 4.0}}
+
+The elements are swapped in square blocks, so that each pair of blocks is
+read and written while it is in the cache. The passed [][]float64 must be
+square and not empty, otherwise this function will panic.
+*/
+func TransposeInPlace(m [][]float64) [][]float64 {
+	checkSquare("TransposeInPlace()", m)
+	n := len(m)
+	for i0 := 0; i0 < n; i0 += transposeBlock {
+		i1 := min(i0+transposeBlock, n)
+		// The blocks on the diagonal are swapped with themselves, the others
+		// with their mirror across the diagonal.
+		for j0 := i0; j0 < n; j0 += transposeBlock {
+			j1 := min(j0+transposeBlock, n)
+			for i := i0; i < i1; i++ {
+				lo := j0
+				if j0 == i0 {
+					lo = i + 1
+				}
+				for j := lo; j < j1; j++ {
+					m[i][j], m[j][i] = m[j][i], m[i][j]
+				}
+			}
+		}
+	}
+	return m
+}
+
+/*
+TransposeTo stores the transpose of m in dst, and returns dst, without
+allocating. The elements are copied in square blocks, so that the columns of
+m, and the rows of dst, are walked while they are in the cache, which is
+several times faster than walking the columns of a large m one element at a
+time. For example:
+
+	dst := mat.New(3, 2)
+	mat.TransposeTo(dst, [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}})
+	// dst is {{1.0, 4.0}, {2.0, 5.0}, {3.0, 6.0}}
+
+m must not be empty or jagged, dst must have as many rows as m has columns,
+and as many columns as m has rows, otherwise this function will panic. dst
+must not share its elements with m. m is not mutated in this function.
+*/
+func TransposeTo(dst, m [][]float64) [][]float64 {
+	r := len(m)
+	c, err := shape(m)
+	if err != nil || r == 0 || c == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 cannot be empty or jagged.\n"
+		s = fmt.Sprintf(s, "TransposeTo()")
+		debug.PrintStack()
+		panic(s)
+	}
+	if dc, err := shape(dst); err != nil || len(dst) != c || dc != r {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 is %d by %d, so the destination must be %d by %d.\n"
+		s = fmt.Sprintf(s, "TransposeTo()", r, c, c, r)
+		debug.PrintStack()
+		panic(s)
+	}
+	transposeBlocks(dst, m)
+	return dst
+}
+
+// transposeBlocks stores the transpose of m in dst, one block at a time.
+func transposeBlocks(dst, m [][]float64) {
+	r, c := len(m), len(m[0])
+	for i0 := 0; i0 < r; i0 += transposeBlock {
+		i1 := min(i0+transposeBlock, r)
+		for j0 := 0; j0 < c; j0 += transposeBlock {
+			j1 := min(j0+transposeBlock, c)
+			for i := i0; i < i1; i++ {
+				row := m[i]
+				for j := j0; j < j1; j++ {
+					dst[j][i] = row[j]
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package mat
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestTransposeInPlace(t *testing.T) {
+	m := [][]float64{{1.0, 2.0}, {3.0, 4.0}}
+	if res := TransposeInPlace(m); &res[0][0] != &m[0][0] {
+		t.Errorf("expected TransposeInPlace() to return m")
+	}
+	if !Equal(m, [][]float64{{1.0, 3.0}, {2.0, 4.0}}) {
+		t.Errorf("expected {{1.0, 3.0}, {2.0, 4.0}}, got %v", m)
+	}
+	// Sizes around the block size cover the ragged blocks at the edges.
+	for _, n := range []int{1, 31, 32, 33, 70} {
+		m := Rand(n, n)
+		expected := T(m)
+		if !Equal(TransposeInPlace(m), expected) {
+			t.Errorf("%d by %d: expected the transpose of T()", n, n)
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf("In mat.%s the [][]float64 must be square, but row %d has %d elements,\n"+
+				"while there are %d rows.\n", "TransposeInPlace()", 0, 3, 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		TransposeInPlace(New(2, 3))
+	}()
+	wg.Wait()
+}
+
+func TestTransposeTo(t *testing.T) {
+	dst := New(3, 2)
+	res := TransposeTo(dst, [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}})
+	if &res[0][0] != &dst[0][0] || !Equal(dst, [][]float64{{1.0, 4.0}, {2.0, 5.0}, {3.0, 6.0}}) {
+		t.Errorf("expected {{1.0, 4.0}, {2.0, 5.0}, {3.0, 6.0}} in place, got %v", dst)
+	}
+	m := Rand(45, 100)
+	tr := TransposeTo(New(100, 45), m)
+	for i := range m {
+		for j := range m[i] {
+			if tr[j][i] != m[i][j] {
+				t.Fatalf("at (%d, %d), expected %v, got %v", j, i, m[i][j], tr[j][i])
+			}
+		}
+	}
+	if !Equal(T(m), tr) {
+		t.Errorf("expected T() to match TransposeTo()")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { TransposeTo(New(2, 2), [][]float64{{1.0, 2.0}, {3.0}}) },
+			fmt.Sprintf("In mat.%s the [][]float64 cannot be empty or jagged.\n", "TransposeTo()"),
+		},
+		{
+			func() { TransposeTo(New(2, 3), New(2, 3)) },
+			fmt.Sprintf("In mat.%s the [][]float64 is %d by %d, so the destination must be %d by %d.\n",
+				"TransposeTo()", 2, 3, 3, 2),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
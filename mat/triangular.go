@@ -0,0 +1,262 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+// ErrSingular is returned when a system of equations cannot be solved since
+// its matrix is singular. It is wrapped by an *errs.SingularError, holding
+// the row or column at which the matrix was found to be singular.
+var ErrSingular = errors.New("mat: the matrix is singular")
+
+// Half picks one of the two triangular halves of a square [][]float64, each
+// of which includes the diagonal.
+type Half int
+
+const (
+	// HalfLower is the diagonal and the elements below it.
+	HalfLower Half = iota
+	// HalfUpper is the diagonal and the elements above it.
+	HalfUpper
+)
+
+/*
+Triangular is a square lower or upper triangular matrix, which stores only
+the n*(n+1)/2 elements of its triangular half, about half of the memory of
+the equivalent [][]float64. The elements outside of the stored half are 0.0.
+*/
+type Triangular struct {
+	half Half
+	// rows[i] holds the elements of row i inside the half, which are the
+	// columns 0 to i for HalfLower, and i to n-1 for HalfUpper.
+	rows [][]float64
+}
+
+/*
+NewTriangular returns the Triangular made of one half of a square
+[][]float64, ignoring the elements of the other half. For example:
+
+	m := [][]float64{{2.0, 9.0}, {1.0, 4.0}}
+	t := mat.NewTriangular(m, mat.HalfLower) // t is {{2.0, 0.0}, {1.0, 4.0}}
+
+The passed [][]float64 must be square and not empty, otherwise this function
+will panic. The elements are copied, and m is not mutated in this function.
+*/
+func NewTriangular(m [][]float64, half Half) *Triangular {
+	checkSquare("NewTriangular()", m)
+	checkHalf("NewTriangular()", half)
+	t := newTriangular(len(m), half)
+	for i, r := range t.rows {
+		copy(r, m[i][t.first(i):])
+	}
+	return t
+}
+
+// newTriangular returns an n by n Triangular of zeros, whose rows share one
+// allocation.
+func newTriangular(n int, half Half) *Triangular {
+	t := &Triangular{half: half, rows: make([][]float64, n)}
+	data := make([]float64, n*(n+1)/2)
+	lo := 0
+	for i := range t.rows {
+		hi := lo + i + 1
+		if half == HalfUpper {
+			hi = lo + n - i
+		}
+		t.rows[i] = data[lo:hi:hi]
+		lo = hi
+	}
+	return t
+}
+
+// first returns the first column of row i inside the half of t.
+func (t *Triangular) first(i int) int {
+	if t.half == HalfUpper {
+		return i
+	}
+	return 0
+}
+
+// Size returns the number of rows, and columns, of t.
+func (t *Triangular) Size() int {
+	return len(t.rows)
+}
+
+// Half returns the half of t which holds its elements.
+func (t *Triangular) Half() Half {
+	return t.half
+}
+
+/*
+At returns the element of t at row i and column j, which is 0.0 outside of
+the half of t. The indices must be in [0, t.Size()), otherwise this function
+will panic.
+*/
+func (t *Triangular) At(i, j int) float64 {
+	checkAt("Triangular.At()", i, j, len(t.rows))
+	if (t.half == HalfLower && j > i) || (t.half == HalfUpper && j < i) {
+		return 0.0
+	}
+	return t.rows[i][j-t.first(i)]
+}
+
+/*
+Dense returns t as a [][]float64, with zeros outside of its half.
+*/
+func (t *Triangular) Dense() [][]float64 {
+	m := New(len(t.rows))
+	for i, r := range t.rows {
+		copy(m[i][t.first(i):], r)
+	}
+	return m
+}
+
+/*
+MulVec returns the product t * v, where v is taken as a column vector, using
+only the elements inside the half of t. The length of v must be t.Size(),
+otherwise this function will panic. v is not mutated in this function.
+*/
+func (t *Triangular) MulVec(v []float64) []float64 {
+	checkLen("Triangular.MulVec()", v, len(t.rows))
+	res := make([]float64, len(v))
+	for i, r := range t.rows {
+		f := t.first(i)
+		for j, x := range r {
+			res[i] += x * v[f+j]
+		}
+	}
+	return res
+}
+
+/*
+Solve returns the x for which t * x = b, by forward substitution for a lower
+triangular t, and back substitution for an upper triangular t. This takes
+about n*n operations, rather than the n*n*n of a general solver. For
+example:
+
+	t := mat.NewTriangular([][]float64{{2.0, 0.0}, {1.0, 4.0}}, mat.HalfLower)
+	x, err := t.Solve([]float64{2.0, 9.0}) // x is {1.0, 2.0}
+
+Solve returns an error wrapping ErrSingular if an element of the diagonal of
+t is 0.0. The length of b must be t.Size(), otherwise this function will
+panic. b is not mutated in this function.
+*/
+func (t *Triangular) Solve(b []float64) ([]float64, error) {
+	checkLen("Triangular.Solve()", b, len(t.rows))
+	x := make([]float64, len(b))
+	copy(x, b)
+	if err := t.solve(x, false); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+/*
+SolveMat returns the x for which t * x = b, where each column of b is a
+right hand side, as with Solve(). b must have t.Size() rows, and must not be
+empty or jagged, otherwise this function will panic. b is not mutated in
+this function.
+*/
+func (t *Triangular) SolveMat(b [][]float64) ([][]float64, error) {
+	checkRHS("Triangular.SolveMat()", b, len(t.rows))
+	return solveCols(b, func(x []float64) error { return t.solve(x, false) })
+}
+
+// solve overwrites x with the solution of op(t) * x = x, where op(t) is the
+// transpose of t if trans is true. The transposed systems are solved by
+// columns, so that the rows of t are still read in order.
+func (t *Triangular) solve(x []float64, trans bool) error {
+	n := len(t.rows)
+	diag := func(i int) (float64, error) {
+		d := t.rows[i][i-t.first(i)]
+		if d == 0.0 {
+			return 0.0, &errs.SingularError{Index: i, Err: fmt.Errorf("%w: the diagonal element %d is 0.0", ErrSingular, i)}
+		}
+		return d, nil
+	}
+	// A lower t and the transpose of an upper t are solved forward, the
+	// others backward.
+	forward := (t.half == HalfLower) != trans
+	for k := 0; k < n; k++ {
+		i := k
+		if !forward {
+			i = n - 1 - k
+		}
+		d, err := diag(i)
+		if err != nil {
+			return err
+		}
+		r, f := t.rows[i], t.first(i)
+		if !trans {
+			s := x[i]
+			for j, a := range r {
+				if f+j != i {
+					s -= a * x[f+j]
+				}
+			}
+			x[i] = s / d
+			continue
+		}
+		x[i] /= d
+		for j, a := range r {
+			if f+j != i {
+				x[f+j] -= a * x[i]
+			}
+		}
+	}
+	return nil
+}
+
+func checkSquare(fn string, m [][]float64) {
+	if len(m) == 0 || len(m[0]) == 0 {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 cannot be empty.\n"
+		s = fmt.Sprintf(s, fn)
+		debug.PrintStack()
+		panic(s)
+	}
+	for i := range m {
+		if len(m[i]) != len(m) {
+			fmt.Println("\ngocrunch/mat error.")
+			s := "In mat.%s the [][]float64 must be square, but row %d has %d elements,\n"
+			s += "while there are %d rows.\n"
+			s = fmt.Sprintf(s, fn, i, len(m[i]), len(m))
+			debug.PrintStack()
+			panic(s)
+		}
+	}
+}
+
+func checkHalf(fn string, half Half) {
+	if half != HalfLower && half != HalfUpper {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the half %d is unknown. It must be mat.HalfLower or mat.HalfUpper.\n"
+		s = fmt.Sprintf(s, fn, half)
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+func checkAt(fn string, i, j, n int) {
+	if i < 0 || i >= n || j < 0 || j >= n {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the element (%d, %d) is outside of bounds [0, %d).\n"
+		s = fmt.Sprintf(s, fn, i, j, n)
+		debug.PrintStack()
+		panic(s)
+	}
+}
+
+func checkLen(fn string, v []float64, n int) {
+	if len(v) != n {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the []float64 has %d elements, while the matrix has %d rows.\n"
+		s = fmt.Sprintf(s, fn, len(v), n)
+		debug.PrintStack()
+		panic(s)
+	}
+}
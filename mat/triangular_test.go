@@ -0,0 +1,94 @@
+package mat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestNewTriangular(t *testing.T) {
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	l := NewTriangular(m, HalfLower)
+	if !Equal(l.Dense(), [][]float64{{1.0, 0.0, 0.0}, {4.0, 5.0, 0.0}, {7.0, 8.0, 9.0}}) {
+		t.Errorf("expected the lower half of m, got %v", l.Dense())
+	}
+	u := NewTriangular(m, HalfUpper)
+	if !Equal(u.Dense(), [][]float64{{1.0, 2.0, 3.0}, {0.0, 5.0, 6.0}, {0.0, 0.0, 9.0}}) {
+		t.Errorf("expected the upper half of m, got %v", u.Dense())
+	}
+	if l.Size() != 3 || l.Half() != HalfLower || u.Half() != HalfUpper {
+		t.Errorf("expected a size of 3 and the passed halves")
+	}
+	if l.At(2, 1) != 8.0 || l.At(1, 2) != 0.0 || u.At(1, 2) != 6.0 || u.At(2, 1) != 0.0 {
+		t.Errorf("expected At() to return the elements of the halves")
+	}
+	m[2][1] = 80.0
+	if l.At(2, 1) != 8.0 {
+		t.Errorf("expected the elements to be copied")
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		f        func()
+		expected string
+	}{
+		{func() { NewTriangular([][]float64{{1.0, 2.0}}, HalfLower) }, fmt.Sprintf("In mat.%s the [][]float64 must be square, but row %d has %d elements,\nwhile there are %d rows.\n", "NewTriangular()", 0, 2, 1)},
+		{func() { NewTriangular(m, Half(2)) }, fmt.Sprintf("In mat.%s the half %d is unknown. It must be mat.HalfLower or mat.HalfUpper.\n", "NewTriangular()", 2)},
+		{func() { l.At(0, 3) }, fmt.Sprintf("In mat.%s the element (%d, %d) is outside of bounds [0, %d).\n", "Triangular.At()", 0, 3, 3)},
+		{func() { l.Solve([]float64{1.0}) }, fmt.Sprintf("In mat.%s the []float64 has %d elements, while the matrix has %d rows.\n", "Triangular.Solve()", 1, 3)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestTriangularSolve(t *testing.T) {
+	m := [][]float64{{2.0, -1.0, 3.0}, {1.0, 4.0, 0.5}, {-2.0, 3.0, 5.0}}
+	b := []float64{1.0, -2.0, 3.0}
+	for _, half := range []Half{HalfLower, HalfUpper} {
+		tr := NewTriangular(m, half)
+		x, err := tr.Solve(b)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		y := tr.MulVec(x)
+		d := MulVec(tr.Dense(), x)
+		for i := range b {
+			if math.Abs(y[i]-b[i]) > 1e-12 || math.Abs(d[i]-b[i]) > 1e-12 {
+				t.Errorf("half %d: expected t * x = %v, got %v and %v", half, b, y, d)
+				break
+			}
+		}
+		// The transposed systems are solved through the other half.
+		x = append([]float64(nil), b...)
+		if err := tr.solve(x, true); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		y = MulVecT(tr.Dense(), x)
+		for i := range b {
+			if math.Abs(y[i]-b[i]) > 1e-12 {
+				t.Errorf("half %d: expected T(t) * x = %v, got %v", half, b, y)
+				break
+			}
+		}
+	}
+	m[1][1] = 0.0
+	if _, err := NewTriangular(m, HalfUpper).Solve(b); !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+	if b[0] != 1.0 || b[1] != -2.0 || b[2] != 3.0 {
+		t.Errorf("expected b not to be mutated, got %v", b)
+	}
+}
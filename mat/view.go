@@ -0,0 +1,52 @@
+package mat
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+/*
+Slice returns a view of the rows r0 to r1, and the columns c0 to c1 of a
+[][]float64, where r1 and c1 are excluded, as with the slicing of Go
+slices. For example:
+
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	w := mat.Slice(m, 1, 3, 0, 2) // w is {{4.0, 5.0}, {7.0, 8.0}}
+
+The view shares its elements with m, so that setting an element of the view
+sets the element of m, and the other way around. Only the headers of the
+rows of the view are allocated, not their elements, which makes extracting a
+window of a large [][]float64 cheap. Since the view is itself a [][]float64,
+it can be passed to all the functions of this package. The capacity of each
+row of the view ends at c1, so appending to a row of the view copies it,
+rather than overwriting the elements of m.
+
+The ranges must satisfy 0 <= r0 <= r1 <= len(m) and 0 <= c0 <= c1 <=
+len(m[0]), and m must not be jagged, otherwise this function will panic.
+*/
+func Slice(m [][]float64, r0, r1, c0, c1 int) [][]float64 {
+	if _, err := shape(m); err != nil {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the [][]float64 cannot be jagged.\n"
+		s = fmt.Sprintf(s, "Slice()")
+		debug.PrintStack()
+		panic(s)
+	}
+	checkRange("Slice()", "rows", r0, r1, len(m))
+	checkRange("Slice()", "columns", c0, c1, rowLen(m))
+	v := make([][]float64, r1-r0)
+	for i := range v {
+		v[i] = m[r0+i][c0:c1:c1]
+	}
+	return v
+}
+
+func checkRange(fn, what string, lo, hi, n int) {
+	if lo < 0 || lo > hi || hi > n {
+		fmt.Println("\ngocrunch/mat error.")
+		s := "In mat.%s the %s [%d, %d) are outside of bounds [0, %d].\n"
+		s = fmt.Sprintf(s, fn, what, lo, hi, n)
+		debug.PrintStack()
+		panic(s)
+	}
+}
@@ -0,0 +1,66 @@
+package mat
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	m := [][]float64{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	w := Slice(m, 1, 3, 0, 2)
+	if !Equal(w, [][]float64{{4.0, 5.0}, {7.0, 8.0}}) {
+		t.Errorf("expected {{4.0, 5.0}, {7.0, 8.0}}, got %v", w)
+	}
+	w[0][1] = 50.0
+	if m[1][1] != 50.0 {
+		t.Errorf("expected the view to share the elements of m")
+	}
+	m[2][0] = 70.0
+	if w[1][0] != 70.0 {
+		t.Errorf("expected the view to see the changes of m")
+	}
+	w[0] = append(w[0], 60.0)
+	if m[1][2] != 6.0 {
+		t.Errorf("appending to a row of the view overwrote m")
+	}
+	if e := Slice(m, 1, 1, 0, 3); len(e) != 0 {
+		t.Errorf("expected an empty view, got %v", e)
+	}
+	if s := Sum(Slice(m, 0, 2, 1, 3)); s != 2.0+3.0+50.0+6.0 {
+		t.Errorf("expected the view to work with the functions of the package, got %v", s)
+	}
+	var wg sync.WaitGroup
+	for _, test := range []struct {
+		r0, r1, c0, c1 int
+		expected       string
+	}{
+		{-1, 2, 0, 1, fmt.Sprintf("In mat.%s the %s [%d, %d) are outside of bounds [0, %d].\n", "Slice()", "rows", -1, 2, 3)},
+		{2, 1, 0, 1, fmt.Sprintf("In mat.%s the %s [%d, %d) are outside of bounds [0, %d].\n", "Slice()", "rows", 2, 1, 3)},
+		{0, 1, 1, 4, fmt.Sprintf("In mat.%s the %s [%d, %d) are outside of bounds [0, %d].\n", "Slice()", "columns", 1, 4, 3)},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			Slice(m, test.r0, test.r1, test.c0, test.c1)
+		}()
+		wg.Wait()
+	}
+	wg.Add(1)
+	go func() {
+		expected := fmt.Sprintf("In mat.%s the [][]float64 cannot be jagged.\n", "Slice()")
+		defer func() {
+			if r := recover(); r != expected {
+				t.Errorf("Expected %s, got %v", expected, r)
+			}
+			wg.Done()
+		}()
+		Slice([][]float64{{1.0, 2.0}, {3.0}}, 0, 2, 0, 2)
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,210 @@
+/*
+Package metric implements distances between vectors stored as []float64.
+
+Every distance in this package has the signature of a Func, and so can be
+passed wherever a distance is expected:
+
+	d, err := metric.Euclidean([]float64{0, 0}, []float64{3, 4}) // 5
+
+metric.PDist() and metric.CDist() compute the distances between all pairs of
+rows of one or two matrices, spreading the work over several goroutines.
+
+Unlike most of gocrunch, where invalid input is a critical error which causes
+a panic, the distances in this package report invalid input, such as vectors
+of different lengths, by returning an error. This allows them to be applied
+to data that has not been validated beforehand, such as rows read from a file.
+The returned errors wrap one of the sentinel errors of this package, and can
+be checked with errors.Is().
+*/
+package metric
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+var (
+	// ErrLength is returned when two vectors do not have the same length. It
+	// is wrapped by an *errs.ShapeError holding the lengths.
+	ErrLength = errors.New("metric: the vectors have different lengths")
+	// ErrZeroVector is returned by Cosine when one of the vectors has a
+	// norm of zero, so that the angle between the vectors is undefined.
+	ErrZeroVector = errors.New("metric: the vector has a norm of zero")
+	// ErrOrder is returned by Minkowski when the order is less than 1, for
+	// which the Minkowski distance is not a metric.
+	ErrOrder = errors.New("metric: the order must be at least 1")
+)
+
+// Func is a distance between two vectors.
+type Func func(a, b []float64) (float64, error)
+
+/*
+Euclidean returns the euclidean distance between a and b, which is the square
+root of the sum of the squared differences of their elements.
+*/
+func Euclidean(a, b []float64) (float64, error) {
+	if err := check(a, b); err != nil {
+		return math.NaN(), err
+	}
+	// Scaling by the largest difference avoids overflow and underflow for
+	// very large and very small differences.
+	scale, sum := 0.0, 1.0
+	for i := range a {
+		d := math.Abs(a[i] - b[i])
+		if d == 0.0 {
+			continue
+		}
+		if scale < d {
+			sum = 1.0 + sum*(scale/d)*(scale/d)
+			scale = d
+		} else {
+			sum += (d / scale) * (d / scale)
+		}
+	}
+	if scale == 0.0 {
+		return 0.0, nil
+	}
+	return scale * math.Sqrt(sum), nil
+}
+
+/*
+SqEuclidean returns the squared euclidean distance between a and b, which is
+the sum of the squared differences of their elements.
+*/
+func SqEuclidean(a, b []float64) (float64, error) {
+	if err := check(a, b); err != nil {
+		return math.NaN(), err
+	}
+	sum := 0.0
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum, nil
+}
+
+/*
+Manhattan returns the manhattan, or city block, distance between a and b,
+which is the sum of the absolute differences of their elements.
+*/
+func Manhattan(a, b []float64) (float64, error) {
+	if err := check(a, b); err != nil {
+		return math.NaN(), err
+	}
+	sum := 0.0
+	for i := range a {
+		sum += math.Abs(a[i] - b[i])
+	}
+	return sum, nil
+}
+
+/*
+Chebyshev returns the chebyshev distance between a and b, which is the largest
+absolute difference of their elements.
+*/
+func Chebyshev(a, b []float64) (float64, error) {
+	if err := check(a, b); err != nil {
+		return math.NaN(), err
+	}
+	max := 0.0
+	for i := range a {
+		if d := math.Abs(a[i] - b[i]); d > max || math.IsNaN(d) {
+			max = d
+		}
+	}
+	return max, nil
+}
+
+/*
+Minkowski returns the minkowski distance of order p between a and b, which is
+the p-th root of the sum of the absolute differences of their elements raised
+to the power p. An order of 1 is the manhattan distance, an order of 2 the
+euclidean distance, and an infinite order the chebyshev distance. p must be
+at least 1, otherwise ErrOrder is returned.
+
+To use a minkowski distance as a Func, wrap it in a closure:
+
+	cubic := func(a, b []float64) (float64, error) {
+		return metric.Minkowski(a, b, 3)
+	}
+*/
+func Minkowski(a, b []float64, p float64) (float64, error) {
+	if err := check(a, b); err != nil {
+		return math.NaN(), err
+	}
+	if !(p >= 1.0) {
+		return math.NaN(), fmt.Errorf("%w: received %v", ErrOrder, p)
+	}
+	switch {
+	case p == 1.0:
+		return Manhattan(a, b)
+	case p == 2.0:
+		return Euclidean(a, b)
+	case math.IsInf(p, 1):
+		return Chebyshev(a, b)
+	}
+	// As in Euclidean, the differences are scaled by the largest one.
+	max, _ := Chebyshev(a, b)
+	if max == 0.0 || math.IsInf(max, 1) || math.IsNaN(max) {
+		return max, nil
+	}
+	sum := 0.0
+	for i := range a {
+		sum += math.Pow(math.Abs(a[i]-b[i])/max, p)
+	}
+	return max * math.Pow(sum, 1.0/p), nil
+}
+
+/*
+Cosine returns the cosine distance between a and b, which is 1 minus the
+cosine of the angle between them. It is 0.0 for vectors pointing in the same
+direction, 1.0 for orthogonal vectors, and 2.0 for vectors pointing in
+opposite directions. If either vector has a norm of zero, ErrZeroVector is
+returned.
+*/
+func Cosine(a, b []float64) (float64, error) {
+	if err := check(a, b); err != nil {
+		return math.NaN(), err
+	}
+	dot, na, nb := 0.0, 0.0, 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0.0 || nb == 0.0 {
+		return math.NaN(), ErrZeroVector
+	}
+	cos := dot / (math.Sqrt(na) * math.Sqrt(nb))
+	// Rounding can push the cosine slightly outside of [-1, 1].
+	cos = math.Max(-1.0, math.Min(1.0, cos))
+	return 1.0 - cos, nil
+}
+
+/*
+Hamming returns the hamming distance between a and b, which is the number of
+positions at which their elements differ. The count is returned as a float64,
+so that Hamming is a Func.
+*/
+func Hamming(a, b []float64) (float64, error) {
+	if err := check(a, b); err != nil {
+		return math.NaN(), err
+	}
+	count := 0
+	for i := range a {
+		if a[i] != b[i] {
+			count++
+		}
+	}
+	return float64(count), nil
+}
+
+func check(a, b []float64) error {
+	if len(a) != len(b) {
+		return &errs.ShapeError{Got: []int{len(b)}, Want: []int{len(a)}, Err: fmt.Errorf("%w: %d and %d", ErrLength, len(a), len(b))}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package metric
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+func TestDistances(t *testing.T) {
+	a := []float64{1.0, -2.0, 3.0, 0.5}
+	b := []float64{4.0, 2.0, 3.0, -0.5}
+	cases := []struct {
+		name     string
+		f        Func
+		expected float64
+	}{
+		{"Euclidean", Euclidean, math.Sqrt(26.0)},
+		{"SqEuclidean", SqEuclidean, 26.0},
+		{"Manhattan", Manhattan, 8.0},
+		{"Chebyshev", Chebyshev, 4.0},
+		{"Minkowski", func(a, b []float64) (float64, error) { return Minkowski(a, b, 3) }, math.Cbrt(92.0)},
+		{"Minkowski1", func(a, b []float64) (float64, error) { return Minkowski(a, b, 1) }, 8.0},
+		{"MinkowskiInf", func(a, b []float64) (float64, error) { return Minkowski(a, b, math.Inf(1)) }, 4.0},
+		{"Cosine", Cosine, 1.0 - (4.0-4.0+9.0-0.25)/(math.Sqrt(14.25)*math.Sqrt(29.25))},
+		{"Hamming", Hamming, 3.0},
+	}
+	for _, c := range cases {
+		d, err := c.f(a, b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if math.Abs(d-c.expected) > 1e-12 {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, d)
+		}
+		if d, _ := c.f(a, a); d != 0.0 {
+			t.Errorf("%s: expected a distance of 0.0 to itself, got %v", c.name, d)
+		}
+		if _, err := c.f(a, b[:3]); !errors.Is(err, ErrLength) {
+			t.Errorf("%s: expected ErrLength, got %v", c.name, err)
+		}
+	}
+}
+
+func TestEuclideanScale(t *testing.T) {
+	d, _ := Euclidean([]float64{3e200, 0.0}, []float64{0.0, 4e200})
+	if math.Abs(d-5e200) > 1e188 {
+		t.Errorf("expected 5e200, got %v", d)
+	}
+	d, _ = Euclidean([]float64{3e-200}, []float64{-1e-200})
+	if math.Abs(d-4e-200) > 1e-212 {
+		t.Errorf("expected 4e-200, got %v", d)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := Cosine([]float64{0.0, 0.0}, []float64{1.0, 2.0}); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if _, err := Minkowski([]float64{1.0}, []float64{2.0}, 0.5); !errors.Is(err, ErrOrder) {
+		t.Errorf("expected ErrOrder, got %v", err)
+	}
+	if d, err := Cosine([]float64{1.0, 1.0}, []float64{-2.0, -2.0}); err != nil || math.Abs(d-2.0) > 1e-15 {
+		t.Errorf("expected 2.0, got %v and %v", d, err)
+	}
+	_, err := Euclidean([]float64{1.0, 2.0}, []float64{1.0})
+	var se *errs.ShapeError
+	if !errors.Is(err, ErrLength) || !errors.As(err, &se) || se.Got[0] != 1 || se.Want[0] != 2 {
+		t.Errorf("expected an *errs.ShapeError of lengths 1 and 2 wrapping ErrLength, got %v", err)
+	}
+}
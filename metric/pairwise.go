@@ -0,0 +1,213 @@
+package metric
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/NDari/gocrunch/errs"
+	"github.com/NDari/gocrunch/parallel"
+)
+
+// blockSize is the number of rows in each side of the tiles of the distance
+// matrix that are computed together, so that the rows of a tile stay in
+// cache while the tile is filled.
+const blockSize = 64
+
+/*
+PDist returns the matrix of the distances d between every pair of rows of X,
+such that the element at [i][j] is the distance between X[i] and X[j]. The
+distance matrix is symmetric, and only one distance of each pair is computed.
+The diagonal is set to 0.0 without calling d.
+
+The distances are computed in tiles of rows, which are spread over up to
+GOMAXPROCS goroutines for large X. All the rows of X must have the same
+length, otherwise ErrLength is returned. If d returns an error, the first
+error in row major order is returned, and the returned matrix is nil.
+*/
+func PDist(X [][]float64, d Func) ([][]float64, error) {
+	if err := checkRows(X, nil); err != nil {
+		return nil, err
+	}
+	n := len(X)
+	res := make([][]float64, n)
+	for i := range res {
+		res[i] = make([]float64, n)
+	}
+	var tiles []tile
+	for i := 0; i < n; i += blockSize {
+		for j := i; j < n; j += blockSize {
+			tiles = append(tiles, tile{i, j})
+		}
+	}
+	err := run(tiles, n*(n-1)/2*width(X), func(t tile) *cellError {
+		for i := t.row; i < t.row+blockSize && i < n; i++ {
+			j0 := t.col
+			if j0 <= i {
+				j0 = i + 1
+			}
+			for j := j0; j < t.col+blockSize && j < n; j++ {
+				v, err := d(X[i], X[j])
+				if err != nil {
+					return &cellError{i, j, err}
+				}
+				res[i][j], res[j][i] = v, v
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+/*
+CDist returns the matrix of the distances d between each row of X and each row
+of Y, such that the element at [i][j] is the distance between X[i] and Y[j].
+See PDist() for details on how the distances are computed.
+
+All the rows of X and Y must have the same length, otherwise ErrLength is
+returned. If d returns an error, the first error in row major order is
+returned, and the returned matrix is nil.
+*/
+func CDist(X, Y [][]float64, d Func) ([][]float64, error) {
+	if err := checkRows(X, Y); err != nil {
+		return nil, err
+	}
+	n, m := len(X), len(Y)
+	res := make([][]float64, n)
+	for i := range res {
+		res[i] = make([]float64, m)
+	}
+	var tiles []tile
+	for i := 0; i < n; i += blockSize {
+		for j := 0; j < m; j += blockSize {
+			tiles = append(tiles, tile{i, j})
+		}
+	}
+	err := run(tiles, n*m*width(X), func(t tile) *cellError {
+		for i := t.row; i < t.row+blockSize && i < n; i++ {
+			for j := t.col; j < t.col+blockSize && j < m; j++ {
+				v, err := d(X[i], Y[j])
+				if err != nil {
+					return &cellError{i, j, err}
+				}
+				res[i][j] = v
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// tile is the square block of a distance matrix whose top left element is
+// at [row][col].
+type tile struct {
+	row, col int
+}
+
+// cellError is the error of the distance between the rows i and j.
+type cellError struct {
+	i, j int
+	err  error
+}
+
+func (e *cellError) Error() string {
+	return fmt.Sprintf("rows %d and %d: %v", e.i, e.j, e.err)
+}
+
+func (e *cellError) Unwrap() error {
+	return e.err
+}
+
+// before reports whether e comes before f in row major order, where a nil
+// error comes after all others.
+func (e *cellError) before(f *cellError) bool {
+	return f == nil || (e != nil && (e.i < f.i || (e.i == f.i && e.j < f.j)))
+}
+
+// run calls fill on each of the tiles, which are ordered by their first
+// row, spreading them over several goroutines when the work of the
+// distances, their number times the length of the rows, reaches
+// parallel.Threshold(). It returns the first error in row major order,
+// whatever the number of goroutines, and skips the tiles which start below
+// the row of an error, since they can only fail later.
+func run(tiles []tile, work int, fill func(tile) *cellError) error {
+	var first *cellError
+	workers := runtime.GOMAXPROCS(0)
+	if work < parallel.Threshold() || workers < 2 {
+		for _, t := range tiles {
+			if first != nil && t.row > first.i {
+				break
+			}
+			if err := fill(t); err.before(first) {
+				first = err
+			}
+		}
+	} else {
+		failed := make([]*cellError, len(tiles))
+		workers = int(math.Min(float64(workers), float64(len(tiles))))
+		next := make(chan int)
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for w := 0; w < workers; w++ {
+			go func() {
+				defer wg.Done()
+				for k := range next {
+					failed[k] = fill(tiles[k])
+				}
+			}()
+		}
+		for k := range tiles {
+			next <- k
+		}
+		close(next)
+		wg.Wait()
+		for _, err := range failed {
+			if err.before(first) {
+				first = err
+			}
+		}
+	}
+	if first != nil {
+		return first
+	}
+	return nil
+}
+
+// width returns the length of the rows of X, or 0 if X is empty.
+func width(X [][]float64) int {
+	if len(X) == 0 {
+		return 0
+	}
+	return len(X[0])
+}
+
+// checkRows returns ErrLength if the rows of X, and of Y when it is not
+// nil, do not all have the same length.
+func checkRows(X, Y [][]float64) error {
+	// want is the length of the first row, or -1 before it is seen, so
+	// that a nil or empty first row is checked as any other.
+	want := -1
+	for _, rows := range [][][]float64{X, Y} {
+		for i := range rows {
+			if want < 0 {
+				want = len(rows[i])
+				continue
+			}
+			if len(rows[i]) != want {
+				return &errs.ShapeError{
+					Got:  []int{len(rows[i])},
+					Want: []int{want},
+					Err:  fmt.Errorf("%w: row %d has length %d, expected %d", ErrLength, i, len(rows[i]), want),
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package metric
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/NDari/gocrunch/parallel"
+)
+
+func randomRows(n, dim int, r *rand.Rand) [][]float64 {
+	rows := make([][]float64, n)
+	for i := range rows {
+		rows[i] = make([]float64, dim)
+		for j := range rows[i] {
+			rows[i][j] = r.NormFloat64()
+		}
+	}
+	return rows
+}
+
+func TestPDist(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	// 150 rows span several tiles, which run in parallel with a low
+	// threshold.
+	parallel.SetThreshold(1)
+	defer parallel.SetThreshold(0)
+	for _, n := range []int{0, 1, 5, 150} {
+		X := randomRows(n, 4, r)
+		D, err := PDist(X, Euclidean)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(D) != n {
+			t.Fatalf("expected %d rows, got %d", n, len(D))
+		}
+		for i := range X {
+			for j := range X {
+				e, _ := Euclidean(X[i], X[j])
+				if i == j {
+					e = 0.0
+				}
+				if math.Abs(D[i][j]-e) > 1e-12 {
+					t.Fatalf("n = %d, at [%d][%d], expected %v, got %v", n, i, j, e, D[i][j])
+				}
+			}
+		}
+	}
+	X := randomRows(100, 3, r)
+	X[70] = []float64{1.0, 2.0}
+	if _, err := PDist(X, Euclidean); !errors.Is(err, ErrLength) {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	X = randomRows(100, 3, r)
+	X[40] = []float64{0.0, 0.0, 0.0}
+	if _, err := PDist(X, Cosine); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+}
+
+func TestCDist(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for _, size := range [][2]int{{0, 3}, {3, 7}, {130, 70}} {
+		X, Y := randomRows(size[0], 5, r), randomRows(size[1], 5, r)
+		D, err := CDist(X, Y, Manhattan)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i := range X {
+			for j := range Y {
+				e, _ := Manhattan(X[i], Y[j])
+				if D[i][j] != e {
+					t.Fatalf("at [%d][%d], expected %v, got %v", i, j, e, D[i][j])
+				}
+			}
+		}
+	}
+	X, Y := randomRows(3, 5, r), randomRows(3, 4, r)
+	if _, err := CDist(X, Y, Manhattan); !errors.Is(err, ErrLength) {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	// A nil first row is checked like any other.
+	if _, err := CDist([][]float64{nil, {1.0}}, [][]float64{{1.0}}, Manhattan); !errors.Is(err, ErrLength) {
+		t.Errorf("expected ErrLength for a nil first row, got %v", err)
+	}
+	// The first tile fails at rows 10 and 0, but the first error in row
+	// major order is at rows 0 and 100, in a later tile.
+	X, Y = randomRows(130, 3, r), randomRows(130, 3, r)
+	X[10], Y[100] = []float64{0.0, 0.0, 0.0}, []float64{0.0, 0.0, 0.0}
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	for _, threshold := range []int{0, 1} {
+		parallel.SetThreshold(threshold)
+		for _, procs := range []int{1, 2, 5} {
+			runtime.GOMAXPROCS(procs)
+			_, err := CDist(X, Y, Cosine)
+			if !errors.Is(err, ErrZeroVector) || !strings.HasPrefix(err.Error(), "rows 0 and 100: ") {
+				t.Errorf("with %d procs, expected the error of rows 0 and 100, got %v", procs, err)
+			}
+		}
+	}
+	parallel.SetThreshold(0)
+}
+
+func BenchmarkPDist(b *testing.B) {
+	X := randomRows(1000, 16, rand.New(rand.NewSource(1)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		PDist(X, SqEuclidean)
+	}
+}
@@ -0,0 +1,499 @@
+/*
+Package ode implements the numerical integration of systems of ordinary
+differential equations of the form
+
+	dy/dt = f(t, y)
+
+where the state y is a []float64. The system is described by a Func, which
+stores the derivative of the state in its last argument:
+
+	// A harmonic oscillator, with y[0] the position and y[1] the velocity.
+	f := func(t float64, y, dydt []float64) {
+		dydt[0] = y[1]
+		dydt[1] = -y[0]
+	}
+
+ode.RK4() integrates a system with a fixed step size, while
+ode.DormandPrince() adapts the step size to reach a requested accuracy, and
+returns a Solution which can be evaluated at any time in the integration
+interval, and which records the events it was asked to look for:
+
+	sol, err := ode.DormandPrince(f, []float64{1, 0}, 0, 10, nil)
+	y := sol.At(2.5)
+
+Invalid arguments, such as a nil Func, are treated as critical errors, and
+cause a panic with a message that names the offending function, as with the
+other packages in gocrunch. Failing to integrate a valid system, for example
+because it is too stiff, is reported by an error value.
+*/
+package ode
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/ode error.\nIn ode.%s, the Func cannot be nil.\n",
+		"\ngocrunch/ode error.\nIn ode.%s, the initial state cannot be empty.\n",
+		"\ngocrunch/ode error.\nIn ode.%s, the number of steps must be greater than 0, received %d.\n",
+		"\ngocrunch/ode error.\nIn ode.%s, the time %g is outside of the solution interval [%g, %g].\n",
+	}
+)
+
+var (
+	// ErrMaxSteps is returned when the integration does not reach the final
+	// time within the maximum number of steps.
+	ErrMaxSteps = errors.New("ode: maximum number of steps reached")
+	// ErrStepSize is returned when the step size needed to reach the
+	// requested accuracy becomes too small to make progress, which usually
+	// means that the system is stiff or singular.
+	ErrStepSize = errors.New("ode: the step size became too small")
+)
+
+// Func stores the derivative of the state y at time t in dydt, which has the
+// same length as y. It must not modify y.
+type Func func(t float64, y, dydt []float64)
+
+/*
+RK4 integrates the system f from t0 to t1 in the passed number of equal steps,
+using the classic fourth order Runge-Kutta method, starting from the state y0.
+It returns the times of the steps, including both t0 and t1, and the state at
+each of these times.
+
+steps must be greater than 0, otherwise this function will panic. The passed
+y0 is not modified in this function.
+*/
+func RK4(f Func, y0 []float64, t0, t1 float64, steps int) ([]float64, [][]float64) {
+	if f == nil {
+		panic(fmt.Sprintf(errStrings[0], "RK4()"))
+	}
+	if len(y0) == 0 {
+		panic(fmt.Sprintf(errStrings[1], "RK4()"))
+	}
+	if steps <= 0 {
+		panic(fmt.Sprintf(errStrings[2], "RK4()", steps))
+	}
+	n := len(y0)
+	h := (t1 - t0) / float64(steps)
+	ts := make([]float64, steps+1)
+	ys := make([][]float64, steps+1)
+	ts[0], ys[0] = t0, clone(y0)
+	k1, k2, k3, k4 := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	tmp := make([]float64, n)
+	for s := 1; s <= steps; s++ {
+		t, y := ts[s-1], ys[s-1]
+		f(t, y, k1)
+		for i := range tmp {
+			tmp[i] = y[i] + 0.5*h*k1[i]
+		}
+		f(t+0.5*h, tmp, k2)
+		for i := range tmp {
+			tmp[i] = y[i] + 0.5*h*k2[i]
+		}
+		f(t+0.5*h, tmp, k3)
+		for i := range tmp {
+			tmp[i] = y[i] + h*k3[i]
+		}
+		f(t+h, tmp, k4)
+		next := make([]float64, n)
+		for i := range next {
+			next[i] = y[i] + h/6.0*(k1[i]+2.0*k2[i]+2.0*k3[i]+k4[i])
+		}
+		ts[s], ys[s] = t0+float64(s)*h, next
+	}
+	ts[steps] = t1
+	return ts, ys
+}
+
+/*
+Event describes a condition to look for during the integration, which occurs
+when the function Func crosses zero.
+*/
+type Event struct {
+	// Func returns a value which changes sign when the event occurs.
+	Func func(t float64, y []float64) float64
+	// Direction restricts the crossings that count as the event: a positive
+	// Direction only detects crossings from negative to positive, a negative
+	// Direction only those from positive to negative, and 0 detects both.
+	Direction int
+	// Terminal stops the integration at the first occurrence of the event.
+	Terminal bool
+}
+
+// EventHit records an occurrence of an event.
+type EventHit struct {
+	// Index is the index of the event in Settings.Events.
+	Index int
+	// T is the time of the event, and Y the state at that time.
+	T float64
+	Y []float64
+}
+
+/*
+Settings configures ode.DormandPrince(). The zero value of each field is
+replaced by the default mentioned in its description.
+*/
+type Settings struct {
+	// AbsTol and RelTol control the accuracy of each step: the estimated
+	// error of each element of the state is kept below
+	// AbsTol + RelTol*|y[i]|. They are 1e-8 and 1e-6 by default.
+	AbsTol, RelTol float64
+	// InitialStep is the size of the first step attempted, which is chosen
+	// automatically by default.
+	InitialStep float64
+	// MaxStep is the largest step size allowed, unbounded by default.
+	MaxStep float64
+	// MaxSteps is the maximum number of accepted steps, 100000 by default.
+	MaxSteps int
+	// Events are the events to look for during the integration.
+	Events []Event
+}
+
+/*
+Solution is the result of an adaptive integration. It holds the state at the
+end of each accepted step, along with what is needed to evaluate the state at
+any time in between.
+*/
+type Solution struct {
+	// T holds the times of the accepted steps, starting with the initial
+	// time, and Y the state at each of these times.
+	T []float64
+	Y [][]float64
+	// Events holds the occurrences of the events in Settings.Events, in
+	// the order they occurred.
+	Events []EventHit
+	// q holds, for each step, the coefficients of the interpolating
+	// polynomial in powers of the fraction of the step, and h the size of
+	// the step they were computed for, which is longer than the interval
+	// between the last two times if a terminal event cut the step short.
+	q [][][4]float64
+	h []float64
+}
+
+/*
+At returns the state of the Solution at time t, interpolated with the fourth
+order continuous extension of the Dormand-Prince method. t must be within the
+interval covered by the Solution, otherwise this function will panic.
+*/
+func (s *Solution) At(t float64) []float64 {
+	first, last := s.T[0], s.T[len(s.T)-1]
+	lo, hi := math.Min(first, last), math.Max(first, last)
+	if t < lo || t > hi {
+		panic(fmt.Sprintf(errStrings[3], "At()", t, lo, hi))
+	}
+	if len(s.T) == 1 {
+		return clone(s.Y[0])
+	}
+	forward := last > first
+	i := sort.Search(len(s.T)-1, func(i int) bool {
+		if forward {
+			return s.T[i+1] >= t
+		}
+		return s.T[i+1] <= t
+	})
+	if i >= len(s.q) {
+		i = len(s.q) - 1
+	}
+	return dense(s.T[i], s.h[i], s.Y[i], s.q[i], t)
+}
+
+// The Dormand-Prince 5(4) coefficients, and those of its continuous
+// extension.
+var (
+	dpC = [7]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1, 1}
+	dpA = [7][6]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+		{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84},
+	}
+	dpE = [7]float64{-71.0 / 57600, 0, 71.0 / 16695, -71.0 / 1920, 17253.0 / 339200, -22.0 / 525, 1.0 / 40}
+	dpP = [7][4]float64{
+		{1, -8048581381.0 / 2820520608, 8663915743.0 / 2820520608, -12715105075.0 / 11282082432},
+		{0, 0, 0, 0},
+		{0, 131558114200.0 / 32700410799, -68118460800.0 / 10900136933, 87487479700.0 / 32700410799},
+		{0, -1754552775.0 / 470086768, 14199869525.0 / 1410260304, -10690763975.0 / 1880347072},
+		{0, 127303824393.0 / 49829197408, -318862633887.0 / 49829197408, 701980252875.0 / 199316789632},
+		{0, -282668133.0 / 205662961, 2019193451.0 / 616988883, -1453857185.0 / 822651844},
+		{0, 40617522.0 / 29380423, -110615467.0 / 29380423, 69997945.0 / 29380423},
+	}
+)
+
+/*
+DormandPrince integrates the system f from t0 to t1, starting from the state
+y0, using the adaptive Dormand-Prince 5(4) method. The step size is adjusted
+at each step to keep the estimated error within the tolerances in the passed
+Settings. t1 may be less than t0, in which case the system is integrated
+backwards in time.
+
+If one of the events in the passed Settings is terminal, the integration stops
+at its first occurrence, and the returned Solution ends there. If the
+integration cannot reach t1, ErrMaxSteps or ErrStepSize is returned, along
+with the Solution up to the point that was reached. A nil Settings uses the
+defaults of all its fields. The passed y0 is not modified in this function.
+*/
+func DormandPrince(f Func, y0 []float64, t0, t1 float64, s *Settings) (*Solution, error) {
+	if f == nil {
+		panic(fmt.Sprintf(errStrings[0], "DormandPrince()"))
+	}
+	if len(y0) == 0 {
+		panic(fmt.Sprintf(errStrings[1], "DormandPrince()"))
+	}
+	set := defaults(s)
+	n := len(y0)
+	sol := &Solution{T: []float64{t0}, Y: [][]float64{clone(y0)}}
+	if t0 == t1 {
+		return sol, nil
+	}
+	dir := 1.0
+	if t1 < t0 {
+		dir = -1.0
+	}
+	k := make([][]float64, 7)
+	for i := range k {
+		k[i] = make([]float64, n)
+	}
+	tmp := make([]float64, n)
+	t, y := t0, clone(y0)
+	f(t, y, k[0])
+	h := set.InitialStep
+	if h == 0.0 {
+		h = initialStep(f, t, y, k[0], dir, set)
+	}
+	h = math.Min(math.Abs(h), set.MaxStep)
+	g := make([]float64, len(set.Events))
+	for i, e := range set.Events {
+		g[i] = e.Func(t, y)
+	}
+	for steps := 0; ; {
+		if steps >= set.MaxSteps {
+			return sol, fmt.Errorf("%w: stopped at t = %g after %d steps", ErrMaxSteps, t, steps)
+		}
+		minStep := 10.0 * math.Abs(math.Nextafter(t, dir*math.Inf(1))-t)
+		if h < minStep {
+			return sol, fmt.Errorf("%w: %g at t = %g", ErrStepSize, h, t)
+		}
+		if dir*(t+dir*h-t1) > 0.0 {
+			h = math.Abs(t1 - t)
+		}
+		hs := dir * h
+		for st := 1; st < 7; st++ {
+			for i := range tmp {
+				sum := 0.0
+				for j := 0; j < st; j++ {
+					sum += dpA[st][j] * k[j][i]
+				}
+				tmp[i] = y[i] + hs*sum
+			}
+			f(t+dpC[st]*hs, tmp, k[st])
+		}
+		// tmp now holds the fifth order solution at t + hs.
+		errNorm := 0.0
+		for i := range y {
+			e := 0.0
+			for j := range dpE {
+				e += dpE[j] * k[j][i]
+			}
+			scale := set.AbsTol + set.RelTol*math.Max(math.Abs(y[i]), math.Abs(tmp[i]))
+			r := hs * e / scale
+			errNorm += r * r
+		}
+		errNorm = math.Sqrt(errNorm / float64(n))
+		if math.IsNaN(errNorm) || errNorm > 1.0 {
+			factor := 0.2
+			if !math.IsNaN(errNorm) {
+				factor = math.Max(0.2, 0.9*math.Pow(errNorm, -0.2))
+			}
+			h *= factor
+			continue
+		}
+		steps++
+		q := make([][4]float64, n)
+		for i := range q {
+			for j := range dpP {
+				for m := 0; m < 4; m++ {
+					q[i][m] += k[j][i] * dpP[j][m]
+				}
+			}
+		}
+		tNew := t + hs
+		if tNew == t1 || dir*(tNew-t1) > 0.0 {
+			tNew = t1
+		}
+		yNew := clone(tmp)
+		sol.q = append(sol.q, q)
+		sol.h = append(sol.h, hs)
+		sol.T = append(sol.T, tNew)
+		sol.Y = append(sol.Y, yNew)
+		if stop := sol.findEvents(set.Events, g, t, hs, y, q, tNew, yNew); stop {
+			return sol, nil
+		}
+		if tNew == t1 {
+			return sol, nil
+		}
+		t, y = tNew, yNew
+		// The last stage is evaluated at the new point, so it is the first
+		// stage of the next step.
+		k[0], k[6] = k[6], k[0]
+		factor := 10.0
+		if errNorm > 0.0 {
+			factor = math.Min(10.0, 0.9*math.Pow(errNorm, -0.2))
+		}
+		h = math.Min(h*factor, set.MaxStep)
+	}
+}
+
+// findEvents records the events which occur in the step from t to tNew, and
+// updates g to the event values at tNew. If a terminal event occurred, the
+// Solution is truncated at the first such event, and true is returned.
+func (s *Solution) findEvents(events []Event, g []float64, t, hs float64, y []float64, q [][4]float64, tNew float64, yNew []float64) bool {
+	type hit struct {
+		index int
+		t     float64
+	}
+	hits := []hit{}
+	for i, e := range events {
+		gNew := e.Func(tNew, yNew)
+		up := g[i] < 0.0 && gNew >= 0.0
+		down := g[i] > 0.0 && gNew <= 0.0
+		if (up && e.Direction >= 0) || (down && e.Direction <= 0) {
+			at := func(tt float64) float64 { return e.Func(tt, dense(t, hs, y, q, tt)) }
+			hits = append(hits, hit{i, locate(at, t, tNew, g[i])})
+		}
+		g[i] = gNew
+	}
+	forward := hs > 0.0
+	sort.Slice(hits, func(a, b int) bool {
+		if forward {
+			return hits[a].t < hits[b].t
+		}
+		return hits[a].t > hits[b].t
+	})
+	for _, h := range hits {
+		yh := dense(t, hs, y, q, h.t)
+		s.Events = append(s.Events, EventHit{Index: h.index, T: h.t, Y: yh})
+		if events[h.index].Terminal {
+			s.T[len(s.T)-1] = h.t
+			s.Y[len(s.Y)-1] = yh
+			return true
+		}
+	}
+	return false
+}
+
+// locate returns the point in [a, b] where g changes sign, where ga is the
+// value of g at a, using the Illinois variant of the false position method.
+func locate(g func(float64) float64, a, b, ga float64) float64 {
+	gb := g(b)
+	if gb == 0.0 {
+		return b
+	}
+	side := 0
+	for i := 0; i < 100; i++ {
+		c := (a*gb - b*ga) / (gb - ga)
+		if math.IsNaN(c) || c == a || c == b {
+			c = 0.5 * (a + b)
+		}
+		gc := g(c)
+		if gc == 0.0 || math.Abs(b-a) <= 4e-16*math.Max(math.Abs(a), math.Abs(b)) {
+			return c
+		}
+		if (gc > 0.0) == (gb > 0.0) {
+			b, gb = c, gc
+			if side == -1 {
+				ga /= 2.0
+			}
+			side = -1
+		} else {
+			a, ga = c, gc
+			if side == 1 {
+				gb /= 2.0
+			}
+			side = 1
+		}
+	}
+	return 0.5 * (a + b)
+}
+
+// dense evaluates the continuous extension of the step of size hs starting
+// at time t with state y, at the time tt.
+func dense(t, hs float64, y []float64, q [][4]float64, tt float64) []float64 {
+	x := (tt - t) / hs
+	p := [4]float64{x, x * x, x * x * x, x * x * x * x}
+	out := make([]float64, len(y))
+	for i := range y {
+		sum := 0.0
+		for m := 0; m < 4; m++ {
+			sum += q[i][m] * p[m]
+		}
+		out[i] = y[i] + hs*sum
+	}
+	return out
+}
+
+// initialStep estimates a suitable size for the first step.
+func initialStep(f Func, t float64, y, dydt []float64, dir float64, set Settings) float64 {
+	d0, d1 := 0.0, 0.0
+	for i := range y {
+		scale := set.AbsTol + set.RelTol*math.Abs(y[i])
+		d0 += (y[i] / scale) * (y[i] / scale)
+		d1 += (dydt[i] / scale) * (dydt[i] / scale)
+	}
+	d0 = math.Sqrt(d0 / float64(len(y)))
+	d1 = math.Sqrt(d1 / float64(len(y)))
+	h0 := 1e-6
+	if d0 >= 1e-5 && d1 >= 1e-5 {
+		h0 = 0.01 * d0 / d1
+	}
+	y1 := make([]float64, len(y))
+	for i := range y {
+		y1[i] = y[i] + dir*h0*dydt[i]
+	}
+	f1 := make([]float64, len(y))
+	f(t+dir*h0, y1, f1)
+	d2 := 0.0
+	for i := range y {
+		scale := set.AbsTol + set.RelTol*math.Abs(y[i])
+		r := (f1[i] - dydt[i]) / scale
+		d2 += r * r
+	}
+	d2 = math.Sqrt(d2/float64(len(y))) / h0
+	h1 := math.Max(1e-6, h0*1e-3)
+	if m := math.Max(d1, d2); m > 1e-15 {
+		h1 = math.Pow(0.01/m, 0.2)
+	}
+	return math.Min(100.0*h0, h1)
+}
+
+func defaults(s *Settings) Settings {
+	var set Settings
+	if s != nil {
+		set = *s
+	}
+	if set.AbsTol == 0.0 {
+		set.AbsTol = 1e-8
+	}
+	if set.RelTol == 0.0 {
+		set.RelTol = 1e-6
+	}
+	if set.MaxStep == 0.0 {
+		set.MaxStep = math.Inf(1)
+	}
+	if set.MaxSteps <= 0 {
+		set.MaxSteps = 100000
+	}
+	return set
+}
+
+func clone(v []float64) []float64 {
+	c := make([]float64, len(v))
+	copy(c, v)
+	return c
+}
@@ -0,0 +1,127 @@
+package ode
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func oscillator(t float64, y, dydt []float64) {
+	dydt[0] = y[1]
+	dydt[1] = -y[0]
+}
+
+func TestRK4(t *testing.T) {
+	decay := func(t float64, y, dydt []float64) {
+		dydt[0] = -y[0]
+	}
+	ts, ys := RK4(decay, []float64{1.0}, 0.0, 1.0, 100)
+	if len(ts) != 101 || len(ys) != 101 {
+		t.Fatalf("expected 101 points, got %d and %d", len(ts), len(ys))
+	}
+	if ts[100] != 1.0 {
+		t.Errorf("expected the last time to be 1.0, got %f", ts[100])
+	}
+	if math.Abs(ys[100][0]-math.Exp(-1.0)) > 1e-9 {
+		t.Errorf("expected %f, got %f", math.Exp(-1.0), ys[100][0])
+	}
+	_, ys = RK4(oscillator, []float64{1.0, 0.0}, 0.0, 2.0*math.Pi, 1000)
+	if math.Abs(ys[1000][0]-1.0) > 1e-9 || math.Abs(ys[1000][1]) > 1e-9 {
+		t.Errorf("expected to return to {1.0, 0.0}, got %v", ys[1000])
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[2], "RK4()", 0)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		RK4(decay, []float64{1.0}, 0.0, 1.0, 0)
+	}()
+	wg.Wait()
+}
+
+func TestDormandPrince(t *testing.T) {
+	sol, err := DormandPrince(oscillator, []float64{1.0, 0.0}, 0.0, 10.0, &Settings{AbsTol: 1e-10, RelTol: 1e-10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := sol.Y[len(sol.Y)-1]
+	if sol.T[len(sol.T)-1] != 10.0 {
+		t.Errorf("expected to end at 10.0, got %f", sol.T[len(sol.T)-1])
+	}
+	if math.Abs(last[0]-math.Cos(10.0)) > 1e-8 || math.Abs(last[1]+math.Sin(10.0)) > 1e-8 {
+		t.Errorf("expected {%f, %f}, got %v", math.Cos(10.0), -math.Sin(10.0), last)
+	}
+	for _, tt := range []float64{0.0, 0.3, 2.5, 7.77, 10.0} {
+		y := sol.At(tt)
+		if math.Abs(y[0]-math.Cos(tt)) > 1e-7 {
+			t.Errorf("dense output at %f: expected %f, got %f", tt, math.Cos(tt), y[0])
+		}
+	}
+	back, err := DormandPrince(oscillator, []float64{math.Cos(10.0), -math.Sin(10.0)}, 10.0, 0.0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if y := back.At(5.0); math.Abs(y[0]-math.Cos(5.0)) > 1e-5 {
+		t.Errorf("backwards: expected %f at 5.0, got %f", math.Cos(5.0), y[0])
+	}
+}
+
+func TestDormandPrinceEvents(t *testing.T) {
+	// A ball dropped from a height of 10 hits the ground at Sqrt(2).
+	fall := func(t float64, y, dydt []float64) {
+		dydt[0] = y[1]
+		dydt[1] = -10.0
+	}
+	ground := Event{Func: func(t float64, y []float64) float64 { return y[0] }, Terminal: true}
+	apex := Event{Func: func(t float64, y []float64) float64 { return y[1] }, Direction: -1}
+	sol, err := DormandPrince(fall, []float64{10.0, 5.0}, 0.0, 100.0, &Settings{Events: []Event{apex, ground}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sol.Events) != 2 {
+		t.Fatalf("expected 2 events, got %v", sol.Events)
+	}
+	if sol.Events[0].Index != 0 || math.Abs(sol.Events[0].T-0.5) > 1e-9 {
+		t.Errorf("expected the apex at 0.5, got %v", sol.Events[0])
+	}
+	hit := (5.0 + math.Sqrt(225.0)) / 10.0
+	if sol.Events[1].Index != 1 || math.Abs(sol.Events[1].T-hit) > 1e-9 {
+		t.Errorf("expected the ground at %f, got %v", hit, sol.Events[1])
+	}
+	if end := sol.T[len(sol.T)-1]; math.Abs(end-hit) > 1e-9 {
+		t.Errorf("expected the solution to end at %f, got %f", hit, end)
+	}
+	// The last step was cut short by the event, and is still interpolated
+	// with the coefficients of the full step.
+	last := len(sol.T) - 1
+	if y := sol.At(sol.T[last]); math.Abs(y[0]-sol.Y[last][0]) > 1e-12 || math.Abs(y[1]-sol.Y[last][1]) > 1e-12 {
+		t.Errorf("expected %v at the end, got %v", sol.Y[last], y)
+	}
+	mid := 0.5 * (sol.T[last-1] + sol.T[last])
+	if y := sol.At(mid); math.Abs(y[0]-(10.0+5.0*mid-5.0*mid*mid)) > 1e-9 {
+		t.Errorf("expected %v at %v, got %v", 10.0+5.0*mid-5.0*mid*mid, mid, y[0])
+	}
+}
+
+func TestDormandPrinceErrors(t *testing.T) {
+	blowup := func(t float64, y, dydt []float64) {
+		dydt[0] = y[0] * y[0]
+	}
+	// The solution 1/(1-t) is singular at t = 1.
+	_, err := DormandPrince(blowup, []float64{1.0}, 0.0, 2.0, nil)
+	if !errors.Is(err, ErrStepSize) && !errors.Is(err, ErrMaxSteps) {
+		t.Errorf("expected the integration to fail, got %v", err)
+	}
+	_, err = DormandPrince(oscillator, []float64{1.0, 0.0}, 0.0, 100.0, &Settings{MaxSteps: 5})
+	if !errors.Is(err, ErrMaxSteps) {
+		t.Errorf("expected ErrMaxSteps, got %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/NDari/gocrunch/progress"
+)
+
+/*
+SimplexSettings configures optimize.NelderMead(). The zero value of each field
+is replaced by the default mentioned in its description.
+*/
+type SimplexSettings struct {
+	// InitialStep is the size of the initial simplex along each variable,
+	// relative to the magnitude of the variable. It is 0.05 by default, and
+	// variables that start at 0.0 use an absolute step of 0.00025.
+	InitialStep float64
+	// Lower and Upper, when not nil, hold the bounds of each variable. The
+	// points of the simplex are kept within the bounds by projection.
+	Lower, Upper []float64
+	// MaxIter is the maximum number of iterations of each start of the
+	// method, 200 times the number of variables by default.
+	MaxIter int
+	// XTol and FuncTol stop the iterations when the simplex spans less
+	// than XTol along every variable, and the values of the function at
+	// its points differ by less than FuncTol. Both are 1e-8 by default.
+	XTol, FuncTol float64
+	// Restarts is the number of times the method is restarted with a new
+	// simplex around the best point found. Restarting guards against the
+	// simplex collapsing before it reaches a minimum. The restarts stop
+	// early when a restart does not improve on the best point. 0 by
+	// default.
+	Restarts int
+	// Observer, when not nil, is passed the lowest value of the function
+	// at the points of the simplex after each iteration, counting the
+	// iterations of all starts, and can stop the search.
+	Observer progress.Observer
+}
+
+/*
+NelderMead searches for a minimum of the function described by the passed
+Problem, starting from x0, using the Nelder-Mead simplex method. The method
+only evaluates the function, so the Grad of the Problem is not needed, and is
+ignored if set. This makes it suitable for functions that are not smooth, or
+whose gradient is not available:
+
+	p := optimize.Problem{
+		Func: func(x []float64) float64 { return math.Abs(x[0]-1) + math.Abs(x[1]) },
+	}
+	res, err := optimize.NelderMead(p, []float64{3, 3}, nil)
+
+NelderMead returns ErrMaxIter if the start of the method which found the
+best point does not meet the stopping criteria within the maximum number of
+iterations, and progress.ErrStopped if the Observer stops any start, along
+with the best point found. A nil SimplexSettings uses the defaults of all
+its fields. The passed x0 is not modified in this function.
+*/
+func NelderMead(p Problem, x0 []float64, s *SimplexSettings) (*Result, error) {
+	if p.Func == nil {
+		panic(fmt.Sprintf(errStrings[0], "NelderMead()", "Func"))
+	}
+	if len(x0) == 0 {
+		panic(fmt.Sprintf(errStrings[1], "NelderMead()"))
+	}
+	set := simplexDefaults(s, len(x0))
+	x := make([]float64, len(x0))
+	copy(x, x0)
+	set.project(x)
+	best := &Result{X: x, F: p.Func(x)}
+	var err error
+	for start := 0; start <= set.Restarts; start++ {
+		res, runErr := set.run(p.Func, best.X, best.Iter)
+		best.Iter += res.Iter
+		if start > 0 && !(res.F < best.F) {
+			// The best point, and its error, are those of an earlier
+			// start, unless the Observer stopped this one.
+			if errors.Is(runErr, progress.ErrStopped) {
+				err = runErr
+			}
+			break
+		}
+		best.X, best.F, err = res.X, res.F, runErr
+		if errors.Is(err, progress.ErrStopped) {
+			break
+		}
+	}
+	return best, err
+}
+
+// run performs one start of the Nelder-Mead method from x0, after done
+// iterations of the previous starts.
+func (set *SimplexSettings) run(f func([]float64) float64, x0 []float64, done int) (*Result, error) {
+	const (
+		reflection  = 1.0
+		expansion   = 2.0
+		contraction = 0.5
+		shrinkage   = 0.5
+	)
+	n := len(x0)
+	pts := make([][]float64, n+1)
+	vals := make([]float64, n+1)
+	for i := range pts {
+		pts[i] = make([]float64, n)
+		copy(pts[i], x0)
+		if i > 0 {
+			step := set.InitialStep * pts[i][i-1]
+			if step == 0.0 {
+				step = 0.00025
+			}
+			pts[i][i-1] += step
+			// A point pushed onto a bound would flatten the simplex, so
+			// step the other way instead.
+			if set.Upper != nil && pts[i][i-1] > set.Upper[i-1] ||
+				set.Lower != nil && pts[i][i-1] < set.Lower[i-1] {
+				pts[i][i-1] = x0[i-1] - step
+			}
+			set.project(pts[i])
+		}
+		vals[i] = f(pts[i])
+	}
+	order := make([]int, n+1)
+	centroid := make([]float64, n)
+	trial := func(coeff float64) ([]float64, float64) {
+		worst := pts[order[n]]
+		x := make([]float64, n)
+		for j := range x {
+			x[j] = centroid[j] + coeff*(centroid[j]-worst[j])
+		}
+		set.project(x)
+		return x, f(x)
+	}
+	for iter := 0; iter < set.MaxIter; iter++ {
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool { return vals[order[a]] < vals[order[b]] })
+		if set.converged(pts, vals, order) {
+			return &Result{X: pts[order[0]], F: vals[order[0]], Iter: iter}, nil
+		}
+		for j := range centroid {
+			centroid[j] = 0.0
+			for _, i := range order[:n] {
+				centroid[j] += pts[i][j]
+			}
+			centroid[j] /= float64(n)
+		}
+		lo, hi, worst := vals[order[0]], vals[order[n-1]], order[n]
+		xr, fr := trial(reflection)
+		switch {
+		case fr < lo:
+			if xe, fe := trial(reflection * expansion); fe < fr {
+				pts[worst], vals[worst] = xe, fe
+			} else {
+				pts[worst], vals[worst] = xr, fr
+			}
+		case fr < hi:
+			pts[worst], vals[worst] = xr, fr
+		default:
+			// Contract towards the better of the worst and reflected
+			// points, and shrink the simplex if that fails.
+			coeff := -contraction
+			target := vals[worst]
+			if fr < vals[worst] {
+				coeff = reflection * contraction
+				target = fr
+			}
+			if xc, fc := trial(coeff); fc < target {
+				pts[worst], vals[worst] = xc, fc
+				break
+			}
+			b := pts[order[0]]
+			for _, i := range order[1:] {
+				for j := range pts[i] {
+					pts[i][j] = b[j] + shrinkage*(pts[i][j]-b[j])
+				}
+				vals[i] = f(pts[i])
+			}
+		}
+		bi := 0
+		for i := range vals {
+			if vals[i] < vals[bi] {
+				bi = i
+			}
+		}
+		if !progress.Continue(set.Observer, done+iter+1, vals[bi]) {
+			return &Result{X: pts[bi], F: vals[bi], Iter: iter + 1}, progress.ErrStopped
+		}
+	}
+	bi := 0
+	for i := range vals {
+		if vals[i] < vals[bi] {
+			bi = i
+		}
+	}
+	return &Result{X: pts[bi], F: vals[bi], Iter: set.MaxIter}, ErrMaxIter
+}
+
+func (set *SimplexSettings) converged(pts [][]float64, vals []float64, order []int) bool {
+	b := order[0]
+	for _, i := range order[1:] {
+		if math.Abs(vals[i]-vals[b]) > set.FuncTol {
+			return false
+		}
+		for j := range pts[i] {
+			if math.Abs(pts[i][j]-pts[b][j]) > set.XTol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// project moves x onto the nearest point within the bounds.
+func (set *SimplexSettings) project(x []float64) {
+	for j := range x {
+		if set.Lower != nil && x[j] < set.Lower[j] {
+			x[j] = set.Lower[j]
+		}
+		if set.Upper != nil && x[j] > set.Upper[j] {
+			x[j] = set.Upper[j]
+		}
+	}
+}
+
+func simplexDefaults(s *SimplexSettings, n int) SimplexSettings {
+	var set SimplexSettings
+	if s != nil {
+		set = *s
+	}
+	for _, b := range []struct {
+		name  string
+		bound []float64
+	}{{"lower", set.Lower}, {"upper", set.Upper}} {
+		if b.bound != nil && len(b.bound) != n {
+			panic(fmt.Sprintf(errStrings[4], "NelderMead()", b.name, len(b.bound), n))
+		}
+	}
+	if set.Lower != nil && set.Upper != nil {
+		for j := range set.Lower {
+			if set.Lower[j] > set.Upper[j] {
+				panic(fmt.Sprintf(errStrings[5], "NelderMead()", set.Lower[j], set.Upper[j], j))
+			}
+		}
+	}
+	if set.MaxIter < 0 {
+		panic(fmt.Sprintf(errStrings[3], "NelderMead()", "maximum number of iterations", set.MaxIter))
+	}
+	if set.Restarts < 0 {
+		panic(fmt.Sprintf(errStrings[3], "NelderMead()", "number of restarts", set.Restarts))
+	}
+	if set.InitialStep == 0.0 {
+		set.InitialStep = 0.05
+	}
+	if set.MaxIter == 0 {
+		set.MaxIter = 200 * n
+	}
+	if set.XTol == 0.0 {
+		set.XTol = 1e-8
+	}
+	if set.FuncTol == 0.0 {
+		set.FuncTol = 1e-8
+	}
+	return set
+}
@@ -0,0 +1,139 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/progress"
+)
+
+func rosenbrock(x []float64) float64 {
+	a, b := 1.0-x[0], x[1]-x[0]*x[0]
+	return a*a + 100.0*b*b
+}
+
+func TestNelderMead(t *testing.T) {
+	x0 := []float64{-1.2, 1.0}
+	res, err := NelderMead(Problem{Func: rosenbrock}, x0, &SimplexSettings{XTol: 1e-10, FuncTol: 1e-14})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(res.X[0]-1.0) > 1e-6 || math.Abs(res.X[1]-1.0) > 1e-6 {
+		t.Errorf("expected {1.0, 1.0}, got %v", res.X)
+	}
+	if x0[0] != -1.2 || x0[1] != 1.0 {
+		t.Errorf("the starting point was modified: %v", x0)
+	}
+	// A function that is not smooth at its minimum.
+	abs := Problem{Func: func(x []float64) float64 {
+		return math.Abs(x[0]-1.0) + 2.0*math.Abs(x[1]+0.5) + math.Abs(x[2])
+	}}
+	res, err = NelderMead(abs, []float64{3.0, 3.0, 3.0}, &SimplexSettings{Restarts: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.F > 1e-6 {
+		t.Errorf("expected a minimum of 0.0, got %v at %v", res.F, res.X)
+	}
+	_, err = NelderMead(Problem{Func: rosenbrock}, x0, &SimplexSettings{MaxIter: 5})
+	if !errors.Is(err, ErrMaxIter) {
+		t.Errorf("expected ErrMaxIter, got %v", err)
+	}
+	// The first start converges at once, and the restart, whose values
+	// grow with each call, neither converges nor improves on it, so the
+	// error is that of the first start.
+	calls := 0
+	growing := Problem{Func: func(x []float64) float64 {
+		calls++
+		if calls <= 3 {
+			return (x[0] - 1.0) * (x[0] - 1.0)
+		}
+		return float64(calls * calls)
+	}}
+	res, err = NelderMead(growing, []float64{1.0}, &SimplexSettings{XTol: 1.0, FuncTol: 1.0, MaxIter: 5, Restarts: 1})
+	if err != nil || res.X[0] != 1.0 {
+		t.Errorf("expected the converged {1.0}, got %v and %v", res.X, err)
+	}
+}
+
+func TestNelderMeadBounds(t *testing.T) {
+	// The unconstrained minimum at {1, 1} is outside of the bounds, so the
+	// minimum is on the boundary x[0] = 0.5, where x[1] = 0.25.
+	s := &SimplexSettings{
+		Lower:    []float64{-2.0, -2.0},
+		Upper:    []float64{0.5, 2.0},
+		XTol:     1e-10,
+		FuncTol:  1e-14,
+		Restarts: 2,
+	}
+	res, err := NelderMead(Problem{Func: rosenbrock}, []float64{-1.2, 1.0}, s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(res.X[0]-0.5) > 1e-6 || math.Abs(res.X[1]-0.25) > 1e-6 {
+		t.Errorf("expected {0.5, 0.25}, got %v", res.X)
+	}
+	// Starting on a lower bound, the initial step away from it stays in
+	// the bounds, so the first variable can still move.
+	bowl := func(x []float64) float64 {
+		return (x[0]-2.0)*(x[0]-2.0) + (x[1]-1.0)*(x[1]-1.0)
+	}
+	s = &SimplexSettings{Lower: []float64{-1.0, -1.0}, XTol: 1e-10, FuncTol: 1e-14}
+	res, err = NelderMead(Problem{Func: bowl}, []float64{-1.0, 0.5}, s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(res.X[0]-2.0) > 1e-6 || math.Abs(res.X[1]-1.0) > 1e-6 {
+		t.Errorf("expected {2.0, 1.0}, got %v", res.X)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[5], "NelderMead()", 1.0, 0.0, 1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		NelderMead(Problem{Func: rosenbrock}, []float64{0.0, 0.0}, &SimplexSettings{
+			Lower: []float64{0.0, 1.0},
+			Upper: []float64{1.0, 0.0},
+		})
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[4], "NelderMead()", "lower", 1, 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		NelderMead(Problem{Func: rosenbrock}, []float64{0.0, 0.0}, &SimplexSettings{
+			Lower: []float64{0.0},
+		})
+	}()
+	wg.Wait()
+}
+
+func TestNelderMeadObserver(t *testing.T) {
+	last := progress.Report{}
+	stop := progress.Func(func(r progress.Report) bool {
+		last = r
+		return r.Iter < 10
+	})
+	res, err := NelderMead(Problem{Func: rosenbrock}, []float64{-1.2, 1.0}, &SimplexSettings{Observer: stop, Restarts: 2})
+	if err != progress.ErrStopped {
+		t.Fatalf("expected progress.ErrStopped, got %v", err)
+	}
+	if res.Iter != 10 || last.Iter != 10 || last.Value != res.F {
+		t.Errorf("expected to stop at iteration 10 with %v, got %d and %v", res.F, res.Iter, last)
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Package optimize implements the minimization of functions of one or more
+variables, where the variables are stored as a []float64.
+
+To minimize a smooth function with gradient based methods, describe it with
+a Problem holding the function and its gradient, and pass it to
+optimize.Minimize() along with a starting point:
+
+	p := optimize.Problem{
+		Func: func(x []float64) float64 {
+			return (x[0]-1)*(x[0]-1) + (x[1]+2)*(x[1]+2)
+		},
+		Grad: func(grad, x []float64) {
+			grad[0] = 2 * (x[0] - 1)
+			grad[1] = 2 * (x[1] + 2)
+		},
+	}
+	res, err := optimize.Minimize(p, []float64{0, 0}, &optimize.Settings{
+		Method:   optimize.Adam,
+		StepSize: optimize.ConstantStep(0.1),
+	})
+
+For functions whose gradient is not available, optimize.NelderMead() searches
+for a minimum using only the values of the function, optionally within bounds
+on each variable.
+
+Minimize returns an error when it could not find a minimum within the limits
+in the passed Settings. Invalid arguments, such as a Problem without a
+function, are treated as critical errors, and cause a panic with a message
+that names the offending function, as with the other packages in gocrunch.
+*/
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/progress"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/optimize error.\nIn optimize.%s, the %s of the Problem cannot be nil.\n",
+		"\ngocrunch/optimize error.\nIn optimize.%s, cannot start from an empty []float64.\n",
+		"\ngocrunch/optimize error.\nIn optimize.%s, unknown method %d.\n",
+		"\ngocrunch/optimize error.\nIn optimize.%s, the %s must not be negative, received %v.\n",
+		"\ngocrunch/optimize error.\nIn optimize.%s, the %s bounds have length %d, while the starting point has length %d.\n",
+		"\ngocrunch/optimize error.\nIn optimize.%s, the lower bound %v is greater than the upper bound %v at index %d.\n",
+	}
+)
+
+// ErrMaxIter is returned when a method does not converge within the
+// allowed number of iterations. The returned result holds the point
+// reached, as with the other errors of the methods.
+var ErrMaxIter = errors.New("optimize: maximum number of iterations reached")
+
+// Problem describes a function to be minimized.
+type Problem struct {
+	// Func returns the value of the function at x.
+	Func func(x []float64) float64
+	// Grad stores the gradient of the function at x in grad, which has the
+	// same length as x.
+	Grad func(grad, x []float64)
+}
+
+// Method is a gradient based minimization method.
+type Method int
+
+const (
+	// GradientDescent steps against the gradient.
+	GradientDescent Method = iota
+	// Momentum steps along an exponentially decaying sum of past gradients,
+	// which speeds progress along shallow valleys.
+	Momentum
+	// Adam scales the step of each variable by running estimates of the first
+	// and second moments of its gradient.
+	Adam
+)
+
+/*
+Schedule returns the step size for the given iteration, starting from 0.
+*/
+type Schedule func(iter int) float64
+
+/*
+ConstantStep returns a Schedule which always uses the passed step size.
+*/
+func ConstantStep(size float64) Schedule {
+	return func(int) float64 { return size }
+}
+
+/*
+ExponentialDecay returns a Schedule which starts at the passed step size, and
+multiplies it by rate at each iteration. rate is expected to be in (0, 1].
+*/
+func ExponentialDecay(size, rate float64) Schedule {
+	return func(iter int) float64 { return size * math.Pow(rate, float64(iter)) }
+}
+
+/*
+InverseTimeDecay returns a Schedule whose step size at iteration i is
+size / (1 + decay*i).
+*/
+func InverseTimeDecay(size, decay float64) Schedule {
+	return func(iter int) float64 { return size / (1.0 + decay*float64(iter)) }
+}
+
+/*
+Settings configures optimize.Minimize(). The zero value of each field is
+replaced by the default mentioned in its description.
+*/
+type Settings struct {
+	// Method is the minimization method, GradientDescent by default.
+	Method Method
+	// StepSize is the step size schedule, ConstantStep(0.01) by default.
+	StepSize Schedule
+	// MomentumDecay is the decay of the sum of past gradients in the
+	// Momentum method, 0.9 by default.
+	MomentumDecay float64
+	// Beta1, Beta2 and Epsilon are the parameters of the Adam method, 0.9,
+	// 0.999 and 1e-8 by default.
+	Beta1, Beta2, Epsilon float64
+	// MaxIter is the maximum number of iterations, 1000 by default.
+	MaxIter int
+	// GradTol stops the iterations when the euclidean norm of the gradient
+	// falls below it, 1e-6 by default.
+	GradTol float64
+	// FuncTol, when positive, also stops the iterations when the function
+	// changes by less than it between two iterations.
+	FuncTol float64
+	// Observer, when not nil, is passed the value of the function after
+	// each iteration, and can stop the minimization.
+	Observer progress.Observer
+}
+
+// Result holds the outcome of a minimization.
+type Result struct {
+	// X is the point reached when the method stopped, and F the value of
+	// the function at X. Minimize() returns its last iterate, which is not
+	// always the one with the smallest value, and NelderMead() the best
+	// point it found.
+	X []float64
+	F float64
+	// Iter is the number of iterations that were performed.
+	Iter int
+}
+
+/*
+Minimize searches for a minimum of the function described by the passed
+Problem, starting from x0, using the method and stopping criteria in the
+passed Settings. A nil Settings uses the defaults of all its fields.
+
+Minimize returns ErrMaxIter if the stopping criteria are not met within the
+maximum number of iterations, and progress.ErrStopped if the Observer stops
+it, along with the last point reached. The passed x0 is not modified in this
+function.
+*/
+func Minimize(p Problem, x0 []float64, s *Settings) (*Result, error) {
+	if p.Func == nil {
+		panic(fmt.Sprintf(errStrings[0], "Minimize()", "Func"))
+	}
+	if p.Grad == nil {
+		panic(fmt.Sprintf(errStrings[0], "Minimize()", "Grad"))
+	}
+	if len(x0) == 0 {
+		panic(fmt.Sprintf(errStrings[1], "Minimize()"))
+	}
+	set := withDefaults(s)
+	if set.Method < GradientDescent || set.Method > Adam {
+		panic(fmt.Sprintf(errStrings[2], "Minimize()", set.Method))
+	}
+	n := len(x0)
+	x := make([]float64, n)
+	copy(x, x0)
+	grad := make([]float64, n)
+	// m and v are the first and second moment estimates, m being the
+	// velocity of the Momentum method.
+	m := make([]float64, n)
+	v := make([]float64, n)
+	f := p.Func(x)
+	for iter := 0; iter < set.MaxIter; iter++ {
+		p.Grad(grad, x)
+		if norm(grad) < set.GradTol {
+			return &Result{X: x, F: f, Iter: iter}, nil
+		}
+		step := set.StepSize(iter)
+		switch set.Method {
+		case GradientDescent:
+			for i := range x {
+				x[i] -= step * grad[i]
+			}
+		case Momentum:
+			for i := range x {
+				m[i] = set.MomentumDecay*m[i] + grad[i]
+				x[i] -= step * m[i]
+			}
+		case Adam:
+			c1 := 1.0 - math.Pow(set.Beta1, float64(iter+1))
+			c2 := 1.0 - math.Pow(set.Beta2, float64(iter+1))
+			for i := range x {
+				m[i] = set.Beta1*m[i] + (1.0-set.Beta1)*grad[i]
+				v[i] = set.Beta2*v[i] + (1.0-set.Beta2)*grad[i]*grad[i]
+				x[i] -= step * (m[i] / c1) / (math.Sqrt(v[i]/c2) + set.Epsilon)
+			}
+		}
+		fNew := p.Func(x)
+		if set.FuncTol > 0.0 && math.Abs(fNew-f) < set.FuncTol {
+			return &Result{X: x, F: fNew, Iter: iter + 1}, nil
+		}
+		f = fNew
+		if !progress.Continue(set.Observer, iter+1, f) {
+			return &Result{X: x, F: f, Iter: iter + 1}, progress.ErrStopped
+		}
+	}
+	return &Result{X: x, F: f, Iter: set.MaxIter}, ErrMaxIter
+}
+
+func withDefaults(s *Settings) Settings {
+	var set Settings
+	if s != nil {
+		set = *s
+	}
+	if set.StepSize == nil {
+		set.StepSize = ConstantStep(0.01)
+	}
+	if set.MomentumDecay == 0.0 {
+		set.MomentumDecay = 0.9
+	}
+	if set.Beta1 == 0.0 {
+		set.Beta1 = 0.9
+	}
+	if set.Beta2 == 0.0 {
+		set.Beta2 = 0.999
+	}
+	if set.Epsilon == 0.0 {
+		set.Epsilon = 1e-8
+	}
+	if set.MaxIter < 0 {
+		panic(fmt.Sprintf(errStrings[3], "Minimize()", "maximum number of iterations", set.MaxIter))
+	}
+	if set.MaxIter == 0 {
+		set.MaxIter = 1000
+	}
+	if set.GradTol == 0.0 {
+		set.GradTol = 1e-6
+	}
+	return set
+}
+
+func norm(v []float64) float64 {
+	sum := 0.0
+	for i := range v {
+		sum += v[i] * v[i]
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,101 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/progress"
+)
+
+var quadratic = Problem{
+	Func: func(x []float64) float64 {
+		return (x[0]-1.0)*(x[0]-1.0) + 10.0*(x[1]+2.0)*(x[1]+2.0)
+	},
+	Grad: func(grad, x []float64) {
+		grad[0] = 2.0 * (x[0] - 1.0)
+		grad[1] = 20.0 * (x[1] + 2.0)
+	},
+}
+
+func TestMinimize(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *Settings
+	}{
+		{"gradient descent", &Settings{Method: GradientDescent, StepSize: ConstantStep(0.04)}},
+		{"momentum", &Settings{Method: Momentum, StepSize: ConstantStep(0.01)}},
+		{"adam", &Settings{Method: Adam, StepSize: ExponentialDecay(0.5, 0.995), MaxIter: 5000}},
+		{"decay", &Settings{StepSize: InverseTimeDecay(0.05, 0.001), MaxIter: 5000}},
+	}
+	for _, test := range tests {
+		x0 := []float64{5.0, 5.0}
+		res, err := Minimize(quadratic, x0, test.s)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if math.Abs(res.X[0]-1.0) > 1e-5 || math.Abs(res.X[1]+2.0) > 1e-5 {
+			t.Errorf("%s: expected {1.0, -2.0}, got %v", test.name, res.X)
+		}
+		if x0[0] != 5.0 {
+			t.Errorf("%s: the starting point was modified", test.name)
+		}
+	}
+}
+
+func TestMinimizeStopping(t *testing.T) {
+	res, err := Minimize(quadratic, []float64{5.0, 5.0}, &Settings{MaxIter: 3})
+	if err != ErrMaxIter {
+		t.Errorf("expected ErrMaxIter, got %v", err)
+	}
+	if res.Iter != 3 {
+		t.Errorf("expected 3 iterations, got %d", res.Iter)
+	}
+	res, err = Minimize(quadratic, []float64{5.0, 5.0}, &Settings{FuncTol: 1.0})
+	if err != nil {
+		t.Errorf("expected FuncTol to stop the iterations, got %v", err)
+	}
+	res, err = Minimize(quadratic, []float64{1.0, -2.0}, nil)
+	if err != nil || res.Iter != 0 {
+		t.Errorf("expected to stop at once at the minimum, got %d iterations and %v", res.Iter, err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[0], "Minimize()", "Grad")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Minimize(Problem{Func: quadratic.Func}, []float64{0.0}, nil)
+	}()
+	wg.Wait()
+}
+
+func TestMinimizeObserver(t *testing.T) {
+	var values []float64
+	stop := progress.Func(func(r progress.Report) bool {
+		if r.Iter != len(values)+1 {
+			t.Errorf("expected iteration %d, got %d", len(values)+1, r.Iter)
+		}
+		values = append(values, r.Value)
+		return r.Iter < 4
+	})
+	res, err := Minimize(quadratic, []float64{5.0, 5.0}, &Settings{Observer: stop})
+	if err != progress.ErrStopped {
+		t.Fatalf("expected progress.ErrStopped, got %v", err)
+	}
+	if res.Iter != 4 || len(values) != 4 || values[3] != res.F {
+		t.Errorf("expected 4 reports ending at %v, got %d iterations and %v", res.F, res.Iter, values)
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i] >= values[i-1] {
+			t.Errorf("expected decreasing values, got %v", values)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/progress"
+)
+
+var (
+	// ErrNoBracket is returned when the function does not change sign over
+	// the passed interval, so a root cannot be bracketed.
+	ErrNoBracket = errors.New("optimize: the interval does not bracket a root")
+	// ErrZeroDerivative is returned when Newton's method reaches a point
+	// where the derivative is zero.
+	ErrZeroDerivative = errors.New("optimize: the derivative is zero")
+)
+
+/*
+RootSettings configures the root finding functions of this package. The zero
+value of each field is replaced by the default mentioned in its description.
+*/
+type RootSettings struct {
+	// XTol stops the iterations when the root is known to within it,
+	// 1e-12 by default.
+	XTol float64
+	// FTol stops the iterations when the absolute value of the function
+	// falls to or below it, 0.0 by default, such that only XTol applies.
+	FTol float64
+	// MaxIter is the maximum number of iterations, 100 by default.
+	MaxIter int
+	// Observer, when not nil, is passed the absolute value of the function
+	// at the last point it was evaluated at after each iteration, and can
+	// stop the search.
+	Observer progress.Observer
+}
+
+/*
+Root returns a root of f in the interval [a, b], using Brent's method, which
+combines the guaranteed convergence of bisection with the speed of inverse
+quadratic interpolation. f(a) and f(b) must have opposite signs, or one of
+them must be 0.0. For example:
+
+	f := func(x float64) float64 { return x*x - 2 }
+	x, err := optimize.Root(f, 0, 2, nil) // x is Sqrt(2)
+
+If f(a) and f(b) have the same sign, ErrNoBracket is returned, and if the
+root is not found within the allowed number of iterations, ErrMaxIter is
+returned along with the best estimate. If the Observer stops the search,
+progress.ErrStopped is returned along with the best estimate. A nil
+RootSettings uses the defaults of all its fields.
+*/
+func Root(f func(float64) float64, a, b float64, s *RootSettings) (float64, error) {
+	set := rootDefaults("Root()", s)
+	fa, fb := f(a), f(b)
+	if err := checkBracket(a, b, fa, fb); err != nil {
+		return math.NaN(), err
+	}
+	if fa == 0.0 {
+		return a, nil
+	}
+	if fb == 0.0 {
+		return b, nil
+	}
+	c, fc := b, fb
+	var d, e float64
+	for iter := 0; iter < set.MaxIter; iter++ {
+		if (fb > 0.0) == (fc > 0.0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol := 2.0*1e-16*math.Abs(b) + 0.5*set.XTol
+		m := 0.5 * (c - b)
+		if math.Abs(m) <= tol || math.Abs(fb) <= set.FTol {
+			return b, nil
+		}
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			// Attempt inverse quadratic interpolation, or the secant method
+			// when only two distinct points are available.
+			var p, q float64
+			ratio := fb / fa
+			if a == c {
+				p = 2.0 * m * ratio
+				q = 1.0 - ratio
+			} else {
+				q = fa / fc
+				r := fb / fc
+				p = ratio * (2.0*m*q*(q-r) - (b-a)*(r-1.0))
+				q = (q - 1.0) * (r - 1.0) * (ratio - 1.0)
+			}
+			if p > 0.0 {
+				q = -q
+			}
+			p = math.Abs(p)
+			if 2.0*p < math.Min(3.0*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = d
+			}
+		} else {
+			d = m
+			e = d
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else if m > 0.0 {
+			b += tol
+		} else {
+			b -= tol
+		}
+		fb = f(b)
+		if !progress.Continue(set.Observer, iter+1, math.Abs(fb)) {
+			return b, progress.ErrStopped
+		}
+	}
+	return b, fmt.Errorf("%w: Root stopped at %g after %d iterations", ErrMaxIter, b, set.MaxIter)
+}
+
+/*
+Bisect returns a root of f in the interval [a, b] using the bisection method,
+which halves the interval at each iteration. It is slower than
+optimize.Root(), but only relies on f being continuous. f(a) and f(b) must
+have opposite signs, or one of them must be 0.0.
+
+The errors returned are the same as those of optimize.Root(). A nil
+RootSettings uses the defaults of all its fields.
+*/
+func Bisect(f func(float64) float64, a, b float64, s *RootSettings) (float64, error) {
+	set := rootDefaults("Bisect()", s)
+	fa, fb := f(a), f(b)
+	if err := checkBracket(a, b, fa, fb); err != nil {
+		return math.NaN(), err
+	}
+	if fa == 0.0 {
+		return a, nil
+	}
+	if fb == 0.0 {
+		return b, nil
+	}
+	for iter := 0; iter < set.MaxIter; iter++ {
+		mid := a + 0.5*(b-a)
+		fm := f(mid)
+		if fm == 0.0 || math.Abs(b-a) <= 2.0*set.XTol || math.Abs(fm) <= set.FTol {
+			return mid, nil
+		}
+		if (fm > 0.0) == (fa > 0.0) {
+			a, fa = mid, fm
+		} else {
+			b = mid
+		}
+		if !progress.Continue(set.Observer, iter+1, math.Abs(fm)) {
+			return a + 0.5*(b-a), progress.ErrStopped
+		}
+	}
+	mid := a + 0.5*(b-a)
+	return mid, fmt.Errorf("%w: Bisect stopped at %g after %d iterations", ErrMaxIter, mid, set.MaxIter)
+}
+
+/*
+Newton returns a root of f using Newton's method starting from x0, where df is
+the derivative of f. Near a simple root, Newton's method converges very
+quickly, but it may diverge when started far from one.
+
+If the derivative is zero at one of the iterates, ErrZeroDerivative is
+returned, and if the root is not found within the allowed number of
+iterations, ErrMaxIter is returned along with the last iterate, as it is with
+progress.ErrStopped if the Observer stops the search. A nil RootSettings uses
+the defaults of all its fields.
+*/
+func Newton(f, df func(float64) float64, x0 float64, s *RootSettings) (float64, error) {
+	set := rootDefaults("Newton()", s)
+	x := x0
+	for iter := 0; iter < set.MaxIter; iter++ {
+		fx := f(x)
+		if fx == 0.0 || math.Abs(fx) <= set.FTol {
+			return x, nil
+		}
+		d := df(x)
+		if d == 0.0 {
+			return x, fmt.Errorf("%w: at x = %g, after %d iterations", ErrZeroDerivative, x, iter)
+		}
+		step := fx / d
+		x -= step
+		if math.Abs(step) <= set.XTol {
+			return x, nil
+		}
+		if !progress.Continue(set.Observer, iter+1, math.Abs(fx)) {
+			return x, progress.ErrStopped
+		}
+	}
+	return x, fmt.Errorf("%w: Newton stopped at %g after %d iterations", ErrMaxIter, x, set.MaxIter)
+}
+
+func checkBracket(a, b, fa, fb float64) error {
+	if math.IsNaN(fa) || math.IsNaN(fb) || (fa > 0.0 && fb > 0.0) || (fa < 0.0 && fb < 0.0) {
+		return fmt.Errorf("%w: f(%g) = %g and f(%g) = %g", ErrNoBracket, a, fa, b, fb)
+	}
+	return nil
+}
+
+func rootDefaults(fn string, s *RootSettings) RootSettings {
+	var set RootSettings
+	if s != nil {
+		set = *s
+	}
+	if set.XTol == 0.0 {
+		set.XTol = 1e-12
+	}
+	if set.MaxIter < 0 {
+		panic(fmt.Sprintf(errStrings[3], fn, "maximum number of iterations", set.MaxIter))
+	}
+	if set.MaxIter == 0 {
+		set.MaxIter = 100
+	}
+	return set
+}
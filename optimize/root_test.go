@@ -0,0 +1,103 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/progress"
+)
+
+func TestRoot(t *testing.T) {
+	tests := []struct {
+		f        func(float64) float64
+		a, b     float64
+		expected float64
+	}{
+		{func(x float64) float64 { return x*x - 2.0 }, 0.0, 2.0, math.Sqrt2},
+		{math.Cos, 0.0, 3.0, math.Pi / 2.0},
+		{func(x float64) float64 { return x*x*x - x - 1.0 }, 1.0, 2.0, 1.324717957244746},
+		{func(x float64) float64 { return x - 3.0 }, 3.0, 5.0, 3.0},
+	}
+	for i, test := range tests {
+		for _, method := range []func(func(float64) float64, float64, float64, *RootSettings) (float64, error){Root, Bisect} {
+			x, err := method(test.f, test.a, test.b, nil)
+			if err != nil {
+				t.Errorf("test %d: unexpected error: %v", i, err)
+			}
+			if math.Abs(x-test.expected) > 1e-10 {
+				t.Errorf("test %d: expected %.15f, got %.15f", i, test.expected, x)
+			}
+		}
+	}
+	_, err := Root(func(x float64) float64 { return x*x + 1.0 }, -1.0, 1.0, nil)
+	if !errors.Is(err, ErrNoBracket) {
+		t.Errorf("expected ErrNoBracket, got %v", err)
+	}
+	_, err = Bisect(math.Cos, 0.0, 3.0, &RootSettings{MaxIter: 5})
+	if !errors.Is(err, ErrMaxIter) {
+		t.Errorf("expected ErrMaxIter, got %v", err)
+	}
+}
+
+func TestNewton(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2.0 }
+	df := func(x float64) float64 { return 2.0 * x }
+	x, err := Newton(f, df, 1.0, nil)
+	if err != nil || math.Abs(x-math.Sqrt2) > 1e-12 {
+		t.Errorf("expected %f, got %f and %v", math.Sqrt2, x, err)
+	}
+	_, err = Newton(f, df, 0.0, nil)
+	if !errors.Is(err, ErrZeroDerivative) {
+		t.Errorf("expected ErrZeroDerivative, got %v", err)
+	}
+	// Newton's method cycles between 0 and 1 for this function.
+	g := func(x float64) float64 { return x*x*x - 2.0*x + 2.0 }
+	dg := func(x float64) float64 { return 3.0*x*x - 2.0 }
+	_, err = Newton(g, dg, 0.0, &RootSettings{MaxIter: 20})
+	if !errors.Is(err, ErrMaxIter) {
+		t.Errorf("expected ErrMaxIter, got %v", err)
+	}
+}
+
+func TestRootObserver(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2.0 }
+	df := func(x float64) float64 { return 2.0 * x }
+	for name, find := range map[string]func(*RootSettings) (float64, error){
+		"Root":   func(s *RootSettings) (float64, error) { return Root(f, 0.0, 2.0, s) },
+		"Bisect": func(s *RootSettings) (float64, error) { return Bisect(f, 0.0, 2.0, s) },
+		"Newton": func(s *RootSettings) (float64, error) { return Newton(f, df, 1.0, s) },
+	} {
+		calls := 0
+		stop := progress.Func(func(r progress.Report) bool {
+			calls++
+			if r.Iter != calls || r.Value < 0.0 {
+				t.Errorf("%s: unexpected report %v", name, r)
+			}
+			return r.Iter < 2
+		})
+		x, err := find(&RootSettings{Observer: stop})
+		if err != progress.ErrStopped || calls != 2 {
+			t.Errorf("%s: expected to stop after 2 iterations, got %d and %v", name, calls, err)
+		}
+		if math.IsNaN(x) {
+			t.Errorf("%s: expected an estimate of the root, got NaN", name)
+		}
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				expectedErr := fmt.Sprintf(errStrings[3], name+"()", "maximum number of iterations", -1)
+				if r != expectedErr {
+					t.Errorf("Expected %s, got %v", expectedErr, r)
+				}
+				wg.Done()
+			}()
+			find(&RootSettings{MaxIter: -1})
+		}()
+		wg.Wait()
+	}
+}
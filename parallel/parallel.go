@@ -0,0 +1,157 @@
+/*
+Package parallel decides when the kernels of gocrunch spread their work over
+several goroutines.
+
+Splitting a kernel over goroutines only pays off once the work saved exceeds
+the cost of starting the goroutines and waiting for them, and where that
+happens depends on the machine. Rather than guess, the first call to
+parallel.Threshold() measures the throughput of a core and the cost of
+starting and joining GOMAXPROCS goroutines, and picks the amount of work at
+which a parallel kernel beats a serial one. The measurement takes a few
+milliseconds, and its result is cached for the life of the process.
+
+The work of a kernel is counted in elementary floating point operations, such
+as the number of distances times the length of the vectors. Kernels run in
+parallel when their work is at least the threshold:
+
+	if n*dim >= parallel.Threshold() {
+		// Spread the rows over runtime.GOMAXPROCS(0) goroutines.
+	}
+
+The measured threshold can be overridden with parallel.SetThreshold(), for
+example to make a benchmark reproducible.
+*/
+package parallel
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// minThreshold and maxThreshold bound the measured threshold, so that a
+	// noisy measurement cannot make every kernel run in parallel, or none.
+	minThreshold = 1 << 10
+	maxThreshold = 1 << 24
+	// trials is the number of times each measurement is repeated, keeping
+	// the fastest, which is the least disturbed by other work.
+	trials = 16
+	// kernelLen is the number of elements of the kernel that is timed.
+	kernelLen = 1 << 14
+)
+
+var (
+	once     sync.Once
+	measured atomic.Int64
+	override atomic.Int64
+	// sink keeps the timed kernel from being optimized away.
+	sink atomic.Uint64
+)
+
+/*
+Threshold returns the amount of work, in elementary floating point
+operations, at or above which kernels are spread over several goroutines.
+The threshold is measured by Calibrate() on the first call, unless it was set
+with SetThreshold(). If GOMAXPROCS is 1, no kernel runs in parallel, and the
+largest int is returned.
+*/
+func Threshold() int {
+	if t := override.Load(); t > 0 {
+		return int(t)
+	}
+	once.Do(func() { measured.Store(int64(measure())) })
+	return int(measured.Load())
+}
+
+/*
+SetThreshold replaces the measured threshold by n. A n of 0 or less restores
+the measured threshold.
+*/
+func SetThreshold(n int) {
+	if n < 0 {
+		n = 0
+	}
+	override.Store(int64(n))
+}
+
+/*
+Calibrate measures the threshold at which a parallel kernel beats a serial
+one on this machine, and replaces the threshold returned by Threshold() with
+it, unless one was set with SetThreshold(). The threshold is measured once by
+Threshold(), and cached, so Calibrate only needs to be called directly to
+measure again, for example after changing GOMAXPROCS.
+*/
+func Calibrate() int {
+	t := measure()
+	// Wait for a measurement started by Threshold(), so that it cannot
+	// overwrite this one.
+	once.Do(func() {})
+	measured.Store(int64(t))
+	return t
+}
+
+// measure returns the threshold at which a parallel kernel beats a serial
+// one.
+func measure() int {
+	workers := runtime.GOMAXPROCS(0)
+	if workers < 2 {
+		return math.MaxInt
+	}
+	x := make([]float64, kernelLen)
+	for i := range x {
+		x[i] = float64(i)
+	}
+	acc := make([]float64, workers)
+	perOp := fastest(func() { acc[0] += kernel(x) }) / kernelLen
+	overhead := fastest(func() {
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for w := 0; w < workers; w++ {
+			go func(w int) {
+				acc[w] += kernel(x[:1])
+				wg.Done()
+			}(w)
+		}
+		wg.Wait()
+	})
+	sink.Store(math.Float64bits(acc[0] + acc[workers-1]))
+	// With w workers, n operations take n*perOp serially, and about
+	// n*perOp/w + overhead in parallel. The threshold is where the time
+	// saved is twice the overhead, leaving a margin for the imbalance
+	// between the workers.
+	saved := perOp * (1.0 - 1.0/float64(workers))
+	t := 2.0 * overhead / saved
+	if math.IsNaN(t) || t > maxThreshold {
+		return maxThreshold
+	}
+	if t < minThreshold {
+		return minThreshold
+	}
+	return int(t)
+}
+
+// fastest returns the shortest time taken by f over the trials, in
+// nanoseconds.
+func fastest(f func()) float64 {
+	best := math.Inf(1)
+	for i := 0; i < trials; i++ {
+		start := time.Now()
+		f()
+		if d := float64(time.Since(start).Nanoseconds()); d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+// kernel is a typical element-wise kernel, a multiply and add per element.
+func kernel(x []float64) float64 {
+	sum := 0.0
+	for _, v := range x {
+		sum += v * 1.0000001
+	}
+	return sum
+}
@@ -0,0 +1,39 @@
+package parallel
+
+import (
+	"math"
+	"runtime"
+	"testing"
+)
+
+func TestThreshold(t *testing.T) {
+	n := Threshold()
+	if runtime.GOMAXPROCS(0) > 1 && (n < minThreshold || n > maxThreshold) {
+		t.Errorf("expected a threshold in [%d, %d], got %d", minThreshold, maxThreshold, n)
+	}
+	if Threshold() != n {
+		t.Errorf("expected the threshold to be cached")
+	}
+	SetThreshold(7)
+	if got := Threshold(); got != 7 {
+		t.Errorf("expected the set threshold 7, got %d", got)
+	}
+	SetThreshold(0)
+	if got := Threshold(); got != n {
+		t.Errorf("expected the measured threshold %d to be restored, got %d", n, got)
+	}
+}
+
+func TestCalibrateSerial(t *testing.T) {
+	procs := runtime.GOMAXPROCS(1)
+	if n := Calibrate(); n != math.MaxInt {
+		t.Errorf("expected no parallelism with one thread, got %d", n)
+	}
+	if n := Threshold(); n != math.MaxInt {
+		t.Errorf("expected the threshold to be measured again, got %d", n)
+	}
+	runtime.GOMAXPROCS(procs)
+	if n := Calibrate(); n != Threshold() {
+		t.Errorf("expected the threshold %d of the calibration, got %d", n, Threshold())
+	}
+}
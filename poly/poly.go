@@ -0,0 +1,351 @@
+/*
+Package poly implements polynomials of one variable with real coefficients.
+
+A Polynomial holds its coefficients in order of increasing power, so that the
+element at index i is the coefficient of x^i:
+
+	p := poly.New(-2, 0, 1) // x^2 - 2
+	p.Eval(3)               // 7
+	p.Derivative()          // 2x
+	p.Roots()               // {-1.4142..., +1.4142...}
+
+Polynomials are values: none of the functions in this package modify the
+Polynomials they are passed, and all of them return new ones. Invalid
+arguments, such as the empty Polynomial, are treated as critical errors, and
+cause a panic with a message that names the offending function, as with the
+other packages in gocrunch.
+*/
+package poly
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/poly error.\nIn poly.%s, the Polynomial cannot be empty.\n",
+		"\ngocrunch/poly error.\nIn poly.%s, the zero Polynomial has no finite set of roots.\n",
+		"\ngocrunch/poly error.\nIn poly.%s, the roots did not converge.\n",
+	}
+)
+
+// Polynomial holds the coefficients of a polynomial in order of increasing
+// power.
+type Polynomial []float64
+
+/*
+New returns the Polynomial with the passed coefficients, in order of
+increasing power. Trailing zero coefficients are removed, except for the
+constant term. At least one coefficient must be passed, otherwise this
+function will panic.
+*/
+func New(coeffs ...float64) Polynomial {
+	if len(coeffs) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "New()"))
+	}
+	p := make(Polynomial, len(coeffs))
+	copy(p, coeffs)
+	return p.trim()
+}
+
+/*
+Degree returns the degree of the Polynomial, which is the highest power with
+a nonzero coefficient. The degree of the zero Polynomial is taken to be 0.
+*/
+func (p Polynomial) Degree() int {
+	p.check("Degree()")
+	return len(p.trim()) - 1
+}
+
+/*
+Eval returns the value of the Polynomial at x, using Horner's method.
+*/
+func (p Polynomial) Eval(x float64) float64 {
+	p.check("Eval()")
+	res := 0.0
+	for i := len(p) - 1; i >= 0; i-- {
+		res = res*x + p[i]
+	}
+	return res
+}
+
+/*
+EvalVec returns a []float64 holding the value of the Polynomial at each
+element of the passed []float64.
+*/
+func (p Polynomial) EvalVec(x []float64) []float64 {
+	p.check("EvalVec()")
+	res := make([]float64, len(x))
+	for i := range x {
+		res[i] = p.Eval(x[i])
+	}
+	return res
+}
+
+/*
+Add returns the sum of the two Polynomials.
+*/
+func (p Polynomial) Add(q Polynomial) Polynomial {
+	p.check("Add()")
+	q.check("Add()")
+	if len(q) > len(p) {
+		p, q = q, p
+	}
+	res := make(Polynomial, len(p))
+	copy(res, p)
+	for i := range q {
+		res[i] += q[i]
+	}
+	return res.trim()
+}
+
+/*
+Sub returns the Polynomial p - q.
+*/
+func (p Polynomial) Sub(q Polynomial) Polynomial {
+	p.check("Sub()")
+	q.check("Sub()")
+	return p.Add(q.Scale(-1.0))
+}
+
+/*
+Scale returns the Polynomial with each coefficient multiplied by c.
+*/
+func (p Polynomial) Scale(c float64) Polynomial {
+	p.check("Scale()")
+	res := make(Polynomial, len(p))
+	for i := range p {
+		res[i] = c * p[i]
+	}
+	return res.trim()
+}
+
+/*
+Mul returns the product of the two Polynomials.
+*/
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	p.check("Mul()")
+	q.check("Mul()")
+	res := make(Polynomial, len(p)+len(q)-1)
+	for i := range p {
+		for j := range q {
+			res[i+j] += p[i] * q[j]
+		}
+	}
+	return res.trim()
+}
+
+/*
+Derivative returns the derivative of the Polynomial.
+*/
+func (p Polynomial) Derivative() Polynomial {
+	p.check("Derivative()")
+	if len(p) == 1 {
+		return Polynomial{0}
+	}
+	res := make(Polynomial, len(p)-1)
+	for i := 1; i < len(p); i++ {
+		res[i-1] = float64(i) * p[i]
+	}
+	return res.trim()
+}
+
+/*
+Integral returns the antiderivative of the Polynomial whose value at 0 is the
+passed constant c.
+*/
+func (p Polynomial) Integral(c float64) Polynomial {
+	p.check("Integral()")
+	res := make(Polynomial, len(p)+1)
+	res[0] = c
+	for i := range p {
+		res[i+1] = p[i] / float64(i+1)
+	}
+	return res.trim()
+}
+
+/*
+Roots returns the roots of the Polynomial, found as the eigenvalues of its
+companion matrix and refined with a few Newton iterations on the Polynomial
+itself. Roots of multiplicity m appear m times. The number of roots is the
+degree of the Polynomial, and a Polynomial of degree 0 has no roots.
+
+The zero Polynomial has infinitely many roots, and passing it to this
+function will cause a panic.
+*/
+func (p Polynomial) Roots() []complex128 {
+	p.check("Roots()")
+	p = p.trim()
+	n := len(p) - 1
+	if n == 0 {
+		if p[0] == 0.0 {
+			panic(fmt.Sprintf(errStrings[1], "Roots()"))
+		}
+		return []complex128{}
+	}
+	// Roots at zero are split off, since they are exact.
+	zeros := 0
+	for p[zeros] == 0.0 {
+		zeros++
+	}
+	p = p[zeros:]
+	n -= zeros
+	roots := make([]complex128, zeros, zeros+n)
+	if n == 0 {
+		return roots
+	}
+	// The companion matrix of the monic polynomial has ones on the
+	// subdiagonal, and the negated coefficients in the last column.
+	c := make([][]complex128, n)
+	for i := range c {
+		c[i] = make([]complex128, n)
+		if i > 0 {
+			c[i][i-1] = 1
+		}
+		c[i][n-1] = complex(-p[i]/p[n], 0)
+	}
+	eig, ok := hessenbergEigenvalues(c)
+	if !ok {
+		panic(fmt.Sprintf(errStrings[2], "Roots()"))
+	}
+	for _, z := range eig {
+		roots = append(roots, p.polish(z))
+	}
+	return roots
+}
+
+// polish refines the root estimate z with Newton's method, keeping the
+// estimate only while it improves.
+func (p Polynomial) polish(z complex128) complex128 {
+	best := cmplx.Abs(p.evalComplex(z))
+	for i := 0; i < 3 && best > 0.0; i++ {
+		v, d := complex(0, 0), complex(0, 0)
+		for j := len(p) - 1; j >= 0; j-- {
+			d = d*z + v
+			v = v*z + complex(p[j], 0)
+		}
+		if d == 0 {
+			break
+		}
+		next := z - v/d
+		r := cmplx.Abs(p.evalComplex(next))
+		if r >= best {
+			break
+		}
+		z, best = next, r
+	}
+	if math.Abs(imag(z)) <= 1e-14*math.Abs(real(z)) {
+		z = complex(real(z), 0)
+	}
+	return z
+}
+
+func (p Polynomial) evalComplex(z complex128) complex128 {
+	res := complex(0, 0)
+	for i := len(p) - 1; i >= 0; i-- {
+		res = res*z + complex(p[i], 0)
+	}
+	return res
+}
+
+// hessenbergEigenvalues returns the eigenvalues of the upper Hessenberg
+// matrix h using the single shift QR algorithm with Wilkinson shifts. h is
+// overwritten in the process. ok is false if the iteration fails to
+// converge.
+func hessenbergEigenvalues(h [][]complex128) (eig []complex128, ok bool) {
+	n := len(h)
+	eig = make([]complex128, n)
+	cs := make([]complex128, n)
+	ss := make([]complex128, n)
+	iter := 0
+	for hi := n - 1; hi >= 0; {
+		// Find the start of the unreduced block ending at hi.
+		l := hi
+		for l > 0 {
+			s := cmplx.Abs(h[l-1][l-1]) + cmplx.Abs(h[l][l])
+			if s == 0.0 {
+				s = 1.0
+			}
+			if cmplx.Abs(h[l][l-1]) <= 1e-16*s {
+				h[l][l-1] = 0
+				break
+			}
+			l--
+		}
+		if l == hi {
+			eig[hi] = h[hi][hi]
+			hi--
+			iter = 0
+			continue
+		}
+		iter++
+		if iter > 100 {
+			return nil, false
+		}
+		var mu complex128
+		if iter%10 == 0 {
+			// An exceptional shift breaks cycles of the iteration.
+			mu = h[hi][hi] + complex(cmplx.Abs(h[hi][hi-1]), 0)
+		} else {
+			a, b, c, d := h[hi-1][hi-1], h[hi-1][hi], h[hi][hi-1], h[hi][hi]
+			tr, disc := (a+d)/2, cmplx.Sqrt((a-d)*(a-d)/4+b*c)
+			mu = tr + disc
+			if cmplx.Abs(tr-disc-d) < cmplx.Abs(mu-d) {
+				mu = tr - disc
+			}
+		}
+		for k := l; k <= hi; k++ {
+			h[k][k] -= mu
+		}
+		// H - mu*I = QR, with Q a product of Givens rotations.
+		for k := l; k < hi; k++ {
+			x, y := h[k][k], h[k+1][k]
+			r := math.Hypot(cmplx.Abs(x), cmplx.Abs(y))
+			c, s := complex(1, 0), complex(0, 0)
+			if r != 0.0 {
+				c, s = x/complex(r, 0), y/complex(r, 0)
+			}
+			cs[k], ss[k] = c, s
+			for j := k; j <= hi; j++ {
+				u, v := h[k][j], h[k+1][j]
+				h[k][j] = cmplx.Conj(c)*u + cmplx.Conj(s)*v
+				h[k+1][j] = -s*u + c*v
+			}
+		}
+		// RQ + mu*I completes the step.
+		for k := l; k < hi; k++ {
+			c, s := cs[k], ss[k]
+			top := k + 2
+			if top > hi {
+				top = hi
+			}
+			for i := l; i <= top; i++ {
+				u, v := h[i][k], h[i][k+1]
+				h[i][k] = u*c + v*s
+				h[i][k+1] = -u*cmplx.Conj(s) + v*cmplx.Conj(c)
+			}
+		}
+		for k := l; k <= hi; k++ {
+			h[k][k] += mu
+		}
+	}
+	return eig, true
+}
+
+// trim returns the Polynomial without its trailing zero coefficients,
+// keeping at least the constant term.
+func (p Polynomial) trim() Polynomial {
+	n := len(p)
+	for n > 1 && p[n-1] == 0.0 {
+		n--
+	}
+	return p[:n]
+}
+
+func (p Polynomial) check(fn string) {
+	if len(p) == 0 {
+		panic(fmt.Sprintf(errStrings[0], fn))
+	}
+}
@@ -0,0 +1,161 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func equal(p, q Polynomial) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if math.Abs(p[i]-q[i]) > 1e-12 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNew(t *testing.T) {
+	p := New(1, 2, 0, 0)
+	if !equal(p, Polynomial{1, 2}) {
+		t.Errorf("expected {1, 2}, got %v", p)
+	}
+	if d := p.Degree(); d != 1 {
+		t.Errorf("expected degree 1, got %d", d)
+	}
+	if z := New(0, 0); !equal(z, Polynomial{0}) || z.Degree() != 0 {
+		t.Errorf("expected the zero Polynomial, got %v", z)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[0], "New()")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		New()
+	}()
+	wg.Wait()
+}
+
+func TestEval(t *testing.T) {
+	p := New(-2, 0, 1)
+	if v := p.Eval(3); v != 7 {
+		t.Errorf("expected 7, got %f", v)
+	}
+	v := p.EvalVec([]float64{0, 1, 2})
+	expected := []float64{-2, -1, 2}
+	for i := range v {
+		if v[i] != expected[i] {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], v[i])
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	p, q := New(1, 1), New(-1, 0, 2)
+	if s := p.Add(q); !equal(s, Polynomial{0, 1, 2}) {
+		t.Errorf("Add: expected {0, 1, 2}, got %v", s)
+	}
+	if s := p.Sub(p); !equal(s, Polynomial{0}) {
+		t.Errorf("Sub: expected {0}, got %v", s)
+	}
+	if m := p.Mul(q); !equal(m, Polynomial{-1, -1, 2, 2}) {
+		t.Errorf("Mul: expected {-1, -1, 2, 2}, got %v", m)
+	}
+	if s := q.Scale(0.5); !equal(s, Polynomial{-0.5, 0, 1}) {
+		t.Errorf("Scale: expected {-0.5, 0, 1}, got %v", s)
+	}
+	if p[0] != 1 || p[1] != 1 || q[0] != -1 || q[2] != 2 {
+		t.Errorf("the passed Polynomials were modified: %v, %v", p, q)
+	}
+}
+
+func TestCalculus(t *testing.T) {
+	p := New(5, 3, 0, 4)
+	if d := p.Derivative(); !equal(d, Polynomial{3, 0, 12}) {
+		t.Errorf("Derivative: expected {3, 0, 12}, got %v", d)
+	}
+	if d := New(5).Derivative(); !equal(d, Polynomial{0}) {
+		t.Errorf("Derivative: expected {0}, got %v", d)
+	}
+	i := p.Integral(2)
+	if !equal(i, Polynomial{2, 5, 1.5, 0, 1}) {
+		t.Errorf("Integral: expected {2, 5, 1.5, 0, 1}, got %v", i)
+	}
+	if d := i.Derivative(); !equal(d, p) {
+		t.Errorf("the derivative of the integral is %v, not %v", d, p)
+	}
+}
+
+func sortRoots(r []complex128) {
+	sort.Slice(r, func(i, j int) bool {
+		if real(r[i]) != real(r[j]) {
+			return real(r[i]) < real(r[j])
+		}
+		return imag(r[i]) < imag(r[j])
+	})
+}
+
+func TestRoots(t *testing.T) {
+	cases := []struct {
+		p     Polynomial
+		roots []complex128
+	}{
+		{New(-2, 0, 1), []complex128{complex(-math.Sqrt2, 0), complex(math.Sqrt2, 0)}},
+		{New(1, 0, 1), []complex128{-1i, 1i}},
+		{New(6, -5, 1), []complex128{2, 3}},
+		{New(0, 0, -1, 1), []complex128{0, 0, 1}},
+		// (x-1)(x-2)(x-3)(x-4)(x-5)
+		{New(-120, 274, -225, 85, -15, 1), []complex128{1, 2, 3, 4, 5}},
+		{New(-1, 0, 0, 0, 1), []complex128{-1, -1i, 1i, 1}},
+	}
+	for i, c := range cases {
+		r := c.p.Roots()
+		if len(r) != len(c.roots) {
+			t.Errorf("case %d: expected %d roots, got %v", i, len(c.roots), r)
+			continue
+		}
+		sortRoots(r)
+		for j := range r {
+			if cmplx.Abs(r[j]-c.roots[j]) > 1e-9 {
+				t.Errorf("case %d: expected %v, got %v", i, c.roots, r)
+				break
+			}
+		}
+	}
+	// Random polynomials: the residual at each root must be small.
+	p := New(0.3, -1.7, 2.2, 0.9, -4.1, 1.3, 0.7, -0.2)
+	for _, z := range p.Roots() {
+		if res := cmplx.Abs(p.evalComplex(z)); res > 1e-10 {
+			t.Errorf("the residual at %v is %g", z, res)
+		}
+	}
+	if r := New(3).Roots(); len(r) != 0 {
+		t.Errorf("expected no roots, got %v", r)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[1], "Roots()")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		New(0).Roots()
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,64 @@
+/*
+Package progress defines the observers through which the iterative routines
+of gocrunch, such as the optimizers, root finders and clustering, report
+their progress, and which can stop them early.
+
+An observer is set in the settings of a routine, and is called after each of
+its iterations with a Report. Returning false stops the routine, which then
+returns the best result reached so far along with ErrStopped. For example,
+to print the progress of a minimization, and stop it after 10 seconds:
+
+	start := time.Now()
+	res, err := optimize.Minimize(p, x0, &optimize.Settings{
+		Observer: progress.Func(func(r progress.Report) bool {
+			fmt.Printf("iteration %d: %g\n", r.Iter, r.Value)
+			return time.Since(start) < 10*time.Second
+		}),
+	})
+	if errors.Is(err, progress.ErrStopped) {
+		// res holds the best point found in 10 seconds.
+	}
+*/
+package progress
+
+import "errors"
+
+// ErrStopped is returned by a routine that was stopped by its Observer.
+var ErrStopped = errors.New("progress: stopped by the observer")
+
+// Report describes the state of a routine after one of its iterations.
+type Report struct {
+	// Iter is the number of iterations performed so far, starting from 1.
+	Iter int
+	// Value measures how far the routine has come, such as the value of
+	// the function being minimized, or the residual of a solver. Each
+	// routine documents what it reports.
+	Value float64
+}
+
+// Observer is notified of the progress of a routine.
+type Observer interface {
+	// Observe is called after each iteration, and returns false to stop
+	// the routine.
+	Observe(r Report) bool
+}
+
+// Func adapts a function to an Observer.
+type Func func(r Report) bool
+
+// Observe calls f(r).
+func (f Func) Observe(r Report) bool {
+	return f(r)
+}
+
+/*
+Continue reports the passed iteration to o, and returns whether the routine
+should continue. A nil o always continues, so that routines can call
+Continue without checking whether an Observer is set.
+*/
+func Continue(o Observer, iter int, value float64) bool {
+	if o == nil {
+		return true
+	}
+	return o.Observe(Report{Iter: iter, Value: value})
+}
@@ -0,0 +1,23 @@
+package progress
+
+import "testing"
+
+func TestContinue(t *testing.T) {
+	if !Continue(nil, 1, 0.0) {
+		t.Errorf("expected a nil Observer to continue")
+	}
+	var got []Report
+	o := Func(func(r Report) bool {
+		got = append(got, r)
+		return r.Iter < 2
+	})
+	if !Continue(o, 1, 3.0) {
+		t.Errorf("expected the first iteration to continue")
+	}
+	if Continue(o, 2, 1.5) {
+		t.Errorf("expected the second iteration to stop")
+	}
+	if len(got) != 2 || got[0] != (Report{1, 3.0}) || got[1] != (Report{2, 1.5}) {
+		t.Errorf("unexpected reports %v", got)
+	}
+}
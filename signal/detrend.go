@@ -0,0 +1,82 @@
+package signal
+
+import "fmt"
+
+// DetrendMode determines the trend removed by signal.Detrend().
+type DetrendMode int
+
+const (
+	// DetrendConstant removes the mean.
+	DetrendConstant DetrendMode = iota
+	// DetrendLinear removes the least squares line.
+	DetrendLinear
+)
+
+/*
+Detrend returns a copy of the passed []float64 with its trend removed. The
+passed mode determines whether the mean or the best fitting straight line is
+removed. For example:
+
+	v := []float64{1.0, 2.0, 4.0, 5.0}
+	signal.Detrend(v, signal.DetrendConstant) // {-2.0, -1.0, 1.0, 2.0}
+	signal.Detrend(v, signal.DetrendLinear)   // {0.1, -0.3, 0.3, -0.1}
+
+Optionally, breakpoints can be passed, in which case the []float64 is split
+into segments at these indices, and the trend of each segment is removed
+separately. For example, signal.Detrend(v, signal.DetrendLinear, 100) fits one
+line to the first 100 samples, and another line to the rest.
+
+The breakpoints must be strictly increasing, and in [1, len(v)), otherwise
+this function will panic. The original []float64 is not modified in this
+function.
+*/
+func Detrend(v []float64, mode DetrendMode, breakpoints ...int) []float64 {
+	if mode != DetrendConstant && mode != DetrendLinear {
+		panic(fmt.Sprintf(errStrings[7], "Detrend()", mode))
+	}
+	prev := 0
+	for _, bp := range breakpoints {
+		if bp <= prev || bp >= len(v) {
+			panic(fmt.Sprintf(errStrings[8], "Detrend()", bp, prev, len(v)))
+		}
+		prev = bp
+	}
+	c := make([]float64, len(v))
+	copy(c, v)
+	bounds := append(append([]int{0}, breakpoints...), len(v))
+	for i := 0; i < len(bounds)-1; i++ {
+		detrendSegment(c[bounds[i]:bounds[i+1]], mode)
+	}
+	return c
+}
+
+// detrendSegment removes the trend of v in place.
+func detrendSegment(v []float64, mode DetrendMode) {
+	n := float64(len(v))
+	if len(v) == 0 {
+		return
+	}
+	mean := 0.0
+	for i := range v {
+		mean += v[i]
+	}
+	mean /= n
+	if mode == DetrendConstant || len(v) == 1 {
+		for i := range v {
+			v[i] -= mean
+		}
+		return
+	}
+	// Fit v[i] = mean + slope*(i - tMean).
+	tMean := (n - 1.0) / 2.0
+	num, den := 0.0, 0.0
+	for i := range v {
+		dt := float64(i) - tMean
+		num += dt * (v[i] - mean)
+		den += dt * dt
+	}
+	slope := num / den
+	for i := range v {
+		v[i] -= mean + slope*(float64(i)-tMean)
+	}
+}
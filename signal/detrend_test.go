@@ -0,0 +1,34 @@
+package signal
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestDetrend(t *testing.T) {
+	v := []float64{1.0, 2.0, 4.0, 5.0}
+	checkClose(t, "constant", Detrend(v, DetrendConstant), []float64{-2.0, -1.0, 1.0, 2.0}, 1e-12)
+	checkClose(t, "linear", Detrend(v, DetrendLinear), []float64{0.1, -0.3, 0.3, -0.1}, 1e-12)
+	if v[0] != 1.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	// Two lines with different slopes are removed exactly with a breakpoint.
+	w := []float64{0.0, 1.0, 2.0, 3.0, 10.0, 8.0, 6.0}
+	checkClose(t, "piecewise", Detrend(w, DetrendLinear, 4), make([]float64, len(w)), 1e-12)
+	checkClose(t, "piecewise constant", Detrend(w, DetrendConstant, 4, 6), []float64{-1.5, -0.5, 0.5, 1.5, 1.0, -1.0, 0.0}, 1e-12)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[8], "Detrend()", 2, 4, 7)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Detrend(w, DetrendLinear, 4, 2)
+	}()
+	wg.Wait()
+}
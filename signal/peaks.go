@@ -0,0 +1,158 @@
+package signal
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+PeakOptions holds the conditions which the peaks returned by
+signal.FindPeaks() must satisfy. The zero value of PeakOptions applies no
+conditions, such that all local maxima are returned.
+*/
+type PeakOptions struct {
+	// Height, if not nil, is the minimum value of a peak.
+	Height *float64
+	// Prominence is the minimum prominence of a peak. See
+	// signal.Prominences() for its definition.
+	Prominence float64
+	// Distance is the minimum number of samples between neighboring peaks.
+	// When two peaks are closer than this, the lower one is dropped. Values
+	// smaller than 1 apply no condition.
+	Distance int
+}
+
+/*
+FindPeaks returns the indices of the peaks of the passed []float64, in
+increasing order. A peak is a sample that is larger than both of its
+neighbors. For a flat peak, spanning several equal samples, the index of the
+middle sample is returned. The first and last samples are never peaks. For
+example:
+
+	v := []float64{0.0, 2.0, 1.0, 5.0, 5.0, 5.0, 0.0, 1.0, 0.0}
+	signal.FindPeaks(v, signal.PeakOptions{})              // {1, 4, 7}
+	signal.FindPeaks(v, signal.PeakOptions{Prominence: 2}) // {4}
+
+The peaks are then filtered by the conditions in the passed PeakOptions, first
+by Height, then by Distance, and lastly by Prominence. The passed []float64 is
+not modified in this function.
+*/
+func FindPeaks(v []float64, opts PeakOptions) []int {
+	peaks := localMaxima(v)
+	if opts.Height != nil {
+		kept := peaks[:0]
+		for _, p := range peaks {
+			if v[p] >= *opts.Height {
+				kept = append(kept, p)
+			}
+		}
+		peaks = kept
+	}
+	if opts.Distance > 1 {
+		peaks = byDistance(v, peaks, opts.Distance)
+	}
+	if opts.Prominence > 0.0 {
+		prom := Prominences(v, peaks)
+		kept := peaks[:0]
+		for i, p := range peaks {
+			if prom[i] >= opts.Prominence {
+				kept = append(kept, p)
+			}
+		}
+		peaks = kept
+	}
+	return peaks
+}
+
+/*
+Prominences returns the prominence of each of the passed peaks of the passed
+[]float64. The prominence of a peak is how far it rises above the higher of
+the two lowest points that separate it from a higher sample, or from the ends
+of the []float64, on either side. Informally, it is how far one must descend
+from the peak before being able to climb to a higher one.
+
+Each of the passed indices must be within the bounds of the []float64,
+otherwise this function will panic. The passed slices are not modified in this
+function.
+*/
+func Prominences(v []float64, peaks []int) []float64 {
+	prom := make([]float64, len(peaks))
+	for i, p := range peaks {
+		if p < 0 || p >= len(v) {
+			panic(fmt.Sprintf(errStrings[6], "Prominences()", p, len(v)))
+		}
+		leftMin := v[p]
+		for j := p - 1; j >= 0 && v[j] <= v[p]; j-- {
+			if v[j] < leftMin {
+				leftMin = v[j]
+			}
+		}
+		rightMin := v[p]
+		for j := p + 1; j < len(v) && v[j] <= v[p]; j++ {
+			if v[j] < rightMin {
+				rightMin = v[j]
+			}
+		}
+		base := leftMin
+		if rightMin > base {
+			base = rightMin
+		}
+		prom[i] = v[p] - base
+	}
+	return prom
+}
+
+// localMaxima returns the indices of all local maxima of v, using the middle
+// of flat peaks.
+func localMaxima(v []float64) []int {
+	peaks := []int{}
+	for i := 1; i < len(v)-1; {
+		if !(v[i] > v[i-1]) {
+			i++
+			continue
+		}
+		j := i
+		for j+1 < len(v) && v[j+1] == v[i] {
+			j++
+		}
+		if j+1 < len(v) && v[j+1] < v[i] {
+			peaks = append(peaks, (i+j)/2)
+		}
+		i = j + 1
+	}
+	return peaks
+}
+
+// byDistance drops peaks which are closer than distance samples to a higher
+// peak, going from the highest peak down.
+func byDistance(v []float64, peaks []int, distance int) []int {
+	order := make([]int, len(peaks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return v[peaks[order[a]]] > v[peaks[order[b]]]
+	})
+	keep := make([]bool, len(peaks))
+	for i := range keep {
+		keep[i] = true
+	}
+	for _, i := range order {
+		if !keep[i] {
+			continue
+		}
+		for j := i - 1; j >= 0 && peaks[i]-peaks[j] < distance; j-- {
+			keep[j] = false
+		}
+		for j := i + 1; j < len(peaks) && peaks[j]-peaks[i] < distance; j++ {
+			keep[j] = false
+		}
+	}
+	kept := []int{}
+	for i, p := range peaks {
+		if keep[i] {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,80 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFindPeaks(t *testing.T) {
+	v := []float64{0.0, 2.0, 1.0, 5.0, 5.0, 5.0, 0.0, 1.0, 0.0}
+	if p := FindPeaks(v, PeakOptions{}); !equalInts(p, []int{1, 4, 7}) {
+		t.Errorf("expected {1, 4, 7}, got %v", p)
+	}
+	if p := FindPeaks(v, PeakOptions{Prominence: 2.0}); !equalInts(p, []int{4}) {
+		t.Errorf("expected {4}, got %v", p)
+	}
+	h := 1.5
+	if p := FindPeaks(v, PeakOptions{Height: &h}); !equalInts(p, []int{1, 4}) {
+		t.Errorf("expected {1, 4}, got %v", p)
+	}
+	if p := FindPeaks(v, PeakOptions{Distance: 4}); !equalInts(p, []int{4}) {
+		t.Errorf("expected {4}, got %v", p)
+	}
+	// Flat regions that are not peaks, and the ends, are not reported.
+	v = []float64{3.0, 1.0, 1.0, 2.0, 2.0, 4.0}
+	if p := FindPeaks(v, PeakOptions{}); len(p) != 0 {
+		t.Errorf("expected no peaks, got %v", p)
+	}
+	// A heartbeat-like signal with small ripples between the beats.
+	n := 500
+	ecg := make([]float64, n)
+	for i := range ecg {
+		ecg[i] = 0.1 * math.Sin(float64(i))
+		if i%100 == 50 {
+			ecg[i] = 1.0
+		}
+	}
+	p := FindPeaks(ecg, PeakOptions{Prominence: 0.5, Distance: 30})
+	if !equalInts(p, []int{50, 150, 250, 350, 450}) {
+		t.Errorf("expected the beats at {50, 150, 250, 350, 450}, got %v", p)
+	}
+}
+
+func TestProminences(t *testing.T) {
+	v := []float64{0.0, 2.0, 1.0, 5.0, 5.0, 5.0, 0.0, 1.0, 0.0}
+	prom := Prominences(v, []int{1, 4, 7})
+	expected := []float64{1.0, 5.0, 1.0}
+	for i := range prom {
+		if prom[i] != expected[i] {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], prom[i])
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[6], "Prominences()", 9, 9)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Prominences(v, []int{9})
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,111 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/fft"
+	"github.com/NDari/gocrunch/vec"
+	"github.com/NDari/gocrunch/window"
+)
+
+/*
+Resample returns the passed []float64 resampled to newLen samples over the
+same time span, using the Fourier method: the spectrum of the signal is
+truncated or zero padded to the new length, and transformed back. For
+example, to double the sampling rate of a signal:
+
+	y := signal.Resample(x, 2*len(x))
+
+Since the Fourier method treats the signal as periodic, a signal whose ends
+do not match shows ringing near the ends of the result. newLen must be
+greater than 0, and the passed []float64 cannot be empty, otherwise this
+function will panic. The passed []float64 is not modified in this function.
+*/
+func Resample(x []float64, newLen int) []float64 {
+	if len(x) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "Resample()", "Resample()"))
+	}
+	if newLen <= 0 {
+		panic(fmt.Sprintf(errStrings[5], "Resample()", "new length", newLen))
+	}
+	X := fft.RFFT(x)
+	n := newLen
+	if len(x) < n {
+		n = len(x)
+	}
+	Y := make([]complex128, newLen/2+1)
+	copy(Y, X[:n/2+1])
+	if n%2 == 0 {
+		// The Nyquist term of the shorter signal stands for both the positive
+		// and negative frequency terms of the longer one.
+		if newLen < len(x) {
+			Y[n/2] *= 2.0
+		} else if newLen > len(x) {
+			Y[n/2] *= 0.5
+		}
+	}
+	y := fft.IRFFT(Y, newLen)
+	scale := float64(newLen) / float64(len(x))
+	for i := range y {
+		y[i] *= scale
+	}
+	return y
+}
+
+/*
+Decimate reduces the sampling rate of the passed []float64 by an integer
+factor, returning every factor-th sample after removing the frequencies which
+cannot be represented at the lower rate. This is done with a zero phase FIR
+lowpass filter, so that features of the signal are not delayed. The result
+has (len(x)+factor-1)/factor samples. For example, to go from 1000Hz to 250Hz:
+
+	y := signal.Decimate(x, 4)
+
+factor must be greater than 0, otherwise this function will panic. The passed
+[]float64 is not modified in this function.
+*/
+func Decimate(x []float64, factor int) []float64 {
+	if factor <= 0 {
+		panic(fmt.Sprintf(errStrings[5], "Decimate()", "factor", factor))
+	}
+	if factor == 1 || len(x) == 0 {
+		return vec.Clone(x)
+	}
+	h := firLowpass(20*factor+1, 1.0/float64(factor))
+	half := (len(h) - 1) / 2
+	var filtered []float64
+	if len(x) == 1 {
+		filtered = vec.Clone(x)
+	} else {
+		padded := vec.Pad(x, half, half, vec.PadReflect)
+		filtered = vec.Convolve(padded, h, vec.ConvValid)
+	}
+	y := make([]float64, 0, (len(x)+factor-1)/factor)
+	for i := 0; i < len(filtered); i += factor {
+		y = append(y, filtered[i])
+	}
+	return y
+}
+
+// firLowpass returns the taps of a Hamming windowed sinc lowpass filter with
+// the passed cutoff, normalized to unit gain at DC.
+func firLowpass(taps int, cutoff float64) []float64 {
+	w := window.Hamming(taps)
+	h := make([]float64, taps)
+	mid := float64(taps-1) / 2.0
+	for i := range h {
+		t := float64(i) - mid
+		if t == 0.0 {
+			h[i] = cutoff
+		} else {
+			h[i] = math.Sin(math.Pi*cutoff*t) / (math.Pi * t)
+		}
+		h[i] *= w[i]
+	}
+	sum := vec.Sum(h)
+	for i := range h {
+		h[i] /= sum
+	}
+	return h
+}
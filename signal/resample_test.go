@@ -0,0 +1,67 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestResample(t *testing.T) {
+	// A periodic band limited signal is resampled exactly.
+	f := func(t float64) float64 {
+		return math.Sin(2.0*math.Pi*t) + 0.5*math.Cos(2.0*math.Pi*3.0*t)
+	}
+	sample := func(n int) []float64 {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = f(float64(i) / float64(n))
+		}
+		return x
+	}
+	for _, lens := range [][2]int{{16, 32}, {16, 15}, {20, 50}, {33, 16}, {21, 21}} {
+		y := Resample(sample(lens[0]), lens[1])
+		checkClose(t, fmt.Sprintf("%d to %d", lens[0], lens[1]), y, sample(lens[1]), 1e-9)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[5], "Resample()", "new length", 0)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Resample([]float64{1.0}, 0)
+	}()
+	wg.Wait()
+}
+
+func TestDecimate(t *testing.T) {
+	n := 400
+	x := make([]float64, n)
+	slow := make([]float64, n)
+	for i := range x {
+		slow[i] = math.Sin(2.0 * math.Pi * 0.01 * float64(i))
+		// The fast component is above the Nyquist frequency after decimation.
+		x[i] = slow[i] + math.Sin(2.0*math.Pi*0.4*float64(i))
+	}
+	y := Decimate(x, 4)
+	if len(y) != 100 {
+		t.Fatalf("expected 100 samples, got %d", len(y))
+	}
+	for i := 10; i < len(y)-10; i++ {
+		if math.Abs(y[i]-slow[4*i]) > 1e-2 {
+			t.Errorf("at index %d, expected %f, got %f", i, slow[4*i], y[i])
+			break
+		}
+	}
+	if y = Decimate([]float64{1.0, 2.0, 3.0}, 2); len(y) != 2 {
+		t.Errorf("expected 2 samples, got %d", len(y))
+	}
+	if y = Decimate(x, 1); len(y) != n || y[5] != x[5] {
+		t.Errorf("expected a copy for a factor of 1")
+	}
+}
@@ -0,0 +1,349 @@
+/*
+Package signal implements digital signal processing routines for signals
+stored as one dimensional slices of float64.
+
+Filters in this package are described by the coefficients of their transfer
+function,
+
+	         b[0] + b[1]*z^-1 + ... + b[M]*z^-M
+	H(z) = --------------------------------------
+	         a[0] + a[1]*z^-1 + ... + a[N]*z^-N
+
+such that a FIR filter has a == []float64{1.0}. Filters are applied with
+signal.Lfilter(), or with signal.FiltFilt() for zero phase distortion, and
+Butterworth filters can be designed with signal.ButterLowpass() and its
+siblings. Frequencies are normalized to the Nyquist frequency, so that 1.0 is
+half of the sampling rate. For example, to remove everything above 10Hz from
+a signal sampled at 100Hz:
+
+	b, a := signal.ButterLowpass(4, 10.0/50.0)
+	smooth := signal.FiltFilt(b, a, x)
+
+As with the other packages in gocrunch, invalid input is treated as
+a critical error, and causes a panic with a message that names the
+offending function.
+*/
+package signal
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/signal error.\nIn signal.%s, cannot use %s on an empty []float64.\n",
+		"\ngocrunch/signal error.\nIn signal.%s, the first element of the denominator cannot be 0.0.\n",
+		"\ngocrunch/signal error.\nIn signal.%s, the order of the filter must be greater than 0, received %d.\n",
+		"\ngocrunch/signal error.\nIn signal.%s, the cutoff frequency %f must be in (0, 1).\n",
+		"\ngocrunch/signal error.\nIn signal.%s, the low cutoff %f must be less than the high cutoff %f.\n",
+		"\ngocrunch/signal error.\nIn signal.%s, the %s must be greater than 0, received %d.\n",
+		"\ngocrunch/signal error.\nIn signal.%s, %d is outside of range [0, %d).\n",
+		"\ngocrunch/signal error.\nIn signal.%s, unknown mode %d.\n",
+		"\ngocrunch/signal error.\nIn signal.%s, the breakpoint %d must be greater than %d, and less than %d.\n",
+		"\ngocrunch/signal error.\nIn signal.%s, the %s cannot be empty.\n",
+	}
+)
+
+/*
+Lfilter applies the filter with numerator b and denominator a to the passed
+[]float64, and returns the filtered signal, which has the same length as the
+original. The filter starts from rest, as if it had only seen zeros before the
+first element of the signal. For example:
+
+	b := []float64{1.0}
+	a := []float64{1.0, -0.5}
+	x := []float64{1.0, 0.0, 0.0}
+	y := signal.Lfilter(b, a, x) // y is {1.0, 0.5, 0.25}
+
+Neither b nor a can be empty, and a[0] cannot be 0.0, otherwise this function
+will panic. The passed []float64s are not modified in this function.
+*/
+func Lfilter(b, a, x []float64) []float64 {
+	b, a = normalize("Lfilter()", b, a)
+	y, _ := lfilter(b, a, x, nil)
+	return y
+}
+
+/*
+FiltFilt applies the filter with numerator b and denominator a to the passed
+[]float64 twice, once forward and once backward, which cancels the phase shift
+of the filter, such that features of the signal are not delayed. The
+magnitude response of the result is the square of that of the filter.
+
+To reduce the transients at the ends of the signal, it is extended by
+reflecting it about its end points before filtering, and the filter state is
+initialized to its steady state for the first extended sample.
+
+Neither b nor a can be empty, and a[0] cannot be 0.0, otherwise this function
+will panic. The passed []float64s are not modified in this function.
+*/
+func FiltFilt(b, a, x []float64) []float64 {
+	b, a = normalize("FiltFilt()", b, a)
+	if len(x) == 0 {
+		return []float64{}
+	}
+	n := len(b)
+	if len(a) > n {
+		n = len(a)
+	}
+	edge := 3 * n
+	if edge > len(x)-1 {
+		edge = len(x) - 1
+	}
+	ext := make([]float64, 0, len(x)+2*edge)
+	for i := edge; i > 0; i-- {
+		ext = append(ext, 2.0*x[0]-x[i])
+	}
+	ext = append(ext, x...)
+	last := x[len(x)-1]
+	for i := len(x) - 2; i >= len(x)-1-edge; i-- {
+		ext = append(ext, 2.0*last-x[i])
+	}
+	zi := steadyState(b, a)
+	y, _ := lfilter(b, a, ext, scaled(zi, ext[0]))
+	reverse(y)
+	y, _ = lfilter(b, a, y, scaled(zi, y[0]))
+	reverse(y)
+	return y[edge : edge+len(x)]
+}
+
+/*
+ButterLowpass designs a digital Butterworth lowpass filter of the given order,
+returning the numerator b and denominator a of its transfer function. The
+cutoff frequency, where the gain of the filter falls to 1/sqrt(2), is
+normalized to the Nyquist frequency, and must be in (0, 1).
+
+The order must be greater than 0, otherwise this function will panic.
+*/
+func ButterLowpass(order int, cutoff float64) (b, a []float64) {
+	checkOrder("ButterLowpass()", order)
+	checkCutoff("ButterLowpass()", cutoff)
+	w := warp(cutoff)
+	p := butterPoles(order)
+	for i := range p {
+		p[i] *= complex(w, 0.0)
+	}
+	return bilinear(nil, p, math.Pow(w, float64(order)))
+}
+
+/*
+ButterHighpass designs a digital Butterworth highpass filter of the given
+order, returning the numerator b and denominator a of its transfer function.
+The cutoff frequency, where the gain of the filter falls to 1/sqrt(2), is
+normalized to the Nyquist frequency, and must be in (0, 1).
+
+The order must be greater than 0, otherwise this function will panic.
+*/
+func ButterHighpass(order int, cutoff float64) (b, a []float64) {
+	checkOrder("ButterHighpass()", order)
+	checkCutoff("ButterHighpass()", cutoff)
+	w := warp(cutoff)
+	p := butterPoles(order)
+	z := make([]complex128, order)
+	prod := complex(1.0, 0.0)
+	for i := range p {
+		prod *= -p[i]
+		p[i] = complex(w, 0.0) / p[i]
+	}
+	return bilinear(z, p, real(1.0/prod))
+}
+
+/*
+ButterBandpass designs a digital Butterworth bandpass filter of the given
+order, returning the numerator b and denominator a of its transfer function.
+The resulting filter has twice the given order. The low and high cutoff
+frequencies, where the gain of the filter falls to 1/sqrt(2), are normalized
+to the Nyquist frequency, and must be in (0, 1), with low less than high.
+
+The order must be greater than 0, otherwise this function will panic.
+*/
+func ButterBandpass(order int, low, high float64) (b, a []float64) {
+	checkOrder("ButterBandpass()", order)
+	checkCutoff("ButterBandpass()", low)
+	checkCutoff("ButterBandpass()", high)
+	if !(low < high) {
+		panic(fmt.Sprintf(errStrings[4], "ButterBandpass()", low, high))
+	}
+	w1, w2 := warp(low), warp(high)
+	bw := complex(w2-w1, 0.0)
+	w02 := complex(w1*w2, 0.0)
+	proto := butterPoles(order)
+	p := make([]complex128, 0, 2*order)
+	for i := range proto {
+		pl := proto[i] * bw / 2.0
+		r := cmplx.Sqrt(pl*pl - w02)
+		p = append(p, pl+r, pl-r)
+	}
+	z := make([]complex128, order)
+	return bilinear(z, p, math.Pow(w2-w1, float64(order)))
+}
+
+// lfilter applies the normalized filter b, a to x in the transposed direct
+// form II, starting from the state zi, or from rest if zi is nil. It returns
+// the filtered signal and the final state.
+func lfilter(b, a, x, zi []float64) ([]float64, []float64) {
+	n := len(b)
+	if len(a) > n {
+		n = len(a)
+	}
+	b, a = padTo(b, n), padTo(a, n)
+	z := make([]float64, n)
+	copy(z, zi)
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = b[0]*x[i] + z[0]
+		for j := 1; j < n; j++ {
+			z[j-1] = b[j]*x[i] + z[j] - a[j]*y[i]
+		}
+	}
+	return y, z[:n-1]
+}
+
+// steadyState returns the state of the filter b, a after it has seen a
+// constant input of 1.0 forever, such that scaling it by the first sample of
+// a signal suppresses the transient at the start.
+func steadyState(b, a []float64) []float64 {
+	n := len(b)
+	if len(a) > n {
+		n = len(a)
+	}
+	b, a = padTo(b, n), padTo(a, n)
+	zi := make([]float64, n-1)
+	if n == 1 {
+		return zi
+	}
+	num, den := 0.0, 1.0
+	for k := 1; k < n; k++ {
+		num += b[k] - a[k]*b[0]
+		den += a[k]
+	}
+	zi[0] = num / den
+	asum, csum := 1.0, 0.0
+	for k := 1; k < n-1; k++ {
+		asum += a[k]
+		csum += b[k] - a[k]*b[0]
+		zi[k] = asum*zi[0] - csum
+	}
+	return zi
+}
+
+// normalize checks the filter coefficients, and returns copies of them
+// scaled such that a[0] is 1.0.
+func normalize(fn string, b, a []float64) ([]float64, []float64) {
+	if len(b) == 0 {
+		panic(fmt.Sprintf(errStrings[9], fn, "numerator"))
+	}
+	if len(a) == 0 {
+		panic(fmt.Sprintf(errStrings[9], fn, "denominator"))
+	}
+	if a[0] == 0.0 {
+		panic(fmt.Sprintf(errStrings[1], fn))
+	}
+	nb, na := make([]float64, len(b)), make([]float64, len(a))
+	for i := range b {
+		nb[i] = b[i] / a[0]
+	}
+	for i := range a {
+		na[i] = a[i] / a[0]
+	}
+	return nb, na
+}
+
+// butterPoles returns the poles of the analog Butterworth lowpass prototype
+// of the given order, with a cutoff of 1 rad/s.
+func butterPoles(order int) []complex128 {
+	p := make([]complex128, order)
+	for k := range p {
+		theta := math.Pi * float64(2*k+order+1) / float64(2*order)
+		p[k] = cmplx.Rect(1.0, theta)
+	}
+	return p
+}
+
+// warp returns the analog frequency which the bilinear transform maps to the
+// normalized digital frequency w.
+func warp(w float64) float64 {
+	return 4.0 * math.Tan(math.Pi*w/2.0)
+}
+
+// bilinear maps the analog filter with zeros z, poles p and gain k to a
+// digital filter through the bilinear transform, and returns its transfer
+// function coefficients.
+func bilinear(z, p []complex128, k float64) (b, a []float64) {
+	const fs2 = 4.0
+	num := complex(1.0, 0.0)
+	den := complex(1.0, 0.0)
+	zd := make([]complex128, 0, len(p))
+	pd := make([]complex128, len(p))
+	for i := range z {
+		num *= fs2 - z[i]
+		zd = append(zd, (fs2+z[i])/(fs2-z[i]))
+	}
+	for i := range p {
+		den *= fs2 - p[i]
+		pd[i] = (fs2 + p[i]) / (fs2 - p[i])
+	}
+	// Zeros at infinity in the analog domain map to the Nyquist frequency.
+	for len(zd) < len(pd) {
+		zd = append(zd, -1.0)
+	}
+	gain := k * real(num/den)
+	b = realPoly(zd)
+	for i := range b {
+		b[i] *= gain
+	}
+	return b, realPoly(pd)
+}
+
+// realPoly returns the real parts of the coefficients of the polynomial with
+// the passed roots, starting from the highest power.
+func realPoly(roots []complex128) []float64 {
+	c := []complex128{1.0}
+	for _, r := range roots {
+		next := make([]complex128, len(c)+1)
+		for i := range c {
+			next[i] += c[i]
+			next[i+1] -= c[i] * r
+		}
+		c = next
+	}
+	p := make([]float64, len(c))
+	for i := range c {
+		p[i] = real(c[i])
+	}
+	return p
+}
+
+func checkOrder(fn string, order int) {
+	if order <= 0 {
+		panic(fmt.Sprintf(errStrings[2], fn, order))
+	}
+}
+
+func checkCutoff(fn string, w float64) {
+	if !(w > 0.0 && w < 1.0) {
+		panic(fmt.Sprintf(errStrings[3], fn, w))
+	}
+}
+
+func padTo(v []float64, n int) []float64 {
+	c := make([]float64, n)
+	copy(c, v)
+	return c
+}
+
+func scaled(v []float64, s float64) []float64 {
+	c := make([]float64, len(v))
+	for i := range v {
+		c[i] = v[i] * s
+	}
+	return c
+}
+
+func reverse(v []float64) {
+	for i, j := 0, len(v)-1; i < j; i, j = i+1, j-1 {
+		v[i], v[j] = v[j], v[i]
+	}
+}
@@ -0,0 +1,190 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sync"
+	"testing"
+)
+
+// gain returns the magnitude of the frequency response of the filter b, a at
+// the normalized frequency w.
+func gain(b, a []float64, w float64) float64 {
+	z := cmplx.Rect(1.0, -math.Pi*w)
+	num, den := complex(0.0, 0.0), complex(0.0, 0.0)
+	zk := complex(1.0, 0.0)
+	for i := 0; i < len(b) || i < len(a); i++ {
+		if i < len(b) {
+			num += complex(b[i], 0.0) * zk
+		}
+		if i < len(a) {
+			den += complex(a[i], 0.0) * zk
+		}
+		zk *= z
+	}
+	return cmplx.Abs(num / den)
+}
+
+func checkClose(t *testing.T, name string, got, expected []float64, tol float64) {
+	if len(got) != len(expected) {
+		t.Errorf("%s: expected length %d, got %d", name, len(expected), len(got))
+		return
+	}
+	for i := range got {
+		if math.Abs(got[i]-expected[i]) > tol {
+			t.Errorf("%s: at index %d, expected %f, got %f", name, i, expected[i], got[i])
+		}
+	}
+}
+
+func TestLfilter(t *testing.T) {
+	y := Lfilter([]float64{1.0}, []float64{1.0, -0.5}, []float64{1.0, 0.0, 0.0})
+	checkClose(t, "IIR", y, []float64{1.0, 0.5, 0.25}, 1e-15)
+	y = Lfilter([]float64{0.5, 0.5}, []float64{1.0}, []float64{2.0, 4.0, 6.0})
+	checkClose(t, "FIR", y, []float64{1.0, 3.0, 5.0}, 1e-15)
+	y = Lfilter([]float64{2.0}, []float64{2.0, -1.0}, []float64{1.0, 0.0})
+	checkClose(t, "unnormalized", y, []float64{1.0, 0.5}, 1e-15)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[1], "Lfilter()")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Lfilter([]float64{1.0}, []float64{0.0, 1.0}, []float64{1.0})
+	}()
+	wg.Wait()
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { Lfilter(nil, []float64{1.0}, []float64{1.0}) },
+			fmt.Sprintf(errStrings[9], "Lfilter()", "numerator"),
+		},
+		{
+			func() { Lfilter([]float64{1.0}, nil, []float64{1.0}) },
+			fmt.Sprintf(errStrings[9], "Lfilter()", "denominator"),
+		},
+	}
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestFiltFilt(t *testing.T) {
+	b, a := ButterLowpass(4, 0.1)
+	n := 400
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Sin(2.0 * math.Pi * 0.005 * float64(i))
+	}
+	y := FiltFilt(b, a, x)
+	if len(y) != n {
+		t.Fatalf("expected length %d, got %d", n, len(y))
+	}
+	// A slow sine passes with no delay, while Lfilter delays it.
+	for i := 50; i < n-50; i++ {
+		if math.Abs(y[i]-x[i]) > 1e-3 {
+			t.Errorf("at index %d, expected %f, got %f", i, x[i], y[i])
+			break
+		}
+	}
+	// A constant signal passes unchanged, without transients.
+	c := make([]float64, 30)
+	for i := range c {
+		c[i] = 3.0
+	}
+	checkClose(t, "constant", FiltFilt(b, a, c), c, 1e-9)
+	if y = FiltFilt(b, a, []float64{}); len(y) != 0 {
+		t.Errorf("expected an empty result, got %v", y)
+	}
+}
+
+func TestButterLowpass(t *testing.T) {
+	b, a := ButterLowpass(2, 0.5)
+	checkClose(t, "b", b, []float64{0.29289321881345254, 0.5857864376269051, 0.29289321881345254}, 1e-12)
+	checkClose(t, "a", a, []float64{1.0, 0.0, 0.17157287525381}, 1e-12)
+	b, a = ButterLowpass(5, 0.2)
+	if g := gain(b, a, 0.0); math.Abs(g-1.0) > 1e-9 {
+		t.Errorf("expected unit gain at DC, got %f", g)
+	}
+	if g := gain(b, a, 0.2); math.Abs(g-1.0/math.Sqrt2) > 1e-9 {
+		t.Errorf("expected a gain of 1/sqrt(2) at the cutoff, got %f", g)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[3], "ButterLowpass()", 1.5)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		ButterLowpass(2, 1.5)
+	}()
+	wg.Wait()
+}
+
+func TestButterHighpass(t *testing.T) {
+	b, a := ButterHighpass(2, 0.5)
+	checkClose(t, "b", b, []float64{0.29289321881345254, -0.5857864376269051, 0.29289321881345254}, 1e-12)
+	checkClose(t, "a", a, []float64{1.0, 0.0, 0.17157287525381}, 1e-12)
+	b, a = ButterHighpass(3, 0.3)
+	if g := gain(b, a, 1.0); math.Abs(g-1.0) > 1e-9 {
+		t.Errorf("expected unit gain at Nyquist, got %f", g)
+	}
+	if g := gain(b, a, 0.3); math.Abs(g-1.0/math.Sqrt2) > 1e-9 {
+		t.Errorf("expected a gain of 1/sqrt(2) at the cutoff, got %f", g)
+	}
+}
+
+func TestButterBandpass(t *testing.T) {
+	b, a := ButterBandpass(3, 0.2, 0.4)
+	if len(b) != 7 || len(a) != 7 {
+		t.Errorf("expected 7 coefficients, got %d and %d", len(b), len(a))
+	}
+	for _, w := range []float64{0.2, 0.4} {
+		if g := gain(b, a, w); math.Abs(g-1.0/math.Sqrt2) > 1e-9 {
+			t.Errorf("expected a gain of 1/sqrt(2) at %f, got %f", w, g)
+		}
+	}
+	if g := gain(b, a, 0.0); g > 1e-9 {
+		t.Errorf("expected no gain at DC, got %f", g)
+	}
+	if g := gain(b, a, 1.0); g > 1e-9 {
+		t.Errorf("expected no gain at Nyquist, got %f", g)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[4], "ButterBandpass()", 0.4, 0.2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		ButterBandpass(3, 0.4, 0.2)
+	}()
+	wg.Wait()
+}
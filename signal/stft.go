@@ -0,0 +1,60 @@
+package signal
+
+import (
+	"fmt"
+
+	"github.com/NDari/gocrunch/fft"
+)
+
+/*
+STFT returns the short-time Fourier transform of the passed []float64. The
+signal is split into frames of len(window) samples, with the start of each
+frame hop samples after the start of the previous one. Each frame is
+multiplied by the window, and transformed with fft.RFFT(). For example:
+
+	w := window.Hann(256)
+	s := signal.STFT(x, w, 64)
+
+Row i of the result holds the len(window)/2+1 non-negative frequency terms of
+the frame starting at sample i*hop, and only frames which fit entirely within
+the signal are returned. Use fft.RFFTFreq() to find the frequency of each
+column.
+
+The window cannot be empty, and hop must be greater than 0, otherwise this
+function will panic. The passed []float64s are not modified in this function.
+*/
+func STFT(v, window []float64, hop int) [][]complex128 {
+	if len(window) == 0 {
+		panic(fmt.Sprintf(errStrings[9], "STFT()", "window"))
+	}
+	if hop <= 0 {
+		panic(fmt.Sprintf(errStrings[5], "STFT()", "hop", hop))
+	}
+	frames := [][]complex128{}
+	frame := make([]float64, len(window))
+	for start := 0; start+len(window) <= len(v); start += hop {
+		for i := range window {
+			frame[i] = v[start+i] * window[i]
+		}
+		frames = append(frames, fft.RFFT(frame))
+	}
+	return frames
+}
+
+/*
+Spectrogram returns the squared magnitude of the short-time Fourier transform
+of the passed []float64, which is the power in each frequency bin for each
+frame. See signal.STFT() for the meaning of the arguments, and the layout of
+the result.
+*/
+func Spectrogram(v, window []float64, hop int) [][]float64 {
+	s := STFT(v, window, hop)
+	p := make([][]float64, len(s))
+	for i := range s {
+		p[i] = make([]float64, len(s[i]))
+		for j, c := range s[i] {
+			p[i][j] = real(c)*real(c) + imag(c)*imag(c)
+		}
+	}
+	return p
+}
@@ -0,0 +1,80 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/window"
+)
+
+func TestSTFT(t *testing.T) {
+	// A tone which jumps from bin 4 to bin 12 half way through.
+	n, size := 1024, 64
+	v := make([]float64, n)
+	for i := range v {
+		f := 4.0
+		if i >= n/2 {
+			f = 12.0
+		}
+		v[i] = math.Sin(2.0 * math.Pi * f * float64(i) / float64(size))
+	}
+	w := window.Hann(size)
+	s := STFT(v, w, 32)
+	if len(s) != (n-size)/32+1 {
+		t.Fatalf("expected %d frames, got %d", (n-size)/32+1, len(s))
+	}
+	if len(s[0]) != size/2+1 {
+		t.Fatalf("expected %d bins, got %d", size/2+1, len(s[0]))
+	}
+	p := Spectrogram(v, w, 32)
+	peak := func(row []float64) int {
+		best := 0
+		for j := range row {
+			if row[j] > row[best] {
+				best = j
+			}
+		}
+		return best
+	}
+	if b := peak(p[0]); b != 4 {
+		t.Errorf("expected the first frame to peak at bin 4, got %d", b)
+	}
+	if b := peak(p[len(p)-1]); b != 12 {
+		t.Errorf("expected the last frame to peak at bin 12, got %d", b)
+	}
+	if c := s[3][5]; math.Abs(real(c)*real(c)+imag(c)*imag(c)-p[3][5]) > 1e-9 {
+		t.Errorf("the spectrogram does not match the STFT")
+	}
+	if s = STFT(v[:10], w, 32); len(s) != 0 {
+		t.Errorf("expected no frames for a short signal, got %d", len(s))
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[5], "STFT()", "hop", 0)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		STFT(v, w, 0)
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[9], "STFT()", "window")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		STFT(v, nil, 32)
+	}()
+	wg.Wait()
+}
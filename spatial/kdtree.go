@@ -0,0 +1,309 @@
+/*
+Package spatial implements data structures for proximity searches among points
+stored as the rows of a matrix.
+
+A KDTree is built once from the points, and then answers nearest neighbor and
+radius queries without comparing the query to every point:
+
+	tree, err := spatial.NewKDTree(X)
+	nn, err := tree.KNN([]float64{0.5, 0.5}, 3) // the 3 rows nearest to {0.5, 0.5}
+	nn[0].Index                                 // the index of the nearest row in X
+	nn[0].Dist                                  // its euclidean distance to {0.5, 0.5}
+
+Invalid arguments, such as an empty matrix, are treated as critical errors,
+and cause a panic with a message that names the offending function, as with
+the other packages in gocrunch. Points of different lengths, like in the
+metric package, are reported with an error wrapping metric.ErrLength.
+*/
+package spatial
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/NDari/gocrunch/check"
+	"github.com/NDari/gocrunch/errs"
+	"github.com/NDari/gocrunch/metric"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/spatial error.\nIn spatial.%s, the matrix cannot be empty.\n",
+		"\ngocrunch/spatial error.\nIn spatial.%s, the %s must not be negative, received %v.\n",
+	}
+)
+
+// leafSize is the largest number of points in a leaf of the tree, which
+// are compared to the query one by one.
+const leafSize = 8
+
+// Neighbor is a point found by a query.
+type Neighbor struct {
+	// Index is the index of the point in the matrix the KDTree was built
+	// from, and Dist its euclidean distance to the query.
+	Index int
+	Dist  float64
+}
+
+/*
+KDTree is a k-d tree, which recursively splits the points in halves along
+the dimension in which they are most spread out.
+*/
+type KDTree struct {
+	points [][]float64
+	// idx holds the indices of the points, ordered such that the points of
+	// each node are contiguous.
+	idx   []int
+	nodes []node
+}
+
+// node holds the points idx[lo:hi]. An inner node splits them at the value
+// split along dim, into its left and right children.
+type node struct {
+	lo, hi      int
+	dim         int
+	split       float64
+	left, right int
+}
+
+/*
+NewKDTree builds a KDTree from the rows of X. The KDTree refers to the rows
+of X rather than copying them, so X must not be modified while the KDTree is
+used.
+
+X must not be empty, otherwise this function will panic. If the rows of X do
+not all have the same length, an error wrapping metric.ErrLength is returned.
+*/
+func NewKDTree(X [][]float64) (*KDTree, error) {
+	if len(X) == 0 || len(X[0]) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "NewKDTree()"))
+	}
+	if err := check.SameLen(X...); err != nil {
+		return nil, fmt.Errorf("%w: the rows of X: %w", metric.ErrLength, err)
+	}
+	t := &KDTree{points: X, idx: make([]int, len(X))}
+	for i := range t.idx {
+		t.idx[i] = i
+	}
+	t.build(0, len(X))
+	return t, nil
+}
+
+// Len returns the number of points in the KDTree.
+func (t *KDTree) Len() int {
+	return len(t.points)
+}
+
+// build adds the node holding idx[lo:hi], and its children, returning its
+// index in t.nodes.
+func (t *KDTree) build(lo, hi int) int {
+	n := len(t.nodes)
+	t.nodes = append(t.nodes, node{lo: lo, hi: hi, left: -1, right: -1})
+	if hi-lo <= leafSize {
+		return n
+	}
+	dim, spread := 0, -1.0
+	for d := range t.points[0] {
+		min, max := math.Inf(1), math.Inf(-1)
+		for _, i := range t.idx[lo:hi] {
+			min = math.Min(min, t.points[i][d])
+			max = math.Max(max, t.points[i][d])
+		}
+		if max-min > spread {
+			dim, spread = d, max-min
+		}
+	}
+	if spread == 0.0 {
+		// All the points coincide, and cannot be split.
+		return n
+	}
+	mid := (lo + hi) / 2
+	t.selectAt(lo, hi, mid, dim)
+	t.nodes[n].dim = dim
+	t.nodes[n].split = t.points[t.idx[mid]][dim]
+	left := t.build(lo, mid)
+	right := t.build(mid, hi)
+	t.nodes[n].left, t.nodes[n].right = left, right
+	return n
+}
+
+// selectAt reorders idx[lo:hi] such that the point at idx[k] has the value
+// along dim it would have if they were sorted along dim, with no greater
+// values before it and no lesser values after it.
+func (t *KDTree) selectAt(lo, hi, k, dim int) {
+	val := func(i int) float64 { return t.points[t.idx[i]][dim] }
+	hi--
+	for lo < hi {
+		pivot := val((lo + hi) / 2)
+		i, j := lo, hi
+		for i <= j {
+			for val(i) < pivot {
+				i++
+			}
+			for val(j) > pivot {
+				j--
+			}
+			if i <= j {
+				t.idx[i], t.idx[j] = t.idx[j], t.idx[i]
+				i++
+				j--
+			}
+		}
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
+
+/*
+KNN returns the k points nearest to q, sorted by increasing distance. Points
+at equal distances are sorted by increasing index. If the KDTree holds fewer
+than k points, all of them are returned.
+
+k must not be negative, otherwise this function will panic. If q does not
+have the length of the points, an error wrapping metric.ErrLength is
+returned.
+*/
+func (t *KDTree) KNN(q []float64, k int) ([]Neighbor, error) {
+	if k < 0 {
+		panic(fmt.Sprintf(errStrings[1], "KNN()", "number of neighbors", k))
+	}
+	if err := t.check(q); err != nil {
+		return nil, err
+	}
+	if k > len(t.points) {
+		k = len(t.points)
+	}
+	h := &maxHeap{}
+	if k > 0 {
+		t.knn(0, q, k, h)
+	}
+	return h.sorted(), nil
+}
+
+func (t *KDTree) knn(n int, q []float64, k int, h *maxHeap) {
+	nd := t.nodes[n]
+	if nd.left < 0 {
+		for _, i := range t.idx[nd.lo:nd.hi] {
+			nb := Neighbor{i, sqDist(q, t.points[i])}
+			if h.Len() < k {
+				heap.Push(h, nb)
+			} else if less(nb, (*h)[0]) {
+				(*h)[0] = nb
+				heap.Fix(h, 0)
+			}
+		}
+		return
+	}
+	diff := q[nd.dim] - nd.split
+	near, far := nd.left, nd.right
+	if diff >= 0.0 {
+		near, far = far, near
+	}
+	t.knn(near, q, k, h)
+	if h.Len() < k || diff*diff <= (*h)[0].Dist {
+		t.knn(far, q, k, h)
+	}
+}
+
+/*
+Radius returns the points within the distance r of q, including those at
+exactly r, sorted by increasing distance. Points at equal distances are
+sorted by increasing index.
+
+r must not be negative, otherwise this function will panic. If q does not
+have the length of the points, an error wrapping metric.ErrLength is
+returned.
+*/
+func (t *KDTree) Radius(q []float64, r float64) ([]Neighbor, error) {
+	if !(r >= 0.0) {
+		panic(fmt.Sprintf(errStrings[1], "Radius()", "radius", r))
+	}
+	if err := t.check(q); err != nil {
+		return nil, err
+	}
+	found := []Neighbor{}
+	t.radius(0, q, r*r, &found)
+	for i := range found {
+		found[i].Dist = math.Sqrt(found[i].Dist)
+	}
+	sort.Slice(found, func(a, b int) bool { return less(found[a], found[b]) })
+	return found, nil
+}
+
+func (t *KDTree) radius(n int, q []float64, r2 float64, found *[]Neighbor) {
+	nd := t.nodes[n]
+	if nd.left < 0 {
+		for _, i := range t.idx[nd.lo:nd.hi] {
+			if d := sqDist(q, t.points[i]); d <= r2 {
+				*found = append(*found, Neighbor{i, d})
+			}
+		}
+		return
+	}
+	diff := q[nd.dim] - nd.split
+	if diff <= 0.0 || diff*diff <= r2 {
+		t.radius(nd.left, q, r2, found)
+	}
+	if diff >= 0.0 || diff*diff <= r2 {
+		t.radius(nd.right, q, r2, found)
+	}
+}
+
+func (t *KDTree) check(q []float64) error {
+	if len(q) != len(t.points[0]) {
+		return &errs.ShapeError{
+			Got:  []int{len(q)},
+			Want: []int{len(t.points[0])},
+			Err:  fmt.Errorf("%w: the query has length %d, expected %d", metric.ErrLength, len(q), len(t.points[0])),
+		}
+	}
+	return nil
+}
+
+// sqDist returns the squared euclidean distance between a and b, which
+// orders points like the distance itself without a square root per point.
+func sqDist(a, b []float64) float64 {
+	d, _ := metric.SqEuclidean(a, b)
+	return d
+}
+
+func less(a, b Neighbor) bool {
+	if a.Dist != b.Dist {
+		return a.Dist < b.Dist
+	}
+	return a.Index < b.Index
+}
+
+// maxHeap holds the nearest neighbors found so far, with the farthest one
+// at the top. The distances in it are squared.
+type maxHeap []Neighbor
+
+func (h maxHeap) Len() int            { return len(h) }
+func (h maxHeap) Less(i, j int) bool  { return less(h[j], h[i]) }
+func (h maxHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x interface{}) { *h = append(*h, x.(Neighbor)) }
+func (h *maxHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// sorted returns the neighbors in the heap by increasing distance, with the
+// distances no longer squared.
+func (h *maxHeap) sorted() []Neighbor {
+	res := make([]Neighbor, h.Len())
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = heap.Pop(h).(Neighbor)
+		res[i].Dist = math.Sqrt(res[i].Dist)
+	}
+	return res
+}
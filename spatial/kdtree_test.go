@@ -0,0 +1,142 @@
+package spatial
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/metric"
+)
+
+// bruteForce returns all the points sorted by their distance to q.
+func bruteForce(X [][]float64, q []float64) []Neighbor {
+	all := make([]Neighbor, len(X))
+	for i := range X {
+		all[i] = Neighbor{i, math.Sqrt(sqDist(X[i], q))}
+	}
+	sort.Slice(all, func(a, b int) bool { return less(all[a], all[b]) })
+	return all
+}
+
+func randomPoints(n, dim int, r *rand.Rand) [][]float64 {
+	X := make([][]float64, n)
+	for i := range X {
+		X[i] = make([]float64, dim)
+		for d := range X[i] {
+			// Rounding creates duplicate coordinates and ties.
+			X[i][d] = math.Round(r.Float64()*20.0) / 2.0
+		}
+	}
+	return X
+}
+
+func sameNeighbors(a, b []Neighbor) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Index != b[i].Index || math.Abs(a[i].Dist-b[i].Dist) > 1e-12 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKNN(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for _, dim := range []int{1, 2, 3, 5} {
+		X := randomPoints(500, dim, r)
+		tree, err := NewKDTree(X)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for trial := 0; trial < 20; trial++ {
+			q := randomPoints(1, dim, r)[0]
+			for _, k := range []int{0, 1, 7, 40} {
+				got, err := tree.KNN(q, k)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if expected := bruteForce(X, q)[:k]; !sameNeighbors(got, expected) {
+					t.Fatalf("dim %d, k %d: expected %v, got %v", dim, k, expected, got)
+				}
+			}
+		}
+	}
+	X := [][]float64{{0.0, 0.0}, {1.0, 1.0}}
+	tree, _ := NewKDTree(X)
+	if got, _ := tree.KNN([]float64{0.0, 0.0}, 5); len(got) != 2 {
+		t.Errorf("expected all 2 points, got %v", got)
+	}
+	if _, err := tree.KNN([]float64{0.0}, 1); !errors.Is(err, metric.ErrLength) {
+		t.Errorf("expected metric.ErrLength, got %v", err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[1], "KNN()", "number of neighbors", -1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		tree.KNN([]float64{0.0, 0.0}, -1)
+	}()
+	wg.Wait()
+}
+
+func TestRadius(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	X := randomPoints(400, 3, r)
+	tree, _ := NewKDTree(X)
+	for trial := 0; trial < 20; trial++ {
+		q := randomPoints(1, 3, r)[0]
+		for _, rad := range []float64{0.0, 1.0, 2.5} {
+			got, err := tree.Radius(q, rad)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := []Neighbor{}
+			for _, nb := range bruteForce(X, q) {
+				if nb.Dist <= rad {
+					expected = append(expected, nb)
+				}
+			}
+			if !sameNeighbors(got, expected) {
+				t.Fatalf("radius %v: expected %v, got %v", rad, expected, got)
+			}
+		}
+	}
+	// Coincident points cannot be split, and stay in one leaf.
+	same := make([][]float64, 50)
+	for i := range same {
+		same[i] = []float64{1.0, 2.0}
+	}
+	tree, _ = NewKDTree(same)
+	if got, _ := tree.Radius([]float64{1.0, 2.0}, 0.0); len(got) != 50 {
+		t.Errorf("expected 50 points, got %d", len(got))
+	}
+	if _, err := NewKDTree([][]float64{{1.0}, {1.0, 2.0}}); !errors.Is(err, metric.ErrLength) {
+		t.Errorf("expected metric.ErrLength, got %v", err)
+	}
+}
+
+func BenchmarkKNN(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	X := make([][]float64, 100000)
+	for i := range X {
+		X[i] = []float64{r.Float64(), r.Float64(), r.Float64()}
+	}
+	tree, _ := NewKDTree(X)
+	q := []float64{0.5, 0.5, 0.5}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.KNN(q, 10)
+	}
+}
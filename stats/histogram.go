@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/vec"
+)
+
+/*
+Histogram2D counts the pairs (x[i], y[i]) in a grid of binsX by binsY bins
+of equal widths, spanning the ranges of the finite elements of x and y, for
+heatmaps of paired measurements. It returns the counts, with one row per bin
+of x and one column per bin of y, along with the binsX+1 edges of the bins
+of x and the binsY+1 edges of the bins of y. For example:
+
+	x := []float64{0.0, 0.5, 1.0, 2.0}
+	y := []float64{0.0, 0.0, 1.0, 1.0}
+	counts, xEdges, yEdges := stats.Histogram2D(x, y, 2, 2)
+	// counts is {{2.0, 0.0}, {0.0, 2.0}},
+	// xEdges is {0.0, 1.0, 2.0}, and yEdges is {0.0, 0.5, 1.0}
+
+The bins are half open, as with vec.Digitize(), except for the last bin of
+each axis, which also holds the largest element. A range with a single
+value is widened by 0.5 on both sides, or by a few units in the last place of
+values too large for 0.5 to change them, and an empty one is [0, 1]. Pairs
+with an element which is NaN or an infinity are not counted. The counts are
+float64s, so that they can be normalized or plotted with the functions of
+the mat package.
+
+x and y must have the same length, and binsX and binsY must be greater than
+0, otherwise this function will panic. The passed []float64s are not
+modified in this function.
+*/
+func Histogram2D(x, y []float64, binsX, binsY int) ([][]float64, []float64, []float64) {
+	if len(x) != len(y) {
+		panic(fmt.Sprintf(errStrings[0], "Histogram2D()", len(x), len(y)))
+	}
+	if binsX <= 0 {
+		panic(fmt.Sprintf(errStrings[1], "Histogram2D()", "bins of x", binsX))
+	}
+	if binsY <= 0 {
+		panic(fmt.Sprintf(errStrings[1], "Histogram2D()", "bins of y", binsY))
+	}
+	finite := func(i int) bool {
+		return !math.IsNaN(x[i]) && !math.IsInf(x[i], 0) && !math.IsNaN(y[i]) && !math.IsInf(y[i], 0)
+	}
+	xLo, xHi, yLo, yHi := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for i := range x {
+		if finite(i) {
+			xLo, xHi = math.Min(xLo, x[i]), math.Max(xHi, x[i])
+			yLo, yHi = math.Min(yLo, y[i]), math.Max(yHi, y[i])
+		}
+	}
+	xEdges, yEdges := edges(xLo, xHi, binsX), edges(yLo, yHi, binsY)
+	counts := make([][]float64, binsX)
+	for i := range counts {
+		counts[i] = make([]float64, binsY)
+	}
+	xBins, yBins := vec.Digitize(x, xEdges), vec.Digitize(y, yEdges)
+	for i := range x {
+		if finite(i) {
+			counts[min(xBins[i], binsX)-1][min(yBins[i], binsY)-1]++
+		}
+	}
+	return counts, xEdges, yEdges
+}
+
+// edges returns the bins+1 edges of bins of equal widths spanning [lo, hi],
+// widening a range with a single value, or no value if lo > hi.
+func edges(lo, hi float64, bins int) []float64 {
+	switch {
+	case lo > hi:
+		lo, hi = 0.0, 1.0
+	case lo == hi:
+		// 0.5 is lost in the rounding of large values, which are widened
+		// relative to their magnitude instead, staying finite.
+		w := math.Max(0.5, math.Abs(lo)*0x1p-50)
+		lo, hi = math.Max(lo-w, -math.MaxFloat64), math.Min(hi+w, math.MaxFloat64)
+	}
+	e := make([]float64, bins+1)
+	for i := range e {
+		// hi - lo can overflow, while the weighted sum of finite bounds
+		// cannot.
+		t := float64(i) / float64(bins)
+		e[i] = lo*(1.0-t) + hi*t
+	}
+	e[bins] = hi
+	return e
+}
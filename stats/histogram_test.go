@@ -0,0 +1,84 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/vec"
+)
+
+func TestHistogram2D(t *testing.T) {
+	x := []float64{0.0, 0.5, 1.0, 2.0, math.NaN()}
+	y := []float64{0.0, 0.0, 1.0, 1.0, 0.5}
+	counts, xEdges, yEdges := Histogram2D(x, y, 2, 2)
+	expected := [][]float64{{2.0, 0.0}, {0.0, 2.0}}
+	for i := range expected {
+		if !vec.Equal(counts[i], expected[i]) {
+			t.Errorf("at row %d, expected %v, got %v", i, expected[i], counts[i])
+		}
+	}
+	if !vec.Equal(xEdges, []float64{0.0, 1.0, 2.0}) {
+		t.Errorf("expected {0.0, 1.0, 2.0}, got %v", xEdges)
+	}
+	if !vec.Equal(yEdges, []float64{0.0, 0.5, 1.0}) {
+		t.Errorf("expected {0.0, 0.5, 1.0}, got %v", yEdges)
+	}
+	if !math.IsNaN(x[4]) || x[3] != 2.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	// A single value is widened, and the counts add up to the pairs.
+	counts, xEdges, _ = Histogram2D([]float64{3.0, 3.0, 3.0}, []float64{1.0, 1.5, 4.0}, 1, 3)
+	if !vec.Equal(xEdges, []float64{2.5, 3.5}) || !vec.Equal(counts[0], []float64{2.0, 0.0, 1.0}) {
+		t.Errorf("expected {2.0, 0.0, 1.0} in {2.5, 3.5}, got %v in %v", counts[0], xEdges)
+	}
+	// A large single value, and a span which overflows, still give finite
+	// increasing edges.
+	counts, xEdges, yEdges = Histogram2D([]float64{1e20, 1e20}, []float64{-math.MaxFloat64, math.MaxFloat64}, 2, 2)
+	if !(xEdges[0] < 1e20 && xEdges[2] > 1e20) || yEdges[0] != -math.MaxFloat64 || yEdges[1] != 0.0 || yEdges[2] != math.MaxFloat64 {
+		t.Errorf("expected edges around 1e20 and {-MaxFloat64, 0.0, MaxFloat64}, got %v and %v", xEdges, yEdges)
+	}
+	if counts[0][0]+counts[1][0] != 1.0 || counts[0][1]+counts[1][1] != 1.0 {
+		t.Errorf("expected one pair in each bin of y, got %v", counts)
+	}
+	if _, xEdges, _ = Histogram2D([]float64{math.MaxFloat64}, []float64{0.0}, 1, 1); !(xEdges[0] < xEdges[1]) || xEdges[1] != math.MaxFloat64 {
+		t.Errorf("expected finite edges around MaxFloat64, got %v", xEdges)
+	}
+	counts, xEdges, yEdges = Histogram2D(nil, nil, 2, 1)
+	if len(counts) != 2 || counts[0][0] != 0.0 || !vec.Equal(xEdges, []float64{0.0, 0.5, 1.0}) || len(yEdges) != 2 {
+		t.Errorf("expected empty bins over [0, 1], got %v, %v and %v", counts, xEdges, yEdges)
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { Histogram2D(x, y[:2], 2, 2) },
+			fmt.Sprintf(errStrings[0], "Histogram2D()", 5, 2),
+		},
+		{
+			func() { Histogram2D(x, y, 0, 2) },
+			fmt.Sprintf(errStrings[1], "Histogram2D()", "bins of x", 0),
+		},
+		{
+			func() { Histogram2D(x, y, 2, -1) },
+			fmt.Sprintf(errStrings[1], "Histogram2D()", "bins of y", -1),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+/*
+GeoMean returns the geometric mean of the elements of v, which is the n-th
+root of their product, for n elements. It is computed as the exponential of
+the mean of their logarithms, so that it does not overflow or underflow when
+the product would. For example:
+
+	g, err := stats.GeoMean([]float64{1.0, 3.0, 9.0}) // g is 3.0
+	h, err := stats.GeoMean([]float64{1e300, 1e300}) // h is 1e300
+
+GeoMean returns an *errs.EmptyError if v is empty, and an error wrapping
+ErrNonPositive, naming the first element which is not greater than 0, if
+there is one, since the geometric mean is only defined for positive
+elements. NaN is not positive. v is not modified in this function.
+*/
+func GeoMean(v []float64) (float64, error) {
+	if err := positive("GeoMean", v); err != nil {
+		return 0.0, err
+	}
+	sum := 0.0
+	for _, x := range v {
+		sum += math.Log(x)
+	}
+	return math.Exp(sum / float64(len(v))), nil
+}
+
+/*
+HarmonicMean returns the harmonic mean of the elements of v, which is the
+number of elements divided by the sum of their reciprocals, as for the
+average of rates. The reciprocals are scaled by the smallest element, so
+that they do not overflow for tiny elements. For example:
+
+	h, err := stats.HarmonicMean([]float64{1.0, 2.0, 4.0}) // h is 12/7
+
+HarmonicMean returns an *errs.EmptyError if v is empty, and an error
+wrapping ErrNonPositive, naming the first element which is not greater than
+0, if there is one, as with stats.GeoMean(). v is not modified in this
+function.
+*/
+func HarmonicMean(v []float64) (float64, error) {
+	if err := positive("HarmonicMean", v); err != nil {
+		return 0.0, err
+	}
+	m := math.Inf(1)
+	for _, x := range v {
+		m = math.Min(m, x)
+	}
+	if math.IsInf(m, 1) {
+		return m, nil
+	}
+	sum := 0.0
+	for _, x := range v {
+		sum += m / x
+	}
+	return m * (float64(len(v)) / sum), nil
+}
+
+// positive returns an error, naming the function fn, if v is empty or holds
+// an element which is not greater than 0.
+func positive(fn string, v []float64) error {
+	if len(v) == 0 {
+		return &errs.EmptyError{Got: []int{0}, Err: errors.New("stats: " + fn + " needs at least one element")}
+	}
+	for i, x := range v {
+		if !(x > 0.0) {
+			return fmt.Errorf("%w: the element at index %d is %v", ErrNonPositive, i, x)
+		}
+	}
+	return nil
+}
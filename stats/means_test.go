@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+	"github.com/NDari/gocrunch/vec"
+)
+
+func TestGeoMean(t *testing.T) {
+	v := []float64{1.0, 3.0, 9.0}
+	if g, err := GeoMean(v); err != nil || math.Abs(g-3.0) > 1e-15 {
+		t.Errorf("expected 3.0, got %v and %v", g, err)
+	}
+	// The product of the elements overflows, and that of their inverses
+	// underflows.
+	if g, err := GeoMean([]float64{1e300, 1e300, 1e300}); err != nil || math.Abs(g-1e300) > 1e288 {
+		t.Errorf("expected 1e300, got %v and %v", g, err)
+	}
+	if g, err := GeoMean([]float64{1e-300, 1e-300, 1e-300}); err != nil || math.Abs(g-1e-300) > 1e-312 {
+		t.Errorf("expected 1e-300, got %v and %v", g, err)
+	}
+	if _, err := GeoMean([]float64{1.0, 0.0}); !errors.Is(err, ErrNonPositive) {
+		t.Errorf("expected ErrNonPositive, got %v", err)
+	}
+	if _, err := GeoMean([]float64{1.0, math.NaN()}); !errors.Is(err, ErrNonPositive) {
+		t.Errorf("expected ErrNonPositive for NaN, got %v", err)
+	}
+	var e *errs.EmptyError
+	if _, err := GeoMean(nil); !errors.As(err, &e) || !errors.Is(err, errs.ErrEmpty) {
+		t.Errorf("expected an *errs.EmptyError, got %v", err)
+	}
+	if !vec.Equal(v, []float64{1.0, 3.0, 9.0}) {
+		t.Errorf("the original []float64 was modified")
+	}
+}
+
+func TestHarmonicMean(t *testing.T) {
+	if h, err := HarmonicMean([]float64{1.0, 2.0, 4.0}); err != nil || math.Abs(h-12.0/7.0) > 1e-15 {
+		t.Errorf("expected 12/7, got %v and %v", h, err)
+	}
+	// The reciprocal of the subnormal element overflows.
+	if h, err := HarmonicMean([]float64{5e-324, 5e-324}); err != nil || h != 5e-324 {
+		t.Errorf("expected 5e-324, got %v and %v", h, err)
+	}
+	if h, err := HarmonicMean([]float64{2.0, math.Inf(1)}); err != nil || h != 4.0 {
+		t.Errorf("expected 4.0, got %v and %v", h, err)
+	}
+	if h, err := HarmonicMean([]float64{math.Inf(1)}); err != nil || !math.IsInf(h, 1) {
+		t.Errorf("expected +Inf, got %v and %v", h, err)
+	}
+	if _, err := HarmonicMean([]float64{1.0, -2.0}); !errors.Is(err, ErrNonPositive) {
+		t.Errorf("expected ErrNonPositive, got %v", err)
+	}
+	if _, err := HarmonicMean(nil); !errors.Is(err, errs.ErrEmpty) {
+		t.Errorf("expected errs.ErrEmpty, got %v", err)
+	}
+}
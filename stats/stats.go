@@ -0,0 +1,35 @@
+/*
+Package stats implements statistics of data held in []float64s, such as
+histograms and weighted means, which summarize measurements rather than
+transform them elementwise, as the functions of the vec package do. For
+example:
+
+	counts, xEdges, yEdges := stats.Histogram2D(x, y, 20, 10)
+	m := stats.WeightedMean(x, w)
+
+As in the vec and mat packages, the functions do not modify their
+arguments, and invalid arguments, such as []float64s of different lengths,
+are treated as critical errors, and cause a panic with a message that names
+the offending function, as with the other packages in gocrunch. The means
+which are only defined for positive data, such as GeoMean, return an error
+instead, since the data, rather than the code, is at fault when it holds a
+zero or a negative value.
+*/
+package stats
+
+import "errors"
+
+// ErrNonPositive is returned by GeoMean and HarmonicMean when an element is
+// not greater than 0.
+var ErrNonPositive = errors.New("stats: the value is not positive")
+
+var (
+	errStrings = []string{
+		"\ngocrunch/stats error.\nIn stats.%s, the length the passed slices does not match: %d and %d.\n",
+		"\ngocrunch/stats error.\nIn stats.%s, the number of %s must be greater than 0, received %d.\n",
+		"\ngocrunch/stats error.\nIn stats.%s, the weights must be finite and not negative, but the weight at index %d is %v.\n",
+		"\ngocrunch/stats error.\nIn stats.%s, the weights cannot all be 0.\n",
+		"\ngocrunch/stats error.\nIn stats.%s, the quantile must be in [0, 1], received %v.\n",
+		"\ngocrunch/stats error.\nIn stats.%s, the sum of the weights must be finite, but it is %v.\n",
+	}
+)
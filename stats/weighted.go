@@ -0,0 +1,151 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/vec"
+)
+
+/*
+WeightedMean returns the mean of the elements of x, each counted with the
+weight of the same index in w, as with survey weights or the weights of
+importance sampling. That is, it returns the sum of w[i] * x[i] divided by
+the sum of the weights. For example:
+
+	x := []float64{1.0, 2.0, 4.0}
+	w := []float64{2.0, 1.0, 1.0}
+	m := stats.WeightedMean(x, w) // 2.0
+
+x and w must have the same length, and the weights must be finite, not
+negative, and not all 0, with a finite sum, otherwise this function will
+panic. The passed
+[]float64s are not modified in this function.
+*/
+func WeightedMean(x, w []float64) float64 {
+	total := checkWeights("WeightedMean()", x, w)
+	return weightedMean(x, w, total)
+}
+
+/*
+WeightedVar returns the variance of the elements of x, each counted with the
+weight of the same index in w. That is, it returns the sum of
+w[i] * (x[i] - m)^2 divided by the sum of the weights, where m is the
+weighted mean, as returned by stats.WeightedMean(). For example:
+
+	x := []float64{1.0, 2.0, 4.0}
+	w := []float64{2.0, 1.0, 1.0}
+	v := stats.WeightedVar(x, w) // 1.5
+
+This is the variance of the distribution defined by the weights, and is not
+corrected for the bias of a sample, since the correction depends on what the
+weights stand for. With weights that are all 1.0, it is the population
+variance. x and w must have the same length, and the weights must be
+finite, not negative, and not all 0, with a finite sum, otherwise this
+function will panic. The
+passed []float64s are not modified in this function.
+*/
+func WeightedVar(x, w []float64) float64 {
+	total := checkWeights("WeightedVar()", x, w)
+	return weightedVar(x, w, total)
+}
+
+/*
+WeightedStd returns the standard deviation of the elements of x, each
+counted with the weight of the same index in w, which is the square root of
+stats.WeightedVar(). The arguments are checked as with stats.WeightedVar(),
+and are not modified in this function.
+*/
+func WeightedStd(x, w []float64) float64 {
+	total := checkWeights("WeightedStd()", x, w)
+	return math.Sqrt(weightedVar(x, w, total))
+}
+
+/*
+WeightedQuantile returns the q-th quantile of the elements of x, each
+counted with the weight of the same index in w, for q in [0, 1]. It is the
+smallest element of x with a weight greater than 0 for which the sum of the
+weights of the elements up to it, in ascending order, is at least q times the
+sum of all the weights, so that the 0.0 and 1.0 quantiles are the smallest
+and largest elements with a weight greater than 0. For example:
+
+	x := []float64{1.0, 2.0, 3.0, 4.0}
+	w := []float64{1.0, 1.0, 1.0, 5.0}
+	m := stats.WeightedQuantile(x, w, 0.5) // 4.0
+
+The quantile is always an element of x, with no interpolation between the
+elements, so that with weights that are all equal, the median of an even
+number of elements is the lower of the two middle ones, rather than their
+average as with vec.Median(). The quantile is NaN if x holds a NaN. x and w
+must have the same length, the weights must be finite, not negative, and not
+all 0, with a finite sum, and q must be in [0, 1], otherwise this function
+will panic. The
+passed []float64s are not modified in this function.
+*/
+func WeightedQuantile(x, w []float64, q float64) float64 {
+	total := checkWeights("WeightedQuantile()", x, w)
+	if !(q >= 0.0 && q <= 1.0) {
+		panic(fmt.Sprintf(errStrings[4], "WeightedQuantile()", q))
+	}
+	for _, xi := range x {
+		if math.IsNaN(xi) {
+			return math.NaN()
+		}
+	}
+	xs, ws := vec.Clone(x), vec.Clone(w)
+	vec.SortBy(xs, ws)
+	target, cum, last := q*total, 0.0, 0
+	for i := range xs {
+		if ws[i] == 0.0 {
+			continue
+		}
+		cum += ws[i]
+		if cum >= target {
+			return xs[i]
+		}
+		last = i
+	}
+	// The sum of the weights can round below the total.
+	return xs[last]
+}
+
+// checkWeights panics, naming the function fn, if w cannot weigh x, or if
+// the sum of the weights overflows, and returns that sum otherwise.
+func checkWeights(fn string, x, w []float64) float64 {
+	if len(x) != len(w) {
+		panic(fmt.Sprintf(errStrings[0], fn, len(x), len(w)))
+	}
+	total := 0.0
+	for i, wi := range w {
+		if !(wi >= 0.0) || math.IsInf(wi, 1) {
+			panic(fmt.Sprintf(errStrings[2], fn, i, wi))
+		}
+		total += wi
+	}
+	if total == 0.0 {
+		panic(fmt.Sprintf(errStrings[3], fn))
+	}
+	if math.IsInf(total, 1) {
+		panic(fmt.Sprintf(errStrings[5], fn, total))
+	}
+	return total
+}
+
+// weightedMean returns the mean of x weighted by w, whose sum is total.
+func weightedMean(x, w []float64, total float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += w[i] * x[i]
+	}
+	return sum / total
+}
+
+// weightedVar returns the variance of x weighted by w, whose sum is total.
+func weightedVar(x, w []float64, total float64) float64 {
+	m := weightedMean(x, w, total)
+	sum := 0.0
+	for i := range x {
+		sum += w[i] * (x[i] - m) * (x[i] - m)
+	}
+	return sum / total
+}
@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/vec"
+)
+
+func TestWeighted(t *testing.T) {
+	x := []float64{1.0, 2.0, 4.0}
+	w := []float64{2.0, 1.0, 1.0}
+	if m := WeightedMean(x, w); m != 2.0 {
+		t.Errorf("expected 2.0, got %v", m)
+	}
+	if v := WeightedVar(x, w); v != 1.5 {
+		t.Errorf("expected 1.5, got %v", v)
+	}
+	if s := WeightedStd(x, w); s != math.Sqrt(1.5) {
+		t.Errorf("expected %v, got %v", math.Sqrt(1.5), s)
+	}
+	// Repeating an element is the same as doubling its weight.
+	if v := WeightedVar([]float64{1.0, 1.0, 2.0, 4.0}, []float64{1.0, 1.0, 1.0, 1.0}); v != WeightedVar(x, w) {
+		t.Errorf("expected %v, got %v", WeightedVar(x, w), v)
+	}
+	if !vec.Equal(x, []float64{1.0, 2.0, 4.0}) || !vec.Equal(w, []float64{2.0, 1.0, 1.0}) {
+		t.Errorf("the original []float64s were modified")
+	}
+}
+
+func TestWeightedQuantile(t *testing.T) {
+	x := []float64{4.0, 1.0, 3.0, 2.0, 9.0}
+	w := []float64{5.0, 1.0, 1.0, 1.0, 0.0}
+	for _, test := range []struct{ q, expected float64 }{
+		{0.0, 1.0}, {0.125, 1.0}, {0.2, 2.0}, {0.375, 3.0}, {0.5, 4.0}, {1.0, 4.0},
+	} {
+		if m := WeightedQuantile(x, w, test.q); m != test.expected {
+			t.Errorf("q = %v, expected %v, got %v", test.q, test.expected, m)
+		}
+	}
+	if m := WeightedQuantile([]float64{1.0, 2.0, 3.0, 4.0}, []float64{1.0, 1.0, 1.0, 1.0}, 0.5); m != 2.0 {
+		t.Errorf("expected the lower middle element, got %v", m)
+	}
+	if m := WeightedQuantile([]float64{1.0, math.NaN()}, []float64{1.0, 1.0}, 0.5); !math.IsNaN(m) {
+		t.Errorf("expected NaN, got %v", m)
+	}
+	if !vec.Equal(x, []float64{4.0, 1.0, 3.0, 2.0, 9.0}) || !vec.Equal(w, []float64{5.0, 1.0, 1.0, 1.0, 0.0}) {
+		t.Errorf("the original []float64s were modified")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { WeightedMean(x, w[:2]) },
+			fmt.Sprintf(errStrings[0], "WeightedMean()", 5, 2),
+		},
+		{
+			func() { WeightedVar([]float64{1.0, 2.0}, []float64{1.0, -1.0}) },
+			fmt.Sprintf(errStrings[2], "WeightedVar()", 1, -1.0),
+		},
+		{
+			func() { WeightedStd([]float64{1.0}, []float64{math.NaN()}) },
+			fmt.Sprintf(errStrings[2], "WeightedStd()", 0, math.NaN()),
+		},
+		{
+			func() { WeightedMean([]float64{1.0}, []float64{math.Inf(1)}) },
+			fmt.Sprintf(errStrings[2], "WeightedMean()", 0, math.Inf(1)),
+		},
+		{
+			func() { WeightedMean([]float64{1.0, 2.0}, []float64{0.0, 0.0}) },
+			fmt.Sprintf(errStrings[3], "WeightedMean()"),
+		},
+		{
+			func() { WeightedMean(nil, nil) },
+			fmt.Sprintf(errStrings[3], "WeightedMean()"),
+		},
+		{
+			func() { WeightedMean([]float64{1.0, 2.0}, []float64{1e308, 1e308}) },
+			fmt.Sprintf(errStrings[5], "WeightedMean()", math.Inf(1)),
+		},
+		{
+			func() { WeightedQuantile(x, w, -0.5) },
+			fmt.Sprintf(errStrings[4], "WeightedQuantile()", -0.5),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
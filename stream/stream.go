@@ -0,0 +1,281 @@
+/*
+Package stream applies the operations of the vec package to streams of
+float64 which are too large to be held in memory, such as files far larger
+than RAM.
+
+A stream is a sequence of float64s encoded in little endian IEEE 754 binary
+form, 8 bytes each, as written by encoding/binary and by stream.Writer. The
+functions of this package read the stream in chunks of a fixed number of
+elements, and only ever hold one chunk, so that their memory use is bounded
+by the chunk size, regardless of the length of the stream:
+
+	f, _ := os.Open("samples.bin")
+	defer f.Close()
+	s, err := stream.Summarize(f, 1<<16) // the mean, variance, min and max
+
+Element-wise transforms read one stream and write the result to another:
+
+	err := stream.Foreach(out, in, 1<<16, math.Sqrt)
+
+and any other chunk-wise operation can be written with stream.Apply() or
+stream.Reduce(), or with a stream.Reader directly.
+
+Errors of the underlying io.Reader or io.Writer are returned, as is
+ErrTruncated if a stream ends within a float64. As with the other packages
+in gocrunch, invalid arguments such as a chunk size that is not positive
+cause a panic with a message that names the offending function.
+*/
+package stream
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/NDari/gocrunch/vec"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/stream error.\nIn stream.%s, the chunk size must be greater than 0, received %d.\n",
+	}
+)
+
+// ErrTruncated is returned when the length of a stream is not a multiple of
+// 8 bytes, so that it ends within a float64.
+var ErrTruncated = errors.New("stream: the stream ends within a float64")
+
+/*
+Reader reads a stream in chunks of float64s.
+*/
+type Reader struct {
+	src   io.Reader
+	buf   []byte
+	chunk []float64
+	done  bool
+}
+
+/*
+NewReader returns a Reader which reads src in chunks of size float64s. size
+must be greater than 0, otherwise this function will panic.
+*/
+func NewReader(src io.Reader, size int) *Reader {
+	if size <= 0 {
+		panic(fmt.Sprintf(errStrings[0], "NewReader()", size))
+	}
+	return &Reader{src: src, buf: make([]byte, 8*size), chunk: make([]float64, size)}
+}
+
+/*
+Next returns the next chunk of the stream. All the chunks hold the chunk size
+of float64s, except for the last one, which may be shorter. Once the stream
+is exhausted, Next returns io.EOF. The returned []float64 is overwritten by
+the following call to Next, so it must be copied to be kept.
+*/
+func (r *Reader) Next() ([]float64, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	n, err := io.ReadFull(r.src, r.buf)
+	switch err {
+	case nil:
+	case io.EOF:
+		r.done = true
+		return nil, io.EOF
+	case io.ErrUnexpectedEOF:
+		r.done = true
+		if n%8 != 0 {
+			return nil, ErrTruncated
+		}
+	default:
+		return nil, err
+	}
+	c := r.chunk[:n/8]
+	for i := range c {
+		c[i] = math.Float64frombits(binary.LittleEndian.Uint64(r.buf[8*i:]))
+	}
+	return c, nil
+}
+
+/*
+Writer writes float64s to a stream.
+*/
+type Writer struct {
+	dst io.Writer
+	buf []byte
+}
+
+/*
+NewWriter returns a Writer which writes to dst.
+*/
+func NewWriter(dst io.Writer) *Writer {
+	return &Writer{dst: dst}
+}
+
+/*
+Write appends the elements of v to the stream. The passed []float64 is not
+modified in this function.
+*/
+func (w *Writer) Write(v []float64) error {
+	if cap(w.buf) < 8*len(v) {
+		w.buf = make([]byte, 8*len(v))
+	}
+	b := w.buf[:8*len(v)]
+	for i, x := range v {
+		binary.LittleEndian.PutUint64(b[8*i:], math.Float64bits(x))
+	}
+	_, err := w.dst.Write(b)
+	return err
+}
+
+/*
+Apply reads src in chunks of size float64s, calls f on each chunk, which may
+modify the chunk in place, and writes the chunk to dst. It returns the number
+of float64s written. For example, to scale a stream:
+
+	n, err := stream.Apply(out, in, 1<<16, func(c []float64) {
+		vec.MulTo(c, c, 2.0)
+	})
+
+size must be greater than 0, otherwise this function will panic.
+*/
+func Apply(dst io.Writer, src io.Reader, size int, f func(chunk []float64)) (int, error) {
+	if size <= 0 {
+		panic(fmt.Sprintf(errStrings[0], "Apply()", size))
+	}
+	return apply(dst, src, size, f)
+}
+
+/*
+Foreach writes the result of applying f to each element of src to dst, as
+vec.Foreach() does for a []float64, reading src in chunks of size float64s.
+It returns the number of float64s written.
+
+size must be greater than 0, otherwise this function will panic.
+*/
+func Foreach(dst io.Writer, src io.Reader, size int, f func(float64) float64) (int, error) {
+	if size <= 0 {
+		panic(fmt.Sprintf(errStrings[0], "Foreach()", size))
+	}
+	return apply(dst, src, size, func(c []float64) { vec.ApplyTo(c, f, c) })
+}
+
+func apply(dst io.Writer, src io.Reader, size int, f func([]float64)) (int, error) {
+	r, w := NewReader(src, size), NewWriter(dst)
+	total := 0
+	for {
+		c, err := r.Next()
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+		f(c)
+		if err := w.Write(c); err != nil {
+			return total, err
+		}
+		total += len(c)
+	}
+}
+
+/*
+Reduce reads src in chunks of size float64s, and folds them into a single
+float64, starting from init, such that the accumulated value is replaced by
+f(acc, chunk) for each chunk. For example, the sum of the squares of the
+elements of a stream is:
+
+	sq, err := stream.Reduce(in, 1<<16, 0.0, func(acc float64, c []float64) float64 {
+		return acc + vec.Dot(c, c)
+	})
+
+size must be greater than 0, otherwise this function will panic.
+*/
+func Reduce(src io.Reader, size int, init float64, f func(acc float64, chunk []float64) float64) (float64, error) {
+	if size <= 0 {
+		panic(fmt.Sprintf(errStrings[0], "Reduce()", size))
+	}
+	r := NewReader(src, size)
+	acc := init
+	for {
+		c, err := r.Next()
+		if err == io.EOF {
+			return acc, nil
+		}
+		if err != nil {
+			return acc, err
+		}
+		acc = f(acc, c)
+	}
+}
+
+/*
+Summary holds statistics of the elements of a stream.
+*/
+type Summary struct {
+	// Count is the number of elements.
+	Count int
+	// Sum, Mean and Var are the sum, the mean and the population variance
+	// of the elements.
+	Sum, Mean, Var float64
+	// Min and Max are the smallest and largest elements.
+	Min, Max float64
+}
+
+/*
+Summarize returns the Summary of the elements of src, reading it in chunks
+of size float64s. The statistics of each chunk are merged into those of the
+previous chunks with the method of Chan et al., which keeps the variance
+accurate for long streams. For an empty stream, the Count is 0, and the
+other fields are NaN, except for Sum, which is 0.0.
+
+size must be greater than 0, otherwise this function will panic.
+*/
+func Summarize(src io.Reader, size int) (Summary, error) {
+	if size <= 0 {
+		panic(fmt.Sprintf(errStrings[0], "Summarize()", size))
+	}
+	r := NewReader(src, size)
+	s := Summary{Min: math.Inf(1), Max: math.Inf(-1)}
+	// m2 is the sum of the squared deviations from the mean, and comp the
+	// compensation of the running sum.
+	var m2, comp float64
+	for {
+		c, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return s, err
+		}
+		if len(c) == 0 {
+			continue
+		}
+		n := float64(len(c))
+		sum := vec.Sum(c)
+		mean := sum / n
+		cm2 := 0.0
+		for _, x := range c {
+			cm2 += (x - mean) * (x - mean)
+			s.Min = math.Min(s.Min, x)
+			s.Max = math.Max(s.Max, x)
+		}
+		total := float64(s.Count) + n
+		delta := mean - s.Mean
+		m2 += cm2 + delta*delta*float64(s.Count)*n/total
+		s.Mean += delta * n / total
+		s.Count += len(c)
+		y := sum - comp
+		t := s.Sum + y
+		comp = (t - s.Sum) - y
+		s.Sum = t
+	}
+	if s.Count == 0 {
+		nan := math.NaN()
+		return Summary{Mean: nan, Var: nan, Min: nan, Max: nan}, nil
+	}
+	s.Var = m2 / float64(s.Count)
+	return s, nil
+}
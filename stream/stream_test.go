@@ -0,0 +1,173 @@
+package stream
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"testing"
+	"testing/iotest"
+)
+
+func encode(v []float64) *bytes.Buffer {
+	var b bytes.Buffer
+	NewWriter(&b).Write(v)
+	return &b
+}
+
+func seq(n int) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = float64(i) + 0.5
+	}
+	return v
+}
+
+func TestReader(t *testing.T) {
+	v := seq(10)
+	// A reader returning one byte at a time checks that the chunks are
+	// filled across short reads.
+	r := NewReader(iotest.OneByteReader(encode(v)), 4)
+	var got []float64
+	var lens []int
+	for {
+		c, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lens = append(lens, len(c))
+		got = append(got, c...)
+	}
+	if fmt.Sprint(lens) != "[4 4 2]" {
+		t.Errorf("expected chunks of lengths [4 4 2], got %v", lens)
+	}
+	for i := range v {
+		if got[i] != v[i] {
+			t.Fatalf("expected %v, got %v", v, got)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF after the end, got %v", err)
+	}
+	b := encode(v)
+	b.Truncate(b.Len() - 3)
+	r = NewReader(b, 4)
+	var err error
+	for err == nil {
+		_, err = r.Next()
+	}
+	if err != ErrTruncated {
+		t.Errorf("expected ErrTruncated, got %v", err)
+	}
+	boom := errors.New("boom")
+	if _, err := NewReader(iotest.ErrReader(boom), 4).Next(); err != boom {
+		t.Errorf("expected the error of the reader, got %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	v := seq(1000)
+	var out bytes.Buffer
+	n, err := Foreach(&out, encode(v), 64, math.Sqrt)
+	if err != nil || n != len(v) {
+		t.Fatalf("expected %d elements and no error, got %d and %v", len(v), n, err)
+	}
+	r := NewReader(&out, 1000)
+	got, _ := r.Next()
+	for i := range v {
+		if got[i] != math.Sqrt(v[i]) {
+			t.Fatalf("at index %d, expected %v, got %v", i, math.Sqrt(v[i]), got[i])
+		}
+	}
+	n, err = Apply(io.Discard, encode(v), 7, func(c []float64) {
+		if len(c) > 7 {
+			t.Errorf("chunk of length %d is larger than the chunk size", len(c))
+		}
+	})
+	if err != nil || n != len(v) {
+		t.Errorf("expected %d elements and no error, got %d and %v", len(v), n, err)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	v := seq(100)
+	sq, err := Reduce(encode(v), 9, 1.0, func(acc float64, c []float64) float64 {
+		for _, x := range c {
+			acc += x * x
+		}
+		return acc
+	})
+	want := 1.0
+	for _, x := range v {
+		want += x * x
+	}
+	if err != nil || math.Abs(sq-want) > 1e-9 {
+		t.Errorf("expected %v, got %v and %v", want, sq, err)
+	}
+	if got, err := Reduce(new(bytes.Buffer), 9, 3.0, nil); err != nil || got != 3.0 {
+		t.Errorf("expected the initial value for an empty stream, got %v and %v", got, err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	// A large offset makes a naive computation of the variance lose its
+	// accuracy.
+	v := seq(1001)
+	for i := range v {
+		v[i] += 1e9
+	}
+	s, err := Summarize(encode(v), 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n := float64(len(v))
+	mean := 1e9 + 500.5
+	variance := (n*n - 1.0) / 12.0
+	if s.Count != len(v) || math.Abs(s.Mean-mean) > 1e-6 || math.Abs(s.Var-variance) > 1e-6 {
+		t.Errorf("expected %d, %v and %v, got %v", len(v), mean, variance, s)
+	}
+	if s.Min != v[0] || s.Max != v[len(v)-1] || math.Abs(s.Sum-mean*n) > 1e-3 {
+		t.Errorf("unexpected min, max or sum in %v", s)
+	}
+	s, err = Summarize(new(bytes.Buffer), 100)
+	if err != nil || s.Count != 0 || s.Sum != 0.0 || !math.IsNaN(s.Mean) || !math.IsNaN(s.Var) {
+		t.Errorf("unexpected summary of an empty stream %v", s)
+	}
+}
+
+func TestChunkSize(t *testing.T) {
+	var wg sync.WaitGroup
+	for _, f := range []struct {
+		name string
+		call func()
+	}{
+		{"NewReader()", func() { NewReader(nil, 0) }},
+		{"Apply()", func() { Apply(nil, nil, -1, nil) }},
+		{"Foreach()", func() { Foreach(nil, nil, -1, nil) }},
+		{"Reduce()", func() { Reduce(nil, -1, 0.0, nil) }},
+		{"Summarize()", func() { Summarize(nil, -1) }},
+	} {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				size := -1
+				if f.name == "NewReader()" {
+					size = 0
+				}
+				expectedErr := fmt.Sprintf(errStrings[0], f.name, size)
+				if r != expectedErr {
+					t.Errorf("Expected %s, got %v", expectedErr, r)
+				}
+				wg.Done()
+			}()
+			f.call()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,75 @@
+package tensor
+
+import (
+	"fmt"
+	"iter"
+)
+
+/*
+Slices returns an iterator over the slices of a [][][]float64 along an axis,
+which are the [][]float64 obtained by fixing the index of the axis, along
+with that index. For example, to loop over the time steps and then the
+samples of a sequence of shape steps by batch by features:
+
+	for t, x := range tensor.Slices(seq, 0) {
+		// x is the batch by features matrix of step t
+	}
+	for b, x := range tensor.Slices(seq, 1) {
+		// x is the steps by features matrix of sample b
+	}
+
+No element is copied for the axes 0 and 1. The slices along axis 0 are the
+matrices of t, and those along axis 1 are made of rows of t, so that setting
+an element of a slice sets the element of t, and the [][]float64 holding
+the rows along axis 1 is reused for all the slices. Since the elements of a
+slice along axis 2 are not contiguous, they are copied into a single
+[][]float64 which is reused for all the slices, so that changes to it are
+not seen by t. The reused slices are only valid until the next iteration.
+
+The axis must be 0, 1 or 2, and t must not be empty or jagged, otherwise
+this function will panic.
+*/
+func Slices(t [][][]float64, axis int) iter.Seq2[int, [][]float64] {
+	d0, d1, d2 := dims("Slices()", t)
+	switch axis {
+	case 0:
+		return func(yield func(int, [][]float64) bool) {
+			for i := range t {
+				if !yield(i, t[i]) {
+					return
+				}
+			}
+		}
+	case 1:
+		return func(yield func(int, [][]float64) bool) {
+			s := make([][]float64, d0)
+			for j := 0; j < d1; j++ {
+				for i := range t {
+					s[i] = t[i][j]
+				}
+				if !yield(j, s) {
+					return
+				}
+			}
+		}
+	case 2:
+		return func(yield func(int, [][]float64) bool) {
+			s := make([][]float64, d0)
+			data := make([]float64, d0*d1)
+			for i := range s {
+				s[i] = data[i*d1 : (i+1)*d1 : (i+1)*d1]
+			}
+			for k := 0; k < d2; k++ {
+				for i := range t {
+					for j := range t[i] {
+						s[i][j] = t[i][j][k]
+					}
+				}
+				if !yield(k, s) {
+					return
+				}
+			}
+		}
+	}
+	panic(fmt.Sprintf(errStrings[4], "Slices()", axis))
+}
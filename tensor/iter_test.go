@@ -0,0 +1,64 @@
+package tensor
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSlices(t *testing.T) {
+	x := random(2, 3, 4)
+	for axis := 0; axis < 3; axis++ {
+		count := 0
+		for n, s := range Slices(x, axis) {
+			count++
+			for i := range s {
+				for j := range s[i] {
+					idx := [3]int{}
+					// The free axes keep their order.
+					free := []int{0, 1, 2}
+					free = append(free[:axis], free[axis+1:]...)
+					idx[axis], idx[free[0]], idx[free[1]] = n, i, j
+					if s[i][j] != x[idx[0]][idx[1]][idx[2]] {
+						t.Fatalf("axis %d, slice %d: wrong element at (%d, %d)", axis, n, i, j)
+					}
+				}
+			}
+		}
+		d := []int{2, 3, 4}
+		if count != d[axis] {
+			t.Errorf("axis %d: expected %d slices, got %d", axis, d[axis], count)
+		}
+	}
+	for _, s := range Slices(x, 1) {
+		s[1][2] = 9.0
+		break
+	}
+	if x[1][0][2] != 9.0 {
+		t.Errorf("expected the slices along axis 1 to be views of t")
+	}
+	count := 0
+	for range Slices(x, 2) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected to stop after 2 slices, got %d", count)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[4], "Slices()", -1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Slices(x, -1)
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,213 @@
+/*
+Package tensor implements functions which act on rank 3 tensors, stored as
+three dimensional slices of float64, [][][]float64, such as a sequence of
+matrices indexed by time step:
+
+	x := tensor.New(steps, batch, features) // x[t] is the matrix of step t
+	y := tensor.TensorDot(x, w, 2)          // x[t] * w, for every step t
+	z := tensor.BatchDot(x, tensor.New(steps, features, 4))
+
+As in the mat package, the tensors are plain Go slices, so that each x[t] is
+a [][]float64 which can be passed to the functions of mat, and each x[t][i]
+a []float64 for those of vec. The products are computed by the current
+engine of the backend package.
+
+Invalid arguments, such as jagged or mismatched tensors, are treated as
+critical errors, and cause a panic with a message that names the offending
+function, as with the other packages in gocrunch.
+*/
+package tensor
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/NDari/gocrunch/backend"
+	"github.com/NDari/gocrunch/parallel"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/tensor error.\nIn tensor.%s, the %s must be greater than 0, received %d.\n",
+		"\ngocrunch/tensor error.\nIn tensor.%s, the [][][]float64 cannot be empty or jagged.\n",
+		"\ngocrunch/tensor error.\nIn tensor.%s, the [][][]float64s hold %d and %d matrices. They must match.\n",
+		"\ngocrunch/tensor error.\nIn tensor.%s, the matrices are %d by %d and %d by %d, and cannot be multiplied.\n",
+		"\ngocrunch/tensor error.\nIn tensor.%s, the axis must be 0, 1 or 2, received %d.\n",
+		"\ngocrunch/tensor error.\nIn tensor.%s, axis %d has length %d, while the [][]float64 has %d rows.\n",
+		"\ngocrunch/tensor error.\nIn tensor.%s, the [][]float64 cannot be empty or jagged.\n",
+	}
+)
+
+/*
+New returns a d0 by d1 by d2 [][][]float64 of zeros, whose elements share a
+single allocation. Each of the dimensions must be greater than 0, otherwise
+this function will panic.
+*/
+func New(d0, d1, d2 int) [][][]float64 {
+	for _, d := range []struct {
+		name string
+		n    int
+	}{{"first dimension", d0}, {"second dimension", d1}, {"third dimension", d2}} {
+		if d.n <= 0 {
+			panic(fmt.Sprintf(errStrings[0], "New()", d.name, d.n))
+		}
+	}
+	data := make([]float64, d0*d1*d2)
+	rows := make([][]float64, d0*d1)
+	for i := range rows {
+		rows[i] = data[i*d2 : (i+1)*d2 : (i+1)*d2]
+	}
+	t := make([][][]float64, d0)
+	for i := range t {
+		t[i] = rows[i*d1 : (i+1)*d1 : (i+1)*d1]
+	}
+	return t
+}
+
+/*
+Dims returns the three dimensions of a [][][]float64: the number of
+matrices, and the numbers of rows and columns of each matrix. The passed
+[][][]float64 must not be empty or jagged, otherwise this function will
+panic.
+*/
+func Dims(t [][][]float64) (d0, d1, d2 int) {
+	return dims("Dims()", t)
+}
+
+/*
+BatchDot returns the products of the matrices of a and b with the same
+index, so that the matrix i of the result is a[i] * b[i]. For example, to
+apply a separate transform to each step of a sequence:
+
+	y := tensor.BatchDot(x, w) // y[t] is x[t] * w[t]
+
+The products are spread over up to GOMAXPROCS goroutines once they are large
+enough, as decided by parallel.Threshold(). a and b must hold the same number
+of matrices, and the number of columns of the matrices of a must be the
+number of rows of those of b, otherwise this function will panic. The passed
+arguments are not mutated in this function.
+*/
+func BatchDot(a, b [][][]float64) [][][]float64 {
+	n, r, k := dims("BatchDot()", a)
+	bn, bk, c := dims("BatchDot()", b)
+	if n != bn {
+		panic(fmt.Sprintf(errStrings[2], "BatchDot()", n, bn))
+	}
+	if k != bk {
+		panic(fmt.Sprintf(errStrings[3], "BatchDot()", r, k, bk, c))
+	}
+	res := New(n, r, c)
+	batch(n, r*k*c, func(i int) {
+		backend.Gemm(false, false, 1.0, a[i], b[i], 0.0, res[i])
+	})
+	return res
+}
+
+/*
+TensorDot returns the product of a [][][]float64 and a [][]float64 along one
+axis of the tensor, which sums the products of the elements of t along the
+axis with the rows of m, and replaces the length of the axis by the number
+of columns of m. For the last axis, this is the product of each matrix of t
+with m:
+
+	y := tensor.TensorDot(x, w, 2) // y[t] is x[t] * w
+
+and for the first axis, each matrix of the result mixes the matrices of t,
+weighted by a column of m:
+
+	y := tensor.TensorDot(x, w, 0) // y[l] is the sum of w[t][l] * x[t]
+
+In index notation, the element (i, j, l) of TensorDot(t, m, 2) is the sum
+over k of t[i][j][k] * m[k][l], and likewise for the other axes. The axis
+must be 0, 1 or 2, its length must be the number of rows of m, and neither
+can be empty or jagged, otherwise this function will panic. The passed
+arguments are not mutated in this function.
+*/
+func TensorDot(t [][][]float64, m [][]float64, axis int) [][][]float64 {
+	var d [3]int
+	d[0], d[1], d[2] = dims("TensorDot()", t)
+	if axis < 0 || axis > 2 {
+		panic(fmt.Sprintf(errStrings[4], "TensorDot()", axis))
+	}
+	if len(m) == 0 || len(m[0]) == 0 {
+		panic(fmt.Sprintf(errStrings[6], "TensorDot()"))
+	}
+	for i := range m {
+		if len(m[i]) != len(m[0]) {
+			panic(fmt.Sprintf(errStrings[6], "TensorDot()"))
+		}
+	}
+	if d[axis] != len(m) {
+		panic(fmt.Sprintf(errStrings[5], "TensorDot()", axis, d[axis], len(m)))
+	}
+	c := len(m[0])
+	switch axis {
+	case 2:
+		res := New(d[0], d[1], c)
+		batch(d[0], d[1]*d[2]*c, func(i int) {
+			backend.Gemm(false, false, 1.0, t[i], m, 0.0, res[i])
+		})
+		return res
+	case 1:
+		res := New(d[0], c, d[2])
+		batch(d[0], d[1]*d[2]*c, func(i int) {
+			backend.Gemm(true, false, 1.0, m, t[i], 0.0, res[i])
+		})
+		return res
+	}
+	res := New(c, d[1], d[2])
+	batch(c, d[0]*d[1]*d[2], func(l int) {
+		for i := range t {
+			for j := range t[i] {
+				backend.Axpy(m[i][l], t[i][j], res[l][j])
+			}
+		}
+	})
+	return res
+}
+
+// dims returns the dimensions of t, and panics if it is empty or jagged.
+func dims(fn string, t [][][]float64) (int, int, int) {
+	if len(t) == 0 || len(t[0]) == 0 || len(t[0][0]) == 0 {
+		panic(fmt.Sprintf(errStrings[1], fn))
+	}
+	d1, d2 := len(t[0]), len(t[0][0])
+	for i := range t {
+		if len(t[i]) != d1 {
+			panic(fmt.Sprintf(errStrings[1], fn))
+		}
+		for j := range t[i] {
+			if len(t[i][j]) != d2 {
+				panic(fmt.Sprintf(errStrings[1], fn))
+			}
+		}
+	}
+	return len(t), d1, d2
+}
+
+// batch calls f for each index in [0, n), spreading the indices over
+// several goroutines when the work of each call times n reaches the
+// threshold of the parallel package.
+func batch(n, work int, f func(i int)) {
+	workers := min(runtime.GOMAXPROCS(0), n)
+	if workers < 2 || n*work < parallel.Threshold() {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
+	}
+	size := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += size {
+		hi := min(lo+size, n)
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			for i := lo; i < hi; i++ {
+				f(i)
+			}
+		}(lo, hi)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,177 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/parallel"
+)
+
+func random(d0, d1, d2 int) [][][]float64 {
+	t := New(d0, d1, d2)
+	for i := range t {
+		for j := range t[i] {
+			for k := range t[i][j] {
+				t[i][j][k] = rand.Float64() - 0.5
+			}
+		}
+	}
+	return t
+}
+
+func near(a, b [][][]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if len(a[i][j]) != len(b[i][j]) {
+				return false
+			}
+			for k := range a[i][j] {
+				if math.Abs(a[i][j][k]-b[i][j][k]) > 1e-12 {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func TestNew(t *testing.T) {
+	x := New(2, 3, 4)
+	if d0, d1, d2 := Dims(x); d0 != 2 || d1 != 3 || d2 != 4 {
+		t.Errorf("expected 2 by 3 by 4, got %d by %d by %d", d0, d1, d2)
+	}
+	x[0][2] = append(x[0][2], 1.0)
+	if x[1][0][0] != 0.0 {
+		t.Errorf("appending to a row overwrote the next row")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { New(2, 0, 4) }, fmt.Sprintf(errStrings[0], "New()", "second dimension", 0)},
+		{func() { Dims(nil) }, fmt.Sprintf(errStrings[1], "Dims()")},
+		{func() { Dims([][][]float64{{{1.0}}, {{1.0, 2.0}}}) }, fmt.Sprintf(errStrings[1], "Dims()")},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestBatchDot(t *testing.T) {
+	// With a low threshold, the batch is spread over the goroutines when
+	// GOMAXPROCS is above 1.
+	parallel.SetThreshold(1)
+	defer parallel.SetThreshold(0)
+	a, b := random(5, 3, 4), random(5, 4, 2)
+	expected := New(5, 3, 2)
+	for n := range a {
+		for i := range a[n] {
+			for j := range b[n][0] {
+				for k := range b[n] {
+					expected[n][i][j] += a[n][i][k] * b[n][k][j]
+				}
+			}
+		}
+	}
+	if !near(BatchDot(a, b), expected) {
+		t.Errorf("expected %v, got %v", expected, BatchDot(a, b))
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { BatchDot(a, random(4, 4, 2)) }, fmt.Sprintf(errStrings[2], "BatchDot()", 5, 4)},
+		{func() { BatchDot(a, random(5, 3, 2)) }, fmt.Sprintf(errStrings[3], "BatchDot()", 3, 4, 3, 2)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestTensorDot(t *testing.T) {
+	x := random(2, 3, 4)
+	d := []int{2, 3, 4}
+	for axis := 0; axis < 3; axis++ {
+		m := random(1, d[axis], 5)[0]
+		e := make([]int, 3)
+		copy(e, d)
+		e[axis] = 5
+		expected := New(e[0], e[1], e[2])
+		for i := range x {
+			for j := range x[i] {
+				for k := range x[i][j] {
+					idx := []int{i, j, k}
+					for l := 0; l < 5; l++ {
+						out := []int{i, j, k}
+						out[axis] = l
+						expected[out[0]][out[1]][out[2]] += x[i][j][k] * m[idx[axis]][l]
+					}
+				}
+			}
+		}
+		if !near(TensorDot(x, m, axis), expected) {
+			t.Errorf("axis %d: expected %v, got %v", axis, expected, TensorDot(x, m, axis))
+		}
+	}
+	// 0*Inf is NaN along the first axis too.
+	inf := [][][]float64{{{math.Inf(1)}}, {{1.0}}}
+	if r := TensorDot(inf, [][]float64{{0.0}, {1.0}}, 0); !math.IsNaN(r[0][0][0]) {
+		t.Errorf("expected NaN, got %v", r[0][0][0])
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { TensorDot(x, random(1, 4, 2)[0], 3) }, fmt.Sprintf(errStrings[4], "TensorDot()", 3)},
+		{func() { TensorDot(x, random(1, 4, 2)[0], 1) }, fmt.Sprintf(errStrings[5], "TensorDot()", 1, 3, 4)},
+		{func() { TensorDot(x, [][]float64{{1.0}, {}}, 0) }, fmt.Sprintf(errStrings[6], "TensorDot()")},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
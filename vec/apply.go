@@ -0,0 +1,45 @@
+package vec
+
+/*
+ApplyIndexed applies a function to each element of a []float64 and its
+index, storing the results in a new []float64 which is returned, for the
+transforms which depend on the position of the elements. For example, a
+Hann window applied to a signal:
+
+	n := float64(len(v) - 1)
+	w := vec.ApplyIndexed(func(i int, x float64) float64 {
+		return x * 0.5 * (1.0 - math.Cos(2.0*math.Pi*float64(i)/n))
+	}, v)
+
+The passed []float64 is not modified in this function. To reuse an existing
+[]float64 for the result, look at vec.ApplyIndexedTo() and
+vec.ApplyIndexedInPlace().
+*/
+func ApplyIndexed(f func(i int, x float64) float64, v []float64) []float64 {
+	c := alloc(len(v))
+	for i, x := range v {
+		c[i] = f(i, x)
+	}
+	return c
+}
+
+// ApplyIndexedInPlace sets each element of v to f applied to its index and
+// itself. The passed []float64 is mutated in this function.
+func ApplyIndexedInPlace(f func(i int, x float64) float64, v []float64) {
+	for i, x := range v {
+		v[i] = f(i, x)
+	}
+}
+
+/*
+ApplyIndexedTo stores the result of vec.ApplyIndexed(f, v) in dst, and
+returns dst, without allocating. dst must have the length of v, and may be v
+itself.
+*/
+func ApplyIndexedTo(dst []float64, f func(i int, x float64) float64, v []float64) []float64 {
+	checkDst("ApplyIndexedTo()", dst, v)
+	for i, x := range v {
+		dst[i] = f(i, x)
+	}
+	return dst
+}
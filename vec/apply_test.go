@@ -0,0 +1,40 @@
+package vec
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestApplyIndexed(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0}
+	f := func(i int, x float64) float64 { return x * float64(i) }
+	if w := ApplyIndexed(f, v); !Equal(w, []float64{0.0, 2.0, 6.0}) {
+		t.Errorf("expected {0.0, 2.0, 6.0}, got %v", w)
+	}
+	if !Equal(v, []float64{1.0, 2.0, 3.0}) {
+		t.Errorf("the original []float64 was modified")
+	}
+	dst := make([]float64, 3)
+	if d := ApplyIndexedTo(dst, f, v); &d[0] != &dst[0] || !Equal(dst, []float64{0.0, 2.0, 6.0}) {
+		t.Errorf("expected {0.0, 2.0, 6.0} in dst, got %v", dst)
+	}
+	ApplyIndexedInPlace(func(i int, x float64) float64 { return x + float64(i) }, v)
+	if !Equal(v, []float64{1.0, 3.0, 5.0}) {
+		t.Errorf("expected {1.0, 3.0, 5.0}, got %v", v)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expected := fmt.Sprintf(errStrings[5], "ApplyIndexedTo()", 3, 2)
+			if r != expected {
+				t.Errorf("Expected %s, got %v", expected, r)
+			}
+			wg.Done()
+		}()
+		ApplyIndexedTo(dst[:2], f, v)
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,162 @@
+package vec
+
+import "fmt"
+
+/*
+Diff returns the n-th discrete difference of the passed []float64. The first
+difference is given by
+
+	d[i] = v[i+1] - v[i]
+
+and higher differences are computed by applying this rule repeatedly. For
+example:
+
+	v := []float64{1.0, 2.0, 4.0, 7.0}
+	vec.Diff(v, 1) // {1.0, 2.0, 3.0}
+	vec.Diff(v, 2) // {1.0, 1.0}
+
+The returned []float64 is n elements shorter than the original, or empty if
+n is not smaller than the length of the []float64. A n of 0 returns a copy.
+n must not be negative, otherwise this function will panic. The original
+[]float64 is not modified in this function.
+*/
+func Diff(v []float64, n int) []float64 {
+	if n < 0 {
+		panic(fmt.Sprintf(errStrings[13], "Diff()", n))
+	}
+	if n >= len(v) {
+		return []float64{}
+	}
+	c := Clone(v)
+	for k := 0; k < n; k++ {
+		for i := 0; i < len(c)-1; i++ {
+			c[i] = c[i+1] - c[i]
+		}
+		c = c[:len(c)-1]
+	}
+	return c
+}
+
+/*
+Gradient returns the derivative of a function sampled at evenly spaced points
+separated by dx, as estimated from the passed []float64 of samples. Central
+differences are used for the interior points, and one sided differences at the
+first and last points. For example:
+
+	v := []float64{1.0, 2.0, 4.0, 7.0}
+	g := vec.Gradient(v, 1.0) // g is {1.0, 1.5, 2.5, 3.0}
+
+The returned []float64 has the same length as the original. The passed
+[]float64 must have at least 2 elements, and dx cannot be 0.0, otherwise this
+function will panic. The original []float64 is not modified in this function.
+*/
+func Gradient(v []float64, dx float64) []float64 {
+	return gradient("Gradient()", alloc(len(v)), v, dx)
+}
+
+/*
+GradientTo stores the result of vec.Gradient(v, dx) in dst, and returns dst,
+without allocating. dst must have the length of v, and may be v itself.
+*/
+func GradientTo(dst, v []float64, dx float64) []float64 {
+	return gradient("GradientTo()", dst, v, dx)
+}
+
+func gradient(fn string, dst, v []float64, dx float64) []float64 {
+	if len(v) < 2 {
+		panic(fmt.Sprintf(errStrings[16], fn, 2, len(v)))
+	}
+	if dx == 0.0 {
+		panic(fmt.Sprintf(errStrings[7], fn))
+	}
+	checkDst(fn, dst, v)
+	n := len(v)
+	// Each sample is read before dst overwrites it, in case dst is v.
+	prev, last := v[0], (v[n-1]-v[n-2])/dx
+	dst[0] = (v[1] - v[0]) / dx
+	for i := 1; i < n-1; i++ {
+		cur := v[i]
+		dst[i] = (v[i+1] - prev) / (2.0 * dx)
+		prev = cur
+	}
+	dst[n-1] = last
+	return dst
+}
+
+/*
+Trapz integrates the passed []float64 of samples using the trapezoidal rule.
+The second argument determines where the samples are located, and can be a
+float64 or a []float64. When it is a float64, the samples are taken to be
+evenly spaced with that spacing:
+
+	y := []float64{0.0, 1.0, 2.0}
+	s := vec.Trapz(y, 0.5) // s is 1.0
+
+When it is a []float64, it holds the location of each sample:
+
+	x := []float64{0.0, 1.0, 3.0}
+	s := vec.Trapz(y, x) // s is 3.5
+
+In the latter case, the length of both arguments must be equal. The integral
+of fewer than 2 samples is 0.0. The passed arguments are not modified in this
+function.
+*/
+func Trapz(y []float64, val interface{}) float64 {
+	c := CumTrapz(y, val)
+	if len(c) == 0 {
+		return 0.0
+	}
+	return c[len(c)-1]
+}
+
+/*
+CumTrapz returns the running integral of the passed []float64 of samples,
+using the trapezoidal rule. The second argument is used as in vec.Trapz().
+The returned []float64 has the same length as the original, and its i-th
+element is the integral from the first sample up to the i-th sample, such that
+the first element is always 0.0. For example:
+
+	v := []float64{1.0, 1.0, 1.0} // velocity sampled every 0.5 seconds
+	p := vec.CumTrapz(v, 0.5)     // position is {0.0, 0.5, 1.0}
+
+The passed arguments are not modified in this function.
+*/
+func CumTrapz(y []float64, val interface{}) []float64 {
+	return cumTrapz("CumTrapz()", alloc(len(y)), y, val)
+}
+
+/*
+CumTrapzTo stores the result of vec.CumTrapz(y, val) in dst, and returns
+dst, without allocating. dst must have the length of y, and may be y itself.
+*/
+func CumTrapzTo(dst, y []float64, val interface{}) []float64 {
+	return cumTrapz("CumTrapzTo()", dst, y, val)
+}
+
+func cumTrapz(fn string, dst, y []float64, val interface{}) []float64 {
+	var width func(i int) float64
+	switch x := val.(type) {
+	case float64:
+		width = func(int) float64 { return x }
+	case []float64:
+		if len(y) != len(x) {
+			panic(fmt.Sprintf(errStrings[5], fn, len(y), len(x)))
+		}
+		width = func(i int) float64 { return x[i] - x[i-1] }
+	default:
+		panic(fmt.Sprintf(errStrings[6], fn, x))
+	}
+	checkDst(fn, dst, y)
+	if len(y) == 0 {
+		return dst
+	}
+	// Each sample is read before dst overwrites it, in case dst is y.
+	prev := y[0]
+	dst[0] = 0.0
+	for i := 1; i < len(y); i++ {
+		cur := y[i]
+		dst[i] = dst[i-1] + 0.5*width(i)*(cur+prev)
+		prev = cur
+	}
+	return dst
+}
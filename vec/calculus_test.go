@@ -0,0 +1,125 @@
+package vec
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	v := []float64{1.0, 2.0, 4.0, 7.0}
+	tests := []struct {
+		n        int
+		expected []float64
+	}{
+		{0, []float64{1.0, 2.0, 4.0, 7.0}},
+		{1, []float64{1.0, 2.0, 3.0}},
+		{2, []float64{1.0, 1.0}},
+		{3, []float64{0.0}},
+		{4, []float64{}},
+	}
+	for _, test := range tests {
+		d := Diff(v, test.n)
+		if !Equal(d, test.expected) {
+			t.Errorf("for n = %d, expected %v, got %v", test.n, test.expected, d)
+		}
+	}
+	if v[0] != 1.0 || len(v) != 4 {
+		t.Errorf("the original []float64 was modified")
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[13], "Diff()", -1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Diff(v, -1)
+	}()
+	wg.Wait()
+}
+
+func TestGradient(t *testing.T) {
+	v := []float64{1.0, 2.0, 4.0, 7.0}
+	g := Gradient(v, 1.0)
+	if !Equal(g, []float64{1.0, 1.5, 2.5, 3.0}) {
+		t.Errorf("expected {1.0, 1.5, 2.5, 3.0}, got %v", g)
+	}
+	g = Gradient(v, 0.5)
+	if !Equal(g, []float64{2.0, 3.0, 5.0, 6.0}) {
+		t.Errorf("expected {2.0, 3.0, 5.0, 6.0}, got %v", g)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[16], "Gradient()", 2, 1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Gradient([]float64{1.0}, 1.0)
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[7], "Gradient()")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Gradient(v, 0.0)
+	}()
+	wg.Wait()
+}
+
+func TestTrapz(t *testing.T) {
+	y := []float64{0.0, 1.0, 2.0}
+	if s := Trapz(y, 0.5); s != 1.0 {
+		t.Errorf("expected 1.0, got %f", s)
+	}
+	if s := Trapz(y, []float64{0.0, 1.0, 3.0}); s != 3.5 {
+		t.Errorf("expected 3.5, got %f", s)
+	}
+	if s := Trapz([]float64{1.0}, 1.0); s != 0.0 {
+		t.Errorf("expected 0.0 for a single sample, got %f", s)
+	}
+	if s := Trapz([]float64{}, 1.0); s != 0.0 {
+		t.Errorf("expected 0.0 for no samples, got %f", s)
+	}
+}
+
+func TestCumTrapz(t *testing.T) {
+	v := []float64{1.0, 1.0, 1.0}
+	p := CumTrapz(v, 0.5)
+	if !Equal(p, []float64{0.0, 0.5, 1.0}) {
+		t.Errorf("expected {0.0, 0.5, 1.0}, got %v", p)
+	}
+	p = CumTrapz([]float64{0.0, 2.0, 2.0}, []float64{0.0, 1.0, 2.0})
+	if !Equal(p, []float64{0.0, 1.0, 3.0}) {
+		t.Errorf("expected {0.0, 1.0, 3.0}, got %v", p)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[5], "CumTrapz()", 3, 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		CumTrapz(v, []float64{0.0, 1.0})
+	}()
+	wg.Wait()
+}
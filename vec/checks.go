@@ -0,0 +1,69 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+// The checks below return the errors of invalid arguments, rather than
+// panicking, so that the functions of this package can panic with them,
+// with raise(), and the methods of Vector can return them as they are.
+
+// arith returns the error of the function fn, which combines v with val
+// elementwise, if val is neither a float64 nor a []float64 of the length of
+// v. A length mismatch is reported with an *errs.ShapeError.
+func arith(fn string, v []float64, val interface{}) error {
+	switch w := val.(type) {
+	case float64:
+	case []float64:
+		return lengths(fn, len(v), len(w))
+	default:
+		return message(6, fn, w)
+	}
+	return nil
+}
+
+// quotient returns the error of the function fn, which divides v by val,
+// as arith() does, or an *errs.DivideByZeroError if val is, or holds, 0.0.
+func quotient(fn string, v []float64, val interface{}) error {
+	if err := arith(fn, v, val); err != nil {
+		return err
+	}
+	switch w := val.(type) {
+	case float64:
+		if w == 0.0 {
+			return &errs.DivideByZeroError{Index: -1, Err: message(7, fn)}
+		}
+	case []float64:
+		for i := range w {
+			if w[i] == 0.0 {
+				return &errs.DivideByZeroError{Index: i, Err: message(8, fn, i)}
+			}
+		}
+	}
+	return nil
+}
+
+// lengths returns an *errs.ShapeError naming the function fn if got, the
+// length of a []float64 passed to fn, is not want, the length of the first
+// one.
+func lengths(fn string, want, got int) error {
+	if got == want {
+		return nil
+	}
+	return &errs.ShapeError{Got: []int{got}, Want: []int{want}, Err: message(5, fn, want, got)}
+}
+
+// message returns an error holding errStrings[k], formatted with args.
+func message(k int, args ...interface{}) error {
+	return errors.New(strings.TrimSpace(fmt.Sprintf(errStrings[k], args...)))
+}
+
+// raise panics with the message of err, formatted as the messages of
+// errStrings are.
+func raise(err error) {
+	panic("\n" + err.Error() + "\n")
+}
@@ -0,0 +1,35 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+func TestChecks(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0}
+	var se *errs.ShapeError
+	err := arith("Add()", v, []float64{1.0})
+	if !errors.As(err, &se) || se.Got[0] != 1 || se.Want[0] != 3 {
+		t.Errorf("expected an *errs.ShapeError of lengths 1 and 3, got %v", err)
+	}
+	if err.Error() != strings.TrimSpace(fmt.Sprintf(errStrings[5], "Add()", 3, 1)) {
+		t.Errorf("expected the message of the panic, got %q", err.Error())
+	}
+	var de *errs.DivideByZeroError
+	if err := quotient("Div()", v, []float64{1.0, 0.0, 2.0}); !errors.As(err, &de) || de.Index != 1 {
+		t.Errorf("expected an *errs.DivideByZeroError at index 1, got %v", err)
+	}
+	if err := quotient("Div()", v, 0.0); !errors.As(err, &de) || de.Index != -1 {
+		t.Errorf("expected an *errs.DivideByZeroError of a scalar, got %v", err)
+	}
+	if err := arith("Mul()", v, "x"); err == nil || errors.Is(err, errs.ErrShapeMismatch) {
+		t.Errorf("expected an untyped error for a second argument of the wrong type, got %v", err)
+	}
+	if err := quotient("Div()", v, []float64{1.0, 2.0, 3.0}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
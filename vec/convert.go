@@ -0,0 +1,105 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrRange is returned by the conversions of this package when an element
+// is NaN, or outside of the range of the type it is converted to.
+var ErrRange = errors.New("vec: the value is outside of the range of the type")
+
+/*
+FromInts returns a []float64 holding the elements of a []int. Integers of
+magnitude up to 2^53 are converted exactly, and larger ones are rounded to
+the nearest float64. v is not mutated in this function.
+*/
+func FromInts(v []int) []float64 {
+	return fromInts(alloc(len(v)), v)
+}
+
+/*
+FromIntsTo stores the result of vec.FromInts(v) in dst, and returns dst,
+without allocating. dst must have the length of v.
+*/
+func FromIntsTo(dst []float64, v []int) []float64 {
+	if len(dst) != len(v) {
+		panic(fmt.Sprintf(errStrings[5], "FromIntsTo()", len(v), len(dst)))
+	}
+	return fromInts(dst, v)
+}
+
+func fromInts(dst []float64, v []int) []float64 {
+	for i, x := range v {
+		dst[i] = float64(x)
+	}
+	return dst
+}
+
+/*
+FromFloat32 returns a []float64 holding the elements of a []float32, which
+are converted exactly. v is not mutated in this function.
+*/
+func FromFloat32(v []float32) []float64 {
+	return fromFloat32(alloc(len(v)), v)
+}
+
+/*
+FromFloat32To stores the result of vec.FromFloat32(v) in dst, and returns
+dst, without allocating. dst must have the length of v.
+*/
+func FromFloat32To(dst []float64, v []float32) []float64 {
+	if len(dst) != len(v) {
+		panic(fmt.Sprintf(errStrings[5], "FromFloat32To()", len(v), len(dst)))
+	}
+	return fromFloat32(dst, v)
+}
+
+func fromFloat32(dst []float64, v []float32) []float64 {
+	for i, x := range v {
+		dst[i] = float64(x)
+	}
+	return dst
+}
+
+/*
+ToFloat32 returns a []float32 holding the elements of a []float64, rounded
+to the nearest float32, which halves the memory they take at the cost of
+their precision, for example to pass them to a GPU. Elements too large for a
+float32 become infinities, and those too small become zeros. v is not
+mutated in this function.
+*/
+func ToFloat32(v []float64) []float32 {
+	f := make([]float32, len(v))
+	for i, x := range v {
+		f[i] = float32(x)
+	}
+	return f
+}
+
+/*
+ToInts returns a []int holding the elements of a []float64, rounded to the
+nearest integer, with halves rounded away from zero, as with math.Round().
+For example:
+
+	n, err := vec.ToInts([]float64{1.4, 2.5, -2.5}) // n is {1, 3, -3}
+
+Unlike a plain conversion, whose result is unspecified for values outside of
+the range of an int, ToInts returns an error wrapping ErrRange if an element
+is NaN, or rounds to an integer outside of the range of an int. v is not
+mutated in this function.
+*/
+func ToInts(v []float64) ([]int, error) {
+	n := make([]int, len(v))
+	for i, x := range v {
+		r := math.Round(x)
+		// -float64(math.MinInt) is the power of two just above math.MaxInt,
+		// which is not itself a float64 on 64-bit platforms.
+		if math.IsNaN(r) || r < float64(math.MinInt) || r >= -float64(math.MinInt) {
+			return nil, fmt.Errorf("%w: the element at index %d is %v", ErrRange, i, x)
+		}
+		n[i] = int(r)
+	}
+	return n, nil
+}
@@ -0,0 +1,39 @@
+package vec
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestFromInts(t *testing.T) {
+	if f := FromInts([]int{1, -2, 3}); !Equal(f, []float64{1.0, -2.0, 3.0}) {
+		t.Errorf("expected {1.0, -2.0, 3.0}, got %v", f)
+	}
+	if f := FromFloat32([]float32{0.5, -1.25}); !Equal(f, []float64{0.5, -1.25}) {
+		t.Errorf("expected {0.5, -1.25}, got %v", f)
+	}
+	f := ToFloat32([]float64{0.1, 1e300, -1e-300})
+	if f[0] != float32(0.1) || !math.IsInf(float64(f[1]), 1) || f[2] != 0.0 {
+		t.Errorf("expected {0.1, +Inf, 0.0}, got %v", f)
+	}
+}
+
+func TestToInts(t *testing.T) {
+	n, err := ToInts([]float64{1.4, 2.5, -2.5, -0.4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(n, []int{1, 3, -3, 0}) {
+		t.Errorf("expected {1, 3, -3, 0}, got %v", n)
+	}
+	if n, err = ToInts([]float64{float64(math.MinInt)}); err != nil || n[0] != math.MinInt {
+		t.Errorf("expected %d, got %v and %v", math.MinInt, n, err)
+	}
+	for _, x := range []float64{math.NaN(), math.Inf(1), -float64(math.MinInt), 2.0 * float64(math.MinInt)} {
+		if n, err = ToInts([]float64{0.0, x}); !errors.Is(err, ErrRange) || n != nil {
+			t.Errorf("ToInts(%v): expected ErrRange, got %v and %v", x, n, err)
+		}
+	}
+}
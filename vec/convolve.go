@@ -0,0 +1,114 @@
+package vec
+
+import (
+	"fmt"
+
+	"github.com/NDari/gocrunch/fft"
+)
+
+// ConvMode determines which part of the full convolution vec.Convolve()
+// returns.
+type ConvMode int
+
+const (
+	// ConvFull returns the convolution at every point where the inputs
+	// overlap, which has a length of len(a)+len(kernel)-1.
+	ConvFull ConvMode = iota
+	// ConvSame returns the central part of the full convolution, with the
+	// length of the longer input.
+	ConvSame
+	// ConvValid returns only the points where the inputs overlap
+	// completely, which has a length of max(len(a), len(kernel)) -
+	// min(len(a), len(kernel)) + 1.
+	ConvValid
+)
+
+// convFFTThreshold is the length of the shorter input above which
+// vec.Convolve() uses the FFT instead of the direct sum.
+var convFFTThreshold = 64
+
+/*
+Convolve returns the discrete linear convolution of the passed []float64 with
+a kernel. The passed mode determines which part of the convolution is
+returned. For example:
+
+	a := []float64{1.0, 2.0, 3.0}
+	k := []float64{0.0, 1.0, 0.5}
+	vec.Convolve(a, k, vec.ConvFull)  // {0.0, 1.0, 2.5, 4.0, 1.5}
+	vec.Convolve(a, k, vec.ConvSame)  // {1.0, 2.5, 4.0}
+	vec.Convolve(a, k, vec.ConvValid) // {2.5}
+
+For short inputs, the convolution is computed directly. When both inputs are
+long, it is computed through the FFT, which is much faster but may differ
+from the direct result by rounding errors.
+
+Neither of the passed []float64s can be empty, otherwise this function will
+panic. The passed []float64s are not modified in this function.
+*/
+func Convolve(a, kernel []float64, mode ConvMode) []float64 {
+	if len(a) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "Convolve()", "Convolve()"))
+	}
+	if len(kernel) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "Convolve()", "Convolve()"))
+	}
+	long, short := len(a), len(kernel)
+	if short > long {
+		long, short = short, long
+	}
+	var start, length int
+	switch mode {
+	case ConvFull:
+		start, length = 0, long+short-1
+	case ConvSame:
+		start, length = (short-1)/2, long
+	case ConvValid:
+		start, length = short-1, long-short+1
+	default:
+		panic(fmt.Sprintf(errStrings[14], "Convolve()", mode))
+	}
+	var full []float64
+	if short > convFFTThreshold {
+		full = convolveFFT(a, kernel)
+	} else {
+		full = convolveDirect(a, kernel)
+	}
+	return full[start : start+length]
+}
+
+// convolveDirect returns the full convolution of a and b, computed directly
+// from the definition.
+func convolveDirect(a, b []float64) []float64 {
+	c := allocZeroed(len(a) + len(b) - 1)
+	for i := range a {
+		for j := range b {
+			c[i+j] += a[i] * b[j]
+		}
+	}
+	return c
+}
+
+// convolveFFT returns the full convolution of a and b, computed as the
+// inverse FFT of the product of their FFTs. The inputs are padded with zeros
+// to a power of 2, for which the transforms of the fft package are fastest.
+func convolveFFT(a, b []float64) []float64 {
+	n := len(a) + len(b) - 1
+	size := nextPow2(n)
+	pa, pb := make([]float64, size), make([]float64, size)
+	copy(pa, a)
+	copy(pb, b)
+	fa, fb := fft.RFFT(pa), fft.RFFT(pb)
+	for i := range fa {
+		fa[i] *= fb[i]
+	}
+	return fft.IRFFT(fa, size)[:n]
+}
+
+// nextPow2 returns the smallest power of 2 which is not smaller than n.
+func nextPow2(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
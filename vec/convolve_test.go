@@ -0,0 +1,73 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestConvolve(t *testing.T) {
+	a := []float64{1.0, 2.0, 3.0}
+	k := []float64{0.0, 1.0, 0.5}
+	tests := []struct {
+		mode     ConvMode
+		expected []float64
+	}{
+		{ConvFull, []float64{0.0, 1.0, 2.5, 4.0, 1.5}},
+		{ConvSame, []float64{1.0, 2.5, 4.0}},
+		{ConvValid, []float64{2.5}},
+	}
+	for _, test := range tests {
+		c := Convolve(a, k, test.mode)
+		if !Equal(c, test.expected) {
+			t.Errorf("for mode %d, expected %v, got %v", test.mode, test.expected, c)
+		}
+		c = Convolve(k, a, test.mode)
+		if len(c) != len(test.expected) {
+			t.Errorf("for mode %d with swapped inputs, expected length %d, got %d", test.mode, len(test.expected), len(c))
+		}
+	}
+	c := Convolve([]float64{1.0, 2.0, 3.0, 4.0}, []float64{1.0, 1.0}, ConvSame)
+	if !Equal(c, []float64{1.0, 3.0, 5.0, 7.0}) {
+		t.Errorf("expected {1.0, 3.0, 5.0, 7.0}, got %v", c)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[0], "Convolve()", "Convolve()")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Convolve(a, []float64{}, ConvFull)
+	}()
+	wg.Wait()
+}
+
+func TestConvolveFFT(t *testing.T) {
+	a := Rand(300)
+	k := Rand(100)
+	direct := convolveDirect(a, k)
+	c := Convolve(a, k, ConvFull)
+	if len(c) != len(direct) {
+		t.Fatalf("expected length %d, got %d", len(direct), len(c))
+	}
+	for i := range c {
+		if math.Abs(c[i]-direct[i]) > 1e-9 {
+			t.Errorf("at index %d, expected %f, got %f", i, direct[i], c[i])
+		}
+	}
+}
+
+func BenchmarkConvolve(b *testing.B) {
+	a := Rand(10000)
+	k := Rand(500)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Convolve(a, k, ConvSame)
+	}
+}
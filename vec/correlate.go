@@ -0,0 +1,89 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+)
+
+// CorrNorm determines how the results of vec.XCorr() and vec.AutoCorr()
+// are normalized.
+type CorrNorm int
+
+const (
+	// CorrNone returns the raw sums of products.
+	CorrNone CorrNorm = iota
+	// CorrBiased divides each lag by the length of the inputs.
+	CorrBiased
+	// CorrUnbiased divides each lag by the number of overlapping elements at
+	// that lag.
+	CorrUnbiased
+	// CorrCoeff divides by the geometric mean of the energies of the inputs,
+	// such that the autocorrelation at lag 0 is 1.0.
+	CorrCoeff
+)
+
+/*
+XCorr returns the cross-correlation of two []float64s for all lags from
+-maxLag to maxLag. The element at index maxLag+k of the result is
+
+	sum over n of a[n+k] * b[n]
+
+such that if a is a copy of b delayed by d elements, the result peaks at lag
+d, which is at index maxLag+d. For example:
+
+	a := []float64{0.0, 0.0, 1.0, 0.0}
+	b := []float64{0.0, 1.0, 0.0, 0.0}
+	c := vec.XCorr(a, b, 2, vec.CorrNone) // c is {0.0, 0.0, 0.0, 1.0, 0.0}
+
+The passed norm determines how the result is normalized. The passed
+[]float64s must be of equal and non-zero length, and maxLag must be in
+[0, len(a)), otherwise this function will panic. The passed []float64s are
+not modified in this function.
+*/
+func XCorr(a, b []float64, maxLag int, norm CorrNorm) []float64 {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf(errStrings[5], "XCorr()", len(a), len(b)))
+	}
+	if len(a) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "XCorr()", "XCorr()"))
+	}
+	n := len(a)
+	if maxLag < 0 || maxLag >= n {
+		panic(fmt.Sprintf(errStrings[1], "XCorr()", maxLag, n))
+	}
+	if norm < CorrNone || norm > CorrCoeff {
+		panic(fmt.Sprintf(errStrings[14], "XCorr()", norm))
+	}
+	full := Convolve(a, Reverse(b), ConvFull)
+	c := Clone(full[n-1-maxLag : n+maxLag])
+	switch norm {
+	case CorrBiased:
+		for i := range c {
+			c[i] /= float64(n)
+		}
+	case CorrUnbiased:
+		for i := range c {
+			lag := i - maxLag
+			if lag < 0 {
+				lag = -lag
+			}
+			c[i] /= float64(n - lag)
+		}
+	case CorrCoeff:
+		scale := math.Sqrt(Dot(a, a) * Dot(b, b))
+		for i := range c {
+			c[i] /= scale
+		}
+	}
+	return c
+}
+
+/*
+AutoCorr returns the autocorrelation of a []float64 for all lags from -maxLag
+to maxLag, which is the cross-correlation of the []float64 with itself. The
+result is symmetric about its central element, at index maxLag. See
+vec.XCorr() for details.
+*/
+func AutoCorr(v []float64, maxLag int, norm CorrNorm) []float64 {
+	return XCorr(v, v, maxLag, norm)
+}
@@ -0,0 +1,67 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestXCorr(t *testing.T) {
+	a := []float64{0.0, 0.0, 1.0, 0.0}
+	b := []float64{0.0, 1.0, 0.0, 0.0}
+	c := XCorr(a, b, 2, CorrNone)
+	if !Equal(c, []float64{0.0, 0.0, 0.0, 1.0, 0.0}) {
+		t.Errorf("expected {0.0, 0.0, 0.0, 1.0, 0.0}, got %v", c)
+	}
+	// Detect the delay between a long signal and a delayed copy of it.
+	sig := Rand(200)
+	delay := 7
+	delayed := make([]float64, len(sig))
+	copy(delayed[delay:], sig)
+	c = XCorr(delayed, sig, 20, CorrNone)
+	best := 0
+	for i := range c {
+		if c[i] > c[best] {
+			best = i
+		}
+	}
+	if best-20 != delay {
+		t.Errorf("expected the peak at lag %d, got %d", delay, best-20)
+	}
+	c = XCorr([]float64{1.0, 2.0}, []float64{1.0, 2.0}, 1, CorrUnbiased)
+	if !Equal(c, []float64{2.0, 2.5, 2.0}) {
+		t.Errorf("expected {2.0, 2.5, 2.0}, got %v", c)
+	}
+	c = XCorr([]float64{1.0, 2.0}, []float64{1.0, 2.0}, 1, CorrBiased)
+	if !Equal(c, []float64{1.0, 2.5, 1.0}) {
+		t.Errorf("expected {1.0, 2.5, 1.0}, got %v", c)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[1], "XCorr()", 4, 4)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		XCorr(a, b, 4, CorrNone)
+	}()
+	wg.Wait()
+}
+
+func TestAutoCorr(t *testing.T) {
+	v := []float64{1.0, -2.0, 3.0, 0.5}
+	c := AutoCorr(v, 3, CorrCoeff)
+	if math.Abs(c[3]-1.0) > 1e-12 {
+		t.Errorf("expected 1.0 at lag 0, got %f", c[3])
+	}
+	for k := 1; k <= 3; k++ {
+		if math.Abs(c[3+k]-c[3-k]) > 1e-12 {
+			t.Errorf("expected symmetry at lag %d, got %f and %f", k, c[3+k], c[3-k])
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package vec
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+Digitize returns the index of the bin of each element of v, against the
+passed edges, which must be strictly increasing. The bins are half open, so
+that an element x is in bin i when edges[i-1] <= x < edges[i]. Elements
+below the first edge are in bin 0, and those at or above the last edge, as
+well as NaNs, are in bin len(edges). For example:
+
+	v := []float64{-1.0, 0.0, 0.5, 1.0, 2.5}
+	edges := []float64{0.0, 1.0, 2.0}
+	bins := vec.Digitize(v, edges) // bins is {0, 1, 1, 2, 3}
+
+The bins of the elements between the first and the last edges are thus in
+[1, len(edges)-1], and can be counted with vecint.Bincount(). Each bin is
+found by a binary search of the edges. edges must have at least 1 element,
+and be strictly increasing, otherwise this function will panic. The passed
+[]float64s are not modified in this function.
+*/
+func Digitize(v, edges []float64) []int {
+	if len(edges) < 1 {
+		panic(fmt.Sprintf(errStrings[16], "Digitize()", 1, len(edges)))
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i] > edges[i-1]) {
+			panic(fmt.Sprintf(errStrings[17], "Digitize()", i))
+		}
+	}
+	bins := make([]int, len(v))
+	for i, x := range v {
+		bins[i] = sort.Search(len(edges), func(j int) bool { return edges[j] > x })
+	}
+	return bins
+}
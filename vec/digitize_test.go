@@ -0,0 +1,53 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestDigitize(t *testing.T) {
+	v := []float64{-1.0, 0.0, 0.5, 1.0, 2.5, 2.0, math.NaN(), math.Inf(-1)}
+	expected := []int{0, 1, 1, 2, 3, 3, 3, 0}
+	bins := Digitize(v, []float64{0.0, 1.0, 2.0})
+	for i := range expected {
+		if bins[i] != expected[i] {
+			t.Errorf("at index %d, expected %d, got %d", i, expected[i], bins[i])
+		}
+	}
+	if bins := Digitize([]float64{-1.0, 1.0}, []float64{1.0}); bins[0] != 0 || bins[1] != 1 {
+		t.Errorf("expected {0, 1}, got %v", bins)
+	}
+	if bins := Digitize(nil, []float64{1.0}); len(bins) != 0 {
+		t.Errorf("expected no bins, got %v", bins)
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { Digitize(v, nil) },
+			fmt.Sprintf(errStrings[16], "Digitize()", 1, 0),
+		},
+		{
+			func() { Digitize(v, []float64{0.0, 1.0, 1.0}) },
+			fmt.Sprintf(errStrings[17], "Digitize()", 2),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
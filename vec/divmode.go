@@ -0,0 +1,133 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+)
+
+// DivMode determines what vec.DivWith() does when dividing by a zero.
+type DivMode int
+
+const (
+	// DivIEEE divides by zeros as IEEE 754 does, which gives an infinity
+	// of the sign of the quotient for a non-zero dividend, and NaN for a
+	// zero one.
+	DivIEEE DivMode = iota
+	// DivFill sets the quotient of a division by zero to a fill value,
+	// which is 0.0 by default.
+	DivFill
+	// DivError returns an *errs.DivideByZeroError holding the index of the
+	// first zero of the divisor.
+	DivError
+)
+
+/*
+DivWith divides the elements of a []float64 by val, as vec.Div() does, but
+handles the divisions by zero with the passed mode rather than panicking.
+val can be a float64 or a []float64. For example:
+
+	v := []float64{1.0, -2.0, 0.0}
+	w := []float64{0.0, 0.0, 0.0}
+	vec.DivWith(v, w, vec.DivIEEE)            // {+Inf, -Inf, NaN}, nil
+	vec.DivWith(v, w, vec.DivFill, 1.0)       // {1.0, 1.0, 1.0}, nil
+	_, err := vec.DivWith(v, w, vec.DivError) // err holds the index 0
+
+In the vec.DivFill mode, an optional float64 can be passed to set the fill
+value. In the vec.DivError mode, an *errs.DivideByZeroError, matching
+errs.ErrDivideByZero and naming vec.DivWith(), is returned, along with a nil
+[]float64, if val is, or contains, 0.0. A scalar zero is reported at the
+index -1.
+
+The length of a []float64 val must be the length of v, otherwise this
+function will panic, as it will for an unknown mode, or for a fill value
+passed with another mode than vec.DivFill. The passed arguments
+are not modified in this function.
+*/
+func DivWith(v []float64, val interface{}, mode DivMode, args ...float64) ([]float64, error) {
+	fill := 0.0
+	switch mode {
+	case DivIEEE, DivError:
+		if len(args) != 0 {
+			panic(fmt.Sprintf(errStrings[4], "DivWith()"))
+		}
+	case DivFill:
+		switch len(args) {
+		case 0:
+		case 1:
+			fill = args[0]
+		default:
+			panic(fmt.Sprintf(errStrings[4], "DivWith()"))
+		}
+	default:
+		panic(fmt.Sprintf(errStrings[14], "DivWith()", mode))
+	}
+	if err := arith("DivWith()", v, val); err != nil {
+		raise(err)
+	}
+	if mode == DivError {
+		if err := quotient("DivWith()", v, val); err != nil {
+			return nil, err
+		}
+	}
+	c := Clone(v)
+	switch w := val.(type) {
+	case float64:
+		for i := range c {
+			if w == 0.0 && mode == DivFill {
+				c[i] = fill
+			} else {
+				c[i] /= w
+			}
+		}
+	case []float64:
+		for i := range c {
+			if w[i] == 0.0 && mode == DivFill {
+				c[i] = fill
+			} else {
+				c[i] /= w[i]
+			}
+		}
+	}
+	return c, nil
+}
+
+/*
+SafeDiv divides the elements of a by those of b, replacing each divisor by
+eps, with its sign, when it is smaller than eps in magnitude, so that the
+quotients stay finite near zero, as in normalization layers. That is, it
+divides a[i] by math.Copysign(max(|b[i]|, eps), b[i]). For example:
+
+	a := []float64{1.0, 1.0, 1.0}
+	b := []float64{4.0, 1e-12, -0.0}
+	q := vec.SafeDiv(a, b, 1e-6) // q is {0.25, 1e6, -1e6}
+
+An eps of 0.0 divides by b unchanged. a and b must have the same length, and
+eps must not be negative or NaN, otherwise this function will panic. The
+passed []float64s are not modified in this function.
+*/
+func SafeDiv(a, b []float64, eps float64) []float64 {
+	return safeDiv("SafeDiv()", alloc(len(a)), a, b, eps)
+}
+
+/*
+SafeDivTo stores the result of vec.SafeDiv(a, b, eps) in dst, and returns
+dst, without allocating. dst must have the length of a, and may be a or b
+itself.
+*/
+func SafeDivTo(dst, a, b []float64, eps float64) []float64 {
+	return safeDiv("SafeDivTo()", dst, a, b, eps)
+}
+
+func safeDiv(fn string, dst, a, b []float64, eps float64) []float64 {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf(errStrings[5], fn, len(a), len(b)))
+	}
+	if !(eps >= 0.0) {
+		panic(fmt.Sprintf(errStrings[20], fn, "epsilon", eps))
+	}
+	checkDst(fn, dst, a)
+	for i := range a {
+		dst[i] = a[i] / math.Copysign(max(math.Abs(b[i]), eps), b[i])
+	}
+	return dst
+}
@@ -0,0 +1,107 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+func TestDivWith(t *testing.T) {
+	v := []float64{1.0, -2.0, 0.0}
+	w := []float64{0.0, 0.0, 0.0}
+	q, err := DivWith(v, w, DivIEEE)
+	if err != nil || !math.IsInf(q[0], 1) || !math.IsInf(q[1], -1) || !math.IsNaN(q[2]) {
+		t.Errorf("expected {+Inf, -Inf, NaN}, got %v and %v", q, err)
+	}
+	if q, err := DivWith(v, []float64{2.0, 0.0, 4.0}, DivFill); err != nil || !Equal(q, []float64{0.5, 0.0, 0.0}) {
+		t.Errorf("expected {0.5, 0.0, 0.0}, got %v and %v", q, err)
+	}
+	if q, err := DivWith(v, 0.0, DivFill, 7.0); err != nil || !Equal(q, []float64{7.0, 7.0, 7.0}) {
+		t.Errorf("expected {7.0, 7.0, 7.0}, got %v and %v", q, err)
+	}
+	if q, err := DivWith(v, 2.0, DivError); err != nil || !Equal(q, []float64{0.5, -1.0, 0.0}) {
+		t.Errorf("expected {0.5, -1.0, 0.0}, got %v and %v", q, err)
+	}
+	var de *errs.DivideByZeroError
+	q, err = DivWith(v, []float64{1.0, 2.0, 0.0}, DivError)
+	if q != nil || !errors.Is(err, errs.ErrDivideByZero) || !errors.As(err, &de) || de.Index != 2 {
+		t.Errorf("expected an error at index 2, got %v and %v", q, err)
+	}
+	if expected := strings.TrimSpace(fmt.Sprintf(errStrings[8], "DivWith()", 2)); de.Err == nil || de.Err.Error() != expected {
+		t.Errorf("expected the error %q, got %v", expected, de.Err)
+	}
+	if _, err := DivWith(v, 0.0, DivError); !errors.As(err, &de) || de.Index != -1 {
+		t.Errorf("expected an error at index -1, got %v", err)
+	}
+	if !Equal(v, []float64{1.0, -2.0, 0.0}) {
+		t.Errorf("the original []float64 was modified")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { DivWith(v, []float64{1.0}, DivIEEE) }, fmt.Sprintf(errStrings[5], "DivWith()", 3, 1)},
+		{func() { DivWith(v, 1, DivIEEE) }, fmt.Sprintf(errStrings[6], "DivWith()", 1)},
+		{func() { DivWith(v, 1.0, DivFill, 1.0, 2.0) }, fmt.Sprintf(errStrings[4], "DivWith()")},
+		{func() { DivWith(v, 1.0, DivIEEE, 1.0) }, fmt.Sprintf(errStrings[4], "DivWith()")},
+		{func() { DivWith(v, 0.0, DivError, 1.0) }, fmt.Sprintf(errStrings[4], "DivWith()")},
+		{func() { DivWith(v, 1.0, DivMode(7)) }, fmt.Sprintf(errStrings[14], "DivWith()", 7)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestSafeDiv(t *testing.T) {
+	a := []float64{1.0, 1.0, 1.0, -2.0}
+	b := []float64{4.0, 1e-12, math.Copysign(0.0, -1.0), 0.0}
+	if q := SafeDiv(a, b, 1e-6); !Equal(q, []float64{0.25, 1e6, -1e6, -2e6}) {
+		t.Errorf("expected {0.25, 1e6, -1e6, -2e6}, got %v", q)
+	}
+	if q := SafeDiv(a[:2], b[:2], 0.0); !Equal(q, []float64{0.25, 1e12}) {
+		t.Errorf("expected {0.25, 1e12}, got %v", q)
+	}
+	if b[1] != 1e-12 {
+		t.Errorf("the original []float64 was modified")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { SafeDiv(a, b[:1], 1e-6) }, fmt.Sprintf(errStrings[5], "SafeDiv()", 4, 1)},
+		{func() { SafeDiv(a, b, -1.0) }, fmt.Sprintf(errStrings[20], "SafeDiv()", "epsilon", -1.0)},
+		{func() { SafeDiv(a, b, math.NaN()) }, fmt.Sprintf(errStrings[20], "SafeDiv()", "epsilon", math.NaN())},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
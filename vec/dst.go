@@ -0,0 +1,128 @@
+package vec
+
+import (
+	"fmt"
+)
+
+/*
+AddTo stores the result of vec.Add(v, val) in dst, and returns dst, without
+allocating. val can be a float64 or a []float64. dst must have the length of
+v, and may be v itself, or val, in which case the result overwrites it.
+*/
+func AddTo(dst, v []float64, val interface{}) []float64 {
+	return arithTo("AddTo()", dst, v, val, exprAdd)
+}
+
+/*
+SubTo stores the result of vec.Sub(v, val) in dst, and returns dst, without
+allocating. val can be a float64 or a []float64. dst must have the length of
+v, and may be v itself, or val, in which case the result overwrites it.
+*/
+func SubTo(dst, v []float64, val interface{}) []float64 {
+	return arithTo("SubTo()", dst, v, val, exprSub)
+}
+
+/*
+MulTo stores the result of vec.Mul(v, val) in dst, and returns dst, without
+allocating. val can be a float64 or a []float64. dst must have the length of
+v, and may be v itself, or val, in which case the result overwrites it.
+*/
+func MulTo(dst, v []float64, val interface{}) []float64 {
+	return arithTo("MulTo()", dst, v, val, exprMul)
+}
+
+/*
+DivTo stores the result of vec.Div(v, val) in dst, and returns dst, without
+allocating. val can be a float64 or a []float64, which must not be, or
+contain, 0.0. dst must have the length of v, and may be v itself, or val, in
+which case the result overwrites it.
+*/
+func DivTo(dst, v []float64, val interface{}) []float64 {
+	if err := quotient("DivTo()", v, val); err != nil {
+		raise(err)
+	}
+	return arithTo("DivTo()", dst, v, val, exprDiv)
+}
+
+/*
+ApplyTo stores the result of vec.Foreach(v, f) in dst, and returns dst,
+without allocating. Together with the functions of the math package, this
+covers the element-wise functions of this package, such as:
+
+	vec.ApplyTo(dst, math.Sin, v) // the same elements as vec.Sin(v)
+
+dst must have the length of v, and may be v itself.
+*/
+func ApplyTo(dst []float64, f func(float64) float64, v []float64) []float64 {
+	checkDst("ApplyTo()", dst, v)
+	for i := range v {
+		dst[i] = f(v[i])
+	}
+	return dst
+}
+
+/*
+CopyTo stores a copy of v in dst, and returns dst, without allocating. Unlike
+the builtin copy, dst must have the length of v.
+*/
+func CopyTo(dst, v []float64) []float64 {
+	checkDst("CopyTo()", dst, v)
+	copy(dst, v)
+	return dst
+}
+
+func arithTo(fn string, dst, v []float64, val interface{}, kind exprKind) []float64 {
+	checkDst(fn, dst, v)
+	switch w := val.(type) {
+	case float64:
+		switch kind {
+		case exprAdd:
+			for i := range v {
+				dst[i] = v[i] + w
+			}
+		case exprSub:
+			for i := range v {
+				dst[i] = v[i] - w
+			}
+		case exprMul:
+			for i := range v {
+				dst[i] = v[i] * w
+			}
+		case exprDiv:
+			for i := range v {
+				dst[i] = v[i] / w
+			}
+		}
+	case []float64:
+		if len(w) != len(v) {
+			panic(fmt.Sprintf(errStrings[5], fn, len(v), len(w)))
+		}
+		switch kind {
+		case exprAdd:
+			for i := range v {
+				dst[i] = v[i] + w[i]
+			}
+		case exprSub:
+			for i := range v {
+				dst[i] = v[i] - w[i]
+			}
+		case exprMul:
+			for i := range v {
+				dst[i] = v[i] * w[i]
+			}
+		case exprDiv:
+			for i := range v {
+				dst[i] = v[i] / w[i]
+			}
+		}
+	default:
+		panic(fmt.Sprintf(errStrings[6], fn, w))
+	}
+	return dst
+}
+
+func checkDst(fn string, dst, v []float64) {
+	if len(dst) != len(v) {
+		panic(fmt.Sprintf(errStrings[5], fn, len(v), len(dst)))
+	}
+}
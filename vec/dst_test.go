@@ -0,0 +1,191 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestArithTo(t *testing.T) {
+	v := []float64{1.0, 2.0, 4.0}
+	w := []float64{2.0, 8.0, 0.5}
+	cases := []struct {
+		name string
+		to   func(dst, v []float64, val interface{}) []float64
+		f    func(v []float64, val interface{}) []float64
+	}{
+		{"AddTo", AddTo, Add},
+		{"SubTo", SubTo, Sub},
+		{"MulTo", MulTo, Mul},
+		{"DivTo", DivTo, Div},
+	}
+	for _, c := range cases {
+		for _, val := range []interface{}{3.0, w} {
+			dst := make([]float64, 3)
+			res := c.to(dst, v, val)
+			if !Equal(res, c.f(v, val)) || &res[0] != &dst[0] {
+				t.Errorf("%s: expected %v in dst, got %v", c.name, c.f(v, val), res)
+			}
+		}
+		// Writing over the first argument.
+		in := Clone(v)
+		c.to(in, in, w)
+		if !Equal(in, c.f(v, w)) {
+			t.Errorf("%s: expected %v in place, got %v", c.name, c.f(v, w), in)
+		}
+	}
+	if !Equal(v, []float64{1.0, 2.0, 4.0}) || !Equal(w, []float64{2.0, 8.0, 0.5}) {
+		t.Errorf("the arguments were modified: %v, %v", v, w)
+	}
+}
+
+func TestApplyTo(t *testing.T) {
+	v := []float64{0.0, 1.0, 2.0}
+	dst := make([]float64, 3)
+	ApplyTo(dst, math.Sin, v)
+	if !Equal(dst, Sin(v)) {
+		t.Errorf("expected %v, got %v", Sin(v), dst)
+	}
+	CopyTo(dst, v)
+	if !Equal(dst, v) {
+		t.Errorf("expected %v, got %v", v, dst)
+	}
+}
+
+func TestTo(t *testing.T) {
+	v := []float64{1.0, -2.5, 4.0, math.NaN(), 7.0}
+	w := []float64{2.0, 3.0, -1.5, 1.0, 0.5}
+	cond := []bool{true, false, true, false, true}
+	cases := []struct {
+		name     string
+		to       func(dst []float64) []float64
+		expected []float64
+		// inPlace writes over the argument which is passed as dst.
+		inPlace func() []float64
+	}{
+		{"ModTo", func(d []float64) []float64 { return ModTo(d, v, w) }, Mod(v, w),
+			func() []float64 { c := Clone(v); return ModTo(c, c, 3.0) }},
+		{"RemainderTo", func(d []float64) []float64 { return RemainderTo(d, v, 2.0) }, Remainder(v, 2.0),
+			func() []float64 { c := Clone(w); return RemainderTo(c, v, c) }},
+		{"MulAddTo", func(d []float64) []float64 { return MulAddTo(d, v, w, 1.0) }, MulAdd(v, w, 1.0),
+			func() []float64 { c := Clone(v); return MulAddTo(c, c, 2.0, w) }},
+		{"WhereTo", func(d []float64) []float64 { return WhereTo(d, cond, v, w) }, Where(cond, v, w),
+			func() []float64 { c := Clone(v); return WhereTo(c, cond, c, 0.0) }},
+		{"SafeDivTo", func(d []float64) []float64 { return SafeDivTo(d, v, w, 1.0) }, SafeDiv(v, w, 1.0),
+			func() []float64 { c := Clone(w); return SafeDivTo(c, v, c, 1.0) }},
+		{"ScanTo", func(d []float64) []float64 { return ScanTo(d, math.Max, 0.0, w) }, Scan(math.Max, 0.0, w),
+			func() []float64 { c := Clone(w); return ScanTo(c, math.Max, 0.0, c) }},
+		{"CumSumTo", func(d []float64) []float64 { return CumSumTo(d, w) }, CumSum(w),
+			func() []float64 { c := Clone(w); return CumSumTo(c, c) }},
+		{"CumProdTo", func(d []float64) []float64 { return CumProdTo(d, w) }, CumProd(w),
+			func() []float64 { c := Clone(w); return CumProdTo(c, c) }},
+		{"ReverseTo", func(d []float64) []float64 { return ReverseTo(d, v) }, Reverse(v),
+			func() []float64 { c := Clone(v); return ReverseTo(c, c) }},
+		{"RollTo", func(d []float64) []float64 { return RollTo(d, v, 2) }, Roll(v, 2),
+			func() []float64 { c := Clone(v); return RollTo(c, c, 2) }},
+		{"GradientTo", func(d []float64) []float64 { return GradientTo(d, w, 0.5) }, Gradient(w, 0.5),
+			func() []float64 { c := Clone(w); return GradientTo(c, c, 0.5) }},
+		{"CumTrapzTo", func(d []float64) []float64 { return CumTrapzTo(d, w, v) }, CumTrapz(w, v),
+			func() []float64 { c := Clone(w); return CumTrapzTo(c, c, 0.5) }},
+		{"InterpTo", func(d []float64) []float64 { return InterpTo(d, w, []float64{0, 1, 2}, []float64{0, 10, 0}, InterpNaN) },
+			Interp(w, []float64{0, 1, 2}, []float64{0, 10, 0}, InterpNaN),
+			func() []float64 {
+				c := Clone(w)
+				return InterpTo(c, c, []float64{0, 1, 2}, []float64{0, 10, 0}, InterpClamp)
+			}},
+		{"FromIntsTo", func(d []float64) []float64 { return FromIntsTo(d, []int{1, -2, 3, 4, 5}) }, FromInts([]int{1, -2, 3, 4, 5}), nil},
+		{"FromFloat32To", func(d []float64) []float64 { return FromFloat32To(d, []float32{1, 2, 3, 4, 0.5}) }, FromFloat32([]float32{1, 2, 3, 4, 0.5}), nil},
+		{"ReplaceNaNTo", func(d []float64) []float64 { return ReplaceNaNTo(d, v, 0.0) }, ReplaceNaN(v, 0.0),
+			func() []float64 { c := Clone(v); return ReplaceNaNTo(c, c, -1.0) }},
+		{"NanToNumTo", func(d []float64) []float64 { return NanToNumTo(d, v, 9.0, 1.0, -1.0) }, NanToNum(v, 9.0, 1.0, -1.0),
+			func() []float64 { c := Clone(v); return NanToNumTo(c, c, 9.0, 1.0, -1.0) }},
+	}
+	inPlace := map[string][]float64{
+		"ModTo":        Mod(v, 3.0),
+		"RemainderTo":  Remainder(v, w),
+		"MulAddTo":     MulAdd(v, 2.0, w),
+		"WhereTo":      Where(cond, v, 0.0),
+		"SafeDivTo":    SafeDiv(v, w, 1.0),
+		"ScanTo":       Scan(math.Max, 0.0, w),
+		"CumSumTo":     CumSum(w),
+		"CumProdTo":    CumProd(w),
+		"ReverseTo":    Reverse(v),
+		"RollTo":       Roll(v, 2),
+		"GradientTo":   Gradient(w, 0.5),
+		"CumTrapzTo":   CumTrapz(w, 0.5),
+		"InterpTo":     Interp(w, []float64{0, 1, 2}, []float64{0, 10, 0}, InterpClamp),
+		"ReplaceNaNTo": ReplaceNaN(v, -1.0),
+		"NanToNumTo":   NanToNum(v, 9.0, 1.0, -1.0),
+	}
+	for _, c := range cases {
+		dst := make([]float64, 5)
+		res := c.to(dst)
+		if !sameFloats(res, c.expected) || &res[0] != &dst[0] {
+			t.Errorf("%s: expected %v in dst, got %v", c.name, c.expected, res)
+		}
+		if c.inPlace == nil {
+			continue
+		}
+		if res := c.inPlace(); !sameFloats(res, inPlace[c.name]) {
+			t.Errorf("%s: expected %v in place, got %v", c.name, inPlace[c.name], res)
+		}
+	}
+	if !sameFloats(v, []float64{1.0, -2.5, 4.0, math.NaN(), 7.0}) || !Equal(w, []float64{2.0, 3.0, -1.5, 1.0, 0.5}) {
+		t.Errorf("the arguments were modified: %v, %v", v, w)
+	}
+}
+
+// sameFloats reports whether a and b have the same elements, taking NaNs to
+// be equal.
+func sameFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && !(math.IsNaN(a[i]) && math.IsNaN(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDstErrors(t *testing.T) {
+	cases := []struct {
+		expectedErr string
+		f           func()
+	}{
+		{fmt.Sprintf(errStrings[5], "AddTo()", 2, 1), func() { AddTo(make([]float64, 1), []float64{1, 2}, 1.0) }},
+		{fmt.Sprintf(errStrings[5], "MulTo()", 2, 3), func() { MulTo(make([]float64, 2), []float64{1, 2}, []float64{1, 2, 3}) }},
+		{fmt.Sprintf(errStrings[7], "DivTo()"), func() { DivTo(make([]float64, 2), []float64{1, 2}, 0.0) }},
+		{fmt.Sprintf(errStrings[8], "DivTo()", 0), func() { DivTo(make([]float64, 2), []float64{1, 2}, []float64{0, 1}) }},
+		{fmt.Sprintf(errStrings[6], "SubTo()", 1), func() { SubTo(make([]float64, 2), []float64{1, 2}, 1) }},
+		{fmt.Sprintf(errStrings[5], "ApplyTo()", 2, 0), func() { ApplyTo(nil, math.Abs, []float64{1, 2}) }},
+		{fmt.Sprintf(errStrings[5], "ModTo()", 2, 1), func() { ModTo(make([]float64, 1), []float64{1, 2}, 1.0) }},
+		{fmt.Sprintf(errStrings[12], "MulAddTo()", 3, "a"), func() { MulAddTo(make([]float64, 1), []float64{1}, 1.0, "a") }},
+		{fmt.Sprintf(errStrings[5], "WhereTo()", 2, 3), func() { WhereTo(make([]float64, 3), []bool{true, false}, 1.0, 0.0) }},
+		{fmt.Sprintf(errStrings[20], "SafeDivTo()", "epsilon", -1.0), func() { SafeDivTo(make([]float64, 1), []float64{1}, []float64{1}, -1.0) }},
+		{fmt.Sprintf(errStrings[5], "CumSumTo()", 2, 1), func() { CumSumTo(make([]float64, 1), []float64{1, 2}) }},
+		{fmt.Sprintf(errStrings[5], "RollTo()", 2, 0), func() { RollTo(nil, []float64{1, 2}, 1) }},
+		{fmt.Sprintf(errStrings[7], "GradientTo()"), func() { GradientTo(make([]float64, 2), []float64{1, 2}, 0.0) }},
+		{fmt.Sprintf(errStrings[6], "CumTrapzTo()", "a"), func() { CumTrapzTo(make([]float64, 2), []float64{1, 2}, "a") }},
+		{fmt.Sprintf(errStrings[5], "InterpTo()", 1, 2), func() { InterpTo(make([]float64, 2), []float64{1}, []float64{0, 1}, []float64{0, 1}, InterpClamp) }},
+		{fmt.Sprintf(errStrings[5], "FromIntsTo()", 1, 2), func() { FromIntsTo(make([]float64, 2), []int{1}) }},
+		{fmt.Sprintf(errStrings[5], "NanToNumTo()", 1, 2), func() { NanToNumTo(make([]float64, 2), []float64{1}, 0, 0, 0) }},
+	}
+	var wg sync.WaitGroup
+	for _, c := range cases {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != c.expectedErr {
+					t.Errorf("Expected %s, got %v", c.expectedErr, r)
+				}
+				wg.Done()
+			}()
+			c.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,186 @@
+package vec
+
+import (
+	"fmt"
+)
+
+// exprBlock is the number of elements an Expression evaluates at a time, so
+// that the intermediate results stay in cache between operations.
+const exprBlock = 256
+
+type exprKind int
+
+const (
+	exprAdd exprKind = iota
+	exprSub
+	exprMul
+	exprDiv
+	exprApply
+)
+
+// exprOp is one operation of an Expression, with either a scalar or a
+// []float64 operand, or a function to apply.
+type exprOp struct {
+	kind   exprKind
+	scalar float64
+	v      []float64
+	f      func(float64) float64
+}
+
+/*
+Expression is a chain of element-wise operations on a []float64, which is
+only computed when Eval() is called. All the operations are then applied to
+blocks of elements in a single pass, with no temporary slices, rather than
+allocating a new []float64 for each step as the functions of this package do.
+For example,
+
+	res := vec.Expr(a).Add(b).Mul(c).Sub(1.0).Eval(nil)
+
+computes the same elements as
+
+	res := vec.Sub(vec.Mul(vec.Add(a, b), c), 1.0)
+
+with one allocation instead of three. The operations accept the same
+arguments as their counterparts in this package, and panic on the same
+invalid input, when they are added to the Expression.
+
+An Expression refers to the slices passed to it rather than copying them, so
+they must not be modified until the Expression is evaluated.
+*/
+type Expression struct {
+	base []float64
+	ops  []exprOp
+}
+
+// Expr returns an Expression starting from the elements of v.
+func Expr(v []float64) *Expression {
+	return &Expression{base: v}
+}
+
+// Len returns the number of elements the Expression evaluates to.
+func (e *Expression) Len() int {
+	return len(e.base)
+}
+
+// Add adds val, a float64 or a []float64, to each element.
+func (e *Expression) Add(val interface{}) *Expression {
+	return e.push(exprAdd, val, "Add()")
+}
+
+// Sub subtracts val, a float64 or a []float64, from each element.
+func (e *Expression) Sub(val interface{}) *Expression {
+	return e.push(exprSub, val, "Sub()")
+}
+
+// Mul multiplies each element by val, a float64 or a []float64.
+func (e *Expression) Mul(val interface{}) *Expression {
+	return e.push(exprMul, val, "Mul()")
+}
+
+/*
+Div divides each element by val, a float64 or a []float64. As with vec.Div(),
+val must not be, or contain, 0.0.
+*/
+func (e *Expression) Div(val interface{}) *Expression {
+	if err := quotient("Div()", e.base, val); err != nil {
+		raise(err)
+	}
+	return e.push(exprDiv, val, "Div()")
+}
+
+// Apply applies f to each element.
+func (e *Expression) Apply(f func(float64) float64) *Expression {
+	e.ops = append(e.ops, exprOp{kind: exprApply, f: f})
+	return e
+}
+
+func (e *Expression) push(kind exprKind, val interface{}, fn string) *Expression {
+	switch w := val.(type) {
+	case float64:
+		e.ops = append(e.ops, exprOp{kind: kind, scalar: w})
+	case []float64:
+		if len(w) != len(e.base) {
+			panic(fmt.Sprintf(errStrings[5], fn, len(e.base), len(w)))
+		}
+		e.ops = append(e.ops, exprOp{kind: kind, v: w})
+	default:
+		panic(fmt.Sprintf(errStrings[6], fn, w))
+	}
+	return e
+}
+
+/*
+Eval computes the Expression, storing the result in dst and returning it. If
+dst is nil, a new []float64 is allocated for the result, from the Pool set
+with SetPool, if any. Since each element of the result only depends on the
+elements at the same index, dst may be one of the slices used in the
+Expression, which then receives the result.
+
+dst must either be nil, or have the length of the Expression, otherwise this
+function will panic.
+*/
+func (e *Expression) Eval(dst []float64) []float64 {
+	if dst == nil {
+		dst = alloc(len(e.base))
+	}
+	if len(dst) != len(e.base) {
+		panic(fmt.Sprintf(errStrings[5], "Eval()", len(e.base), len(dst)))
+	}
+	var buf [exprBlock]float64
+	for lo := 0; lo < len(e.base); lo += exprBlock {
+		hi := lo + exprBlock
+		if hi > len(e.base) {
+			hi = len(e.base)
+		}
+		b := buf[:hi-lo]
+		copy(b, e.base[lo:hi])
+		for _, op := range e.ops {
+			if op.v != nil {
+				w := op.v[lo:hi]
+				switch op.kind {
+				case exprAdd:
+					for i := range b {
+						b[i] += w[i]
+					}
+				case exprSub:
+					for i := range b {
+						b[i] -= w[i]
+					}
+				case exprMul:
+					for i := range b {
+						b[i] *= w[i]
+					}
+				case exprDiv:
+					for i := range b {
+						b[i] /= w[i]
+					}
+				}
+				continue
+			}
+			switch op.kind {
+			case exprAdd:
+				for i := range b {
+					b[i] += op.scalar
+				}
+			case exprSub:
+				for i := range b {
+					b[i] -= op.scalar
+				}
+			case exprMul:
+				for i := range b {
+					b[i] *= op.scalar
+				}
+			case exprDiv:
+				for i := range b {
+					b[i] /= op.scalar
+				}
+			case exprApply:
+				for i := range b {
+					b[i] = op.f(b[i])
+				}
+			}
+		}
+		copy(dst[lo:hi], b)
+	}
+	return dst
+}
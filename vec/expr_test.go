@@ -0,0 +1,80 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestExpr(t *testing.T) {
+	n := 1000
+	a, b, c := make([]float64, n), make([]float64, n), make([]float64, n)
+	for i := range a {
+		a[i] = float64(i)
+		b[i] = float64(n - i)
+		c[i] = 0.5 + float64(i%7)
+	}
+	expected := Foreach(Div(Sub(Mul(Add(a, b), c), 1.0), c), math.Sqrt)
+	got := Expr(a).Add(b).Mul(c).Sub(1.0).Div(c).Apply(math.Sqrt).Eval(nil)
+	if !Equal(got, expected) {
+		t.Errorf("the fused result differs from the unfused one")
+	}
+	// The result may be written over one of the operands.
+	dst := Clone(a)
+	Expr(dst).Mul(2.0).Add(dst).Eval(dst)
+	if !Equal(dst, Mul(a, 3.0)) {
+		t.Errorf("expected 3 times a, got a different result")
+	}
+	if e := Expr([]float64{}).Add(1.0).Eval(nil); len(e) != 0 {
+		t.Errorf("expected an empty result, got %v", e)
+	}
+	if v := Expr([]float64{1.0, 2.0}).Eval(nil); !Equal(v, []float64{1.0, 2.0}) {
+		t.Errorf("expected a copy, got %v", v)
+	}
+}
+
+func TestExprErrors(t *testing.T) {
+	cases := []struct {
+		expectedErr string
+		f           func()
+	}{
+		{fmt.Sprintf(errStrings[5], "Add()", 2, 3), func() { Expr([]float64{1, 2}).Add([]float64{1, 2, 3}) }},
+		{fmt.Sprintf(errStrings[7], "Div()"), func() { Expr([]float64{1, 2}).Div(0.0) }},
+		{fmt.Sprintf(errStrings[8], "Div()", 1), func() { Expr([]float64{1, 2}).Div([]float64{1, 0}) }},
+		{fmt.Sprintf(errStrings[6], "Mul()", "x"), func() { Expr([]float64{1, 2}).Mul("x") }},
+		{fmt.Sprintf(errStrings[5], "Eval()", 2, 1), func() { Expr([]float64{1, 2}).Eval([]float64{0}) }},
+	}
+	var wg sync.WaitGroup
+	for _, c := range cases {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != c.expectedErr {
+					t.Errorf("Expected %s, got %v", c.expectedErr, r)
+				}
+				wg.Done()
+			}()
+			c.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func BenchmarkExprFused(b *testing.B) {
+	x, y, z := Rand(100000), Rand(100000), Rand(100000)
+	dst := make([]float64, len(x))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Expr(x).Add(y).Mul(z).Sub(1.0).Mul(2.0).Eval(dst)
+	}
+}
+
+func BenchmarkExprUnfused(b *testing.B) {
+	x, y, z := Rand(100000), Rand(100000), Rand(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Mul(Sub(Mul(Add(x, y), z), 1.0), 2.0)
+	}
+}
@@ -0,0 +1,38 @@
+package vec
+
+import "math"
+
+/*
+MulAdd returns a new []float64 whose element i is a[i]*b + c, where b and c
+can each be a float64 or a []float64, in which case their element i is
+used. Each element is computed with math.FMA(), which rounds only once,
+rather than once after the product and again after the sum, such as in the
+steps of the evaluation of a polynomial by Horner's rule:
+
+	// p(x) = 2x^2 - 3x + 1, at each element of x
+	p := vec.MulAdd(vec.MulAdd(vec.Set(x, 2.0), x, -3.0), x, 1.0)
+
+A []float64 b or c must have the length of a, otherwise this function will
+panic. The passed arguments are not modified in this function.
+*/
+func MulAdd(a []float64, b, c interface{}) []float64 {
+	return mulAdd("MulAdd()", alloc(len(a)), a, b, c)
+}
+
+/*
+MulAddTo stores the result of vec.MulAdd(a, b, c) in dst, and returns dst,
+without allocating. dst must have the length of a, and may be a itself, or b
+or c.
+*/
+func MulAddTo(dst, a []float64, b, c interface{}) []float64 {
+	return mulAdd("MulAddTo()", dst, a, b, c)
+}
+
+func mulAdd(fn string, dst, a []float64, b, c interface{}) []float64 {
+	fb, fc := operand(fn, b, 2, len(a)), operand(fn, c, 3, len(a))
+	checkDst(fn, dst, a)
+	for i := range a {
+		dst[i] = math.FMA(a[i], fb(i), fc(i))
+	}
+	return dst
+}
@@ -0,0 +1,53 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestMulAdd(t *testing.T) {
+	a := []float64{1.0, 2.0, 3.0}
+	if r := MulAdd(a, 2.0, 1.0); !Equal(r, []float64{3.0, 5.0, 7.0}) {
+		t.Errorf("expected {3.0, 5.0, 7.0}, got %v", r)
+	}
+	if r := MulAdd(a, []float64{1.0, 0.5, -1.0}, []float64{0.0, 1.0, 3.0}); !Equal(r, []float64{1.0, 2.0, 0.0}) {
+		t.Errorf("expected {1.0, 2.0, 0.0}, got %v", r)
+	}
+	// x*x - 1 with x = 1 + 2^-30 loses its last bits when rounded twice.
+	x := 1.0 + math.Ldexp(1.0, -30)
+	if r := MulAdd([]float64{x}, x, -1.0); r[0] != math.Ldexp(1.0, -29)+math.Ldexp(1.0, -60) {
+		t.Errorf("expected the exact result %v, got %v", math.Ldexp(1.0, -29)+math.Ldexp(1.0, -60), r[0])
+	}
+	// Horner's rule for 2x^2 - 3x + 1.
+	xs := []float64{0.0, 1.0, 2.0}
+	if p := MulAdd(MulAdd(Set(xs, 2.0), xs, -3.0), xs, 1.0); !Equal(p, []float64{1.0, 0.0, 3.0}) {
+		t.Errorf("expected {1.0, 0.0, 3.0}, got %v", p)
+	}
+	if a[0] != 1.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { MulAdd(a, []float64{1.0}, 0.0) }, fmt.Sprintf(errStrings[5], "MulAdd()", 3, 1)},
+		{func() { MulAdd(a, 1.0, 2) }, fmt.Sprintf(errStrings[12], "MulAdd()", 3, 2)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
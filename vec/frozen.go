@@ -0,0 +1,96 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrFrozen is returned by the mutating methods of a Frozen, which cannot
+// modify its elements.
+var ErrFrozen = errors.New("vec: the vector is frozen")
+
+/*
+Frozen is a read-only view of a []float64, which lets a library hand out its
+internal buffers without copying them, and without its callers being able
+to modify them:
+
+	func (m *Model) Weights() vec.Frozen {
+		return vec.Freeze(m.weights)
+	}
+
+	w := model.Weights()
+	x := w.At(0)           // reads the buffer of the model
+	err := w.Set(0, 1.0)   // err wraps vec.ErrFrozen
+	w2 := w.With(0, 1.0)   // a copy of the buffer, with a new element 0
+	buf := w.Thaw()        // a copy of the buffer, which the caller owns
+
+The elements are only copied by the methods which change them, With() and
+Thaw(), so handing out a Frozen is free. The zero Frozen has no elements.
+*/
+type Frozen struct {
+	data []float64
+}
+
+/*
+Freeze returns a Frozen view of v, without copying it. The Frozen cannot
+modify v, but it sees the changes made to v through other references, such
+as those of the owner of v.
+*/
+func Freeze(v []float64) Frozen {
+	return Frozen{data: v}
+}
+
+// Len returns the number of elements of f.
+func (f Frozen) Len() int {
+	return len(f.data)
+}
+
+/*
+At returns the element i of f. The index must be in [0, f.Len()), otherwise
+this function will panic.
+*/
+func (f Frozen) At(i int) float64 {
+	if i < 0 || i >= len(f.data) {
+		panic(fmt.Sprintf(errStrings[1], "Frozen.At()", i, len(f.data)))
+	}
+	return f.data[i]
+}
+
+// Set does not modify f, which is read-only, and returns an error wrapping
+// ErrFrozen. Use With() to get a modified copy instead.
+func (f Frozen) Set(i int, x float64) error {
+	return fmt.Errorf("%w: cannot set the element %d to %v", ErrFrozen, i, x)
+}
+
+/*
+With returns a new Frozen holding a copy of the elements of f, with the
+element i set to x, leaving f and the []float64 it views intact. The index
+must be in [0, f.Len()), otherwise this function will panic.
+*/
+func (f Frozen) With(i int, x float64) Frozen {
+	if i < 0 || i >= len(f.data) {
+		panic(fmt.Sprintf(errStrings[1], "Frozen.With()", i, len(f.data)))
+	}
+	c := Clone(f.data)
+	c[i] = x
+	return Frozen{data: c}
+}
+
+// Thaw returns a copy of the elements of f, in a new []float64 which the
+// caller can modify.
+func (f Frozen) Thaw() []float64 {
+	return Clone(f.data)
+}
+
+// Values returns an iterator over the elements of f, as vec.Values() does
+// for a []float64.
+func (f Frozen) Values() iter.Seq[float64] {
+	return Values(f.data)
+}
+
+// Vector returns a Vector holding the elements of f, without copying them,
+// since the methods of a Vector never modify it.
+func (f Frozen) Vector() Vector {
+	return NewVector(f.data)
+}
@@ -0,0 +1,68 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestFrozen(t *testing.T) {
+	buf := []float64{1.0, 2.0, 3.0}
+	f := Freeze(buf)
+	if f.Len() != 3 || f.At(2) != 3.0 {
+		t.Errorf("expected a view of {1.0, 2.0, 3.0}, got %v", f.Thaw())
+	}
+	if err := f.Set(0, 5.0); !errors.Is(err, ErrFrozen) || buf[0] != 1.0 {
+		t.Errorf("expected ErrFrozen and an intact buffer, got %v and %v", err, buf)
+	}
+	g := f.With(0, 5.0)
+	if g.At(0) != 5.0 || f.At(0) != 1.0 || buf[0] != 1.0 {
+		t.Errorf("expected With() to copy the elements, got %v, %v and %v", g.Thaw(), f.Thaw(), buf)
+	}
+	c := f.Thaw()
+	c[1] = 20.0
+	if buf[1] != 2.0 {
+		t.Errorf("expected Thaw() to copy the elements")
+	}
+	buf[2] = 30.0
+	if f.At(2) != 30.0 {
+		t.Errorf("expected the Frozen to see the changes of its owner")
+	}
+	if v := slices.Collect(f.Values()); !Equal(v, buf) {
+		t.Errorf("expected %v, got %v", buf, v)
+	}
+	if sum, err := f.Vector().Sum(); err != nil || sum != 33.0 {
+		t.Errorf("expected a sum of 33.0, got %v and %v", sum, err)
+	}
+	if buf[0] != 1.0 {
+		t.Errorf("the buffer was modified")
+	}
+	var zero Frozen
+	if zero.Len() != 0 || len(zero.Thaw()) != 0 {
+		t.Errorf("expected the zero Frozen to be empty")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { f.At(3) }, fmt.Sprintf(errStrings[1], "Frozen.At()", 3, 3)},
+		{func() { f.With(-1, 0.0) }, fmt.Sprintf(errStrings[1], "Frozen.With()", -1, 3)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
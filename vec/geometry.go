@@ -0,0 +1,243 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+var (
+	// ErrLength is returned by the geometry functions of this package when
+	// their arguments do not have the lengths they need. It is wrapped by an
+	// *errs.ShapeError, or an *errs.EmptyError, holding the lengths.
+	ErrLength = errors.New("vec: the vectors have the wrong length")
+	// ErrZeroVector is returned by the geometry functions of this package
+	// when a vector has a norm of zero, so that it has no direction.
+	ErrZeroVector = errors.New("vec: the vector has a norm of zero")
+)
+
+// UnitPolicy determines what vec.Unit() and vec.UnitInPlace() do with a
+// vector whose norm is zero, which has no direction.
+type UnitPolicy int
+
+const (
+	// UnitError returns an error wrapping ErrZeroVector.
+	UnitError UnitPolicy = iota
+	// UnitZero returns the zero vector.
+	UnitZero
+	// UnitNaN returns a vector of NaN, which propagates through the
+	// computations that use it.
+	UnitNaN
+)
+
+/*
+Cross returns the cross product of two []float64 of length 3, which is
+perpendicular to both, and follows the right hand rule. For example:
+
+	x := []float64{1.0, 0.0, 0.0}
+	y := []float64{0.0, 1.0, 0.0}
+	z, err := vec.Cross(x, y) // z is {0.0, 0.0, 1.0}
+
+Unlike most functions of this package, Cross returns an error wrapping
+ErrLength, rather than panicking, if a or b does not have a length of 3. The
+passed []float64s are not modified in this function.
+*/
+func Cross(a, b []float64) ([]float64, error) {
+	if len(a) != 3 || len(b) != 3 {
+		got := len(a)
+		if got == 3 {
+			got = len(b)
+		}
+		return nil, &errs.ShapeError{
+			Got:  []int{got},
+			Want: []int{3},
+			Err:  fmt.Errorf("%w: Cross needs two vectors of length 3, received lengths %d and %d", ErrLength, len(a), len(b)),
+		}
+	}
+	return []float64{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}, nil
+}
+
+/*
+Angle returns the angle between two []float64 of the same length, in radians,
+between 0.0 and math.Pi. For example:
+
+	a, err := vec.Angle([]float64{1.0, 0.0}, []float64{1.0, 1.0}) // a is math.Pi / 4.0
+
+The angle is computed from the distance between the unit vectors along a and
+b, rather than from the arc cosine of their normalized dot product, so it is
+accurate for nearly parallel vectors as well.
+
+Angle returns an error wrapping ErrLength if a and b are empty or have
+different lengths, and wrapping ErrZeroVector if either has a norm of zero.
+The passed []float64s are not modified in this function.
+*/
+func Angle(a, b []float64) (float64, error) {
+	if len(a) == 0 || len(a) != len(b) {
+		err := fmt.Errorf("%w: Angle needs two non-empty vectors of the same length, received lengths %d and %d", ErrLength, len(a), len(b))
+		return 0.0, lengthError(err, len(a), len(b))
+	}
+	na, nb := norm2(a), norm2(b)
+	if na == 0.0 || nb == 0.0 {
+		return 0.0, fmt.Errorf("%w: Angle is undefined when a vector is zero", ErrZeroVector)
+	}
+	// Kahan's formula: with u and w the unit vectors, the angle is
+	// 2*atan(|u - w| / |u + w|).
+	var diff, sum float64
+	for i := range a {
+		u, w := a[i]/na, b[i]/nb
+		diff += (u - w) * (u - w)
+		sum += (u + w) * (u + w)
+	}
+	return 2.0 * math.Atan2(math.Sqrt(diff), math.Sqrt(sum)), nil
+}
+
+/*
+Project returns the projection of a onto the direction of onto, the part of
+a which is parallel to onto. For example:
+
+	p, err := vec.Project([]float64{2.0, 3.0}, []float64{4.0, 0.0}) // p is {2.0, 0.0}
+
+Project returns an error wrapping ErrLength if a and onto are empty or have
+different lengths, and wrapping ErrZeroVector if onto has a norm of zero,
+since it then has no direction. The passed []float64s are not modified in
+this function.
+*/
+func Project(a, onto []float64) ([]float64, error) {
+	return project("Project", a, onto)
+}
+
+/*
+Reject returns the rejection of a from the direction of onto, the part of a
+which is perpendicular to onto, such that a is the sum of its projection and
+its rejection. For example:
+
+	r, err := vec.Reject([]float64{2.0, 3.0}, []float64{4.0, 0.0}) // r is {0.0, 3.0}
+
+This is the step of the Gram-Schmidt process which removes one direction
+from a vector. The errors returned are the same as those of vec.Project().
+The passed []float64s are not modified in this function.
+*/
+func Reject(a, onto []float64) ([]float64, error) {
+	p, err := project("Reject", a, onto)
+	if err != nil {
+		return nil, err
+	}
+	for i := range p {
+		p[i] = a[i] - p[i]
+	}
+	return p, nil
+}
+
+/*
+Unit returns the unit vector in the direction of v, that is v divided by its
+euclidean norm. For example:
+
+	u, err := vec.Unit([]float64{3.0, 4.0}, vec.UnitError) // u is {0.6, 0.8}
+
+The norm is computed with the elements scaled by the largest one, so that
+vectors with very large or very small elements are normalized without
+overflow or underflow. If the norm of v is zero, the result depends on the
+passed UnitPolicy.
+
+Unit returns an error wrapping ErrLength if v is empty, and, with UnitError,
+an error wrapping ErrZeroVector if v is zero. The policy must be one of the
+UnitPolicy constants, otherwise this function will panic. The passed
+[]float64 is not modified in this function.
+*/
+func Unit(v []float64, policy UnitPolicy) ([]float64, error) {
+	c := Clone(v)
+	if err := unit("Unit", c, policy); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+/*
+UnitInPlace divides v by its euclidean norm, as with vec.Unit(), overwriting
+its elements. If an error is returned, v is not modified.
+*/
+func UnitInPlace(v []float64, policy UnitPolicy) error {
+	return unit("UnitInPlace", v, policy)
+}
+
+func unit(fn string, v []float64, policy UnitPolicy) error {
+	if policy < UnitError || policy > UnitNaN {
+		panic(fmt.Sprintf(errStrings[14], fn+"()", policy))
+	}
+	if len(v) == 0 {
+		return &errs.EmptyError{Got: []int{0}, Err: fmt.Errorf("%w: %s needs a non-empty vector", ErrLength, fn)}
+	}
+	n := norm2(v)
+	if n == 0.0 {
+		switch policy {
+		case UnitError:
+			return fmt.Errorf("%w: %s found no direction", ErrZeroVector, fn)
+		case UnitNaN:
+			n = math.NaN()
+		}
+	}
+	for i := range v {
+		if n == 0.0 {
+			v[i] = 0.0
+		} else {
+			v[i] /= n
+		}
+	}
+	return nil
+}
+
+// project returns the projection of a onto onto, checking the arguments of
+// the function fn.
+func project(fn string, a, onto []float64) ([]float64, error) {
+	if len(a) == 0 || len(a) != len(onto) {
+		err := fmt.Errorf("%w: %s needs two non-empty vectors of the same length, received lengths %d and %d", ErrLength, fn, len(a), len(onto))
+		return nil, lengthError(err, len(a), len(onto))
+	}
+	n := norm2(onto)
+	if n == 0.0 {
+		return nil, fmt.Errorf("%w: %s cannot project onto a zero vector", ErrZeroVector, fn)
+	}
+	u := alloc(len(onto))
+	d := 0.0
+	for i := range onto {
+		u[i] = onto[i] / n
+		d += a[i] * u[i]
+	}
+	for i := range u {
+		u[i] *= d
+	}
+	return u, nil
+}
+
+// norm2 returns the euclidean norm of v, scaling the elements by the
+// largest one to avoid overflow and underflow.
+func norm2(v []float64) float64 {
+	scale := 0.0
+	for _, x := range v {
+		scale = math.Max(scale, math.Abs(x))
+	}
+	if scale == 0.0 || math.IsInf(scale, 1) {
+		return scale
+	}
+	sum := 0.0
+	for _, x := range v {
+		sum += (x / scale) * (x / scale)
+	}
+	return scale * math.Sqrt(sum)
+}
+
+// lengthError wraps err, which reports that two vectors of lengths la and lb
+// do not have the same, non-zero, length, in an *errs.EmptyError if la is 0,
+// and in an *errs.ShapeError otherwise.
+func lengthError(err error, la, lb int) error {
+	if la == 0 {
+		return &errs.EmptyError{Got: []int{0}, Err: err}
+	}
+	return &errs.ShapeError{Got: []int{lb}, Want: []int{la}, Err: err}
+}
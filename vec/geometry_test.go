@@ -0,0 +1,159 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+func TestCross(t *testing.T) {
+	tests := []struct {
+		a, b, expected []float64
+	}{
+		{[]float64{1.0, 0.0, 0.0}, []float64{0.0, 1.0, 0.0}, []float64{0.0, 0.0, 1.0}},
+		{[]float64{0.0, 1.0, 0.0}, []float64{1.0, 0.0, 0.0}, []float64{0.0, 0.0, -1.0}},
+		{[]float64{1.0, 2.0, 3.0}, []float64{4.0, 5.0, 6.0}, []float64{-3.0, 6.0, -3.0}},
+		{[]float64{2.0, 2.0, 2.0}, []float64{1.0, 1.0, 1.0}, []float64{0.0, 0.0, 0.0}},
+	}
+	for _, test := range tests {
+		c, err := Cross(test.a, test.b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i := range c {
+			if c[i] != test.expected[i] {
+				t.Errorf("Cross(%v, %v): expected %v, got %v", test.a, test.b, test.expected, c)
+				break
+			}
+		}
+	}
+	if _, err := Cross([]float64{1.0, 2.0}, []float64{1.0, 2.0, 3.0}); !errors.Is(err, ErrLength) {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+}
+
+func TestAngle(t *testing.T) {
+	tests := []struct {
+		a, b     []float64
+		expected float64
+	}{
+		{[]float64{1.0, 0.0}, []float64{1.0, 1.0}, math.Pi / 4.0},
+		{[]float64{1.0, 0.0, 0.0}, []float64{0.0, 3.0, 0.0}, math.Pi / 2.0},
+		{[]float64{1.0, 2.0}, []float64{-2.0, -4.0}, math.Pi},
+		{[]float64{5.0}, []float64{2.0}, 0.0},
+		// Nearly parallel vectors, where the arc cosine loses accuracy.
+		{[]float64{1.0, 0.0}, []float64{1.0, 1e-10}, 1e-10},
+		// Large elements, whose squares overflow.
+		{[]float64{1e200, 0.0}, []float64{0.0, 1e200}, math.Pi / 2.0},
+	}
+	for _, test := range tests {
+		a, err := Angle(test.a, test.b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(a-test.expected) > 1e-15*math.Max(1.0, test.expected) {
+			t.Errorf("Angle(%v, %v): expected %v, got %v", test.a, test.b, test.expected, a)
+		}
+	}
+	if _, err := Angle([]float64{1.0}, []float64{1.0, 2.0}); !errors.Is(err, ErrLength) {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	if _, err := Angle(nil, nil); !errors.Is(err, ErrLength) || !errors.Is(err, errs.ErrEmpty) {
+		t.Errorf("expected ErrLength and errs.ErrEmpty for empty vectors, got %v", err)
+	}
+	var se *errs.ShapeError
+	if _, err := Angle([]float64{1.0}, []float64{1.0, 2.0}); !errors.As(err, &se) || se.Got[0] != 2 || se.Want[0] != 1 {
+		t.Errorf("expected an *errs.ShapeError of lengths 2 and 1, got %v", err)
+	}
+	if _, err := Angle([]float64{0.0, 0.0}, []float64{1.0, 2.0}); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+}
+
+func TestProjectReject(t *testing.T) {
+	tests := []struct {
+		a, onto, project []float64
+	}{
+		{[]float64{2.0, 3.0}, []float64{4.0, 0.0}, []float64{2.0, 0.0}},
+		{[]float64{1.0, 1.0, 0.0}, []float64{0.0, 0.0, 2.0}, []float64{0.0, 0.0, 0.0}},
+		{[]float64{3.0, 1.0}, []float64{1.0, 1.0}, []float64{2.0, 2.0}},
+		{[]float64{-1.0}, []float64{1e-300}, []float64{-1.0}},
+	}
+	for _, test := range tests {
+		p, err := Project(test.a, test.onto)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		r, err := Reject(test.a, test.onto)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		perp := 0.0
+		for i := range p {
+			if math.Abs(p[i]-test.project[i]) > 1e-12 {
+				t.Errorf("Project(%v, %v): expected %v, got %v", test.a, test.onto, test.project, p)
+			}
+			if math.Abs(p[i]+r[i]-test.a[i]) > 1e-12 {
+				t.Errorf("the projection %v and rejection %v do not sum to %v", p, r, test.a)
+			}
+			perp += r[i] * test.onto[i]
+		}
+		if math.Abs(perp) > 1e-12 {
+			t.Errorf("the rejection %v is not perpendicular to %v", r, test.onto)
+		}
+	}
+	for _, f := range []func(a, b []float64) ([]float64, error){Project, Reject} {
+		if _, err := f([]float64{1.0, 2.0}, []float64{0.0, 0.0}); !errors.Is(err, ErrZeroVector) {
+			t.Errorf("expected ErrZeroVector, got %v", err)
+		}
+		if _, err := f([]float64{1.0, 2.0}, []float64{1.0}); !errors.Is(err, ErrLength) {
+			t.Errorf("expected ErrLength, got %v", err)
+		}
+	}
+}
+
+func TestUnit(t *testing.T) {
+	v := []float64{3.0, 4.0}
+	u, err := Unit(v, UnitError)
+	if err != nil || u[0] != 0.6 || u[1] != 0.8 {
+		t.Errorf("expected {0.6, 0.8}, got %v and %v", u, err)
+	}
+	if v[0] != 3.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	big := []float64{3e300, -4e300}
+	if err := UnitInPlace(big, UnitError); err != nil || math.Abs(big[0]-0.6) > 1e-15 || math.Abs(big[1]+0.8) > 1e-15 {
+		t.Errorf("expected {0.6, -0.8}, got %v and %v", big, err)
+	}
+	zero := []float64{0.0, -0.0, 0.0}
+	if _, err := Unit(zero, UnitError); !errors.Is(err, ErrZeroVector) {
+		t.Errorf("expected ErrZeroVector, got %v", err)
+	}
+	if u, err := Unit(zero, UnitZero); err != nil || u[0] != 0.0 || u[1] != 0.0 || math.Signbit(u[1]) {
+		t.Errorf("expected the zero vector, got %v and %v", u, err)
+	}
+	if err := UnitInPlace(zero, UnitNaN); err != nil || !math.IsNaN(zero[0]) || !math.IsNaN(zero[2]) {
+		t.Errorf("expected a vector of NaN, got %v and %v", zero, err)
+	}
+	if _, err := Unit(nil, UnitZero); !errors.Is(err, ErrLength) {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[14], "UnitInPlace()", 7)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		UnitInPlace(v, UnitPolicy(7))
+	}()
+	wg.Wait()
+}
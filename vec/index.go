@@ -0,0 +1,84 @@
+package vec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+// ErrIndex is returned by the indexing functions of this package when an
+// index is outside of the []float64 it picks elements from.
+var ErrIndex = errors.New("vec: index out of range")
+
+/*
+Take returns a new []float64 holding the elements of v at the passed
+indices, in the order of the indices. The indices may repeat, and may be
+negative, counting from the end of v, so that -1 picks the last element. For
+example:
+
+	v := []float64{10.0, 20.0, 30.0, 40.0}
+	w, err := vec.Take(v, []int{3, 0, -1, 1}) // w is {40.0, 10.0, 40.0, 20.0}
+
+Unlike most functions of this package, Take returns an error wrapping
+ErrIndex, rather than panicking, if an index is outside of [-len(v),
+len(v)), since the indices usually come from data rather than from the
+code. v is not mutated in this function.
+*/
+func Take(v []float64, idx []int) ([]float64, error) {
+	w := alloc(len(idx))
+	for i, k := range idx {
+		j, err := index(k, len(v))
+		if err != nil {
+			return nil, fmt.Errorf("%w: index %d is %v", ErrIndex, i, err)
+		}
+		w[i] = v[j]
+	}
+	return w, nil
+}
+
+/*
+Put sets the elements of v at the passed indices to the passed values, in
+order, so that the last value is kept for an index that repeats. The indices
+may be negative, counting from the end of v, as with vec.Take(). For
+example:
+
+	v := []float64{0.0, 0.0, 0.0, 0.0}
+	err := vec.Put(v, []int{1, -1}, []float64{5.0, 6.0}) // v is {0.0, 5.0, 0.0, 6.0}
+
+Put returns an error wrapping ErrLength if the indices and values have
+different lengths, and one wrapping ErrIndex if an index is outside of
+[-len(v), len(v)). All the indices are checked before any element is set, so
+v is left intact when an error is returned.
+*/
+func Put(v []float64, idx []int, vals []float64) error {
+	if len(idx) != len(vals) {
+		return &errs.ShapeError{
+			Got:  []int{len(vals)},
+			Want: []int{len(idx)},
+			Err:  fmt.Errorf("%w: %d indices and %d values", ErrLength, len(idx), len(vals)),
+		}
+	}
+	for i, k := range idx {
+		if _, err := index(k, len(v)); err != nil {
+			return fmt.Errorf("%w: index %d is %v", ErrIndex, i, err)
+		}
+	}
+	for i, k := range idx {
+		j, _ := index(k, len(v))
+		v[j] = vals[i]
+	}
+	return nil
+}
+
+// index returns the element of a []float64 of length n picked by k, which
+// may be negative.
+func index(k, n int) (int, error) {
+	if k >= n || k < -n {
+		return 0, fmt.Errorf("%d, outside of [-%d, %d)", k, n, n)
+	}
+	if k < 0 {
+		k += n
+	}
+	return k, nil
+}
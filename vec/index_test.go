@@ -0,0 +1,48 @@
+package vec
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTake(t *testing.T) {
+	v := []float64{10.0, 20.0, 30.0, 40.0}
+	w, err := Take(v, []int{3, 0, -1, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(w, []float64{40.0, 10.0, 40.0, 20.0}) {
+		t.Errorf("expected {40.0, 10.0, 40.0, 20.0}, got %v", w)
+	}
+	w[0] = 0.0
+	if v[3] != 40.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	if w, err = Take(v, nil); err != nil || len(w) != 0 {
+		t.Errorf("expected an empty []float64, got %v and %v", w, err)
+	}
+	for _, idx := range [][]int{{0, 4}, {-5}} {
+		if w, err = Take(v, idx); !errors.Is(err, ErrIndex) || w != nil {
+			t.Errorf("Take(%v): expected ErrIndex, got %v and %v", idx, w, err)
+		}
+	}
+}
+
+func TestPut(t *testing.T) {
+	v := []float64{0.0, 0.0, 0.0, 0.0}
+	if err := Put(v, []int{1, -1, 1}, []float64{5.0, 6.0, 7.0}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Equal(v, []float64{0.0, 7.0, 0.0, 6.0}) {
+		t.Errorf("expected {0.0, 7.0, 0.0, 6.0}, got %v", v)
+	}
+	if err := Put(v, []int{0, 4}, []float64{1.0, 1.0}); !errors.Is(err, ErrIndex) {
+		t.Errorf("expected ErrIndex, got %v", err)
+	}
+	if v[0] != 0.0 {
+		t.Errorf("expected v to be left intact on error, got %v", v)
+	}
+	if err := Put(v, []int{0}, []float64{1.0, 2.0}); !errors.Is(err, ErrLength) {
+		t.Errorf("expected ErrLength, got %v", err)
+	}
+}
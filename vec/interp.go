@@ -0,0 +1,94 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// InterpMode determines the value vec.Interp() returns for points outside of
+// the range of the sample points.
+type InterpMode int
+
+const (
+	// InterpClamp returns the value of the nearest sample point.
+	InterpClamp InterpMode = iota
+	// InterpExtrapolate extends the line through the two nearest sample
+	// points.
+	InterpExtrapolate
+	// InterpNaN returns NaN.
+	InterpNaN
+)
+
+/*
+Interp performs piecewise linear interpolation of the function sampled at
+the points x with values y, returning the interpolated values at the points
+in xNew. For example:
+
+	x := []float64{0.0, 1.0, 2.0}
+	y := []float64{0.0, 10.0, 0.0}
+	xNew := []float64{0.5, 1.5, 3.0}
+	vec.Interp(xNew, x, y, vec.InterpClamp)       // {5.0, 5.0, 0.0}
+	vec.Interp(xNew, x, y, vec.InterpExtrapolate) // {5.0, 5.0, -10.0}
+	vec.Interp(xNew, x, y, vec.InterpNaN)         // {5.0, 5.0, NaN}
+
+The passed mode determines how points of xNew outside of [x[0], x[len(x)-1]]
+are treated. x and y must have equal lengths of at least 2, and the elements
+of x must be strictly increasing, otherwise this function will panic. The
+elements of xNew can be in any order. The passed []float64s are not modified
+in this function.
+*/
+func Interp(xNew, x, y []float64, mode InterpMode) []float64 {
+	return interp("Interp()", alloc(len(xNew)), xNew, x, y, mode)
+}
+
+/*
+InterpTo stores the result of vec.Interp(xNew, x, y, mode) in dst, and
+returns dst, without allocating. dst must have the length of xNew, and may
+be xNew itself.
+*/
+func InterpTo(dst, xNew, x, y []float64, mode InterpMode) []float64 {
+	return interp("InterpTo()", dst, xNew, x, y, mode)
+}
+
+func interp(fn string, dst, xNew, x, y []float64, mode InterpMode) []float64 {
+	if len(x) != len(y) {
+		panic(fmt.Sprintf(errStrings[5], fn, len(x), len(y)))
+	}
+	if len(x) < 2 {
+		panic(fmt.Sprintf(errStrings[16], fn, 2, len(x)))
+	}
+	for i := 1; i < len(x); i++ {
+		if !(x[i] > x[i-1]) {
+			panic(fmt.Sprintf(errStrings[17], fn, i))
+		}
+	}
+	if mode < InterpClamp || mode > InterpNaN {
+		panic(fmt.Sprintf(errStrings[14], fn, mode))
+	}
+	checkDst(fn, dst, xNew)
+	n := len(x)
+	for i, p := range xNew {
+		switch {
+		case p < x[0] && mode == InterpClamp:
+			dst[i] = y[0]
+			continue
+		case p > x[n-1] && mode == InterpClamp:
+			dst[i] = y[n-1]
+			continue
+		case (p < x[0] || p > x[n-1]) && mode == InterpNaN, math.IsNaN(p):
+			dst[i] = math.NaN()
+			continue
+		}
+		// j is the index of the right end of the segment used for p.
+		j := sort.SearchFloat64s(x, p)
+		if j < 1 {
+			j = 1
+		} else if j > n-1 {
+			j = n - 1
+		}
+		t := (p - x[j-1]) / (x[j] - x[j-1])
+		dst[i] = y[j-1] + t*(y[j]-y[j-1])
+	}
+	return dst
+}
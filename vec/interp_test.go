@@ -0,0 +1,55 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestInterp(t *testing.T) {
+	x := []float64{0.0, 1.0, 2.0}
+	y := []float64{0.0, 10.0, 0.0}
+	xNew := []float64{-1.0, 0.0, 0.5, 1.0, 1.5, 2.0, 3.0}
+	c := Interp(xNew, x, y, InterpClamp)
+	expected := []float64{0.0, 0.0, 5.0, 10.0, 5.0, 0.0, 0.0}
+	if !Equal(c, expected) {
+		t.Errorf("clamp: expected %v, got %v", expected, c)
+	}
+	c = Interp(xNew, x, y, InterpExtrapolate)
+	expected = []float64{-10.0, 0.0, 5.0, 10.0, 5.0, 0.0, -10.0}
+	if !Equal(c, expected) {
+		t.Errorf("extrapolate: expected %v, got %v", expected, c)
+	}
+	c = Interp(xNew, x, y, InterpNaN)
+	if !math.IsNaN(c[0]) || !math.IsNaN(c[6]) || c[2] != 5.0 || c[5] != 0.0 {
+		t.Errorf("NaN: expected NaN at both ends, got %v", c)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[17], "Interp()", 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Interp(xNew, []float64{0.0, 1.0, 1.0}, y, InterpClamp)
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[5], "Interp()", 3, 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Interp(xNew, x, y[:2], InterpClamp)
+	}()
+	wg.Wait()
+}
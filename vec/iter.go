@@ -0,0 +1,79 @@
+package vec
+
+import (
+	"iter"
+)
+
+/*
+Values returns an iterator over the elements of a []float64, for use in
+range-over-func loops and with the iterator utilities of the standard
+library. For example:
+
+	for x := range vec.Values(v) {
+		fmt.Println(x)
+	}
+
+The iterator reads v as it goes, so changes to v during the iteration are
+seen by it.
+*/
+func Values(v []float64) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for i := range v {
+			if !yield(v[i]) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Enumerate returns an iterator over the indices and elements of a []float64.
+For example:
+
+	for i, x := range vec.Enumerate(v) {
+		fmt.Println(i, x)
+	}
+*/
+func Enumerate(v []float64) iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		for i := range v {
+			if !yield(i, v[i]) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Collect returns a []float64 holding the values produced by the passed
+iterator, in order. For example, to keep the positive elements of v:
+
+	pos := vec.Collect(func(yield func(float64) bool) {
+		for x := range vec.Values(v) {
+			if x > 0 && !yield(x) {
+				return
+			}
+		}
+	})
+
+The returned []float64 is empty, but not nil, for an iterator which produces
+no values.
+*/
+func Collect(seq iter.Seq[float64]) []float64 {
+	v := []float64{}
+	for x := range seq {
+		v = append(v, x)
+	}
+	return v
+}
+
+/*
+Values returns an iterator over the elements of the Vector. A Vector holding
+an error produces no values, so check Err() after the iteration.
+*/
+func (v Vector) Values() iter.Seq[float64] {
+	if v.err != nil {
+		return func(func(float64) bool) {}
+	}
+	return Values(v.data)
+}
@@ -0,0 +1,70 @@
+package vec
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValues(t *testing.T) {
+	v := []float64{1.0, -2.0, 3.0, -4.0}
+	got := []float64{}
+	for x := range Values(v) {
+		got = append(got, x)
+	}
+	if !Equal(got, v) {
+		t.Errorf("expected %v, got %v", v, got)
+	}
+	// Stopping early must stop the iterator.
+	count := 0
+	for range Values(v) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected to stop after 2 values, got %d", count)
+	}
+	if s := slices.Collect(Values(v)); !Equal(s, v) {
+		t.Errorf("expected %v, got %v", v, s)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	v := []float64{5.0, 6.0, 7.0}
+	for i, x := range Enumerate(v) {
+		if v[i] != x {
+			t.Errorf("at index %d, expected %v, got %v", i, v[i], x)
+		}
+		if i == 1 {
+			break
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	v := []float64{1.0, -2.0, 3.0, -4.0}
+	pos := Collect(func(yield func(float64) bool) {
+		for x := range Values(v) {
+			if x > 0 && !yield(x) {
+				return
+			}
+		}
+	})
+	if !Equal(pos, []float64{1.0, 3.0}) {
+		t.Errorf("expected {1.0, 3.0}, got %v", pos)
+	}
+	if e := Collect(Values(nil)); e == nil || len(e) != 0 {
+		t.Errorf("expected an empty, non nil []float64, got %#v", e)
+	}
+	if s := Collect(slices.Values([]float64{2.0, 4.0})); !Equal(s, []float64{2.0, 4.0}) {
+		t.Errorf("expected {2.0, 4.0}, got %v", s)
+	}
+	vv := NewVector(v)
+	if s := Collect(vv.Values()); !Equal(s, v) {
+		t.Errorf("expected %v, got %v", v, s)
+	}
+	if s := Collect(vv.Add([]float64{1.0}).Values()); len(s) != 0 {
+		t.Errorf("expected no values from a Vector with an error, got %v", s)
+	}
+}
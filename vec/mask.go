@@ -0,0 +1,77 @@
+package vec
+
+import "fmt"
+
+/*
+MaskedSelect returns a new []float64 containing the elements of the passed
+[]float64 for which the corresponding entry of mask is true, in their
+original order. For example:
+
+	v := []float64{1.0, 2.0, 3.0, 4.0}
+	mask := []bool{true, false, false, true}
+	w := vec.MaskedSelect(v, mask) // w is {1.0, 4.0}
+
+The length of mask must match the length of the []float64, otherwise this
+function will panic. The original []float64 is not modified in this function.
+*/
+func MaskedSelect(v []float64, mask []bool) []float64 {
+	if len(v) != len(mask) {
+		panic(fmt.Sprintf(errStrings[5], "MaskedSelect()", len(v), len(mask)))
+	}
+	n := 0
+	for _, m := range mask {
+		if m {
+			n++
+		}
+	}
+	c := alloc(n)[:0]
+	for i := range v {
+		if mask[i] {
+			c = append(c, v[i])
+		}
+	}
+	return c
+}
+
+/*
+Filter returns a new []float64 containing the elements of the passed
+[]float64 for which the passed function returns true, in their original
+order. Consider:
+
+	positive := func(i float64) bool {
+		return i > 0.0
+	}
+	v := []float64{-1.0, 2.0, -3.0, 4.0}
+	w := vec.Filter(v, positive) // w is {2.0, 4.0}
+
+The original []float64 is not modified in this function. To also get the
+indices of the matching elements, look at vec.FilterWithIndices().
+*/
+func Filter(v []float64, f func(float64) bool) []float64 {
+	c, _ := FilterWithIndices(v, f)
+	return c
+}
+
+/*
+FilterWithIndices is like vec.Filter(), but also returns the index in the
+passed []float64 of each of the matching elements. Consider:
+
+	positive := func(i float64) bool {
+		return i > 0.0
+	}
+	v := []float64{-1.0, 2.0, -3.0, 4.0}
+	w, idx := vec.FilterWithIndices(v, positive) // w is {2.0, 4.0}, idx is {1, 3}
+
+The original []float64 is not modified in this function.
+*/
+func FilterWithIndices(v []float64, f func(float64) bool) ([]float64, []int) {
+	c := []float64{}
+	idx := []int{}
+	for i := range v {
+		if f(v[i]) {
+			c = append(c, v[i])
+			idx = append(idx, i)
+		}
+	}
+	return c, idx
+}
@@ -0,0 +1,51 @@
+package vec
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestMaskedSelect(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0, 4.0}
+	w := MaskedSelect(v, []bool{true, false, false, true})
+	if !Equal(w, []float64{1.0, 4.0}) {
+		t.Errorf("expected {1.0, 4.0}, got %v", w)
+	}
+	w = MaskedSelect(v, make([]bool, 4))
+	if len(w) != 0 {
+		t.Errorf("expected empty []float64, got %v", w)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[5], "MaskedSelect()", 4, 1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		MaskedSelect(v, []bool{true})
+	}()
+	wg.Wait()
+}
+
+func TestFilter(t *testing.T) {
+	positive := func(i float64) bool {
+		return i > 0.0
+	}
+	v := []float64{-1.0, 2.0, -3.0, 4.0}
+	w := Filter(v, positive)
+	if !Equal(w, []float64{2.0, 4.0}) {
+		t.Errorf("expected {2.0, 4.0}, got %v", w)
+	}
+	w, idx := FilterWithIndices(v, positive)
+	if !Equal(w, []float64{2.0, 4.0}) {
+		t.Errorf("expected {2.0, 4.0}, got %v", w)
+	}
+	if len(idx) != 2 || idx[0] != 1 || idx[1] != 3 {
+		t.Errorf("expected indices {1, 3}, got %v", idx)
+	}
+}
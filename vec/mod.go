@@ -0,0 +1,88 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Mod takes a []float64, and a second argument, which can be a float64 or a
+[]float64, and returns the floating point remainder of the division of each
+element by the second argument, or by its element of the same index, as
+math.Mod() does. The result has the sign of the dividend, and is smaller
+than the divisor in magnitude. For example:
+
+	v := []float64{7.0, -7.0, 2.5}
+	m := vec.Mod(v, 3.0) // m is {1.0, -1.0, 2.5}
+
+The remainder of a division by 0.0 is NaN, as with math.Mod(). The length of
+a []float64 second argument must be the length of v, otherwise this
+function will panic. The passed arguments are not modified in this function.
+*/
+func Mod(v []float64, val interface{}) []float64 {
+	return elementwise("Mod()", v, val, math.Mod)
+}
+
+/*
+ModTo stores the result of vec.Mod(v, val) in dst, and returns dst, without
+allocating. dst must have the length of v, and may be v itself, or val.
+*/
+func ModTo(dst, v []float64, val interface{}) []float64 {
+	return elementwiseTo("ModTo()", dst, v, val, math.Mod)
+}
+
+/*
+Remainder is like vec.Mod(), but returns the IEEE 754 remainder of each
+division, as math.Remainder() does, which is the dividend minus the nearest
+multiple of the divisor, with ties going to the even multiple. The result is
+at most half the divisor in magnitude, whatever the sign of the dividend,
+which suits wrapping phases to [-Pi, Pi]:
+
+	phase := vec.Remainder([]float64{3.5 * math.Pi, -0.5}, 2.0*math.Pi)
+	// phase is {-0.5 * Pi, -0.5}
+
+The remainder of a division by 0.0 is NaN, as with math.Remainder(). The
+length of a []float64 second argument must be the length of v, otherwise
+this function will panic. The passed arguments are not modified in this
+function.
+*/
+func Remainder(v []float64, val interface{}) []float64 {
+	return elementwise("Remainder()", v, val, math.Remainder)
+}
+
+/*
+RemainderTo stores the result of vec.Remainder(v, val) in dst, and returns
+dst, without allocating. dst must have the length of v, and may be v itself,
+or val.
+*/
+func RemainderTo(dst, v []float64, val interface{}) []float64 {
+	return elementwiseTo("RemainderTo()", dst, v, val, math.Remainder)
+}
+
+// elementwise returns f applied to each element of v, and val, which can be
+// a float64 or a []float64, or the element of val at the same index,
+// panicking on behalf of the function fn.
+func elementwise(fn string, v []float64, val interface{}, f func(x, y float64) float64) []float64 {
+	return elementwiseTo(fn, alloc(len(v)), v, val, f)
+}
+
+// elementwiseTo is elementwise, storing the results in dst.
+func elementwiseTo(fn string, dst, v []float64, val interface{}, f func(x, y float64) float64) []float64 {
+	checkDst(fn, dst, v)
+	switch w := val.(type) {
+	case float64:
+		for i := range v {
+			dst[i] = f(v[i], w)
+		}
+	case []float64:
+		if len(v) != len(w) {
+			panic(fmt.Sprintf(errStrings[5], fn, len(v), len(w)))
+		}
+		for i := range v {
+			dst[i] = f(v[i], w[i])
+		}
+	default:
+		panic(fmt.Sprintf(errStrings[6], fn, w))
+	}
+	return dst
+}
@@ -0,0 +1,53 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestMod(t *testing.T) {
+	v := []float64{7.0, -7.0, 2.5}
+	if m := Mod(v, 3.0); !Equal(m, []float64{1.0, -1.0, 2.5}) {
+		t.Errorf("expected {1.0, -1.0, 2.5}, got %v", m)
+	}
+	if m := Mod(v, []float64{2.0, -4.0, 1.0}); !Equal(m, []float64{1.0, -3.0, 0.5}) {
+		t.Errorf("expected {1.0, -3.0, 0.5}, got %v", m)
+	}
+	if r := Remainder(v, 3.0); !Equal(r, []float64{1.0, -1.0, -0.5}) {
+		t.Errorf("expected {1.0, -1.0, -0.5}, got %v", r)
+	}
+	phase := Remainder([]float64{3.5 * math.Pi, -0.5}, 2.0*math.Pi)
+	if math.Abs(phase[0]+0.5*math.Pi) > 1e-12 || phase[1] != -0.5 {
+		t.Errorf("expected {-0.5 * Pi, -0.5}, got %v", phase)
+	}
+	if m := Mod(v, 0.0); !math.IsNaN(m[0]) || !math.IsNaN(Remainder(v, []float64{1.0, 0.0, 1.0})[1]) {
+		t.Errorf("expected NaN for a division by zero")
+	}
+	if v[0] != 7.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { Mod(v, []float64{1.0}) }, fmt.Sprintf(errStrings[5], "Mod()", 3, 1)},
+		{func() { Remainder(v, 2) }, fmt.Sprintf(errStrings[6], "Remainder()", 2)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,89 @@
+package vec
+
+import "fmt"
+
+/*
+MustCross is like vec.Cross(), but panics instead of returning an error,
+like most functions of this package. It suits scripts and tests, where
+vectors of the wrong length are a bug to crash on rather than a failure to
+handle:
+
+	z := vec.MustCross(x, y)
+
+Code which must survive bad input, such as a service, should call
+vec.Cross() instead.
+*/
+func MustCross(a, b []float64) []float64 {
+	c, err := Cross(a, b)
+	must("MustCross()", "Cross()", err)
+	return c
+}
+
+// MustAngle is like vec.Angle(), but panics instead of returning an error.
+func MustAngle(a, b []float64) float64 {
+	x, err := Angle(a, b)
+	must("MustAngle()", "Angle()", err)
+	return x
+}
+
+// MustProject is like vec.Project(), but panics instead of returning an
+// error.
+func MustProject(a, onto []float64) []float64 {
+	p, err := Project(a, onto)
+	must("MustProject()", "Project()", err)
+	return p
+}
+
+// MustReject is like vec.Reject(), but panics instead of returning an error.
+func MustReject(a, onto []float64) []float64 {
+	r, err := Reject(a, onto)
+	must("MustReject()", "Reject()", err)
+	return r
+}
+
+// MustUnit is like vec.Unit(), but panics instead of returning an error.
+func MustUnit(v []float64, policy UnitPolicy) []float64 {
+	u, err := Unit(v, policy)
+	must("MustUnit()", "Unit()", err)
+	return u
+}
+
+// MustTake is like vec.Take(), but panics instead of returning an error.
+func MustTake(v []float64, idx []int) []float64 {
+	t, err := Take(v, idx)
+	must("MustTake()", "Take()", err)
+	return t
+}
+
+// MustPut is like vec.Put(), but panics instead of returning an error.
+func MustPut(v []float64, idx []int, vals []float64) {
+	must("MustPut()", "Put()", Put(v, idx, vals))
+}
+
+// MustToInts is like vec.ToInts(), but panics instead of returning an error.
+func MustToInts(v []float64) []int {
+	n, err := ToInts(v)
+	must("MustToInts()", "ToInts()", err)
+	return n
+}
+
+/*
+Must returns the elements of the Vector, like Slice(), but panics with the
+first error encountered in the pipeline that produced it, rather than
+returning it, for pipelines in scripts:
+
+	w := v.Add(u).Scale(2.0).Must()
+*/
+func (v Vector) Must() []float64 {
+	data, err := v.Slice()
+	must("Vector.Must()", "Vector.Slice()", err)
+	return data
+}
+
+// must panics with a message naming the function fn, and the error err
+// returned by the function it wraps, if err is not nil.
+func must(fn, wrapped string, err error) {
+	if err != nil {
+		panic(fmt.Sprintf(errStrings[19], fn, wrapped, err))
+	}
+}
@@ -0,0 +1,59 @@
+package vec
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestMust(t *testing.T) {
+	x := []float64{1.0, 0.0, 0.0}
+	y := []float64{0.0, 1.0, 0.0}
+	if z := MustCross(x, y); !Equal(z, []float64{0.0, 0.0, 1.0}) {
+		t.Errorf("expected {0.0, 0.0, 1.0}, got %v", z)
+	}
+	if u := MustUnit([]float64{3.0, 4.0}, UnitError); !Equal(u, []float64{0.6, 0.8}) {
+		t.Errorf("expected {0.6, 0.8}, got %v", u)
+	}
+	if v := MustTake(x, []int{-3}); !Equal(v, []float64{1.0}) {
+		t.Errorf("expected {1.0}, got %v", v)
+	}
+	w := Clone(x)
+	MustPut(w, []int{1}, []float64{5.0})
+	if !Equal(w, []float64{1.0, 5.0, 0.0}) {
+		t.Errorf("expected {1.0, 5.0, 0.0}, got %v", w)
+	}
+	if v := NewVector(x).Add(y).Scale(2.0).Must(); !Equal(v, []float64{2.0, 2.0, 0.0}) {
+		t.Errorf("expected {2.0, 2.0, 0.0}, got %v", v)
+	}
+	_, errCross := Cross(x, x[:2])
+	_, errUnit := Unit([]float64{0.0}, UnitError)
+	_, errTake := Take(x, []int{3})
+	errPut := Put(w, []int{1}, nil)
+	errVector := NewVector(x).Add(y[:1]).Err()
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { MustCross(x, x[:2]) }, fmt.Sprintf(errStrings[19], "MustCross()", "Cross()", errCross)},
+		{func() { MustUnit([]float64{0.0}, UnitError) }, fmt.Sprintf(errStrings[19], "MustUnit()", "Unit()", errUnit)},
+		{func() { MustTake(x, []int{3}) }, fmt.Sprintf(errStrings[19], "MustTake()", "Take()", errTake)},
+		{func() { MustPut(w, []int{1}, nil) }, fmt.Sprintf(errStrings[19], "MustPut()", "Put()", errPut)},
+		{func() { NewVector(x).Add(y[:1]).Must() }, fmt.Sprintf(errStrings[19], "Vector.Must()", "Vector.Slice()", errVector)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,227 @@
+package vec
+
+import "math"
+
+/*
+NanSum adds all elements in a []float64, treating NaN entries as if they were
+not present. Consider:
+
+	v := []float64{1.0, math.NaN(), 3.0}
+	s := vec.NanSum(v) // 4.0
+
+If there are no non-NaN elements, 0.0 is returned. This function does not
+alter the original []float64.
+*/
+func NanSum(v []float64) float64 {
+	sum := 0.0
+	for i := range v {
+		if !math.IsNaN(v[i]) {
+			sum += v[i]
+		}
+	}
+	return sum
+}
+
+/*
+NanMean returns the average of the non-NaN elements of a []float64. Consider:
+
+	v := []float64{1.0, math.NaN(), 3.0}
+	s := vec.NanMean(v) // 2.0
+
+If there are no non-NaN elements, NaN is returned. This function does not
+alter the original []float64.
+*/
+func NanMean(v []float64) float64 {
+	sum := 0.0
+	n := 0
+	for i := range v {
+		if !math.IsNaN(v[i]) {
+			sum += v[i]
+			n++
+		}
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return sum / float64(n)
+}
+
+/*
+NanMin returns the smallest non-NaN element of a []float64. Consider:
+
+	v := []float64{2.0, math.NaN(), -3.0}
+	s := vec.NanMin(v) // -3.0
+
+If there are no non-NaN elements, NaN is returned. This function does not
+alter the original []float64.
+*/
+func NanMin(v []float64) float64 {
+	min := math.NaN()
+	for i := range v {
+		if math.IsNaN(v[i]) {
+			continue
+		}
+		if math.IsNaN(min) || v[i] < min {
+			min = v[i]
+		}
+	}
+	return min
+}
+
+/*
+NanMax returns the largest non-NaN element of a []float64. Consider:
+
+	v := []float64{2.0, math.NaN(), -3.0}
+	s := vec.NanMax(v) // 2.0
+
+If there are no non-NaN elements, NaN is returned. This function does not
+alter the original []float64.
+*/
+func NanMax(v []float64) float64 {
+	max := math.NaN()
+	for i := range v {
+		if math.IsNaN(v[i]) {
+			continue
+		}
+		if math.IsNaN(max) || v[i] > max {
+			max = v[i]
+		}
+	}
+	return max
+}
+
+/*
+NanStd returns the population standard deviation of the non-NaN elements of
+a []float64, that is, the square root of the average squared distance of each
+non-NaN element from vec.NanMean(). Consider:
+
+	v := []float64{1.0, math.NaN(), 3.0}
+	s := vec.NanStd(v) // 1.0
+
+If there are no non-NaN elements, NaN is returned. This function does not
+alter the original []float64.
+*/
+func NanStd(v []float64) float64 {
+	mean := NanMean(v)
+	if math.IsNaN(mean) {
+		return mean
+	}
+	sum := 0.0
+	n := 0
+	for i := range v {
+		if !math.IsNaN(v[i]) {
+			d := v[i] - mean
+			sum += d * d
+			n++
+		}
+	}
+	return math.Sqrt(sum / float64(n))
+}
+
+/*
+HasNaN checks if any element of a []float64 is NaN. Consider:
+
+	v := []float64{1.0, math.NaN(), 3.0}
+	vec.HasNaN(v) // true
+
+This function does not alter the original []float64.
+*/
+func HasNaN(v []float64) bool {
+	for i := range v {
+		if math.IsNaN(v[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+IsFinite returns a []bool of the same length as the passed []float64, where
+each entry is true if the corresponding element is neither NaN nor an
+infinity. Consider:
+
+	v := []float64{1.0, math.NaN(), math.Inf(1)}
+	b := vec.IsFinite(v) // b is {true, false, false}
+
+This function does not alter the original []float64.
+*/
+func IsFinite(v []float64) []bool {
+	b := make([]bool, len(v))
+	for i := range v {
+		b[i] = !math.IsNaN(v[i]) && !math.IsInf(v[i], 0)
+	}
+	return b
+}
+
+/*
+ReplaceNaN returns a copy of the passed []float64 where all NaN entries are
+replaced by the passed float64. Consider:
+
+	v := []float64{1.0, math.NaN(), 3.0}
+	w := vec.ReplaceNaN(v, 0.0) // w is {1.0, 0.0, 3.0}
+
+The original []float64 is not modified in this function.
+*/
+func ReplaceNaN(v []float64, with float64) []float64 {
+	return replaceNaN(Clone(v), with)
+}
+
+/*
+ReplaceNaNTo stores the result of vec.ReplaceNaN(v, with) in dst, and
+returns dst, without allocating. dst must have the length of v, and may be v
+itself.
+*/
+func ReplaceNaNTo(dst, v []float64, with float64) []float64 {
+	checkDst("ReplaceNaNTo()", dst, v)
+	copy(dst, v)
+	return replaceNaN(dst, with)
+}
+
+func replaceNaN(c []float64, with float64) []float64 {
+	for i := range c {
+		if math.IsNaN(c[i]) {
+			c[i] = with
+		}
+	}
+	return c
+}
+
+/*
+NanToNum returns a copy of the passed []float64 where NaN entries are
+replaced by nan, positive infinities by posinf, and negative infinities by
+neginf. Consider:
+
+	v := []float64{math.NaN(), math.Inf(1), math.Inf(-1), 2.0}
+	w := vec.NanToNum(v, 0.0, math.MaxFloat64, -math.MaxFloat64)
+	// w is {0.0, math.MaxFloat64, -math.MaxFloat64, 2.0}
+
+The original []float64 is not modified in this function.
+*/
+func NanToNum(v []float64, nan, posinf, neginf float64) []float64 {
+	return nanToNum(Clone(v), nan, posinf, neginf)
+}
+
+/*
+NanToNumTo stores the result of vec.NanToNum(v, nan, posinf, neginf) in dst,
+and returns dst, without allocating. dst must have the length of v, and may
+be v itself.
+*/
+func NanToNumTo(dst, v []float64, nan, posinf, neginf float64) []float64 {
+	checkDst("NanToNumTo()", dst, v)
+	copy(dst, v)
+	return nanToNum(dst, nan, posinf, neginf)
+}
+
+func nanToNum(c []float64, nan, posinf, neginf float64) []float64 {
+	for i := range c {
+		switch {
+		case math.IsNaN(c[i]):
+			c[i] = nan
+		case math.IsInf(c[i], 1):
+			c[i] = posinf
+		case math.IsInf(c[i], -1):
+			c[i] = neginf
+		}
+	}
+	return c
+}
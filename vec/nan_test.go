@@ -0,0 +1,99 @@
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNanSum(t *testing.T) {
+	v := []float64{1.0, math.NaN(), 3.0}
+	if s := NanSum(v); s != 4.0 {
+		t.Errorf("expected 4.0, got %f", s)
+	}
+	if s := NanSum([]float64{math.NaN()}); s != 0.0 {
+		t.Errorf("expected 0.0 for all NaN, got %f", s)
+	}
+}
+
+func TestNanMean(t *testing.T) {
+	v := []float64{1.0, math.NaN(), 3.0}
+	if s := NanMean(v); s != 2.0 {
+		t.Errorf("expected 2.0, got %f", s)
+	}
+	if s := NanMean([]float64{}); !math.IsNaN(s) {
+		t.Errorf("expected NaN for empty []float64, got %f", s)
+	}
+}
+
+func TestNanMin(t *testing.T) {
+	v := []float64{math.NaN(), 2.0, math.NaN(), -3.0}
+	if s := NanMin(v); s != -3.0 {
+		t.Errorf("expected -3.0, got %f", s)
+	}
+	if s := NanMin([]float64{math.NaN(), math.NaN()}); !math.IsNaN(s) {
+		t.Errorf("expected NaN for all NaN, got %f", s)
+	}
+}
+
+func TestNanMax(t *testing.T) {
+	v := []float64{math.NaN(), 2.0, math.NaN(), -3.0}
+	if s := NanMax(v); s != 2.0 {
+		t.Errorf("expected 2.0, got %f", s)
+	}
+	if s := NanMax([]float64{math.NaN()}); !math.IsNaN(s) {
+		t.Errorf("expected NaN for all NaN, got %f", s)
+	}
+}
+
+func TestNanStd(t *testing.T) {
+	v := []float64{1.0, math.NaN(), 3.0}
+	if s := NanStd(v); s != 1.0 {
+		t.Errorf("expected 1.0, got %f", s)
+	}
+	v = []float64{2.0, 4.0, 4.0, 4.0, math.NaN(), 5.0, 5.0, 7.0, 9.0}
+	if s := NanStd(v); s != 2.0 {
+		t.Errorf("expected 2.0, got %f", s)
+	}
+	if s := NanStd([]float64{math.NaN()}); !math.IsNaN(s) {
+		t.Errorf("expected NaN for all NaN, got %f", s)
+	}
+}
+
+func TestHasNaN(t *testing.T) {
+	if !HasNaN([]float64{1.0, math.NaN()}) {
+		t.Errorf("expected NaN to be found")
+	}
+	if HasNaN([]float64{1.0, math.Inf(1)}) {
+		t.Errorf("expected no NaN to be found")
+	}
+}
+
+func TestIsFinite(t *testing.T) {
+	v := []float64{1.0, math.NaN(), math.Inf(1), math.Inf(-1)}
+	b := IsFinite(v)
+	expected := []bool{true, false, false, false}
+	for i := range b {
+		if b[i] != expected[i] {
+			t.Errorf("at index %d, expected %t, got %t", i, expected[i], b[i])
+		}
+	}
+}
+
+func TestReplaceNaN(t *testing.T) {
+	v := []float64{1.0, math.NaN(), 3.0}
+	w := ReplaceNaN(v, -1.0)
+	if !Equal(w, []float64{1.0, -1.0, 3.0}) {
+		t.Errorf("expected {1.0, -1.0, 3.0}, got %v", w)
+	}
+	if !math.IsNaN(v[1]) {
+		t.Errorf("the original []float64 was modified")
+	}
+}
+
+func TestNanToNum(t *testing.T) {
+	v := []float64{math.NaN(), math.Inf(1), math.Inf(-1), 2.0}
+	w := NanToNum(v, 0.0, 10.0, -10.0)
+	if !Equal(w, []float64{0.0, 10.0, -10.0, 2.0}) {
+		t.Errorf("expected {0.0, 10.0, -10.0, 2.0}, got %v", w)
+	}
+}
@@ -0,0 +1,98 @@
+package vec
+
+import "fmt"
+
+// PadMode determines how the values used for padding are chosen in
+// vec.Pad().
+type PadMode int
+
+const (
+	// PadConstant pads with a constant value, which is 0.0 by default.
+	PadConstant PadMode = iota
+	// PadEdge pads by repeating the first and last elements.
+	PadEdge
+	// PadReflect pads with the reflection of the []float64 about its first
+	// and last elements, which are not themselves repeated.
+	PadReflect
+)
+
+/*
+Pad returns a new []float64 made of the passed []float64 with before elements
+added in front of it, and after elements added behind it. The values of the
+added elements depend on the passed mode. For example:
+
+	v := []float64{1.0, 2.0, 3.0}
+	vec.Pad(v, 2, 1, vec.PadConstant)      // {0.0, 0.0, 1.0, 2.0, 3.0, 0.0}
+	vec.Pad(v, 2, 1, vec.PadConstant, 9.0) // {9.0, 9.0, 1.0, 2.0, 3.0, 9.0}
+	vec.Pad(v, 2, 1, vec.PadEdge)          // {1.0, 1.0, 1.0, 2.0, 3.0, 3.0}
+	vec.Pad(v, 2, 1, vec.PadReflect)       // {3.0, 2.0, 1.0, 2.0, 3.0, 2.0}
+
+In the vec.PadConstant mode, an optional float64 can be passed to set the
+padding value. For vec.PadReflect, the reflection is repeated as needed when
+the padding is longer than the []float64 itself.
+
+before and after must not be negative, and the vec.PadEdge and vec.PadReflect
+modes require a non-empty []float64, otherwise this function will panic. The
+original []float64 is not modified in this function.
+*/
+func Pad(v []float64, before, after int, mode PadMode, args ...float64) []float64 {
+	if before < 0 {
+		panic(fmt.Sprintf(errStrings[13], "Pad()", before))
+	}
+	if after < 0 {
+		panic(fmt.Sprintf(errStrings[13], "Pad()", after))
+	}
+	n := len(v)
+	c := alloc(before + n + after)
+	copy(c[before:], v)
+	switch mode {
+	case PadConstant:
+		val := 0.0
+		switch len(args) {
+		case 0:
+		case 1:
+			val = args[0]
+		default:
+			panic(fmt.Sprintf(errStrings[4], "Pad()"))
+		}
+		for i := 0; i < before; i++ {
+			c[i] = val
+		}
+		for i := before + n; i < len(c); i++ {
+			c[i] = val
+		}
+	case PadEdge, PadReflect:
+		if n == 0 {
+			panic(fmt.Sprintf(errStrings[0], "Pad()", "edge or reflect padding"))
+		}
+		for i := range c {
+			if i >= before && i < before+n {
+				continue
+			}
+			c[i] = v[padIndex(i-before, n, mode)]
+		}
+	default:
+		panic(fmt.Sprintf(errStrings[14], "Pad()", mode))
+	}
+	return c
+}
+
+// padIndex maps an index i, which may lie outside of [0, n), to the index of
+// the element of a []float64 of length n that it takes its value from.
+func padIndex(i, n int, mode PadMode) int {
+	if mode == PadEdge || n == 1 {
+		if i < 0 {
+			return 0
+		}
+		return n - 1
+	}
+	period := 2 * (n - 1)
+	i %= period
+	if i < 0 {
+		i += period
+	}
+	if i >= n {
+		i = period - i
+	}
+	return i
+}
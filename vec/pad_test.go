@@ -0,0 +1,61 @@
+package vec
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestPad(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0}
+	tests := []struct {
+		before, after int
+		mode          PadMode
+		args          []float64
+		expected      []float64
+	}{
+		{2, 1, PadConstant, nil, []float64{0.0, 0.0, 1.0, 2.0, 3.0, 0.0}},
+		{2, 1, PadConstant, []float64{9.0}, []float64{9.0, 9.0, 1.0, 2.0, 3.0, 9.0}},
+		{2, 1, PadEdge, nil, []float64{1.0, 1.0, 1.0, 2.0, 3.0, 3.0}},
+		{2, 1, PadReflect, nil, []float64{3.0, 2.0, 1.0, 2.0, 3.0, 2.0}},
+		{5, 5, PadReflect, nil, []float64{2.0, 1.0, 2.0, 3.0, 2.0, 1.0, 2.0, 3.0, 2.0, 1.0, 2.0, 3.0, 2.0}},
+		{0, 0, PadReflect, nil, []float64{1.0, 2.0, 3.0}},
+	}
+	for _, test := range tests {
+		w := Pad(v, test.before, test.after, test.mode, test.args...)
+		if !Equal(w, test.expected) {
+			t.Errorf("for mode %d, expected %v, got %v", test.mode, test.expected, w)
+		}
+	}
+	w := Pad([]float64{4.0}, 2, 2, PadReflect)
+	if !Equal(w, []float64{4.0, 4.0, 4.0, 4.0, 4.0}) {
+		t.Errorf("expected {4.0, 4.0, 4.0, 4.0, 4.0}, got %v", w)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[0], "Pad()", "edge or reflect padding")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Pad([]float64{}, 1, 1, PadEdge)
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[13], "Pad()", -1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Pad(v, -1, 1, PadConstant)
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,166 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/NDari/gocrunch/backend"
+	"github.com/NDari/gocrunch/parallel"
+)
+
+// reduceChunk is the number of elements of the chunks of the parallel
+// reductions. It is fixed, rather than derived from GOMAXPROCS, so that the
+// partial results, and the order in which they are combined, are the same on
+// every machine.
+const reduceChunk = 1 << 12
+
+/*
+SumParallel returns the sum of the elements of v, as with vec.Sum(), splitting
+v into chunks of a fixed length which are summed on up to GOMAXPROCS
+goroutines once v is large enough, as decided by parallel.Threshold(). The
+sums of the chunks are then added pairwise, in a fixed tree order, so that
+the result is the same from one call to the next, whatever the number of
+goroutines. For example:
+
+	s := vec.SumParallel(v) // the same on every call, with 1 or 64 cores
+
+The pairwise additions also make the rounding error grow with the length of
+a chunk plus the logarithm of the number of chunks, rather than with the
+length of v, so the result can differ from that of vec.Sum() in the last
+bits. This function does not alter the passed []float64.
+*/
+func SumParallel(v []float64) float64 {
+	return treeReduce(len(v), func(lo, hi int) float64 {
+		return Sum(v[lo:hi])
+	}, func(a, b float64) float64 {
+		return a + b
+	})
+}
+
+/*
+DotParallel returns the dot product of v1 and v2, as with vec.Dot(), computing
+the products of chunks of a fixed length on up to GOMAXPROCS goroutines once
+the vectors are large enough, and adding them pairwise in a fixed tree order,
+as with vec.SumParallel(), so that the result is the same from one call to
+the next. For example:
+
+	d := vec.DotParallel(v1, v2)
+
+The []float64s must have the same length, otherwise this function will
+panic. The passed []float64s are not altered in this function.
+*/
+func DotParallel(v1, v2 []float64) float64 {
+	if len(v1) != len(v2) {
+		panic(fmt.Sprintf(errStrings[5], "DotParallel()", len(v1), len(v2)))
+	}
+	return treeReduce(len(v1), func(lo, hi int) float64 {
+		return backend.Dot(v1[lo:hi], v2[lo:hi])
+	}, func(a, b float64) float64 {
+		return a + b
+	})
+}
+
+/*
+NormParallel returns the euclidean norm of v, computing the sums of squares
+of chunks of a fixed length on up to GOMAXPROCS goroutines once v is large
+enough, and combining them pairwise in a fixed tree order, as with
+vec.SumParallel(), so that the result is the same from one call to the next.
+Each sum of squares is scaled by the largest element of its chunk, so that
+the norm does not overflow or underflow when the squares would. For example:
+
+	n := vec.NormParallel([]float64{3e200, 4e200}) // 5e200
+
+The norm of an empty []float64 is 0.0. This function does not alter the
+passed []float64.
+*/
+func NormParallel(v []float64) float64 {
+	p := treeReduce(len(v), func(lo, hi int) scaledSquares {
+		return squares(v[lo:hi])
+	}, scaledSquares.add)
+	if p.scale == 0.0 || math.IsInf(p.scale, 1) || math.IsNaN(p.scale) {
+		return p.scale
+	}
+	return p.scale * math.Sqrt(p.ssq)
+}
+
+// scaledSquares is a sum of squares, stored as ssq times the square of scale,
+// where scale is the largest magnitude of the squared elements.
+type scaledSquares struct {
+	scale, ssq float64
+}
+
+// squares returns the scaled sum of the squares of the elements of v.
+func squares(v []float64) scaledSquares {
+	scale := 0.0
+	for _, x := range v {
+		scale = math.Max(scale, math.Abs(x))
+	}
+	if scale == 0.0 || math.IsInf(scale, 1) || math.IsNaN(scale) {
+		return scaledSquares{scale: scale}
+	}
+	ssq := 0.0
+	for _, x := range v {
+		ssq += (x / scale) * (x / scale)
+	}
+	return scaledSquares{scale, ssq}
+}
+
+// add returns the scaled sum of the squares of p and q, rescaled to the
+// larger of their scales. As with math.Max, an infinity wins over a NaN.
+func (p scaledSquares) add(q scaledSquares) scaledSquares {
+	switch {
+	case math.IsInf(p.scale, 1) || math.IsInf(q.scale, 1):
+		return scaledSquares{scale: math.Inf(1)}
+	case math.IsNaN(p.scale) || math.IsNaN(q.scale):
+		return scaledSquares{scale: math.NaN()}
+	}
+	if p.scale < q.scale {
+		p, q = q, p
+	}
+	if q.scale == 0.0 {
+		return p
+	}
+	r := q.scale / p.scale
+	return scaledSquares{p.scale, p.ssq + q.ssq*r*r}
+}
+
+// treeReduce splits n elements into chunks of reduceChunk elements, reduces
+// each chunk with chunk, and combines the partial results pairwise with
+// combine, as the leaves of a balanced binary tree. The chunks are reduced
+// on separate goroutines when n is large enough, but the partial results and
+// the order in which they are combined do not depend on it.
+func treeReduce[T any](n int, chunk func(lo, hi int) T, combine func(a, b T) T) T {
+	chunks := max((n+reduceChunk-1)/reduceChunk, 1)
+	partials := make([]T, chunks)
+	reduce := func(c int) {
+		partials[c] = chunk(c*reduceChunk, min((c+1)*reduceChunk, n))
+	}
+	workers := min(runtime.GOMAXPROCS(0), chunks)
+	if workers < 2 || n < parallel.Threshold() {
+		for c := range partials {
+			reduce(c)
+		}
+	} else {
+		var next atomic.Int64
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for w := 0; w < workers; w++ {
+			go func() {
+				defer wg.Done()
+				for c := int(next.Add(1)) - 1; c < chunks; c = int(next.Add(1)) - 1 {
+					reduce(c)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	for stride := 1; stride < chunks; stride *= 2 {
+		for c := 0; c+stride < chunks; c += 2 * stride {
+			partials[c] = combine(partials[c], partials[c+stride])
+		}
+	}
+	return partials[0]
+}
@@ -0,0 +1,94 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/parallel"
+)
+
+func TestParallelReductions(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	// An odd number of chunks leaves one unpaired at some levels of the tree.
+	v, w := make([]float64, 5*reduceChunk+17), make([]float64, 5*reduceChunk+17)
+	for i := range v {
+		v[i], w[i] = r.NormFloat64()*1e3, r.NormFloat64()
+	}
+	s, d, n := SumParallel(v), DotParallel(v, w), NormParallel(v)
+	if math.Abs(s-Sum(v)) > 1e-9*float64(len(v))*1e3 {
+		t.Errorf("expected about %v, got %v", Sum(v), s)
+	}
+	if math.Abs(d-Dot(v, w)) > 1e-9*math.Abs(Dot(v, w)) {
+		t.Errorf("expected about %v, got %v", Dot(v, w), d)
+	}
+	if math.Abs(n-math.Sqrt(Dot(v, v))) > 1e-12*n {
+		t.Errorf("expected about %v, got %v", math.Sqrt(Dot(v, v)), n)
+	}
+	// The results must be the same, to the bit, in parallel, with any
+	// number of goroutines.
+	parallel.SetThreshold(1)
+	defer parallel.SetThreshold(0)
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	for _, procs := range []int{1, 2, 3, 8} {
+		runtime.GOMAXPROCS(procs)
+		for k := 0; k < 3; k++ {
+			if p := SumParallel(v); p != s {
+				t.Errorf("with %d procs, expected %v, got %v", procs, s, p)
+			}
+			if p := DotParallel(v, w); p != d {
+				t.Errorf("with %d procs, expected %v, got %v", procs, d, p)
+			}
+			if p := NormParallel(v); p != n {
+				t.Errorf("with %d procs, expected %v, got %v", procs, n, p)
+			}
+		}
+	}
+}
+
+func TestNormParallel(t *testing.T) {
+	if n := NormParallel(nil); n != 0.0 {
+		t.Errorf("expected 0.0, got %v", n)
+	}
+	if n := NormParallel([]float64{3e200, 4e200}); math.Abs(n-5e200) > 1e188 {
+		t.Errorf("expected 5e200, got %v", n)
+	}
+	// The chunks have very different scales, and neither square fits in a
+	// float64.
+	v := make([]float64, 2*reduceChunk)
+	v[0], v[reduceChunk] = 3e-200, 4e-200
+	if n := NormParallel(v); math.Abs(n-5e-200) > 1e-212 {
+		t.Errorf("expected 5e-200, got %v", n)
+	}
+	v[1] = math.NaN()
+	if n := NormParallel(v); !math.IsNaN(n) {
+		t.Errorf("expected NaN, got %v", n)
+	}
+	v[reduceChunk+1] = math.Inf(-1)
+	if n := NormParallel(v); !math.IsInf(n, 1) {
+		t.Errorf("expected +Inf, got %v", n)
+	}
+	if s := SumParallel(nil); s != 0.0 {
+		t.Errorf("expected 0.0, got %v", s)
+	}
+}
+
+func TestDotParallelPanics(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expected := fmt.Sprintf(errStrings[5], "DotParallel()", 1, 2)
+			if r != expected {
+				t.Errorf("Expected %s, got %v", expected, r)
+			}
+			wg.Done()
+		}()
+		DotParallel([]float64{1.0}, []float64{1.0, 2.0})
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,108 @@
+package vec
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+/*
+Pool is a set of reusable []float64 buffers, grouped by capacity in powers of
+two, and backed by a sync.Pool for each group. A Pool is safe for concurrent
+use by multiple goroutines, and its zero value is ready to use.
+
+A Pool can be used directly for temporary buffers:
+
+	var p vec.Pool
+	buf := p.Get(n)
+	// ... use buf ...
+	p.Put(buf)
+
+After SetPool(p), the functions of this package which allocate a new
+[]float64 for their result, such as vec.Add() and vec.Clone(), take it from
+p instead, and their results can be returned to p with Put once they are no
+longer used. In services which run very many small operations, this keeps
+most of the allocations off the garbage collector. The exceptions are the
+results which grow as they are computed, those of vec.Filter(),
+vec.FilterWithIndices(), vec.Collect(), vec.Push() and vec.Unshift(), and
+the convolutions of vec.Convolve() computed with FFTs, whose []float64 comes
+from the fft package.
+*/
+type Pool struct {
+	buckets [64]sync.Pool
+}
+
+/*
+Get returns a []float64 of length n, with all elements set to 0.0. Its
+capacity is n rounded up to a power of two, and it may have been returned by
+an earlier Put. n must not be negative, otherwise this function will panic.
+*/
+func (p *Pool) Get(n int) []float64 {
+	v := p.get("Get()", n)
+	clear(v)
+	return v
+}
+
+// get is Get, without setting the elements to 0.0.
+func (p *Pool) get(fn string, n int) []float64 {
+	if n < 0 {
+		panic(fmt.Sprintf(errStrings[13], fn, n))
+	}
+	if n == 0 {
+		return []float64{}
+	}
+	b := bits.Len(uint(n - 1))
+	if ptr, ok := p.buckets[b].Get().(*[]float64); ok {
+		return (*ptr)[:n]
+	}
+	return make([]float64, n, 1<<b)
+}
+
+/*
+Put makes v available to later calls of Get. v must not be used after it is
+passed to Put. Slices with a capacity of 0 are ignored.
+*/
+func (p *Pool) Put(v []float64) {
+	c := cap(v)
+	if c == 0 {
+		return
+	}
+	// A slice is kept with the largest power of two it can hold, so that
+	// every slice in a bucket can serve every request of that bucket.
+	b := bits.Len(uint(c)) - 1
+	v = v[:c]
+	p.buckets[b].Put(&v)
+}
+
+// pool is the Pool used by the allocating functions of this package, or nil
+// when they allocate with make.
+var pool atomic.Pointer[Pool]
+
+/*
+SetPool makes the functions of this package which allocate a new []float64
+for their result take it from p, until SetPool is called again, with the
+exceptions listed in the documentation of Pool. A nil p,
+which is the default, makes them allocate with make.
+*/
+func SetPool(p *Pool) {
+	pool.Store(p)
+}
+
+// allocZeroed is alloc, with all elements set to 0.0.
+func allocZeroed(n int) []float64 {
+	if p := pool.Load(); p != nil {
+		return p.Get(n)
+	}
+	return make([]float64, n)
+}
+
+// alloc returns a []float64 of length n whose elements are to be
+// overwritten by the caller, taking it from the Pool set with SetPool, if
+// any.
+func alloc(n int) []float64 {
+	if p := pool.Load(); p != nil {
+		return p.get("alloc()", n)
+	}
+	return make([]float64, n)
+}
@@ -0,0 +1,134 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	var p Pool
+	for _, n := range []int{0, 1, 3, 4, 5, 1000} {
+		v := p.Get(n)
+		if len(v) != n {
+			t.Errorf("expected length %d, got %d", n, len(v))
+		}
+		if n > 0 && cap(v)&(cap(v)-1) != 0 {
+			t.Errorf("expected a power of two capacity, got %d", cap(v))
+		}
+		for i := range v {
+			v[i] = 1.0
+		}
+		p.Put(v)
+		// Whether or not the buffer is reused, it must come back zeroed.
+		w := p.Get(n)
+		for i := range w {
+			if w[i] != 0.0 {
+				t.Fatalf("expected zeroed elements, got %v", w)
+			}
+		}
+	}
+	// A slice of any capacity can be returned.
+	p.Put(make([]float64, 3, 7))
+	if v := p.Get(4); len(v) != 4 || cap(v) < 4 {
+		t.Errorf("expected length 4, got %d with capacity %d", len(v), cap(v))
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[13], "Get()", -1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		p.Get(-1)
+	}()
+	wg.Wait()
+}
+
+func TestSetPool(t *testing.T) {
+	p := &Pool{}
+	SetPool(p)
+	defer SetPool(nil)
+	v := []float64{1.0, 2.0, 3.0}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s := Add(v, 1.0)
+				if !Equal(s, []float64{2.0, 3.0, 4.0}) {
+					t.Errorf("expected {2.0, 3.0, 4.0}, got %v", s)
+				}
+				p.Put(s)
+				e := Expr(v).Mul(2.0).Eval(nil)
+				if !Equal(e, []float64{2.0, 4.0, 6.0}) {
+					t.Errorf("expected {2.0, 4.0, 6.0}, got %v", e)
+				}
+				p.Put(e)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSetPoolResults(t *testing.T) {
+	p := &Pool{}
+	SetPool(p)
+	defer SetPool(nil)
+	v := []float64{1.0, 2.0, 4.0, 7.0, 11.0}
+	results := map[string]func() []float64{
+		"Gradient":     func() []float64 { return Gradient(v, 1.0) },
+		"CumTrapz":     func() []float64 { return CumTrapz(v, 1.0) },
+		"Where":        func() []float64 { return Where([]bool{true, false, true, false, true}, v, 0.0) },
+		"Interp":       func() []float64 { return Interp(v, []float64{0, 20}, []float64{0, 2}, InterpClamp) },
+		"Pad":          func() []float64 { return Pad(v[:3], 1, 1, PadEdge) },
+		"Convolve":     func() []float64 { return Convolve(v[:3], v[:3], ConvFull) },
+		"Repeat":       func() []float64 { return Repeat(v[:2], 3) },
+		"Tile":         func() []float64 { return Tile(v[:3], 2) },
+		"Concat":       func() []float64 { return Concat(v[:3], v[:2]) },
+		"Atan2":        func() []float64 { return Atan2(v, v) },
+		"Rand":         func() []float64 { return Rand(5) },
+		"MaskedSelect": func() []float64 { return MaskedSelect(v, []bool{true, true, true, false, true}) },
+		"Dense":        func() []float64 { return NewSparse(5, []int{2}, []float64{1.0}).Dense() },
+		"Strided":      func() []float64 { return Slice(v, 0, 5, 1).Dense() },
+	}
+	for name, f := range results {
+		// The results of the Pool have a capacity of a power of two. The
+		// results reusing a dirty buffer must not depend on its elements.
+		expected := func() []float64 {
+			SetPool(nil)
+			defer SetPool(p)
+			return f()
+		}()
+		dirty := p.Get(len(expected))
+		for i := range dirty {
+			dirty[i] = math.NaN()
+		}
+		p.Put(dirty)
+		res := f()
+		if cap(res)&(cap(res)-1) != 0 {
+			t.Errorf("%s: expected a result from the pool, got a capacity of %d", name, cap(res))
+		}
+		if name != "Rand" && !Equal(res, expected) {
+			t.Errorf("%s: expected %v, got %v", name, expected, res)
+		}
+	}
+}
+
+func BenchmarkAddPooled(b *testing.B) {
+	v := Rand(64)
+	p := &Pool{}
+	SetPool(p)
+	defer SetPool(nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Put(Add(v, 1.0))
+	}
+}
@@ -0,0 +1,131 @@
+package vec
+
+/*
+Reduce folds the elements of a []float64 into a single float64, by calling f
+with an accumulator, starting from init, and each element in turn, from the
+first to the last, and returning the final accumulator. For example, the
+log-likelihood of independent observations with probabilities p:
+
+	ll := vec.Reduce(func(acc, x float64) float64 {
+		return acc + math.Log(x)
+	}, 0.0, p)
+
+init is returned for an empty []float64. The passed []float64 is not
+modified in this function.
+*/
+func Reduce(f func(acc, x float64) float64, init float64, v []float64) float64 {
+	acc := init
+	for _, x := range v {
+		acc = f(acc, x)
+	}
+	return acc
+}
+
+/*
+ReduceIndexed is like vec.Reduce(), but also passes the index of each element
+to f, for reductions which depend on the position of the elements, such as
+a weighted sum of squares:
+
+	s := vec.ReduceIndexed(func(acc float64, i int, x float64) float64 {
+		return acc + w[i]*x*x
+	}, 0.0, v)
+
+init is returned for an empty []float64. The passed []float64 is not
+modified in this function.
+*/
+func ReduceIndexed(f func(acc float64, i int, x float64) float64, init float64, v []float64) float64 {
+	acc := init
+	for i, x := range v {
+		acc = f(acc, i, x)
+	}
+	return acc
+}
+
+/*
+Scan returns the running results of vec.Reduce(f, init, v), as a new
+[]float64 whose element i is the accumulator after the elements 0 to i of v
+were folded into it. For example, the running maximum and the running
+product:
+
+	v := []float64{2.0, 1.0, 3.0}
+	m := vec.Scan(math.Max, math.Inf(-1), v) // m is {2.0, 2.0, 3.0}
+	p := vec.Scan(func(acc, x float64) float64 {
+		return acc * x
+	}, 1.0, v) // p is {2.0, 2.0, 6.0}
+
+init is not part of the result, which has the length of v. The passed
+[]float64 is not modified in this function.
+*/
+func Scan(f func(acc, x float64) float64, init float64, v []float64) []float64 {
+	return scan(alloc(len(v)), f, init, v)
+}
+
+/*
+ScanTo stores the result of vec.Scan(f, init, v) in dst, and returns dst,
+without allocating. dst must have the length of v, and may be v itself.
+*/
+func ScanTo(dst []float64, f func(acc, x float64) float64, init float64, v []float64) []float64 {
+	checkDst("ScanTo()", dst, v)
+	return scan(dst, f, init, v)
+}
+
+func scan(dst []float64, f func(acc, x float64) float64, init float64, v []float64) []float64 {
+	acc := init
+	for i, x := range v {
+		acc = f(acc, x)
+		dst[i] = acc
+	}
+	return dst
+}
+
+/*
+CumSum returns the cumulative sums of a []float64, whose element i is the
+sum of the elements 0 to i of v, which is vec.Scan() with addition. For
+example:
+
+	c := vec.CumSum([]float64{1.0, 2.0, 3.0}) // c is {1.0, 3.0, 6.0}
+
+The passed []float64 is not modified in this function.
+*/
+func CumSum(v []float64) []float64 {
+	return scan(alloc(len(v)), plus, 0.0, v)
+}
+
+/*
+CumSumTo stores the result of vec.CumSum(v) in dst, and returns dst, without
+allocating. dst must have the length of v, and may be v itself.
+*/
+func CumSumTo(dst, v []float64) []float64 {
+	checkDst("CumSumTo()", dst, v)
+	return scan(dst, plus, 0.0, v)
+}
+
+/*
+CumProd returns the cumulative products of a []float64, whose element i is
+the product of the elements 0 to i of v, which is vec.Scan() with
+multiplication. For example:
+
+	c := vec.CumProd([]float64{1.0, 2.0, 3.0}) // c is {1.0, 2.0, 6.0}
+
+The passed []float64 is not modified in this function.
+*/
+func CumProd(v []float64) []float64 {
+	return scan(alloc(len(v)), times, 1.0, v)
+}
+
+/*
+CumProdTo stores the result of vec.CumProd(v) in dst, and returns dst,
+without allocating. dst must have the length of v, and may be v itself.
+*/
+func CumProdTo(dst, v []float64) []float64 {
+	checkDst("CumProdTo()", dst, v)
+	return scan(dst, times, 1.0, v)
+}
+
+func plus(acc, x float64) float64 {
+	return acc + x
+}
+
+func times(acc, x float64) float64 {
+	return acc * x
+}
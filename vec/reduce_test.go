@@ -0,0 +1,58 @@
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReduce(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0, 4.0}
+	if s := Reduce(func(acc, x float64) float64 { return acc + x }, 0.0, v); s != Sum(v) {
+		t.Errorf("expected %v, got %v", Sum(v), s)
+	}
+	if m := Reduce(math.Max, math.Inf(-1), v); m != 4.0 {
+		t.Errorf("expected 4.0, got %v", m)
+	}
+	// The elements are folded from the first to the last.
+	if d := Reduce(func(acc, x float64) float64 { return acc*10.0 + x }, 0.0, v); d != 1234.0 {
+		t.Errorf("expected 1234.0, got %v", d)
+	}
+	if r := Reduce(func(acc, x float64) float64 { return acc + x }, 7.0, nil); r != 7.0 {
+		t.Errorf("expected the initial value for an empty []float64, got %v", r)
+	}
+	w := []float64{0.5, 0.0, 1.0, 2.0}
+	s := ReduceIndexed(func(acc float64, i int, x float64) float64 {
+		return acc + w[i]*x*x
+	}, 0.0, v)
+	if s != 41.5 {
+		t.Errorf("expected 41.5, got %v", s)
+	}
+	if r := ReduceIndexed(func(acc float64, i int, x float64) float64 { return acc + float64(i) }, -1.0, v); r != 5.0 {
+		t.Errorf("expected 5.0, got %v", r)
+	}
+}
+
+func TestScan(t *testing.T) {
+	v := []float64{2.0, 1.0, 3.0}
+	if m := Scan(math.Max, math.Inf(-1), v); !Equal(m, []float64{2.0, 2.0, 3.0}) {
+		t.Errorf("expected {2.0, 2.0, 3.0}, got %v", m)
+	}
+	if m := Scan(math.Min, 0.0, v); !Equal(m, []float64{0.0, 0.0, 0.0}) {
+		t.Errorf("expected the initial value to take part, got %v", m)
+	}
+	if c := CumSum(v); !Equal(c, []float64{2.0, 3.0, 6.0}) {
+		t.Errorf("expected {2.0, 3.0, 6.0}, got %v", c)
+	}
+	if c := CumProd(v); !Equal(c, []float64{2.0, 2.0, 6.0}) {
+		t.Errorf("expected {2.0, 2.0, 6.0}, got %v", c)
+	}
+	if c := CumSum(nil); len(c) != 0 {
+		t.Errorf("expected an empty []float64, got %v", c)
+	}
+	if last := CumSum(v)[len(v)-1]; last != Reduce(func(acc, x float64) float64 { return acc + x }, 0.0, v) {
+		t.Errorf("expected the last running sum to be the reduction, got %v", last)
+	}
+	if !Equal(v, []float64{2.0, 1.0, 3.0}) {
+		t.Errorf("the original []float64 was modified")
+	}
+}
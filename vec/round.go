@@ -0,0 +1,147 @@
+package vec
+
+import "math"
+
+/*
+Round returns a new []float64 where each element of the passed []float64 is
+rounded to the nearest integer, with halves rounded away from zero. For
+example:
+
+	v := []float64{1.4, 1.5, -2.5}
+	r := vec.Round(v) // r is {1.0, 2.0, -3.0}
+
+The original []float64 is not modified in this function. To round the
+elements in place, look at vec.RoundInPlace().
+*/
+func Round(v []float64) []float64 {
+	return Foreach(v, math.Round)
+}
+
+/*
+RoundInPlace rounds each element of the passed []float64 to the nearest
+integer, with halves rounded away from zero. The passed []float64 is mutated
+in this function.
+*/
+func RoundInPlace(v []float64) {
+	applyInPlace(v, math.Round)
+}
+
+/*
+Floor returns a new []float64 where each element is the greatest integer
+value less than or equal to the corresponding element of the passed
+[]float64. For example:
+
+	v := []float64{1.7, -1.2}
+	f := vec.Floor(v) // f is {1.0, -2.0}
+
+The original []float64 is not modified in this function.
+*/
+func Floor(v []float64) []float64 {
+	return Foreach(v, math.Floor)
+}
+
+/*
+FloorInPlace sets each element of the passed []float64 to the greatest
+integer value less than or equal to it. The passed []float64 is mutated in
+this function.
+*/
+func FloorInPlace(v []float64) {
+	applyInPlace(v, math.Floor)
+}
+
+/*
+Ceil returns a new []float64 where each element is the least integer value
+greater than or equal to the corresponding element of the passed []float64.
+For example:
+
+	v := []float64{1.2, -1.7}
+	c := vec.Ceil(v) // c is {2.0, -1.0}
+
+The original []float64 is not modified in this function.
+*/
+func Ceil(v []float64) []float64 {
+	return Foreach(v, math.Ceil)
+}
+
+/*
+CeilInPlace sets each element of the passed []float64 to the least integer
+value greater than or equal to it. The passed []float64 is mutated in this
+function.
+*/
+func CeilInPlace(v []float64) {
+	applyInPlace(v, math.Ceil)
+}
+
+/*
+Trunc returns a new []float64 where the fractional part of each element of
+the passed []float64 is dropped. For example:
+
+	v := []float64{1.7, -1.7}
+	c := vec.Trunc(v) // c is {1.0, -1.0}
+
+The original []float64 is not modified in this function.
+*/
+func Trunc(v []float64) []float64 {
+	return Foreach(v, math.Trunc)
+}
+
+/*
+TruncInPlace drops the fractional part of each element of the passed
+[]float64. The passed []float64 is mutated in this function.
+*/
+func TruncInPlace(v []float64) {
+	applyInPlace(v, math.Trunc)
+}
+
+/*
+RoundTo returns a new []float64 where each element of the passed []float64
+is rounded to the given number of decimal places. For example:
+
+	v := []float64{3.14159, 2.71828}
+	r := vec.RoundTo(v, 2) // r is {3.14, 2.72}
+
+A negative number of decimals rounds to the left of the decimal point, so
+that vec.RoundTo(v, -2) rounds each element to the nearest hundred. Elements
+which already have no more than the given number of decimals at the
+precision of a float64, such as 1e300 for any number of decimals, are kept
+as they are, while from -309 decimals on, every finite element rounds to
+the zero of its sign. The original []float64 is not modified in this
+function.
+*/
+func RoundTo(v []float64, decimals int) []float64 {
+	c := Clone(v)
+	RoundToInPlace(c, decimals)
+	return c
+}
+
+/*
+RoundToInPlace rounds each element of the passed []float64 to the given
+number of decimal places. See vec.RoundTo() for details. The passed
+[]float64 is mutated in this function.
+*/
+func RoundToInPlace(v []float64, decimals int) {
+	p := math.Pow(10.0, float64(decimals))
+	for i := range v {
+		// Below about -323 decimals, p underflows to 0, while every finite
+		// element rounds to the zero of its sign, as it does from -309 on.
+		if p == 0.0 {
+			if !math.IsInf(v[i], 0) && !math.IsNaN(v[i]) {
+				v[i] = math.Copysign(0.0, v[i])
+			}
+			continue
+		}
+		// From 2^52 on, a float64 has no fractional digits, so the element
+		// is kept rather than scaled, possibly past the largest float64.
+		// This also keeps NaNs and infinities.
+		if x := v[i] * p; math.Abs(x) < 1<<52 {
+			v[i] = math.Round(x) / p
+		}
+	}
+}
+
+// applyInPlace sets each element of v to f of that element.
+func applyInPlace(v []float64, f func(float64) float64) {
+	for i := range v {
+		v[i] = f(v[i])
+	}
+}
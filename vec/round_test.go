@@ -0,0 +1,97 @@
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	v := []float64{1.4, 1.5, -2.5, 0.0}
+	r := Round(v)
+	expected := []float64{1.0, 2.0, -3.0, 0.0}
+	if !Equal(r, expected) {
+		t.Errorf("expected %v, got %v", expected, r)
+	}
+	if v[0] != 1.4 {
+		t.Errorf("the original []float64 was modified")
+	}
+	RoundInPlace(v)
+	if !Equal(v, expected) {
+		t.Errorf("expected %v, got %v", expected, v)
+	}
+}
+
+func TestFloor(t *testing.T) {
+	v := []float64{1.7, -1.2}
+	expected := []float64{1.0, -2.0}
+	if f := Floor(v); !Equal(f, expected) {
+		t.Errorf("expected %v, got %v", expected, f)
+	}
+	FloorInPlace(v)
+	if !Equal(v, expected) {
+		t.Errorf("expected %v, got %v", expected, v)
+	}
+}
+
+func TestCeil(t *testing.T) {
+	v := []float64{1.2, -1.7}
+	expected := []float64{2.0, -1.0}
+	if c := Ceil(v); !Equal(c, expected) {
+		t.Errorf("expected %v, got %v", expected, c)
+	}
+	CeilInPlace(v)
+	if !Equal(v, expected) {
+		t.Errorf("expected %v, got %v", expected, v)
+	}
+}
+
+func TestTrunc(t *testing.T) {
+	v := []float64{1.7, -1.7}
+	expected := []float64{1.0, -1.0}
+	if c := Trunc(v); !Equal(c, expected) {
+		t.Errorf("expected %v, got %v", expected, c)
+	}
+	TruncInPlace(v)
+	if !Equal(v, expected) {
+		t.Errorf("expected %v, got %v", expected, v)
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	v := []float64{3.14159, 2.71828, 1234.5}
+	r := RoundTo(v, 2)
+	expected := []float64{3.14, 2.72, 1234.5}
+	for i := range r {
+		if math.Abs(r[i]-expected[i]) > 1e-12 {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], r[i])
+		}
+	}
+	r = RoundTo(v, -2)
+	expected = []float64{0.0, 0.0, 1200.0}
+	if !Equal(r, expected) {
+		t.Errorf("expected %v, got %v", expected, r)
+	}
+	RoundToInPlace(v, 0)
+	expected = []float64{3.0, 3.0, 1235.0}
+	if !Equal(v, expected) {
+		t.Errorf("expected %v, got %v", expected, v)
+	}
+	// Scaling these elements by 10^10 overflows, or leaves no digits to
+	// round.
+	v = []float64{1e300, -math.MaxFloat64, 123456789.123, math.Inf(1)}
+	r = RoundTo(v, 10)
+	if !Equal(r, v) {
+		t.Errorf("expected %v, got %v", v, r)
+	}
+	if r := RoundTo([]float64{math.NaN()}, 3); !math.IsNaN(r[0]) {
+		t.Errorf("expected NaN, got %v", r[0])
+	}
+	// Past -323 decimals, each finite element rounds to the zero of its
+	// sign.
+	for _, decimals := range []int{-320, -400, math.MinInt} {
+		r = RoundTo([]float64{1e300, -2.5, math.Inf(-1), math.NaN()}, decimals)
+		if r[0] != 0.0 || math.Signbit(r[0]) || r[1] != 0.0 || !math.Signbit(r[1]) || !math.IsInf(r[2], -1) || !math.IsNaN(r[3]) {
+			t.Errorf("expected {0, -0, -Inf, NaN} with %d decimals, got %v", decimals, r)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// selectMin is the length of the ranges which Select() sorts rather than
+// partitions further.
+const selectMin = 16
+
+/*
+Select returns the k-th smallest element of v, counting from 0, so that
+vec.Select(v, 0) is the smallest element and vec.Select(v, len(v)-1) the
+largest, without sorting v. It uses introselect, which partitions a copy of
+v around pivots as quicksort does, but only recurses into the side which
+holds the k-th element, in O(n) time on average. If the partitions keep
+shrinking too slowly, as they can for inputs crafted against the choice of
+pivots, the remaining range is sorted instead, which bounds the time by
+O(n log n). For example:
+
+	v := []float64{7.0, 1.0, 5.0, 3.0}
+	x := vec.Select(v, 1) // 3.0
+
+NaNs are placed after all other elements. k must be in [0, len(v)),
+otherwise this function will panic. v is not modified in this function.
+*/
+func Select(v []float64, k int) float64 {
+	if k < 0 || k >= len(v) {
+		panic(fmt.Sprintf(errStrings[1], "Select()", k, len(v)))
+	}
+	c := Clone(v)
+	nth(c, k)
+	return c[k]
+}
+
+/*
+Median returns the median of the elements of v, which is the middle element
+once they are sorted, or the average of the two middle ones if v has an even
+number of elements. It is found with vec.Select() rather than by sorting
+v. For example:
+
+	v := []float64{7.0, 1.0, 5.0, 3.0}
+	m := vec.Median(v) // 4.0
+
+The median is NaN if v holds a NaN. v cannot be empty, otherwise this
+function will panic. v is not modified in this function.
+*/
+func Median(v []float64) float64 {
+	if len(v) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "Median()", "Median()"))
+	}
+	return quantile(v, 0.5)
+}
+
+/*
+Quantile returns the q-th quantile of the elements of v, for q in [0, 1],
+interpolating linearly between the two elements of the sorted v closest to
+the position q * (len(v)-1), so that the 0.0, 0.5 and 1.0 quantiles are the
+smallest element, the median and the largest element. The elements are found
+with vec.Select() rather than by sorting v. For example:
+
+	v := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
+	x := vec.Quantile(v, 0.25) // 2.0
+	y := vec.Quantile(v, 0.9)  // 4.6
+
+The quantile is NaN if v holds a NaN. v cannot be empty, and q must be in
+[0, 1], otherwise this function will panic. v is not modified in this
+function.
+*/
+func Quantile(v []float64, q float64) float64 {
+	if len(v) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "Quantile()", "Quantile()"))
+	}
+	if !(q >= 0.0 && q <= 1.0) {
+		panic(fmt.Sprintf(errStrings[22], "Quantile()", q))
+	}
+	return quantile(v, q)
+}
+
+// quantile returns the q-th quantile of the non-empty v.
+func quantile(v []float64, q float64) float64 {
+	for _, x := range v {
+		if math.IsNaN(x) {
+			return math.NaN()
+		}
+	}
+	c := Clone(v)
+	h := q * float64(len(c)-1)
+	lo := int(math.Floor(h))
+	nth(c, lo)
+	if lo == len(c)-1 || h == float64(lo) {
+		return c[lo]
+	}
+	// The element after c[lo] in the sorted order is the smallest of
+	// those after it once c is partitioned.
+	next := c[lo+1]
+	for _, x := range c[lo+2:] {
+		next = math.Min(next, x)
+	}
+	return c[lo] + (h-float64(lo))*(next-c[lo])
+}
+
+// nth partitions x in place so that x[k] is the element that would be at k
+// if x were sorted, with no greater element before it and no smaller one
+// after it.
+func nth(x []float64, k int) {
+	lo, hi := 0, len(x)
+	budget := 2 * bits.Len(uint(len(x)))
+	for hi-lo > selectMin {
+		if budget == 0 {
+			break
+		}
+		budget--
+		p := median3(x[lo], x[lo+(hi-lo)/2], x[hi-1])
+		// Partition into the elements before p, those equal to it, and
+		// those after it, so that repeated elements end the search early.
+		lt, i, gt := lo, lo, hi
+		for i < gt {
+			switch {
+			case less(x[i], p):
+				x[lt], x[i] = x[i], x[lt]
+				lt++
+				i++
+			case less(p, x[i]):
+				gt--
+				x[i], x[gt] = x[gt], x[i]
+			default:
+				i++
+			}
+		}
+		switch {
+		case k < lt:
+			hi = lt
+		case k >= gt:
+			lo = gt
+		default:
+			return
+		}
+	}
+	r := x[lo:hi]
+	sort.Slice(r, func(a, b int) bool { return less(r[a], r[b]) })
+}
+
+// median3 returns the median of a, b and c.
+func median3(a, b, c float64) float64 {
+	if less(b, a) {
+		a, b = b, a
+	}
+	if less(c, b) {
+		b = c
+		if less(b, a) {
+			b = a
+		}
+	}
+	return b
+}
+
+// less reports whether x comes before y in ascending order, with NaNs
+// after all other values.
+func less(x, y float64) bool {
+	return x < y || (!math.IsNaN(x) && math.IsNaN(y))
+}
@@ -0,0 +1,121 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	v := []float64{7.0, 1.0, 5.0, 3.0}
+	for k, expected := range []float64{1.0, 3.0, 5.0, 7.0} {
+		if x := Select(v, k); x != expected {
+			t.Errorf("k = %d, expected %v, got %v", k, expected, x)
+		}
+	}
+	if !Equal(v, []float64{7.0, 1.0, 5.0, 3.0}) {
+		t.Errorf("the original []float64 was modified")
+	}
+	// Long inputs are partitioned, and repeated elements are grouped.
+	inputs := [][]float64{make([]float64, 1001), make([]float64, 1000), make([]float64, 500)}
+	for i := range inputs[0] {
+		inputs[0][i] = math.Sin(float64(i) * 3.7)
+	}
+	for i := range inputs[1] {
+		inputs[1][i] = float64(i % 3)
+	}
+	for i := range inputs[2] {
+		inputs[2][i] = float64(len(inputs[2]) - i)
+	}
+	inputs[2][7] = math.NaN()
+	for _, w := range inputs {
+		sorted := Clone(w)
+		SortBy(sorted)
+		for _, k := range []int{0, 1, len(w) / 3, len(w) / 2, len(w) - 2} {
+			if x := Select(w, k); x != sorted[k] {
+				t.Errorf("k = %d, expected %v, got %v", k, sorted[k], x)
+			}
+		}
+		if x := Select(w, len(w)-1); x != sorted[len(w)-1] && !math.IsNaN(x) {
+			t.Errorf("expected %v, got %v", sorted[len(w)-1], x)
+		}
+	}
+	// nth leaves no greater element before k, and no smaller one after it.
+	x := Clone(inputs[0])
+	nth(x, 300)
+	for i := range x {
+		if (i < 300 && x[i] > x[300]) || (i > 300 && x[i] < x[300]) {
+			t.Errorf("at index %d, %v is on the wrong side of %v", i, x[i], x[300])
+		}
+	}
+}
+
+func TestMedianQuantile(t *testing.T) {
+	if m := Median([]float64{7.0, 1.0, 5.0, 3.0}); m != 4.0 {
+		t.Errorf("expected 4.0, got %v", m)
+	}
+	if m := Median([]float64{7.0, 1.0, 5.0}); m != 5.0 {
+		t.Errorf("expected 5.0, got %v", m)
+	}
+	v := []float64{5.0, 2.0, 4.0, 1.0, 3.0}
+	for _, test := range []struct{ q, expected float64 }{
+		{0.0, 1.0}, {0.25, 2.0}, {0.5, 3.0}, {0.9, 4.6}, {1.0, 5.0},
+	} {
+		if x := Quantile(v, test.q); math.Abs(x-test.expected) > 1e-12 {
+			t.Errorf("q = %v, expected %v, got %v", test.q, test.expected, x)
+		}
+	}
+	if !Equal(v, []float64{5.0, 2.0, 4.0, 1.0, 3.0}) {
+		t.Errorf("the original []float64 was modified")
+	}
+	w := make([]float64, 999)
+	for i := range w {
+		w[i] = float64((i * 2) % 999)
+	}
+	if m := Median(w); m != 499.0 {
+		t.Errorf("expected 499.0, got %v", m)
+	}
+	if x := Quantile(w, 0.1005); math.Abs(x-100.299) > 1e-9 {
+		t.Errorf("expected 100.299, got %v", x)
+	}
+	if m := Median([]float64{1.0, math.NaN(), 2.0}); !math.IsNaN(m) {
+		t.Errorf("expected NaN, got %v", m)
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { Select(v, 5) },
+			fmt.Sprintf(errStrings[1], "Select()", 5, 5),
+		},
+		{
+			func() { Median(nil) },
+			fmt.Sprintf(errStrings[0], "Median()", "Median()"),
+		},
+		{
+			func() { Quantile(v, 1.5) },
+			fmt.Sprintf(errStrings[22], "Quantile()", 1.5),
+		},
+		{
+			func() { Quantile(v, math.NaN()) },
+			fmt.Sprintf(errStrings[22], "Quantile()", math.NaN()),
+		},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
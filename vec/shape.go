@@ -0,0 +1,217 @@
+package vec
+
+import "fmt"
+
+/*
+Reverse returns a copy of the passed []float64 with the order of its elements
+reversed. For example:
+
+	v := []float64{1.0, 2.0, 3.0}
+	w := vec.Reverse(v) // w is {3.0, 2.0, 1.0}
+
+The original []float64 is not modified in this function. To reverse the
+elements in place, look at vec.ReverseInPlace().
+*/
+func Reverse(v []float64) []float64 {
+	c := Clone(v)
+	ReverseInPlace(c)
+	return c
+}
+
+/*
+ReverseTo stores the result of vec.Reverse(v) in dst, and returns dst,
+without allocating. dst must have the length of v, and may be v itself, but
+must not otherwise overlap it.
+*/
+func ReverseTo(dst, v []float64) []float64 {
+	checkDst("ReverseTo()", dst, v)
+	copy(dst, v)
+	ReverseInPlace(dst)
+	return dst
+}
+
+/*
+ReverseInPlace reverses the order of the elements of the passed []float64.
+The passed []float64 is mutated in this function.
+*/
+func ReverseInPlace(v []float64) {
+	for i, j := 0, len(v)-1; i < j; i, j = i+1, j-1 {
+		v[i], v[j] = v[j], v[i]
+	}
+}
+
+/*
+Roll returns a copy of the passed []float64 with its elements circularly
+shifted by k positions. Elements that are shifted beyond the last position
+are re-introduced at the first. For example:
+
+	v := []float64{1.0, 2.0, 3.0, 4.0}
+	w := vec.Roll(v, 1)  // w is {4.0, 1.0, 2.0, 3.0}
+	w = vec.Roll(v, -1)  // w is {2.0, 3.0, 4.0, 1.0}
+
+A positive k shifts the elements towards higher indices, and a negative k
+towards lower indices. k may be larger than the length of the []float64. The
+original []float64 is not modified in this function. To roll the elements
+in place, look at vec.RollInPlace().
+*/
+func Roll(v []float64, k int) []float64 {
+	c := Clone(v)
+	RollInPlace(c, k)
+	return c
+}
+
+/*
+RollTo stores the result of vec.Roll(v, k) in dst, and returns dst, without
+allocating. dst must have the length of v, and may be v itself, but must not
+otherwise overlap it.
+*/
+func RollTo(dst, v []float64, k int) []float64 {
+	checkDst("RollTo()", dst, v)
+	copy(dst, v)
+	RollInPlace(dst, k)
+	return dst
+}
+
+/*
+RollInPlace circularly shifts the elements of the passed []float64 by k
+positions, without allocating. See vec.Roll() for details. The passed
+[]float64 is mutated in this function.
+*/
+func RollInPlace(v []float64, k int) {
+	n := len(v)
+	if n == 0 {
+		return
+	}
+	k %= n
+	if k < 0 {
+		k += n
+	}
+	if k == 0 {
+		return
+	}
+	ReverseInPlace(v)
+	ReverseInPlace(v[:k])
+	ReverseInPlace(v[k:])
+}
+
+/*
+Repeat returns a new []float64 where each element of the passed []float64 is
+repeated n times in a row. For example:
+
+	v := []float64{1.0, 2.0}
+	w := vec.Repeat(v, 3) // w is {1.0, 1.0, 1.0, 2.0, 2.0, 2.0}
+
+n must not be negative, otherwise this function will panic. The original
+[]float64 is not modified in this function.
+*/
+func Repeat(v []float64, n int) []float64 {
+	if n < 0 {
+		panic(fmt.Sprintf(errStrings[13], "Repeat()", n))
+	}
+	c := alloc(len(v) * n)[:0]
+	for i := range v {
+		for j := 0; j < n; j++ {
+			c = append(c, v[i])
+		}
+	}
+	return c
+}
+
+/*
+Tile returns a new []float64 made of n copies of the passed []float64, placed
+one after the other. For example:
+
+	v := []float64{1.0, 2.0}
+	w := vec.Tile(v, 3) // w is {1.0, 2.0, 1.0, 2.0, 1.0, 2.0}
+
+n must not be negative, otherwise this function will panic. The original
+[]float64 is not modified in this function.
+*/
+func Tile(v []float64, n int) []float64 {
+	if n < 0 {
+		panic(fmt.Sprintf(errStrings[13], "Tile()", n))
+	}
+	c := alloc(len(v) * n)[:0]
+	for j := 0; j < n; j++ {
+		c = append(c, v...)
+	}
+	return c
+}
+
+/*
+Concat returns a new []float64 made of all the passed []float64s, placed one
+after the other. For example:
+
+	v := []float64{1.0, 2.0}
+	w := []float64{3.0}
+	c := vec.Concat(v, w, v) // c is {1.0, 2.0, 3.0, 1.0, 2.0}
+
+The passed []float64s are not modified in this function.
+*/
+func Concat(vs ...[]float64) []float64 {
+	n := 0
+	for i := range vs {
+		n += len(vs[i])
+	}
+	c := alloc(n)[:0]
+	for i := range vs {
+		c = append(c, vs[i]...)
+	}
+	return c
+}
+
+/*
+Split divides the passed []float64 into n parts whose lengths differ by at
+most one, returning copies of each part. When the length of the []float64 is
+not divisible by n, the first parts are one element longer. For example:
+
+	v := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
+	p := vec.Split(v, 2) // p is {{1.0, 2.0, 3.0}, {4.0, 5.0}}
+
+If n is larger than the length of the []float64, the trailing parts are
+empty. n must be greater than 0, otherwise this function will panic. The
+original []float64 is not modified in this function.
+*/
+func Split(v []float64, n int) [][]float64 {
+	if n <= 0 {
+		panic(fmt.Sprintf(errStrings[15], "Split()", "number of parts", n))
+	}
+	parts := make([][]float64, n)
+	size, extra := len(v)/n, len(v)%n
+	start := 0
+	for i := range parts {
+		end := start + size
+		if i < extra {
+			end++
+		}
+		parts[i] = Clone(v[start:end])
+		start = end
+	}
+	return parts
+}
+
+/*
+Chunk divides the passed []float64 into consecutive chunks of the passed
+size, returning copies of each chunk. If the length of the []float64 is not
+divisible by size, the last chunk holds the remaining elements. For example:
+
+	v := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
+	c := vec.Chunk(v, 2) // c is {{1.0, 2.0}, {3.0, 4.0}, {5.0}}
+
+size must be greater than 0, otherwise this function will panic. The original
+[]float64 is not modified in this function.
+*/
+func Chunk(v []float64, size int) [][]float64 {
+	if size <= 0 {
+		panic(fmt.Sprintf(errStrings[15], "Chunk()", "chunk size", size))
+	}
+	chunks := make([][]float64, 0, (len(v)+size-1)/size)
+	for start := 0; start < len(v); start += size {
+		end := start + size
+		if end > len(v) {
+			end = len(v)
+		}
+		chunks = append(chunks, Clone(v[start:end]))
+	}
+	return chunks
+}
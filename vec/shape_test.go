@@ -0,0 +1,172 @@
+package vec
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestReverse(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0}
+	w := Reverse(v)
+	if !Equal(w, []float64{3.0, 2.0, 1.0}) {
+		t.Errorf("expected {3.0, 2.0, 1.0}, got %v", w)
+	}
+	if v[0] != 1.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	v = []float64{1.0, 2.0, 3.0, 4.0}
+	ReverseInPlace(v)
+	if !Equal(v, []float64{4.0, 3.0, 2.0, 1.0}) {
+		t.Errorf("expected {4.0, 3.0, 2.0, 1.0}, got %v", v)
+	}
+	ReverseInPlace([]float64{})
+}
+
+func TestRoll(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0, 4.0}
+	tests := []struct {
+		k        int
+		expected []float64
+	}{
+		{0, []float64{1.0, 2.0, 3.0, 4.0}},
+		{1, []float64{4.0, 1.0, 2.0, 3.0}},
+		{-1, []float64{2.0, 3.0, 4.0, 1.0}},
+		{6, []float64{3.0, 4.0, 1.0, 2.0}},
+		{-8, []float64{1.0, 2.0, 3.0, 4.0}},
+	}
+	for _, test := range tests {
+		w := Roll(v, test.k)
+		if !Equal(w, test.expected) {
+			t.Errorf("for k = %d, expected %v, got %v", test.k, test.expected, w)
+		}
+	}
+	RollInPlace(v, 2)
+	if !Equal(v, []float64{3.0, 4.0, 1.0, 2.0}) {
+		t.Errorf("expected {3.0, 4.0, 1.0, 2.0}, got %v", v)
+	}
+	if w := Roll([]float64{}, 3); len(w) != 0 {
+		t.Errorf("expected empty []float64, got %v", w)
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	v := []float64{1.0, 2.0}
+	w := Repeat(v, 3)
+	if !Equal(w, []float64{1.0, 1.0, 1.0, 2.0, 2.0, 2.0}) {
+		t.Errorf("expected {1.0, 1.0, 1.0, 2.0, 2.0, 2.0}, got %v", w)
+	}
+	if w = Repeat(v, 0); len(w) != 0 {
+		t.Errorf("expected empty []float64, got %v", w)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[13], "Repeat()", -1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Repeat(v, -1)
+	}()
+	wg.Wait()
+}
+
+func TestTile(t *testing.T) {
+	v := []float64{1.0, 2.0}
+	w := Tile(v, 3)
+	if !Equal(w, []float64{1.0, 2.0, 1.0, 2.0, 1.0, 2.0}) {
+		t.Errorf("expected {1.0, 2.0, 1.0, 2.0, 1.0, 2.0}, got %v", w)
+	}
+	w[0] = 10.0
+	if v[0] != 1.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[13], "Tile()", -2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Tile(v, -2)
+	}()
+	wg.Wait()
+}
+
+func TestConcat(t *testing.T) {
+	v := []float64{1.0, 2.0}
+	w := []float64{3.0}
+	c := Concat(v, w, v)
+	if !Equal(c, []float64{1.0, 2.0, 3.0, 1.0, 2.0}) {
+		t.Errorf("expected {1.0, 2.0, 3.0, 1.0, 2.0}, got %v", c)
+	}
+	if c = Concat(); len(c) != 0 {
+		t.Errorf("expected empty []float64, got %v", c)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
+	p := Split(v, 2)
+	if len(p) != 2 || !Equal(p[0], []float64{1.0, 2.0, 3.0}) || !Equal(p[1], []float64{4.0, 5.0}) {
+		t.Errorf("expected {{1.0, 2.0, 3.0}, {4.0, 5.0}}, got %v", p)
+	}
+	p = Split(v, 7)
+	if len(p) != 7 || len(p[4]) != 1 || len(p[5]) != 0 {
+		t.Errorf("expected 5 parts of length 1 and 2 empty parts, got %v", p)
+	}
+	p[0][0] = 10.0
+	if v[0] != 1.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[15], "Split()", "number of parts", 0)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Split(v, 0)
+	}()
+	wg.Wait()
+}
+
+func TestChunk(t *testing.T) {
+	v := []float64{1.0, 2.0, 3.0, 4.0, 5.0}
+	c := Chunk(v, 2)
+	if len(c) != 3 || !Equal(c[0], []float64{1.0, 2.0}) || !Equal(c[2], []float64{5.0}) {
+		t.Errorf("expected {{1.0, 2.0}, {3.0, 4.0}, {5.0}}, got %v", c)
+	}
+	if c = Chunk(v, 5); len(c) != 1 || !Equal(c[0], v) {
+		t.Errorf("expected a single chunk, got %v", c)
+	}
+	if c = Chunk([]float64{}, 3); len(c) != 0 {
+		t.Errorf("expected no chunks, got %v", c)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[15], "Chunk()", "chunk size", -1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Chunk(v, -1)
+	}()
+	wg.Wait()
+}
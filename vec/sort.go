@@ -0,0 +1,156 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+SortBy sorts keys in ascending order, in place, and applies the same
+permutation to each of the passed values, which can be []float64s or []ints,
+so that the elements of companion slices, such as the x and y coordinates of
+points, stay aligned with their keys. For example:
+
+	x := []float64{3.0, 1.0, 2.0}
+	y := []float64{30.0, 10.0, 20.0}
+	labels := []int{2, 0, 1}
+	order := vec.SortBy(x, y, labels)
+	// x is {1.0, 2.0, 3.0}, y is {10.0, 20.0, 30.0}, labels is {0, 1, 2},
+	// and order is {1, 2, 0}
+
+The returned []int holds, for each sorted position, the index the key had
+before sorting, so that other slices can be put in the same order with
+vec.Take(). The sort is stable, keeping equal keys in their original order,
+and NaN keys are placed after all others. Each of the values must have the
+length of keys, and be a []float64 or a []int, otherwise this function will
+panic without modifying any of its arguments.
+*/
+func SortBy(keys []float64, values ...interface{}) []int {
+	for i, val := range values {
+		n := 0
+		switch w := val.(type) {
+		case []float64:
+			n = len(w)
+		case []int:
+			n = len(w)
+		default:
+			panic(fmt.Sprintf(errStrings[21], "SortBy()", i+2, w))
+		}
+		if n != len(keys) {
+			panic(fmt.Sprintf(errStrings[5], "SortBy()", len(keys), n))
+		}
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return less(keys[order[a]], keys[order[b]])
+	})
+	permute(keys, order)
+	for _, val := range values {
+		switch w := val.(type) {
+		case []float64:
+			permute(w, order)
+		case []int:
+			permute(w, order)
+		}
+	}
+	return order
+}
+
+// permute reorders s in place so that its i-th element is the element that
+// was at order[i].
+func permute[T float64 | int](s []T, order []int) {
+	c := make([]T, len(s))
+	for i, k := range order {
+		c[i] = s[k]
+	}
+	copy(s, c)
+}
+
+/*
+TopK returns the k largest elements of v, from the largest down, along with
+their indices in v, without sorting v. The elements are kept in a heap of k
+elements, so that the cost is O(n log k) rather than the O(n log n) of a
+full sort, which matters when k is much smaller than the length of v. For
+example:
+
+	v := []float64{4.0, 9.0, 1.0, 7.0, 9.0}
+	vals, idx := vec.TopK(v, 3) // vals is {9.0, 9.0, 7.0}, idx is {1, 4, 3}
+
+Equal elements are ordered by their index, and NaNs are ignored, so that
+fewer than k elements are returned if v has fewer than k elements which are
+not NaN. k must not be negative, otherwise this function will panic. v is not
+modified in this function.
+*/
+func TopK(v []float64, k int) ([]float64, []int) {
+	return selectK("TopK()", v, k, func(i, j int) bool {
+		return v[i] > v[j] || (v[i] == v[j] && i < j)
+	})
+}
+
+/*
+BottomK returns the k smallest elements of v, from the smallest up, along
+with their indices in v, as vec.TopK() does for the largest ones. For
+example:
+
+	v := []float64{4.0, 9.0, 1.0, 7.0, 9.0}
+	vals, idx := vec.BottomK(v, 2) // vals is {1.0, 4.0}, idx is {2, 0}
+
+k must not be negative, otherwise this function will panic. v is not
+modified in this function.
+*/
+func BottomK(v []float64, k int) ([]float64, []int) {
+	return selectK("BottomK()", v, k, func(i, j int) bool {
+		return v[i] < v[j] || (v[i] == v[j] && i < j)
+	})
+}
+
+// selectK returns the k elements of v that come first in the order of
+// before, which compares the elements at two indices, and their indices.
+// The indices are kept in a heap with the last of them at the root, so that
+// each element of v is compared with the root, and replaces it if it comes
+// before it.
+func selectK(fn string, v []float64, k int, before func(i, j int) bool) ([]float64, []int) {
+	if k < 0 {
+		panic(fmt.Sprintf(errStrings[13], fn, k))
+	}
+	h := make([]int, 0, min(k, len(v)))
+	down := func(p int) {
+		for {
+			c := 2*p + 1
+			if c >= len(h) {
+				return
+			}
+			if c+1 < len(h) && before(h[c], h[c+1]) {
+				c++
+			}
+			if !before(h[p], h[c]) {
+				return
+			}
+			h[p], h[c] = h[c], h[p]
+			p = c
+		}
+	}
+	for i, x := range v {
+		switch {
+		case math.IsNaN(x) || k == 0:
+		case len(h) < k:
+			h = append(h, i)
+			for c := len(h) - 1; c > 0 && before(h[(c-1)/2], h[c]); c = (c - 1) / 2 {
+				h[c], h[(c-1)/2] = h[(c-1)/2], h[c]
+			}
+		case before(i, h[0]):
+			h[0] = i
+			down(0)
+		}
+	}
+	sort.Slice(h, func(a, b int) bool { return before(h[a], h[b]) })
+	vals := alloc(len(h))
+	for i, j := range h {
+		vals[i] = v[j]
+	}
+	return vals, h
+}
@@ -0,0 +1,120 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSortBy(t *testing.T) {
+	x := []float64{3.0, 1.0, math.NaN(), 2.0, 1.0}
+	y := []float64{30.0, 10.0, 0.0, 20.0, 11.0}
+	labels := []int{3, 1, 0, 2, 4}
+	order := SortBy(x, y, labels)
+	if !Equal(x[:4], []float64{1.0, 1.0, 2.0, 3.0}) || !math.IsNaN(x[4]) {
+		t.Errorf("expected {1.0, 1.0, 2.0, 3.0, NaN}, got %v", x)
+	}
+	// Equal keys keep their order.
+	if !Equal(y, []float64{10.0, 11.0, 20.0, 30.0, 0.0}) {
+		t.Errorf("expected {10.0, 11.0, 20.0, 30.0, 0.0}, got %v", y)
+	}
+	wantLabels, wantOrder := []int{1, 4, 2, 3, 0}, []int{1, 4, 3, 0, 2}
+	for i := range wantOrder {
+		if labels[i] != wantLabels[i] || order[i] != wantOrder[i] {
+			t.Errorf("at index %d, expected label %d and order %d, got %d and %d", i, wantLabels[i], wantOrder[i], labels[i], order[i])
+		}
+	}
+	if order := SortBy(nil); len(order) != 0 {
+		t.Errorf("expected an empty order, got %v", order)
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{
+			func() { SortBy(x, y, []float64{1.0}) },
+			fmt.Sprintf(errStrings[5], "SortBy()", 5, 1),
+		},
+		{
+			func() { SortBy(x, y, []string{"a"}) },
+			fmt.Sprintf(errStrings[21], "SortBy()", 3, []string{"a"}),
+		},
+	}
+	keys := Clone(y)
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+	if !Equal(y, keys) {
+		t.Errorf("the values were modified by a call that panicked")
+	}
+}
+
+func TestTopK(t *testing.T) {
+	v := []float64{4.0, 9.0, math.NaN(), 1.0, 7.0, 9.0}
+	vals, idx := TopK(v, 3)
+	if !Equal(vals, []float64{9.0, 9.0, 7.0}) {
+		t.Errorf("expected {9.0, 9.0, 7.0}, got %v", vals)
+	}
+	for i, j := range []int{1, 5, 4} {
+		if idx[i] != j {
+			t.Errorf("at index %d, expected %d, got %d", i, j, idx[i])
+		}
+	}
+	vals, idx = BottomK(v, 2)
+	if !Equal(vals, []float64{1.0, 4.0}) || idx[0] != 3 || idx[1] != 0 {
+		t.Errorf("expected {1.0, 4.0} at {3, 0}, got %v at %v", vals, idx)
+	}
+	// The NaN is never selected.
+	if vals, _ := TopK(v, 10); len(vals) != 5 || vals[4] != 1.0 {
+		t.Errorf("expected the 5 elements which are not NaN, got %v", vals)
+	}
+	if vals, idx := BottomK(v, 0); len(vals) != 0 || len(idx) != 0 {
+		t.Errorf("expected no elements, got %v and %v", vals, idx)
+	}
+	// The selection agrees with a full sort.
+	w := make([]float64, 1000)
+	for i := range w {
+		w[i] = math.Sin(float64(i) * 7.3)
+	}
+	sorted := Clone(w)
+	order := SortBy(sorted)
+	vals, idx = BottomK(w, 25)
+	if !Equal(vals, sorted[:25]) {
+		t.Errorf("expected %v, got %v", sorted[:25], vals)
+	}
+	for i := range idx {
+		if idx[i] != order[i] {
+			t.Errorf("at index %d, expected %d, got %d", i, order[i], idx[i])
+		}
+	}
+	if !math.IsNaN(v[2]) || v[0] != 4.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expected := fmt.Sprintf(errStrings[13], "TopK()", -1)
+			if r != expected {
+				t.Errorf("Expected %s, got %v", expected, r)
+			}
+			wg.Done()
+		}()
+		TopK(v, -1)
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,232 @@
+package vec
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+Sparse is a vector of float64 most of whose elements are 0.0, such as a
+feature vector over a large vocabulary, which stores only the indices and
+values of its other elements. The memory of a Sparse, and the time taken by
+its operations, are proportional to the number of stored elements, rather
+than to the length of the vector.
+
+A Sparse is created from its indices and values with vec.NewSparse(), or
+from a dense []float64 with vec.Gather(). It is never modified by its
+methods.
+*/
+type Sparse struct {
+	n int
+	// index holds the strictly increasing indices of the stored elements,
+	// and value their values.
+	index []int
+	value []float64
+}
+
+/*
+NewSparse returns the Sparse of length n whose elements at the passed
+indices have the passed values, and whose other elements are 0.0. For
+example:
+
+	s := vec.NewSparse(6, []int{1, 4}, []float64{2.0, -1.0})
+	d := s.Dense() // d is {0.0, 2.0, 0.0, 0.0, -1.0, 0.0}
+
+n must be greater than 0, the indices and values must have the same length,
+and the indices must be strictly increasing and in [0, n), otherwise this
+function will panic. The indices and values are copied.
+*/
+func NewSparse(n int, index []int, value []float64) Sparse {
+	if n <= 0 {
+		panic(fmt.Sprintf(errStrings[15], "NewSparse()", "length", n))
+	}
+	if len(index) != len(value) {
+		panic(fmt.Sprintf(errStrings[5], "NewSparse()", len(index), len(value)))
+	}
+	for i, k := range index {
+		if k < 0 || k >= n {
+			panic(fmt.Sprintf(errStrings[1], "NewSparse()", k, n))
+		}
+		if i > 0 && k <= index[i-1] {
+			panic(fmt.Sprintf(errStrings[17], "NewSparse()", i))
+		}
+	}
+	s := Sparse{n: n, index: make([]int, len(index)), value: make([]float64, len(value))}
+	copy(s.index, index)
+	copy(s.value, value)
+	return s
+}
+
+/*
+Gather returns the Sparse holding the elements of a []float64 which are not
+0.0. For example:
+
+	s := vec.Gather([]float64{0.0, 3.0, 0.0, 1.0}) // s stores 3.0 at 1, and 1.0 at 3
+
+The passed []float64 must not be empty, otherwise this function will panic.
+It is not mutated in this function.
+*/
+func Gather(v []float64) Sparse {
+	if len(v) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "Gather()", "Gather()"))
+	}
+	s := Sparse{n: len(v)}
+	for i, x := range v {
+		if x != 0.0 {
+			s.index = append(s.index, i)
+			s.value = append(s.value, x)
+		}
+	}
+	return s
+}
+
+/*
+GatherAt returns the Sparse holding the elements of a []float64 at the
+passed indices, whether or not they are 0.0, and no others. This picks the
+elements of a dense vector which match the pattern of a Sparse:
+
+	g := vec.GatherAt(grad, s.Indices())
+
+The indices must be strictly increasing and in [0, len(v)), and v must not
+be empty, otherwise this function will panic. The passed arguments are not
+mutated in this function.
+*/
+func GatherAt(v []float64, index []int) Sparse {
+	if len(v) == 0 {
+		panic(fmt.Sprintf(errStrings[0], "GatherAt()", "GatherAt()"))
+	}
+	value := make([]float64, len(index))
+	for i, k := range index {
+		if k < 0 || k >= len(v) {
+			panic(fmt.Sprintf(errStrings[1], "GatherAt()", k, len(v)))
+		}
+		value[i] = v[k]
+	}
+	return NewSparse(len(v), index, value)
+}
+
+// Len returns the length of s, including the elements which are not stored.
+func (s Sparse) Len() int {
+	return s.n
+}
+
+// NNZ returns the number of stored elements of s.
+func (s Sparse) NNZ() int {
+	return len(s.index)
+}
+
+// Indices returns a copy of the indices of the stored elements of s, in
+// increasing order.
+func (s Sparse) Indices() []int {
+	index := make([]int, len(s.index))
+	copy(index, s.index)
+	return index
+}
+
+// Values returns a copy of the values of the stored elements of s, in the
+// order of their indices.
+func (s Sparse) Values() []float64 {
+	return Clone(s.value)
+}
+
+/*
+At returns the element of s at index i, which is 0.0 if it is not stored.
+The index is found by a binary search over the stored indices. The index
+must be in [0, s.Len()), otherwise this function will panic.
+*/
+func (s Sparse) At(i int) float64 {
+	if i < 0 || i >= s.n {
+		panic(fmt.Sprintf(errStrings[1], "Sparse.At()", i, s.n))
+	}
+	k := sort.SearchInts(s.index, i)
+	if k < len(s.index) && s.index[k] == i {
+		return s.value[k]
+	}
+	return 0.0
+}
+
+/*
+Dense returns s as a []float64 of length s.Len(), with zeros at the indices
+which are not stored.
+*/
+func (s Sparse) Dense() []float64 {
+	v := allocZeroed(s.n)
+	s.ScatterTo(v)
+	return v
+}
+
+/*
+ScatterTo sets the elements of a []float64 at the stored indices of s to
+their values in s, leaving its other elements intact, and returns the
+[]float64. The length of v must be s.Len(), otherwise this function will
+panic.
+*/
+func (s Sparse) ScatterTo(v []float64) []float64 {
+	s.checkLen("Sparse.ScatterTo()", v)
+	for k, i := range s.index {
+		v[i] = s.value[k]
+	}
+	return v
+}
+
+/*
+Dot returns the dot product of s and a dense []float64, reading only the
+elements of v at the stored indices of s. The length of v must be s.Len(),
+otherwise this function will panic. v is not mutated in this function.
+*/
+func (s Sparse) Dot(v []float64) float64 {
+	s.checkLen("Sparse.Dot()", v)
+	sum := 0.0
+	for k, i := range s.index {
+		sum += s.value[k] * v[i]
+	}
+	return sum
+}
+
+/*
+DotSparse returns the dot product of s and t, by merging their stored
+indices, in a time proportional to s.NNZ() + t.NNZ(). s and t must have the
+same length, otherwise this function will panic.
+*/
+func (s Sparse) DotSparse(t Sparse) float64 {
+	if s.n != t.n {
+		panic(fmt.Sprintf(errStrings[5], "Sparse.DotSparse()", s.n, t.n))
+	}
+	sum := 0.0
+	for a, b := 0, 0; a < len(s.index) && b < len(t.index); {
+		switch {
+		case s.index[a] < t.index[b]:
+			a++
+		case s.index[a] > t.index[b]:
+			b++
+		default:
+			sum += s.value[a] * t.value[b]
+			a++
+			b++
+		}
+	}
+	return sum
+}
+
+/*
+Axpy stores alpha*s + y in y, and returns y, only updating the elements of
+y at the stored indices of s. For example, to accumulate sparse gradients
+into a dense vector of weights:
+
+	g.Axpy(-rate, w)
+
+The length of y must be s.Len(), otherwise this function will panic.
+*/
+func (s Sparse) Axpy(alpha float64, y []float64) []float64 {
+	s.checkLen("Sparse.Axpy()", y)
+	for k, i := range s.index {
+		y[i] += alpha * s.value[k]
+	}
+	return y
+}
+
+func (s Sparse) checkLen(fn string, v []float64) {
+	if len(v) != s.n {
+		panic(fmt.Sprintf(errStrings[5], fn, s.n, len(v)))
+	}
+}
@@ -0,0 +1,155 @@
+package vec
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestNewSparse(t *testing.T) {
+	index := []int{1, 4}
+	value := []float64{2.0, -1.0}
+	s := NewSparse(6, index, value)
+	if s.Len() != 6 || s.NNZ() != 2 {
+		t.Errorf("expected a length of 6 with 2 stored elements, got %d and %d", s.Len(), s.NNZ())
+	}
+	if d := s.Dense(); !Equal(d, []float64{0.0, 2.0, 0.0, 0.0, -1.0, 0.0}) {
+		t.Errorf("expected {0.0, 2.0, 0.0, 0.0, -1.0, 0.0}, got %v", d)
+	}
+	for i, expected := range []float64{0.0, 2.0, 0.0, 0.0, -1.0, 0.0} {
+		if s.At(i) != expected {
+			t.Errorf("At(%d): expected %v, got %v", i, expected, s.At(i))
+		}
+	}
+	index[0], value[0] = 0, 5.0
+	if s.At(1) != 2.0 || s.At(0) != 0.0 {
+		t.Errorf("the Sparse shares its elements with the passed slices")
+	}
+	got := s.Indices()
+	got[0] = 3
+	if s.Indices()[0] != 1 || !Equal(s.Values(), []float64{2.0, -1.0}) {
+		t.Errorf("the Sparse shares its elements with the returned slices")
+	}
+	if e := NewSparse(3, nil, nil); e.NNZ() != 0 || !Equal(e.Dense(), []float64{0.0, 0.0, 0.0}) {
+		t.Errorf("expected a Sparse of zeros, got %v", e.Dense())
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { NewSparse(0, nil, nil) }, fmt.Sprintf(errStrings[15], "NewSparse()", "length", 0)},
+		{func() { NewSparse(3, []int{0}, nil) }, fmt.Sprintf(errStrings[5], "NewSparse()", 1, 0)},
+		{func() { NewSparse(3, []int{0, 3}, []float64{1.0, 1.0}) }, fmt.Sprintf(errStrings[1], "NewSparse()", 3, 3)},
+		{func() { NewSparse(3, []int{-1}, []float64{1.0}) }, fmt.Sprintf(errStrings[1], "NewSparse()", -1, 3)},
+		{func() { NewSparse(3, []int{1, 1}, []float64{1.0, 1.0}) }, fmt.Sprintf(errStrings[17], "NewSparse()", 1)},
+		{func() { s.At(6) }, fmt.Sprintf(errStrings[1], "Sparse.At()", 6, 6)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestGather(t *testing.T) {
+	v := []float64{0.0, 3.0, 0.0, 1.0}
+	s := Gather(v)
+	if s.Len() != 4 || s.NNZ() != 2 || s.At(1) != 3.0 || s.At(3) != 1.0 {
+		t.Errorf("expected 3.0 at 1 and 1.0 at 3, got %v", s.Dense())
+	}
+	if !Equal(s.Dense(), v) {
+		t.Errorf("expected %v, got %v", v, s.Dense())
+	}
+	g := GatherAt(v, []int{0, 1})
+	if g.NNZ() != 2 || !Equal(g.Values(), []float64{0.0, 3.0}) {
+		t.Errorf("expected the values {0.0, 3.0}, got %v", g.Values())
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { Gather(nil) }, fmt.Sprintf(errStrings[0], "Gather()", "Gather()")},
+		{func() { GatherAt(nil, nil) }, fmt.Sprintf(errStrings[0], "GatherAt()", "GatherAt()")},
+		{func() { GatherAt(v, []int{4}) }, fmt.Sprintf(errStrings[1], "GatherAt()", 4, 4)},
+		{func() { GatherAt(v, []int{2, 0}) }, fmt.Sprintf(errStrings[17], "NewSparse()", 1)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestSparseKernels(t *testing.T) {
+	s := NewSparse(5, []int{0, 2, 4}, []float64{1.0, 2.0, 3.0})
+	v := []float64{5.0, 7.0, -1.0, 9.0, 2.0}
+	if d := s.Dot(v); d != 9.0 {
+		t.Errorf("Dot(): expected 9.0, got %v", d)
+	}
+	u := NewSparse(5, []int{1, 2, 3, 4}, []float64{4.0, 1.0, 6.0, -2.0})
+	if d := s.DotSparse(u); d != -4.0 {
+		t.Errorf("DotSparse(): expected -4.0, got %v", d)
+	}
+	if d := u.DotSparse(s); d != -4.0 {
+		t.Errorf("DotSparse(): expected -4.0, got %v", d)
+	}
+	if d := s.DotSparse(u); d != Dot(s.Dense(), u.Dense()) {
+		t.Errorf("DotSparse(): expected the dense dot product, got %v", d)
+	}
+	y := Clone(v)
+	if w := s.Axpy(2.0, y); &w[0] != &y[0] {
+		t.Errorf("Axpy() did not return the passed []float64")
+	}
+	if !Equal(y, []float64{7.0, 7.0, 3.0, 9.0, 8.0}) {
+		t.Errorf("Axpy(): expected {7.0, 7.0, 3.0, 9.0, 8.0}, got %v", y)
+	}
+	y = Clone(v)
+	s.ScatterTo(y)
+	if !Equal(y, []float64{1.0, 7.0, 2.0, 9.0, 3.0}) {
+		t.Errorf("ScatterTo(): expected {1.0, 7.0, 2.0, 9.0, 3.0}, got %v", y)
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { s.Dot(v[:4]) }, fmt.Sprintf(errStrings[5], "Sparse.Dot()", 5, 4)},
+		{func() { s.Axpy(1.0, nil) }, fmt.Sprintf(errStrings[5], "Sparse.Axpy()", 5, 0)},
+		{func() { s.ScatterTo(make([]float64, 6)) }, fmt.Sprintf(errStrings[5], "Sparse.ScatterTo()", 5, 6)},
+		{func() { s.DotSparse(NewSparse(4, nil, nil)) }, fmt.Sprintf(errStrings[5], "Sparse.DotSparse()", 5, 4)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
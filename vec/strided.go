@@ -0,0 +1,111 @@
+package vec
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/NDari/gocrunch/internal/stride"
+)
+
+/*
+Strided is a view of every step-th element of a []float64, between a start
+and a stop, as with the v[start:stop:step] slices of numpy. It shares its
+elements with the []float64, so that setting an element of the view sets
+the element of the []float64, and the other way around, and no element is
+copied when the view is created.
+*/
+type Strided struct {
+	data []float64
+	// The element i of the view is data[off+i*step].
+	off, step, n int
+}
+
+/*
+Slice returns the Strided view of the elements of v from start, included,
+to stop, excluded, every step elements, with the semantics of numpy. A
+negative start or stop counts from the end of v, and bounds past either end
+of v are clamped to it. A negative step walks v backward, from start down
+to stop. For example:
+
+	v := []float64{0.0, 1.0, 2.0, 3.0, 4.0, 5.0}
+	vec.Slice(v, 1, 5, 2).Dense()   // {1.0, 3.0}
+	vec.Slice(v, -1, 0, -2).Dense() // {5.0, 3.0, 1.0}
+
+Since Go has no omitted bounds, math.MinInt and math.MaxInt stand for them,
+as the bounds past the start and the end of v, whichever the direction:
+
+	vec.Slice(v, math.MaxInt, math.MinInt, -1).Dense() // v reversed
+
+The step must not be 0, otherwise this function will panic. A view of no
+elements is returned if the range is empty.
+*/
+func Slice(v []float64, start, stop, step int) Strided {
+	if step == 0 {
+		panic(fmt.Sprintf(errStrings[18], "Slice()"))
+	}
+	off, n := stride.Span(len(v), start, stop, step)
+	return Strided{data: v, off: off, step: step, n: n}
+}
+
+// Len returns the number of elements of s.
+func (s Strided) Len() int {
+	return s.n
+}
+
+/*
+At returns the element i of s. The index must be in [0, s.Len()), otherwise
+this function will panic.
+*/
+func (s Strided) At(i int) float64 {
+	if i < 0 || i >= s.n {
+		panic(fmt.Sprintf(errStrings[1], "Strided.At()", i, s.n))
+	}
+	return s.data[s.off+i*s.step]
+}
+
+/*
+Set sets the element i of s, and so the element of the underlying
+[]float64, to x. The index must be in [0, s.Len()), otherwise this function
+will panic.
+*/
+func (s Strided) Set(i int, x float64) {
+	if i < 0 || i >= s.n {
+		panic(fmt.Sprintf(errStrings[1], "Strided.Set()", i, s.n))
+	}
+	s.data[s.off+i*s.step] = x
+}
+
+/*
+Slice returns the view of the elements of s picked by start, stop and step,
+as with vec.Slice() on the elements of s, which is again a Strided view of
+the underlying []float64. The step must not be 0, otherwise this function
+will panic.
+*/
+func (s Strided) Slice(start, stop, step int) Strided {
+	if step == 0 {
+		panic(fmt.Sprintf(errStrings[18], "Strided.Slice()"))
+	}
+	off, n := stride.Span(s.n, start, stop, step)
+	return Strided{data: s.data, off: s.off + off*s.step, step: s.step * step, n: n}
+}
+
+// Dense returns a copy of the elements of s, in a new []float64.
+func (s Strided) Dense() []float64 {
+	v := alloc(s.n)
+	for i := range v {
+		v[i] = s.data[s.off+i*s.step]
+	}
+	return v
+}
+
+// Values returns an iterator over the elements of s, as vec.Values() does
+// for a []float64.
+func (s Strided) Values() iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for i := 0; i < s.n; i++ {
+			if !yield(s.data[s.off+i*s.step]) {
+				return
+			}
+		}
+	}
+}
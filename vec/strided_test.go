@@ -0,0 +1,74 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	v := []float64{0.0, 1.0, 2.0, 3.0, 4.0, 5.0}
+	tests := []struct {
+		start, stop, step int
+		expected          []float64
+	}{
+		{1, 5, 2, []float64{1.0, 3.0}},
+		{1, 6, 2, []float64{1.0, 3.0, 5.0}},
+		{-1, 0, -2, []float64{5.0, 3.0, 1.0}},
+		{math.MaxInt, math.MinInt, -1, []float64{5.0, 4.0, 3.0, 2.0, 1.0, 0.0}},
+		{math.MinInt, math.MaxInt, 4, []float64{0.0, 4.0}},
+		{-2, 100, 1, []float64{4.0, 5.0}},
+		{4, 1, 1, []float64{}},
+		{1, 4, -1, []float64{}},
+		{-10, math.MinInt, -1, []float64{}},
+		{2, -7, -1, []float64{2.0, 1.0, 0.0}},
+		{0, 3, math.MaxInt, []float64{0.0}},
+		{5, math.MinInt, math.MinInt, []float64{5.0}},
+	}
+	for _, test := range tests {
+		s := Slice(v, test.start, test.stop, test.step)
+		if d := s.Dense(); !Equal(d, test.expected) || s.Len() != len(test.expected) {
+			t.Errorf("Slice(v, %d, %d, %d): expected %v, got %v", test.start, test.stop, test.step, test.expected, d)
+		}
+	}
+	s := Slice(v, 5, math.MinInt, -2)
+	s.Set(1, 30.0)
+	if v[3] != 30.0 || s.At(1) != 30.0 {
+		t.Errorf("expected the view to share the elements of v")
+	}
+	if r := s.Slice(math.MaxInt, math.MinInt, -1); !Equal(r.Dense(), []float64{1.0, 30.0, 5.0}) {
+		t.Errorf("expected {1.0, 30.0, 5.0}, got %v", r.Dense())
+	}
+	if r := s.Slice(1, 3, 1); !Equal(slices.Collect(r.Values()), []float64{30.0, 1.0}) {
+		t.Errorf("expected {30.0, 1.0}, got %v", r.Dense())
+	}
+	if e := Slice(nil, math.MaxInt, math.MinInt, -1); e.Len() != 0 {
+		t.Errorf("expected an empty view, got %v", e.Dense())
+	}
+	panics := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { Slice(v, 0, 1, 0) }, fmt.Sprintf(errStrings[18], "Slice()")},
+		{func() { s.Slice(0, 1, 0) }, fmt.Sprintf(errStrings[18], "Strided.Slice()")},
+		{func() { s.At(3) }, fmt.Sprintf(errStrings[1], "Strided.At()", 3, 3)},
+		{func() { s.Set(-1, 0.0) }, fmt.Sprintf(errStrings[1], "Strided.Set()", -1, 3)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range panics {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,97 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Sin returns a new []float64 whose elements are the sine of the elements of
+the passed []float64, which are taken to be in radians. For example:
+
+	v := []float64{0.0, math.Pi / 2.0}
+	s := vec.Sin(v) // s is {0.0, 1.0}
+
+The original []float64 is not modified in this function.
+*/
+func Sin(v []float64) []float64 {
+	return Foreach(v, math.Sin)
+}
+
+/*
+Cos returns a new []float64 whose elements are the cosine of the elements of
+the passed []float64, which are taken to be in radians. For example:
+
+	v := []float64{0.0, math.Pi}
+	c := vec.Cos(v) // c is {1.0, -1.0}
+
+The original []float64 is not modified in this function.
+*/
+func Cos(v []float64) []float64 {
+	return Foreach(v, math.Cos)
+}
+
+/*
+Tan returns a new []float64 whose elements are the tangent of the elements
+of the passed []float64, which are taken to be in radians.
+
+The original []float64 is not modified in this function.
+*/
+func Tan(v []float64) []float64 {
+	return Foreach(v, math.Tan)
+}
+
+/*
+Asin returns a new []float64 whose elements are the arcsine, in radians, of
+the elements of the passed []float64. Elements outside of [-1, 1] result in
+NaN, as per math.Asin.
+
+The original []float64 is not modified in this function.
+*/
+func Asin(v []float64) []float64 {
+	return Foreach(v, math.Asin)
+}
+
+/*
+Acos returns a new []float64 whose elements are the arccosine, in radians,
+of the elements of the passed []float64. Elements outside of [-1, 1] result
+in NaN, as per math.Acos.
+
+The original []float64 is not modified in this function.
+*/
+func Acos(v []float64) []float64 {
+	return Foreach(v, math.Acos)
+}
+
+/*
+Atan returns a new []float64 whose elements are the arctangent, in radians,
+of the elements of the passed []float64.
+
+The original []float64 is not modified in this function.
+*/
+func Atan(v []float64) []float64 {
+	return Foreach(v, math.Atan)
+}
+
+/*
+Atan2 returns a new []float64 whose elements are the arctangent of y[i]/x[i],
+using the signs of the two to determine the quadrant of the result. This is
+useful when converting from cartesian to polar coordinates. For example:
+
+	y := []float64{1.0, -1.0}
+	x := []float64{0.0, 0.0}
+	a := vec.Atan2(y, x) // a is {Pi/2, -Pi/2}
+
+The passed []float64s must be of equal length, otherwise this function will
+panic. The original []float64s are not modified in this function.
+*/
+func Atan2(y, x []float64) []float64 {
+	if len(y) != len(x) {
+		panic(fmt.Sprintf(errStrings[5], "Atan2()", len(y), len(x)))
+	}
+	c := alloc(len(y))
+	for i := range y {
+		c[i] = math.Atan2(y[i], x[i])
+	}
+	return c
+}
@@ -0,0 +1,89 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSin(t *testing.T) {
+	v := []float64{0.0, math.Pi / 2.0, -math.Pi / 2.0}
+	s := Sin(v)
+	expected := []float64{0.0, 1.0, -1.0}
+	for i := range s {
+		if math.Abs(s[i]-expected[i]) > 1e-12 {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], s[i])
+		}
+	}
+	if v[1] != math.Pi/2.0 {
+		t.Errorf("the original []float64 was modified")
+	}
+}
+
+func TestCos(t *testing.T) {
+	v := []float64{0.0, math.Pi}
+	c := Cos(v)
+	expected := []float64{1.0, -1.0}
+	for i := range c {
+		if math.Abs(c[i]-expected[i]) > 1e-12 {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], c[i])
+		}
+	}
+}
+
+func TestTan(t *testing.T) {
+	v := []float64{0.0, math.Pi / 4.0}
+	c := Tan(v)
+	expected := []float64{0.0, 1.0}
+	for i := range c {
+		if math.Abs(c[i]-expected[i]) > 1e-12 {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], c[i])
+		}
+	}
+}
+
+func TestInverseTrig(t *testing.T) {
+	v := []float64{-1.0, 0.0, 0.5, 1.0}
+	as, ac, at := Asin(v), Acos(v), Atan(v)
+	for i := range v {
+		if math.Abs(math.Sin(as[i])-v[i]) > 1e-12 {
+			t.Errorf("Asin: at index %d, sin(%f) is not %f", i, as[i], v[i])
+		}
+		if math.Abs(math.Cos(ac[i])-v[i]) > 1e-12 {
+			t.Errorf("Acos: at index %d, cos(%f) is not %f", i, ac[i], v[i])
+		}
+		if math.Abs(math.Tan(at[i])-v[i]) > 1e-12 {
+			t.Errorf("Atan: at index %d, tan(%f) is not %f", i, at[i], v[i])
+		}
+	}
+	if !math.IsNaN(Asin([]float64{2.0})[0]) {
+		t.Errorf("expected NaN for Asin(2.0)")
+	}
+}
+
+func TestAtan2(t *testing.T) {
+	y := []float64{1.0, -1.0, 0.0}
+	x := []float64{0.0, 0.0, -1.0}
+	a := Atan2(y, x)
+	expected := []float64{math.Pi / 2.0, -math.Pi / 2.0, math.Pi}
+	for i := range a {
+		if math.Abs(a[i]-expected[i]) > 1e-12 {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], a[i])
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[5], "Atan2()", 3, 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Atan2(y, x[:2])
+	}()
+	wg.Wait()
+}
@@ -21,6 +21,22 @@ encountered is printed to the screen along with the reason for the panic,
 in addition to the full stack trace, in order to help fix any issues
 rapidly.
 
+For pipelines of operations, the Vector type wraps a []float64 with chainable
+methods which record the first error encountered instead of panicking. The
+geometry functions, such as vec.Cross(), vec.Angle() and vec.Project(), also
+return errors, wrapping ErrLength or ErrZeroVector, since their arguments are
+often computed at runtime.
+
+Most of the functions returning a new []float64 of the length of one of
+their arguments have a variant with the To suffix, such as vec.AddTo() and
+vec.CumSumTo(), which stores the result in a []float64 passed as its first
+argument, so that loops can reuse their buffers rather than allocate. The
+element-wise functions which apply a function of the math package, such as
+vec.Sin() and vec.Floor(), are covered by vec.ApplyTo(), and those of two
+[]float64s, such as vec.Hypot() and vec.Atan2(), by vec.ZipWithTo(). The
+functions whose result has another length, such as vec.Diff(),
+vec.Convolve(), vec.Filter() or vec.Concat(), have no such variant.
+
 As mentioned, all the functions in this library act on Go primitive types,
 which allows the code to be easily modified to serve in different situations.
 */
@@ -30,6 +46,8 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+
+	"github.com/NDari/gocrunch/backend"
 )
 
 var (
@@ -46,6 +64,17 @@ var (
 		"\ngocrunch/vec error.\nIn vec.%s, the length of slice %d is not divisible by the stride %d.\n",
 		"\ngocrunch/vec error.\nIn vec.%s, the first argument %f must be less than the second, %f.\n",
 		"\ngocrunch/vec error.\nIn vec.%s, expected 0 to 0 float64 arguments, but got %d.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, argument %d must be float64 or []float64, received %v.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, the count must not be negative, received %d.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, unknown mode %d.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, the %s must be greater than 0, received %d.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, the []float64 must have at least %d elements, but has %d.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, the values must be strictly increasing, but the element at index %d is not.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, the step cannot be 0.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, the call to vec.%s failed:\n%v\n",
+		"\ngocrunch/vec error.\nIn vec.%s, the %s must not be negative or NaN, received %v.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, argument %d must be []float64 or []int, received %T.\n",
+		"\ngocrunch/vec error.\nIn vec.%s, the quantile must be in [0, 1], received %v.\n",
 	}
 )
 
@@ -175,7 +204,7 @@ func To2D(v []float64, stride int) [][]float64 {
 	}
 	m := make([][]float64, len(v)/stride)
 	for i := range m {
-		m[i] = make([]float64, stride)
+		m[i] = alloc(stride)
 	}
 	idx := 0
 	for i := range m {
@@ -212,7 +241,7 @@ the range is [arg1, arg2). For this case, arg1 must be less than arg2, or
 the function will panic.
 */
 func Rand(x int, args ...float64) []float64 {
-	v := make([]float64, x)
+	v := alloc(x)
 	switch len(args) {
 	case 0:
 		for i := range v {
@@ -236,10 +265,11 @@ func Rand(x int, args ...float64) []float64 {
 Clone replicated the passed []slice. The returned slice is a copy of
 original, both in terms of the length and the value of the elements
 at each index. The returned copy is "deep", and manupilating it does
-not effect the original slice.
+not effect the original slice. The copy is taken from the Pool set with
+SetPool, if any.
 */
 func Clone(v []float64) []float64 {
-	c := make([]float64, len(v))
+	c := alloc(len(v))
 	copy(c, v)
 	return c
 }
@@ -260,6 +290,53 @@ func Equal(v, w []float64) bool {
 	return true
 }
 
+/*
+EqualULP checks if two []float64s are equal within maxUlps units in the last
+place, by checking that they have the same length, and that at most maxUlps
+float64s lie between the entries at each index. Unlike a fixed tolerance,
+this scales with the magnitude of the entries, so that it suits []float64s
+whose entries span many orders of magnitude. For example:
+
+	v := []float64{1.0, 1e-300}
+	w := []float64{math.Nextafter(1.0, 2.0), math.Nextafter(1e-300, 0.0)}
+	vec.EqualULP(v, w, 1) // true
+	vec.EqualULP(v, w, 0) // false, as with vec.Equal(v, w)
+
+0.0 and -0.0 are equal, while NaN is not equal to anything, including
+itself.
+*/
+func EqualULP(v, w []float64, maxUlps uint64) bool {
+	if len(v) != len(w) {
+		return false
+	}
+	for i := range v {
+		if v[i] == w[i] {
+			continue
+		}
+		if math.IsNaN(v[i]) || math.IsNaN(w[i]) {
+			return false
+		}
+		a, b := ordered(v[i]), ordered(w[i])
+		if a < b {
+			a, b = b, a
+		}
+		if a-b > maxUlps {
+			return false
+		}
+	}
+	return true
+}
+
+// ordered maps a float64 to a uint64, such that consecutive float64s map to
+// consecutive uint64s in the same order, and 0.0 and -0.0 map to the same one.
+func ordered(x float64) uint64 {
+	b := math.Float64bits(x)
+	if b>>63 == 1 {
+		return 1<<63 - b&^(1<<63)
+	}
+	return b | 1<<63
+}
+
 /*
 Set returns a copy of the passed []float64 where all of the elements are set to
 the passed float64 in the second argument.
@@ -346,6 +423,46 @@ func Any(v []float64, f func(float64) bool) bool {
 	return false
 }
 
+/*
+Count returns the number of elements of a []float64 for which a passed
+function returns true. Consider:
+
+	negative := func(i float64) bool {
+		return i < 0.0
+	}
+	v := []float64{-1.0, 2.0, -3.0}
+	n := vec.Count(v, negative) // 2
+
+To only check if any or all elements pass the function, look at vec.Any()
+and vec.All(), which stop at the first element that decides the result.
+*/
+func Count(v []float64, f func(float64) bool) int {
+	n := 0
+	for i := range v {
+		if f(v[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+/*
+CountNonzero returns the number of elements of a []float64 which are not
+0.0. NaN entries are counted as nonzero. Consider:
+
+	v := []float64{0.0, 2.0, -3.0, 0.0}
+	n := vec.CountNonzero(v) // 2
+*/
+func CountNonzero(v []float64) int {
+	n := 0
+	for i := range v {
+		if v[i] != 0.0 {
+			n++
+		}
+	}
+	return n
+}
+
 /*
 Sum adds all elements in a []float64. Consider:
 
@@ -415,6 +532,9 @@ In the case where the second argument is a []float64, the length of both
 arguments must be equal.
 */
 func Mul(v []float64, val interface{}) []float64 {
+	if err := arith("Mul()", v, val); err != nil {
+		raise(err)
+	}
 	c := Clone(v)
 	switch w := val.(type) {
 	case float64:
@@ -422,14 +542,9 @@ func Mul(v []float64, val interface{}) []float64 {
 			c[i] *= w
 		}
 	case []float64:
-		if len(v) != len(w) {
-			panic(fmt.Sprintf(errStrings[5], "Mul()", len(c), len(w)))
-		}
 		for i := range c {
 			c[i] *= w[i]
 		}
-	default:
-		panic(fmt.Sprintf(errStrings[6], "Mul()", w))
 	}
 	return c
 }
@@ -452,9 +567,13 @@ The second argument can also we a []float64, as below:
 
 The original arguments are not modified in this function.
 In the case where the second argument is a []float64, the length of both
-arguments must be equal.
+arguments must be equal, and the operation is computed by the current engine
+of the backend package.
 */
 func Add(v []float64, val interface{}) []float64 {
+	if err := arith("Add()", v, val); err != nil {
+		raise(err)
+	}
 	c := Clone(v)
 	switch w := val.(type) {
 	case float64:
@@ -462,14 +581,7 @@ func Add(v []float64, val interface{}) []float64 {
 			c[i] += w
 		}
 	case []float64:
-		if len(c) != len(w) {
-			panic(fmt.Sprintf(errStrings[5], "Add()", len(c), len(w)))
-		}
-		for i := range c {
-			c[i] += w[i]
-		}
-	default:
-		panic(fmt.Sprintf(errStrings[6], "Mul()", w))
+		backend.Axpy(1.0, w, c)
 	}
 	return c
 }
@@ -492,9 +604,13 @@ The second argument can also we a []float64, as below:
 
 The original arguments are not modified in this function.
 In the case where the second argument is a []float64, the length of both
-arguments must be equal.
+arguments must be equal, and the operation is computed by the current engine
+of the backend package.
 */
 func Sub(v []float64, val interface{}) []float64 {
+	if err := arith("Sub()", v, val); err != nil {
+		raise(err)
+	}
 	c := Clone(v)
 	switch w := val.(type) {
 	case float64:
@@ -502,14 +618,7 @@ func Sub(v []float64, val interface{}) []float64 {
 			c[i] -= w
 		}
 	case []float64:
-		if len(c) != len(w) {
-			panic(fmt.Sprintf(errStrings[5], "Sub()", len(c), len(w)))
-		}
-		for i := range c {
-			c[i] -= w[i]
-		}
-	default:
-		panic(fmt.Sprintf(errStrings[6], "Mul()", w))
+		backend.Axpy(-1.0, w, c)
 	}
 	return c
 }
@@ -536,47 +645,35 @@ not allowed.
 
 In the case where the second argument is a []float64, the length of both
 arguments must be equal. Additionally, the second argument must not contain
-any elements whose value is 0.0.
+any elements whose value is 0.0. To divide by zeros without panicking, use
+vec.DivWith().
 */
 func Div(v []float64, val interface{}) []float64 {
+	if err := quotient("Div()", v, val); err != nil {
+		raise(err)
+	}
 	c := Clone(v)
 	switch w := val.(type) {
 	case float64:
-		if w == 0.0 {
-			panic(fmt.Sprintf(errStrings[7], "Div()"))
-		}
 		for i := range c {
 			c[i] /= w
 		}
 	case []float64:
-		if len(c) != len(w) {
-			panic(fmt.Sprintf(errStrings[5], "Div()", len(c), len(w)))
-		}
-		for i := range w {
-			if w[i] == 0.0 {
-				panic(fmt.Sprintf(errStrings[8], "Div()", i))
-			}
-		}
 		for i := range c {
 			c[i] /= w[i]
 		}
-	default:
-		panic(fmt.Sprintf(errStrings[6], "Mul()", w))
 	}
 	return c
 }
 
 /*
 Dot returns the sum of the element-wise multiplication of two []float64s passed
-to it. The passed slices are not altered in this function.
+to it, computed by the current engine of the backend package. The passed slices
+are not altered in this function.
 */
 func Dot(v1, v2 []float64) float64 {
-	if len(v1) != len(v2) {
-		panic(fmt.Sprintf(errStrings[5], "Dot()", len(v1), len(v2)))
-	}
-	result := 0.0
-	for i := range v1 {
-		result += (v1[i] * v2[i])
+	if err := lengths("Dot()", len(v1), len(v2)); err != nil {
+		raise(err)
 	}
-	return result
+	return backend.Dot(v1, v2)
 }
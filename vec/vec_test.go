@@ -2,6 +2,7 @@ package vec
 
 import (
 	"fmt"
+	"math"
 	"sync"
 	"testing"
 )
@@ -225,6 +226,33 @@ func TestEqual(t *testing.T) {
 	}
 }
 
+func TestEqualULP(t *testing.T) {
+	v := []float64{1.0, 1e-300, -1e300, 0.0}
+	w := []float64{math.Nextafter(1.0, 2.0), math.Nextafter(1e-300, 0.0), math.Nextafter(-1e300, 0.0), math.Copysign(0.0, -1.0)}
+	if !EqualULP(v, w, 1) {
+		t.Errorf("expected equal within 1 ULP, got not equal")
+	}
+	if EqualULP(v, w, 0) {
+		t.Errorf("expected not equal within 0 ULP, got equal")
+	}
+	// The smallest positive and negative float64s are 2 ULP apart, across 0.
+	tiny := math.SmallestNonzeroFloat64
+	if !EqualULP([]float64{tiny}, []float64{-tiny}, 2) || EqualULP([]float64{tiny}, []float64{-tiny}, 1) {
+		t.Errorf("expected %v and %v to be 2 ULP apart", tiny, -tiny)
+	}
+	if EqualULP([]float64{1.0, 2.0}, []float64{1.0}, 10) {
+		t.Errorf("expected not equal for different lengths, got equal")
+	}
+	nan := []float64{math.NaN()}
+	if EqualULP(nan, nan, math.MaxUint64) {
+		t.Errorf("expected NaN not to equal NaN")
+	}
+	inf := []float64{math.Inf(1)}
+	if !EqualULP(inf, inf, 0) {
+		t.Errorf("expected +Inf to equal +Inf")
+	}
+}
+
 func TestSet(t *testing.T) {
 	w := make([]float64, 14)
 	w = Set(w, 10.0)
@@ -277,6 +305,26 @@ func TestAny(t *testing.T) {
 	}
 }
 
+func TestCount(t *testing.T) {
+	negative := func(i float64) bool {
+		return i < 0.0
+	}
+	v := []float64{-1.0, 2.0, -3.0}
+	if n := Count(v, negative); n != 2 {
+		t.Errorf("expected 2 negative values, got %d", n)
+	}
+	if n := Count([]float64{}, negative); n != 0 {
+		t.Errorf("expected 0 for an empty []float64, got %d", n)
+	}
+}
+
+func TestCountNonzero(t *testing.T) {
+	v := []float64{0.0, 2.0, -3.0, 0.0}
+	if n := CountNonzero(v); n != 2 {
+		t.Errorf("expected 2 nonzero values, got %d", n)
+	}
+}
+
 func TestSum(t *testing.T) {
 	v := make([]float64, 10)
 	s := Sum(v)
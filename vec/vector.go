@@ -0,0 +1,254 @@
+package vec
+
+import (
+	"fmt"
+	"log"
+	"math"
+)
+
+/*
+Vector wraps a []float64 with methods that can be chained into a pipeline.
+Rather than panicking like the functions of this package, a Vector records
+the first error encountered in the pipeline, after which the remaining
+operations do nothing. The error is reported by the methods which end a
+pipeline, such as Sum() and Slice():
+
+	v := vec.NewVector([]float64{1.0, 2.0, 3.0})
+	w := vec.NewVector([]float64{4.0, 5.0, 6.0})
+	sum, err := v.Add(w).Scale(2.0).Sum() // sum is 42.0
+
+	_, err = v.Add([]float64{1.0}).Sum() // err reports the mismatched lengths
+
+The errors of mismatched lengths and of divisions by zero match
+errs.ErrShapeMismatch and errs.ErrDivideByZero with errors.Is(), and hold
+the lengths, or the index of the zero, in an *errs.ShapeError or an
+*errs.DivideByZeroError.
+
+What a Vector does with an error is set with WithPolicy(), so that the same
+pipeline can crash in a batch script, and keep serving with NaN results in a
+long-running service:
+
+	v := vec.NewVector(x).WithPolicy(vec.ErrorNaN)
+	avg, _ := v.Div(w).Avg() // avg is NaN, and the error is logged, if w has a zero
+
+The methods of a Vector never modify it, or the slices passed to them, and
+return a new Vector instead. The functions of this package remain the
+simplest way to work on plain slices.
+*/
+type Vector struct {
+	data   []float64
+	err    error
+	policy ErrorPolicy
+}
+
+// ErrorPolicy determines what a Vector does with an error encountered in a
+// pipeline.
+type ErrorPolicy int
+
+const (
+	// ErrorReturn records the first error, which is returned by the
+	// methods which end the pipeline. It is the policy of a new Vector.
+	ErrorReturn ErrorPolicy = iota
+	// ErrorPanic panics with the error, as the functions of this package
+	// do.
+	ErrorPanic
+	// ErrorNaN logs the error with the standard logger of the log package,
+	// and continues the pipeline with a Vector of NaN, of the length of the
+	// Vector whose operation failed, or with a NaN result, and no error.
+	ErrorNaN
+)
+
+/*
+NewVector returns a Vector holding the passed []float64. The Vector refers
+to v rather than copying it, so v must not be modified while the Vector is
+used.
+*/
+func NewVector(v []float64) Vector {
+	return Vector{data: v}
+}
+
+/*
+WithPolicy returns the Vector with the passed ErrorPolicy, which applies to
+the operations after it in the pipeline, and is kept by the Vectors they
+return. An error recorded before the policy was set is kept. p must be one
+of the ErrorPolicy constants, otherwise this function will panic.
+*/
+func (v Vector) WithPolicy(p ErrorPolicy) Vector {
+	if p < ErrorReturn || p > ErrorNaN {
+		panic(fmt.Sprintf(errStrings[14], "Vector.WithPolicy()", p))
+	}
+	v.policy = p
+	return v
+}
+
+// Len returns the number of elements of the Vector.
+func (v Vector) Len() int {
+	return len(v.data)
+}
+
+// Err returns the first error encountered in the pipeline that produced the
+// Vector, or nil if there was none.
+func (v Vector) Err() error {
+	return v.err
+}
+
+// Slice returns a copy of the elements of the Vector, and the first error
+// encountered in the pipeline that produced it. The copy is nil if there
+// was an error.
+func (v Vector) Slice() ([]float64, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return Clone(v.data), nil
+}
+
+/*
+Add returns the Vector with val added to each element. val can be a float64,
+a []float64 or a Vector, as with vec.Add().
+*/
+func (v Vector) Add(val interface{}) Vector {
+	return v.binary("Add()", val, Add, arith)
+}
+
+/*
+Sub returns the Vector with val subtracted from each element. val can be a
+float64, a []float64 or a Vector, as with vec.Sub().
+*/
+func (v Vector) Sub(val interface{}) Vector {
+	return v.binary("Sub()", val, Sub, arith)
+}
+
+/*
+Mul returns the Vector with each element multiplied by val. val can be a
+float64, a []float64 or a Vector, as with vec.Mul().
+*/
+func (v Vector) Mul(val interface{}) Vector {
+	return v.binary("Mul()", val, Mul, arith)
+}
+
+/*
+Div returns the Vector with each element divided by val. val can be a
+float64, a []float64 or a Vector, as with vec.Div().
+*/
+func (v Vector) Div(val interface{}) Vector {
+	return v.binary("Div()", val, Div, quotient)
+}
+
+// Scale returns the Vector with each element multiplied by c.
+func (v Vector) Scale(c float64) Vector {
+	return v.binary("Mul()", c, Mul, arith)
+}
+
+// Apply returns the Vector with f applied to each element, as with
+// vec.Foreach().
+func (v Vector) Apply(f func(float64) float64) Vector {
+	if v.err != nil {
+		return v
+	}
+	return v.result(Foreach(v.data, f), nil)
+}
+
+// Sum returns the sum of the elements of the Vector, and the first error
+// encountered in the pipeline that produced it.
+func (v Vector) Sum() (float64, error) {
+	return v.reduce(Sum)
+}
+
+// Prod returns the product of the elements of the Vector, and the first
+// error encountered in the pipeline that produced it.
+func (v Vector) Prod() (float64, error) {
+	return v.reduce(Prod)
+}
+
+// Avg returns the average of the elements of the Vector, and the first
+// error encountered in the pipeline that produced it.
+func (v Vector) Avg() (float64, error) {
+	return v.reduce(Avg)
+}
+
+/*
+Dot returns the dot product of the Vector with w, which can be a []float64
+or a Vector, and the first error encountered in the pipelines that produced
+them.
+*/
+func (v Vector) Dot(w interface{}) (float64, error) {
+	if v.err != nil {
+		return 0.0, v.err
+	}
+	if u, ok := w.(Vector); ok {
+		if u.err != nil {
+			return v.value(0.0, u.err)
+		}
+		w = u.data
+	}
+	s, ok := w.([]float64)
+	if !ok {
+		return v.value(0.0, message(6, "Dot()", w))
+	}
+	if err := lengths("Dot()", len(v.data), len(s)); err != nil {
+		return v.value(0.0, err)
+	}
+	return Dot(v.data, s), nil
+}
+
+// binary applies one of the arithmetic functions of this package, fn, to
+// the Vector and val, unwrapping val if it is a Vector. The arguments are
+// checked with check, whose error is recorded rather than raised by f.
+func (v Vector) binary(fn string, val interface{}, f func([]float64, interface{}) []float64, check func(string, []float64, interface{}) error) Vector {
+	if v.err != nil {
+		return v
+	}
+	if w, ok := val.(Vector); ok {
+		if w.err != nil {
+			return v.result(nil, w.err)
+		}
+		val = w.data
+	}
+	if err := check(fn, v.data, val); err != nil {
+		return v.result(nil, err)
+	}
+	return v.result(f(v.data, val), nil)
+}
+
+func (v Vector) reduce(f func([]float64) float64) (float64, error) {
+	if v.err != nil {
+		return 0.0, v.err
+	}
+	return f(v.data), nil
+}
+
+// result returns the Vector holding data, the result of an operation on v,
+// applying the ErrorPolicy of v if the operation failed with err.
+func (v Vector) result(data []float64, err error) Vector {
+	if err != nil {
+		if !v.handle(err) {
+			return Vector{err: err, policy: v.policy}
+		}
+		data = alloc(len(v.data))
+		for i := range data {
+			data[i] = math.NaN()
+		}
+	}
+	return Vector{data: data, policy: v.policy}
+}
+
+// value is result() for the operations which end a pipeline with a float64.
+func (v Vector) value(x float64, err error) (float64, error) {
+	if err != nil && v.handle(err) {
+		return math.NaN(), nil
+	}
+	return x, err
+}
+
+// handle applies the ErrorPolicy of v to err, and reports whether the
+// pipeline continues with NaN.
+func (v Vector) handle(err error) bool {
+	switch v.policy {
+	case ErrorPanic:
+		panic(fmt.Sprintf("\n%v\n", err))
+	case ErrorNaN:
+		log.Printf("continuing with NaN after the error:\n%v", err)
+		return true
+	}
+	return false
+}
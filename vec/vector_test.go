@@ -0,0 +1,138 @@
+package vec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NDari/gocrunch/errs"
+)
+
+func TestVector(t *testing.T) {
+	v := NewVector([]float64{1.0, 2.0, 3.0})
+	w := NewVector([]float64{4.0, 5.0, 6.0})
+	sum, err := v.Add(w).Scale(2.0).Sum()
+	if err != nil || sum != 42.0 {
+		t.Errorf("expected 42.0, got %v and %v", sum, err)
+	}
+	s, err := v.Sub(1.0).Mul([]float64{2.0, 2.0, 2.0}).Div(w).Apply(func(x float64) float64 { return x * 10.0 }).Slice()
+	expected := []float64{0.0, 4.0, 20.0 / 3.0}
+	if err != nil || len(s) != 3 {
+		t.Fatalf("expected %v, got %v and %v", expected, s, err)
+	}
+	for i := range s {
+		if math.Abs(s[i]-expected[i]) > 1e-12 {
+			t.Errorf("at index %d, expected %v, got %v", i, expected[i], s[i])
+		}
+	}
+	if d, err := v.Dot(w); err != nil || d != 32.0 {
+		t.Errorf("expected 32.0, got %v and %v", d, err)
+	}
+	if p, _ := v.Prod(); p != 6.0 {
+		t.Errorf("expected 6.0, got %v", p)
+	}
+	if a, _ := w.Avg(); a != 5.0 {
+		t.Errorf("expected 5.0, got %v", a)
+	}
+	data, _ := v.Slice()
+	if !Equal(data, []float64{1.0, 2.0, 3.0}) || v.Len() != 3 {
+		t.Errorf("the Vector was modified: %v", data)
+	}
+}
+
+func TestVectorErrors(t *testing.T) {
+	v := NewVector([]float64{1.0, 2.0, 3.0})
+	bad := v.Add([]float64{1.0})
+	expected := strings.TrimSpace(fmt.Sprintf(errStrings[5], "Add()", 3, 1))
+	if bad.Err() == nil || bad.Err().Error() != expected {
+		t.Errorf("expected %s, got %v", expected, bad.Err())
+	}
+	// The first error is kept through the rest of the pipeline.
+	_, err := bad.Scale(2.0).Div(0.0).Sum()
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %s, got %v", expected, err)
+	}
+	if _, err := v.Mul(bad).Slice(); err == nil || err.Error() != expected {
+		t.Errorf("expected the error of the argument, got %v", err)
+	}
+	if _, err := v.Dot(bad); err == nil || err.Error() != expected {
+		t.Errorf("expected the error of the argument, got %v", err)
+	}
+	if err := v.Div([]float64{1.0, 0.0, 1.0}).Err(); err == nil {
+		t.Errorf("expected an error when dividing by zero")
+	}
+	var de *errs.DivideByZeroError
+	if err := v.Div([]float64{1.0, 0.0, 1.0}).Err(); !errors.As(err, &de) || de.Index != 1 {
+		t.Errorf("expected an *errs.DivideByZeroError at index 1, got %v", err)
+	}
+	if err := v.Div(0.0).Err(); !errors.As(err, &de) || de.Index != -1 {
+		t.Errorf("expected an *errs.DivideByZeroError of a scalar, got %v", err)
+	}
+	var se *errs.ShapeError
+	if !errors.Is(bad.Err(), errs.ErrShapeMismatch) || !errors.As(bad.Err(), &se) || se.Got[0] != 1 || se.Want[0] != 3 {
+		t.Errorf("expected an *errs.ShapeError of lengths 1 and 3, got %v", bad.Err())
+	}
+	if _, err := v.Dot("x"); err == nil {
+		t.Errorf("expected an error for a string argument")
+	}
+	if s, err := bad.Slice(); s != nil || err == nil {
+		t.Errorf("expected no elements and an error, got %v and %v", s, err)
+	}
+}
+
+func TestVectorPolicy(t *testing.T) {
+	var buf bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&buf)
+	v := NewVector([]float64{1.0, 2.0, 3.0}).WithPolicy(ErrorNaN)
+	w, err := v.Div([]float64{1.0, 0.0, 1.0}).Scale(2.0).Slice()
+	if err != nil || len(w) != 3 || !math.IsNaN(w[0]) || !math.IsNaN(w[2]) {
+		t.Errorf("expected 3 NaN and no error, got %v and %v", w, err)
+	}
+	if !strings.Contains(buf.String(), fmt.Sprintf(strings.TrimSpace(errStrings[8]), "Div()", 1)) {
+		t.Errorf("expected the error to be logged, got %q", buf.String())
+	}
+	if s, err := v.Dot([]float64{1.0}); !math.IsNaN(s) || err != nil {
+		t.Errorf("expected NaN and no error, got %v and %v", s, err)
+	}
+	bad := NewVector([]float64{1.0}).Add([]float64{1.0, 2.0})
+	if a, err := v.Add(bad).Avg(); !math.IsNaN(a) || err != nil {
+		t.Errorf("expected NaN for the error of the argument, got %v and %v", a, err)
+	}
+	if s, err := v.Sum(); s != 6.0 || err != nil {
+		t.Errorf("expected 6.0, got %v and %v", s, err)
+	}
+	if _, err := bad.WithPolicy(ErrorNaN).Sum(); err == nil {
+		t.Errorf("expected the error recorded before the policy was set")
+	}
+	p := v.WithPolicy(ErrorPanic)
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { p.Add([]float64{1.0}) }, fmt.Sprintf(errStrings[5], "Add()", 3, 1)},
+		{func() { p.Dot("x") }, fmt.Sprintf(errStrings[6], "Dot()", "x")},
+		{func() { p.Mul(bad) }, fmt.Sprintf(errStrings[5], "Add()", 1, 2)},
+		{func() { v.WithPolicy(ErrorPolicy(3)) }, fmt.Sprintf(errStrings[14], "Vector.WithPolicy()", 3)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,60 @@
+package vec
+
+import "fmt"
+
+/*
+Where chooses elementwise between two values based on a []bool mask. The
+returned []float64 has the same length as cond, and its element at index i is
+taken from a if cond[i] is true, and from b otherwise. Both a and b can be
+either a float64 or a []float64. For example:
+
+	cond := []bool{true, false, true}
+	a := []float64{1.0, 2.0, 3.0}
+	w := vec.Where(cond, a, 0.0) // w is {1.0, 0.0, 3.0}
+
+When a or b is a []float64, its length must match the length of cond,
+otherwise this function will panic. The passed arguments are not modified in
+this function.
+*/
+func Where(cond []bool, a, b interface{}) []float64 {
+	return where("Where()", alloc(len(cond)), cond, a, b)
+}
+
+/*
+WhereTo stores the result of vec.Where(cond, a, b) in dst, and returns dst,
+without allocating. dst must have the length of cond, and may be a or b.
+*/
+func WhereTo(dst []float64, cond []bool, a, b interface{}) []float64 {
+	return where("WhereTo()", dst, cond, a, b)
+}
+
+func where(fn string, dst []float64, cond []bool, a, b interface{}) []float64 {
+	fa, fb := operand(fn, a, 2, len(cond)), operand(fn, b, 3, len(cond))
+	if len(dst) != len(cond) {
+		panic(fmt.Sprintf(errStrings[5], fn, len(cond), len(dst)))
+	}
+	for i := range cond {
+		if cond[i] {
+			dst[i] = fa(i)
+		} else {
+			dst[i] = fb(i)
+		}
+	}
+	return dst
+}
+
+// operand returns the element at an index of arg, argument pos of the
+// function fn, which can be a float64, or a []float64 of length n.
+func operand(fn string, arg interface{}, pos, n int) func(int) float64 {
+	switch w := arg.(type) {
+	case float64:
+		return func(int) float64 { return w }
+	case []float64:
+		if len(w) != n {
+			panic(fmt.Sprintf(errStrings[5], fn, n, len(w)))
+		}
+		return func(i int) float64 { return w[i] }
+	default:
+		panic(fmt.Sprintf(errStrings[12], fn, pos, w))
+	}
+}
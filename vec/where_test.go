@@ -0,0 +1,52 @@
+package vec
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestWhere(t *testing.T) {
+	cond := []bool{true, false, true}
+	a := []float64{1.0, 2.0, 3.0}
+	b := []float64{-1.0, -2.0, -3.0}
+	w := Where(cond, a, b)
+	if !Equal(w, []float64{1.0, -2.0, 3.0}) {
+		t.Errorf("expected {1.0, -2.0, 3.0}, got %v", w)
+	}
+	w = Where(cond, a, 0.0)
+	if !Equal(w, []float64{1.0, 0.0, 3.0}) {
+		t.Errorf("expected {1.0, 0.0, 3.0}, got %v", w)
+	}
+	w = Where(cond, 5.0, b)
+	if !Equal(w, []float64{5.0, -2.0, 5.0}) {
+		t.Errorf("expected {5.0, -2.0, 5.0}, got %v", w)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[5], "Where()", 3, 2)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Where(cond, a, b[:2])
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[12], "Where()", 3, 1)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Where(cond, a, 1)
+	}()
+	wg.Wait()
+}
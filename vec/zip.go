@@ -0,0 +1,71 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+ZipWith applies a function to the pairs of elements of two []float64s at
+the same index, storing the results in a new []float64 which is returned.
+For example:
+
+	a := []float64{1.0, 2.0, 3.0}
+	b := []float64{3.0, 2.0, 1.0}
+	m := vec.ZipWith(math.Max, a, b) // m is {3.0, 2.0, 3.0}
+
+a and b must have the same length, otherwise this function will panic. The
+passed []float64s are not modified in this function. To reuse an existing
+[]float64 for the result, look at vec.ZipWithTo() and vec.ZipWithInPlace().
+*/
+func ZipWith(f func(x, y float64) float64, a, b []float64) []float64 {
+	return elementwise("ZipWith()", a, b, f)
+}
+
+/*
+ZipWithInPlace sets each element of a to f applied to it and the element of
+b at the same index. a and b must have the same length, otherwise this
+function will panic. a is mutated in this function.
+*/
+func ZipWithInPlace(f func(x, y float64) float64, a, b []float64) {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf(errStrings[5], "ZipWithInPlace()", len(a), len(b)))
+	}
+	for i := range a {
+		a[i] = f(a[i], b[i])
+	}
+}
+
+/*
+ZipWithTo stores the result of vec.ZipWith(f, a, b) in dst, and returns dst,
+without allocating. a and b must have the same length, and dst must have
+their length, and may be a or b itself.
+*/
+func ZipWithTo(dst []float64, f func(x, y float64) float64, a, b []float64) []float64 {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf(errStrings[5], "ZipWithTo()", len(a), len(b)))
+	}
+	checkDst("ZipWithTo()", dst, a)
+	for i := range a {
+		dst[i] = f(a[i], b[i])
+	}
+	return dst
+}
+
+/*
+Hypot returns a new []float64 whose element i is the length of the
+hypotenuse of a right triangle of sides a[i] and b[i], that is
+sqrt(a[i]*a[i] + b[i]*b[i]), computed by math.Hypot() without overflow or
+underflow. For example, the magnitudes of complex numbers from their real
+and imaginary parts:
+
+	re := []float64{3.0, 5.0}
+	im := []float64{4.0, 12.0}
+	mag := vec.Hypot(re, im) // mag is {5.0, 13.0}
+
+a and b must have the same length, otherwise this function will panic. The
+passed []float64s are not modified in this function.
+*/
+func Hypot(a, b []float64) []float64 {
+	return elementwise("Hypot()", a, b, math.Hypot)
+}
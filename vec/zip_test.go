@@ -0,0 +1,56 @@
+package vec
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestZipWith(t *testing.T) {
+	a := []float64{1.0, 2.0, 3.0}
+	b := []float64{3.0, 2.0, 1.0}
+	if m := ZipWith(math.Max, a, b); !Equal(m, []float64{3.0, 2.0, 3.0}) {
+		t.Errorf("expected {3.0, 2.0, 3.0}, got %v", m)
+	}
+	dst := make([]float64, 3)
+	if d := ZipWithTo(dst, math.Min, a, b); &d[0] != &dst[0] || !Equal(dst, []float64{1.0, 2.0, 1.0}) {
+		t.Errorf("expected {1.0, 2.0, 1.0} in dst, got %v", dst)
+	}
+	c := Clone(a)
+	ZipWithInPlace(func(x, y float64) float64 { return x - 2.0*y }, c, b)
+	if !Equal(c, []float64{-5.0, -2.0, 1.0}) {
+		t.Errorf("expected {-5.0, -2.0, 1.0}, got %v", c)
+	}
+	if h := Hypot([]float64{3.0, 5.0, 1e300}, []float64{4.0, 12.0, 1e300}); h[0] != 5.0 || h[1] != 13.0 || math.IsInf(h[2], 1) {
+		t.Errorf("expected {5.0, 13.0, 1.414e300}, got %v", h)
+	}
+	if !Equal(a, []float64{1.0, 2.0, 3.0}) || !Equal(b, []float64{3.0, 2.0, 1.0}) {
+		t.Errorf("the original []float64s were modified")
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { ZipWith(math.Max, a, b[:2]) }, fmt.Sprintf(errStrings[5], "ZipWith()", 3, 2)},
+		{func() { ZipWithInPlace(math.Max, a, b[:1]) }, fmt.Sprintf(errStrings[5], "ZipWithInPlace()", 3, 1)},
+		{func() { ZipWithTo(dst, math.Max, a, b[:1]) }, fmt.Sprintf(errStrings[5], "ZipWithTo()", 3, 1)},
+		{func() { ZipWithTo(dst[:2], math.Max, a, b) }, fmt.Sprintf(errStrings[5], "ZipWithTo()", 3, 2)},
+		{func() { Hypot(a, nil) }, fmt.Sprintf(errStrings[5], "Hypot()", 3, 0)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
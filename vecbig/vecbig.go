@@ -0,0 +1,324 @@
+/*
+Package vecbig implements the core functions of the vec package for
+vectors of arbitrary precision floating point numbers, stored as
+[]*big.Float, for computations where the 53 bits of a float64 are not
+enough, such as sums of many amounts of money, or long integrations of
+orbits.
+
+The precision, in bits of mantissa, is chosen when creating a vector, and
+carries through the functions of this package: each element of a result,
+and each reduction, is computed with the largest precision of the elements
+it is computed from. For example, with 200 bits, about 60 decimal digits:
+
+	v := vecbig.FromFloat64([]float64{0.1, 0.2, 0.3}, 200)
+	w := vecbig.Mul(v, big.NewFloat(3.0))
+	s := vecbig.Sum(w) // s has a precision of 200 bits
+
+Note that the float64 0.1 is itself only an approximation of 1/10, which is
+converted exactly. Create the elements from strings with big.ParseFloat to
+get the closest approximation at the chosen precision. All the functions
+round to the nearest, with ties to even.
+
+A *big.Float holds infinities, but not NaN, so the functions creating a
+[]*big.Float from NaN, or computing a NaN element, such as Inf - Inf or
+0 * Inf, panic instead.
+
+As in the vec package, the functions return new []*big.Float and do not
+modify their arguments, and invalid arguments, such as slices of different
+lengths, are treated as critical errors, and cause a panic with a message
+that names the offending function, as with the other packages in gocrunch.
+*/
+package vecbig
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/vecbig error.\nIn vecbig.%s, the length the passed slices does not match: %d and %d.\n",
+		"\ngocrunch/vecbig error.\nIn vecbig.%s, second arg must be *big.Float or []*big.Float, received %v.\n",
+		"\ngocrunch/vecbig error.\nIn vecbig.%s, the divisor is 0.0 at index %d.\n",
+		"\ngocrunch/vecbig error.\nIn vecbig.%s, the divisor cannot be 0.0.\n",
+		"\ngocrunch/vecbig error.\nIn vecbig.%s, cannot use %s on an empty []*big.Float.\n",
+		"\ngocrunch/vecbig error.\nIn vecbig.%s, the precision must be greater than 0.\n",
+		"\ngocrunch/vecbig error.\nIn vecbig.%s, the element at index %d is NaN, which a *big.Float cannot hold.\n",
+		"\ngocrunch/vecbig error.\nIn vecbig.%s, the result at index %d is NaN, which a *big.Float cannot hold.\n",
+	}
+)
+
+/*
+New returns a []*big.Float of n zeros, each with the passed precision, in
+bits of mantissa. The precision must be greater than 0, otherwise this
+function will panic.
+*/
+func New(n int, prec uint) []*big.Float {
+	checkPrec("New()", prec)
+	v := make([]*big.Float, n)
+	for i := range v {
+		v[i] = new(big.Float).SetPrec(prec)
+	}
+	return v
+}
+
+/*
+FromFloat64 returns a []*big.Float holding the elements of a []float64,
+each with the passed precision, in bits of mantissa. A precision of 53 or
+more converts the elements exactly. The precision must be greater than 0,
+and the elements must not be NaN, otherwise this function will panic. v is
+not mutated in this function.
+*/
+func FromFloat64(v []float64, prec uint) []*big.Float {
+	checkPrec("FromFloat64()", prec)
+	b := make([]*big.Float, len(v))
+	for i, x := range v {
+		if math.IsNaN(x) {
+			panic(fmt.Sprintf(errStrings[6], "FromFloat64()", i))
+		}
+		b[i] = new(big.Float).SetPrec(prec).SetFloat64(x)
+	}
+	return b
+}
+
+/*
+ToFloat64 returns a []float64 holding the elements of a []*big.Float,
+rounded to the nearest float64. Elements too large for a float64 are
+converted to infinities. v is not mutated in this function.
+*/
+func ToFloat64(v []*big.Float) []float64 {
+	f := make([]float64, len(v))
+	for i, x := range v {
+		f[i], _ = x.Float64()
+	}
+	return f
+}
+
+/*
+SetPrec returns a copy of a []*big.Float whose elements are rounded to the
+passed precision, in bits of mantissa, which is then used by the functions
+computing with them. The precision must be greater than 0, otherwise this
+function will panic. v is not mutated in this function.
+*/
+func SetPrec(v []*big.Float, prec uint) []*big.Float {
+	checkPrec("SetPrec()", prec)
+	c := make([]*big.Float, len(v))
+	for i, x := range v {
+		c[i] = new(big.Float).SetPrec(prec).Set(x)
+	}
+	return c
+}
+
+/*
+Clone returns a deep copy of a []*big.Float, whose elements have the
+values and precisions of those of v, and can be modified without affecting
+v.
+*/
+func Clone(v []*big.Float) []*big.Float {
+	c := make([]*big.Float, len(v))
+	for i, x := range v {
+		c[i] = new(big.Float).Copy(x)
+	}
+	return c
+}
+
+/*
+Equal checks if two []*big.Float have the same length, and the same values
+at each index, regardless of their precisions.
+*/
+func Equal(v, w []*big.Float) bool {
+	if len(v) != len(w) {
+		return false
+	}
+	for i := range v {
+		if v[i].Cmp(w[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Add returns the sum of a []*big.Float and a second argument, which can be a
+*big.Float, added to each element, or a []*big.Float of the same length,
+added element-wise, as vec.Add() does for a []float64. For example:
+
+	v := vecbig.FromFloat64([]float64{1.0, 2.0}, 100)
+	w := vecbig.Add(v, big.NewFloat(0.5)) // w is {1.5, 2.5}
+
+The length of a second []*big.Float must be the length of v, and no two
+infinities of opposite signs may be added, since their sum is NaN,
+otherwise this function will panic. The passed arguments are not mutated
+in this function.
+*/
+func Add(v []*big.Float, val interface{}) []*big.Float {
+	return binary("Add()", v, val, (*big.Float).Add, func(x, y *big.Float) bool {
+		return x.IsInf() && y.IsInf() && x.Signbit() != y.Signbit()
+	})
+}
+
+/*
+Sub returns the difference of a []*big.Float and a second argument, which
+can be a *big.Float or a []*big.Float, as with vecbig.Add(). No infinity
+may be subtracted from an infinity of the same sign, otherwise this
+function will panic. The passed arguments are not mutated in this function.
+*/
+func Sub(v []*big.Float, val interface{}) []*big.Float {
+	return binary("Sub()", v, val, (*big.Float).Sub, func(x, y *big.Float) bool {
+		return x.IsInf() && y.IsInf() && x.Signbit() == y.Signbit()
+	})
+}
+
+/*
+Mul returns the product of a []*big.Float and a second argument, which can
+be a *big.Float or a []*big.Float, as with vecbig.Add(). No infinity may
+be multiplied by 0.0, otherwise this function will panic. The passed
+arguments are not mutated in this function.
+*/
+func Mul(v []*big.Float, val interface{}) []*big.Float {
+	return binary("Mul()", v, val, (*big.Float).Mul, func(x, y *big.Float) bool {
+		return x.IsInf() && y.Sign() == 0 || x.Sign() == 0 && y.IsInf()
+	})
+}
+
+/*
+Div returns the quotient of a []*big.Float and a second argument, which can
+be a *big.Float or a []*big.Float, as with vecbig.Add(). The divisors cannot
+be 0.0, otherwise this function will panic, as vec.Div() does, and no
+infinity may be divided by an infinity. The passed arguments are not mutated
+in this function.
+*/
+func Div(v []*big.Float, val interface{}) []*big.Float {
+	switch w := val.(type) {
+	case *big.Float:
+		if w.Sign() == 0 {
+			panic(fmt.Sprintf(errStrings[3], "Div()"))
+		}
+	case []*big.Float:
+		for i := range w {
+			if w[i].Sign() == 0 {
+				panic(fmt.Sprintf(errStrings[2], "Div()", i))
+			}
+		}
+	}
+	return binary("Div()", v, val, (*big.Float).Quo, func(x, y *big.Float) bool {
+		return x.IsInf() && y.IsInf()
+	})
+}
+
+/*
+Sum returns the sum of the elements of a []*big.Float, computed with the
+largest precision of its elements, or 0 for an empty []*big.Float. v is not
+mutated in this function.
+*/
+func Sum(v []*big.Float) *big.Float {
+	sum := new(big.Float).SetPrec(maxPrec(v))
+	for _, x := range v {
+		sum.Add(sum, x)
+	}
+	return sum
+}
+
+/*
+Prod returns the product of the elements of a []*big.Float, computed with
+the largest precision of its elements, or 1 for an empty []*big.Float. v is
+not mutated in this function.
+*/
+func Prod(v []*big.Float) *big.Float {
+	prod := new(big.Float).SetPrec(maxPrec(v)).SetInt64(1)
+	for _, x := range v {
+		prod.Mul(prod, x)
+	}
+	return prod
+}
+
+/*
+Avg returns the average of the elements of a []*big.Float, computed with
+the largest precision of its elements. The []*big.Float must not be empty,
+otherwise this function will panic. v is not mutated in this function.
+*/
+func Avg(v []*big.Float) *big.Float {
+	if len(v) == 0 {
+		panic(fmt.Sprintf(errStrings[4], "Avg()", "Avg()"))
+	}
+	sum := Sum(v)
+	return sum.Quo(sum, new(big.Float).SetInt64(int64(len(v))))
+}
+
+/*
+Dot returns the sum of the element-wise products of two []*big.Float,
+computed with the largest precision of their elements. The slices must have
+the same length, otherwise this function will panic. The passed slices are
+not mutated in this function.
+*/
+func Dot(v, w []*big.Float) *big.Float {
+	if len(v) != len(w) {
+		panic(fmt.Sprintf(errStrings[0], "Dot()", len(v), len(w)))
+	}
+	prec := max(maxPrec(v), maxPrec(w))
+	sum := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	for i := range v {
+		sum.Add(sum, t.Mul(v[i], w[i]))
+	}
+	return sum
+}
+
+/*
+Norm returns the euclidean norm of a []*big.Float, the square root of the
+sum of the squares of its elements, computed with the largest precision of
+its elements. v is not mutated in this function.
+*/
+func Norm(v []*big.Float) *big.Float {
+	d := Dot(v, v)
+	return d.Sqrt(d)
+}
+
+// binary returns op applied to each element of v and to val, which is a
+// *big.Float or a []*big.Float of the length of v. nan reports the operands
+// for which op gives NaN, which big.Float panics on with a bare big.ErrNaN.
+func binary(fn string, v []*big.Float, val interface{}, op func(z, x, y *big.Float) *big.Float, nan func(x, y *big.Float) bool) []*big.Float {
+	c := make([]*big.Float, len(v))
+	switch w := val.(type) {
+	case *big.Float:
+		for i := range v {
+			if nan(v[i], w) {
+				panic(fmt.Sprintf(errStrings[7], fn, i))
+			}
+			c[i] = op(new(big.Float).SetPrec(max(v[i].Prec(), w.Prec())), v[i], w)
+		}
+	case []*big.Float:
+		if len(v) != len(w) {
+			panic(fmt.Sprintf(errStrings[0], fn, len(v), len(w)))
+		}
+		for i := range v {
+			if nan(v[i], w[i]) {
+				panic(fmt.Sprintf(errStrings[7], fn, i))
+			}
+			c[i] = op(new(big.Float).SetPrec(max(v[i].Prec(), w[i].Prec())), v[i], w[i])
+		}
+	default:
+		panic(fmt.Sprintf(errStrings[1], fn, val))
+	}
+	return c
+}
+
+// maxPrec returns the largest precision of the elements of v, or that of a
+// float64 if v is empty.
+func maxPrec(v []*big.Float) uint {
+	if len(v) == 0 {
+		return 53
+	}
+	prec := uint(0)
+	for _, x := range v {
+		prec = max(prec, x.Prec())
+	}
+	return prec
+}
+
+func checkPrec(fn string, prec uint) {
+	if prec == 0 {
+		panic(fmt.Sprintf(errStrings[5], fn))
+	}
+}
@@ -0,0 +1,133 @@
+package vecbig
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"testing"
+)
+
+func parse(t *testing.T, prec uint, s ...string) []*big.Float {
+	v := make([]*big.Float, len(s))
+	for i := range s {
+		x, _, err := big.ParseFloat(s[i], 10, prec, big.ToNearestEven)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		v[i] = x
+	}
+	return v
+}
+
+func TestConvert(t *testing.T) {
+	v := FromFloat64([]float64{0.5, -2.0, 3.25}, 100)
+	if v[0].Prec() != 100 || len(v) != 3 {
+		t.Errorf("expected 3 elements with 100 bits, got %d with %d", len(v), v[0].Prec())
+	}
+	f := ToFloat64(v)
+	if f[0] != 0.5 || f[1] != -2.0 || f[2] != 3.25 {
+		t.Errorf("expected {0.5, -2.0, 3.25}, got %v", f)
+	}
+	z := New(2, 80)
+	if z[1].Sign() != 0 || z[1].Prec() != 80 {
+		t.Errorf("expected zeros with 80 bits, got %v", z)
+	}
+	c := Clone(v)
+	c[0].SetInt64(7)
+	if v[0].Cmp(big.NewFloat(0.5)) != 0 {
+		t.Errorf("expected Clone() to copy the elements")
+	}
+	p := SetPrec(v, 8)
+	if p[0].Prec() != 8 || v[0].Prec() != 100 {
+		t.Errorf("expected a copy with 8 bits, got %d and %d", p[0].Prec(), v[0].Prec())
+	}
+	if !Equal(p, v) || Equal(p, v[:2]) || Equal(c, v) {
+		t.Errorf("Equal() compares the values of the elements")
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	v := parse(t, 200, "0.1", "0.2", "0.3")
+	three := big.NewFloat(3.0)
+	w := Mul(v, three)
+	if w[0].Prec() != 200 {
+		t.Errorf("expected the precision of v, got %d", w[0].Prec())
+	}
+	back := Div(w, three)
+	diff := Sub(back, v)
+	for i := range diff {
+		if e := diff[i].MantExp(nil); diff[i].Sign() != 0 && e > -190 {
+			t.Errorf("at index %d, expected a difference below 2^-190, got %v", i, diff[i])
+		}
+	}
+	// 0.1 + 0.2 - 0.3 is 0 within the precision, unlike with float64.
+	s := Sum(Sub(v[:2], []*big.Float{big.NewFloat(0.0), v[2]}))
+	if e := s.MantExp(nil); s.Sign() != 0 && e > -190 {
+		t.Errorf("expected 0.1 + 0.2 - 0.3 to vanish, got %v", s)
+	}
+	a := Add(FromFloat64([]float64{1.0, 2.0}, 64), big.NewFloat(0.5))
+	if !Equal(a, FromFloat64([]float64{1.5, 2.5}, 64)) {
+		t.Errorf("expected {1.5, 2.5}, got %v", a)
+	}
+	if p := Prod(FromFloat64([]float64{2.0, 3.0, 4.0}, 64)); p.Cmp(big.NewFloat(24.0)) != 0 {
+		t.Errorf("expected 24, got %v", p)
+	}
+	if p := Prod(nil); p.Cmp(big.NewFloat(1.0)) != 0 {
+		t.Errorf("expected 1 for an empty []*big.Float, got %v", p)
+	}
+	if m := Avg(FromFloat64([]float64{1.0, 2.0, 6.0}, 64)); m.Cmp(big.NewFloat(3.0)) != 0 {
+		t.Errorf("expected 3, got %v", m)
+	}
+	x := FromFloat64([]float64{3.0, 4.0}, 64)
+	if d := Dot(x, x); d.Cmp(big.NewFloat(25.0)) != 0 {
+		t.Errorf("expected 25, got %v", d)
+	}
+	if n := Norm(x); n.Cmp(big.NewFloat(5.0)) != 0 {
+		t.Errorf("expected 5, got %v", n)
+	}
+	two := Norm(FromFloat64([]float64{1.0, 1.0}, 300))
+	sqrt2 := new(big.Float).SetPrec(300).Sqrt(big.NewFloat(2.0).SetPrec(300))
+	if two.Cmp(sqrt2) != 0 || two.Prec() != 300 {
+		t.Errorf("expected the square root of 2 with 300 bits, got %v", two)
+	}
+	if v[0].Cmp(parse(t, 200, "0.1")[0]) != 0 {
+		t.Errorf("the original []*big.Float was modified")
+	}
+	inf := FromFloat64([]float64{1.0, math.Inf(1)}, 64)
+	if s := Add(inf, inf); !s[1].IsInf() || s[1].Signbit() {
+		t.Errorf("expected +Inf, got %v", s[1])
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { Add(x, v) }, fmt.Sprintf(errStrings[0], "Add()", 2, 3)},
+		{func() { Mul(x, 2.0) }, fmt.Sprintf(errStrings[1], "Mul()", 2.0)},
+		{func() { Div(x, new(big.Float)) }, fmt.Sprintf(errStrings[3], "Div()")},
+		{func() { Div(x, FromFloat64([]float64{1.0, 0.0}, 64)) }, fmt.Sprintf(errStrings[2], "Div()", 1)},
+		{func() { Avg(nil) }, fmt.Sprintf(errStrings[4], "Avg()", "Avg()")},
+		{func() { Dot(x, v) }, fmt.Sprintf(errStrings[0], "Dot()", 2, 3)},
+		{func() { New(2, 0) }, fmt.Sprintf(errStrings[5], "New()")},
+		{func() { FromFloat64([]float64{1.0, math.NaN()}, 64) }, fmt.Sprintf(errStrings[6], "FromFloat64()", 1)},
+		{func() { Add(inf, FromFloat64([]float64{1.0, math.Inf(-1)}, 64)) }, fmt.Sprintf(errStrings[7], "Add()", 1)},
+		{func() { Sub(inf, big.NewFloat(math.Inf(1))) }, fmt.Sprintf(errStrings[7], "Sub()", 1)},
+		{func() { Mul(inf, new(big.Float)) }, fmt.Sprintf(errStrings[7], "Mul()", 1)},
+		{func() { Div(inf, inf) }, fmt.Sprintf(errStrings[7], "Div()", 1)},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Package vecint implements functions which act on slices of integers, such
+as []int64 and []int32, for indices and counts which must stay exact. A
+float64 only holds the integers up to 2^53 exactly, so that converting
+larger integers to use the vec package loses their low digits.
+
+The functions are generic over the integer types, and are called with
+slices of any of them:
+
+	v := []int64{3, 1, 3, 2}
+	s := vecint.Sum(v)         // s is 9
+	u, _ := vecint.Unique(v)   // u is {1, 2, 3}
+	n := vecint.Bincount(v, 0) // n is {0, 1, 1, 2}
+
+The arithmetic follows the rules of Go for the type of the elements, so
+that results too large for the type wrap around. Use a wider type, such as
+int64, when this is a concern.
+
+As in the vec package, the functions return new slices and do not modify
+their arguments, and invalid arguments, such as slices of different
+lengths, are treated as critical errors, and cause a panic with a message
+that names the offending function, as with the other packages in gocrunch.
+*/
+package vecint
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/vecint error.\nIn vecint.%s, the length the passed slices does not match: %d and %d.\n",
+		"\ngocrunch/vecint error.\nIn vecint.%s, second arg must be an integer or a slice of the type of the first, received %v.\n",
+		"\ngocrunch/vecint error.\nIn vecint.%s, cannot use %s on an empty slice.\n",
+		"\ngocrunch/vecint error.\nIn vecint.%s, the elements must not be negative, but the element at index %d is %d.\n",
+		"\ngocrunch/vecint error.\nIn vecint.%s, the minimum length must not be negative, received %d.\n",
+		"\ngocrunch/vecint error.\nIn vecint.%s, the element at index %d is %d, which is too large for the length of an []int.\n",
+	}
+)
+
+// Integer is the set of the integer types which the functions of this
+// package act on.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+/*
+Add takes a slice of integers, and a second argument, which can be an
+integer of the same type or a slice of that type, and returns the sum of
+each element with the integer, or with the element of the same index, as
+vec.Add() does for a []float64. For example:
+
+	v := []int64{1, 2, 3}
+	w := vecint.Add(v, int64(10))        // w is {11, 12, 13}
+	q := vecint.Add(v, []int64{3, 2, 1}) // q is {4, 4, 4}
+
+An integer must have the type of the elements of v, so that untyped
+constants need a conversion, as above. The length of a second slice must be
+the length of v, otherwise this function will panic. The passed arguments
+are not mutated in this function.
+*/
+func Add[T Integer](v []T, val interface{}) []T {
+	c := slices.Clone(v)
+	switch w := val.(type) {
+	case T:
+		for i := range c {
+			c[i] += w
+		}
+	case []T:
+		if len(c) != len(w) {
+			panic(fmt.Sprintf(errStrings[0], "Add()", len(c), len(w)))
+		}
+		for i := range c {
+			c[i] += w[i]
+		}
+	default:
+		panic(fmt.Sprintf(errStrings[1], "Add()", val))
+	}
+	return c
+}
+
+// Sum returns the sum of the elements of a slice of integers, which is 0
+// for an empty slice.
+func Sum[T Integer](v []T) T {
+	var sum T
+	for _, x := range v {
+		sum += x
+	}
+	return sum
+}
+
+/*
+Min returns the smallest element of a slice of integers, and its index,
+which is the first one if the smallest element repeats. The slice must not
+be empty, otherwise this function will panic.
+*/
+func Min[T Integer](v []T) (T, int) {
+	if len(v) == 0 {
+		panic(fmt.Sprintf(errStrings[2], "Min()", "Min()"))
+	}
+	k := 0
+	for i, x := range v {
+		if x < v[k] {
+			k = i
+		}
+	}
+	return v[k], k
+}
+
+/*
+Max returns the largest element of a slice of integers, and its index,
+which is the first one if the largest element repeats. The slice must not be
+empty, otherwise this function will panic.
+*/
+func Max[T Integer](v []T) (T, int) {
+	if len(v) == 0 {
+		panic(fmt.Sprintf(errStrings[2], "Max()", "Max()"))
+	}
+	k := 0
+	for i, x := range v {
+		if x > v[k] {
+			k = i
+		}
+	}
+	return v[k], k
+}
+
+/*
+CumSum returns the cumulative sums of a slice of integers, whose element i
+is the sum of the elements 0 to i of v. For example:
+
+	c := vecint.CumSum([]int32{1, 2, 3}) // c is {1, 3, 6}
+
+v is not mutated in this function.
+*/
+func CumSum[T Integer](v []T) []T {
+	c := make([]T, len(v))
+	var sum T
+	for i, x := range v {
+		sum += x
+		c[i] = sum
+	}
+	return c
+}
+
+/*
+Unique returns the distinct elements of a slice of integers, in increasing
+order, along with the number of times each of them appears in v. For
+example:
+
+	u, n := vecint.Unique([]int64{3, 1, 3, 2}) // u is {1, 2, 3}, n is {1, 1, 2}
+
+v is not mutated in this function.
+*/
+func Unique[T Integer](v []T) ([]T, []int) {
+	s := slices.Clone(v)
+	slices.Sort(s)
+	u := []T{}
+	n := []int{}
+	for i, x := range s {
+		if i > 0 && x == s[i-1] {
+			n[len(n)-1]++
+			continue
+		}
+		u = append(u, x)
+		n = append(n, 1)
+	}
+	return u, n
+}
+
+/*
+Bincount returns the number of times each integer from 0 appears in a slice
+of non-negative integers, as an []int of length one more than the largest
+element, or minLength if that is longer. For example:
+
+	n := vecint.Bincount([]int{1, 3, 1}, 0) // n is {0, 2, 0, 1}
+	n = vecint.Bincount([]int{1, 3, 1}, 6)  // n is {0, 2, 0, 1, 0, 0}
+
+The elements of v must not be negative, nor so large that one more is not an
+int, and minLength must not be negative, otherwise this function will
+panic. v is not mutated in this function.
+*/
+func Bincount[T Integer](v []T, minLength int) []int {
+	if minLength < 0 {
+		panic(fmt.Sprintf(errStrings[4], "Bincount()", minLength))
+	}
+	n := minLength
+	for i, x := range v {
+		if x < 0 {
+			panic(fmt.Sprintf(errStrings[3], "Bincount()", i, x))
+		}
+		// x+1 must be an int, which a large uint64 or int64 is not.
+		if uint64(x) >= math.MaxInt {
+			panic(fmt.Sprintf(errStrings[5], "Bincount()", i, x))
+		}
+		n = max(n, int(x)+1)
+	}
+	counts := make([]int, n)
+	for _, x := range v {
+		counts[x]++
+	}
+	return counts
+}
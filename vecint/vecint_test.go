@@ -0,0 +1,144 @@
+package vecint
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	v := []int64{1, 2, 3}
+	if w := Add(v, int64(10)); !slices.Equal(w, []int64{11, 12, 13}) {
+		t.Errorf("expected {11, 12, 13}, got %v", w)
+	}
+	if w := Add(v, []int64{3, 2, 1}); !slices.Equal(w, []int64{4, 4, 4}) {
+		t.Errorf("expected {4, 4, 4}, got %v", w)
+	}
+	if v[0] != 1 {
+		t.Errorf("the original slice was modified")
+	}
+	// Integers above 2^53 are exact, unlike float64.
+	big := []int64{1 << 60}
+	if w := Add(big, int64(1)); w[0] != 1<<60+1 {
+		t.Errorf("expected %d, got %d", int64(1<<60+1), w[0])
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { Add(v, []int64{1}) }, fmt.Sprintf(errStrings[0], "Add()", 3, 1)},
+		{func() { Add(v, 10) }, fmt.Sprintf(errStrings[1], "Add()", 10)},
+		{func() { Add(v, []int32{1, 2, 3}) }, fmt.Sprintf(errStrings[1], "Add()", []int32{1, 2, 3})},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestReductions(t *testing.T) {
+	v := []int32{3, -1, 7, -1, 7}
+	if s := Sum(v); s != 15 {
+		t.Errorf("expected 15, got %d", s)
+	}
+	if s := Sum([]uint8{}); s != 0 {
+		t.Errorf("expected 0, got %d", s)
+	}
+	if x, i := Min(v); x != -1 || i != 1 {
+		t.Errorf("expected -1 at 1, got %d at %d", x, i)
+	}
+	if x, i := Max(v); x != 7 || i != 2 {
+		t.Errorf("expected 7 at 2, got %d at %d", x, i)
+	}
+	if c := CumSum(v); !slices.Equal(c, []int32{3, 2, 9, 8, 15}) {
+		t.Errorf("expected {3, 2, 9, 8, 15}, got %v", c)
+	}
+	if s := Sum([]int64{math.MaxInt64 - 1, 1}); s != math.MaxInt64 {
+		t.Errorf("expected %d, got %d", int64(math.MaxInt64), s)
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { Min([]int{}) }, fmt.Sprintf(errStrings[2], "Min()", "Min()")},
+		{func() { Max([]int{}) }, fmt.Sprintf(errStrings[2], "Max()", "Max()")},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestUnique(t *testing.T) {
+	v := []int64{3, 1, 3, 2}
+	u, n := Unique(v)
+	if !slices.Equal(u, []int64{1, 2, 3}) || !slices.Equal(n, []int{1, 1, 2}) {
+		t.Errorf("expected {1, 2, 3} and {1, 1, 2}, got %v and %v", u, n)
+	}
+	if !slices.Equal(v, []int64{3, 1, 3, 2}) {
+		t.Errorf("the original slice was modified")
+	}
+	if u, n := Unique([]uint{}); len(u) != 0 || len(n) != 0 {
+		t.Errorf("expected empty slices, got %v and %v", u, n)
+	}
+}
+
+func TestBincount(t *testing.T) {
+	if n := Bincount([]int{1, 3, 1}, 0); !slices.Equal(n, []int{0, 2, 0, 1}) {
+		t.Errorf("expected {0, 2, 0, 1}, got %v", n)
+	}
+	if n := Bincount([]uint16{1, 3, 1}, 6); !slices.Equal(n, []int{0, 2, 0, 1, 0, 0}) {
+		t.Errorf("expected {0, 2, 0, 1, 0, 0}, got %v", n)
+	}
+	if n := Bincount([]int{}, 0); len(n) != 0 {
+		t.Errorf("expected an empty []int, got %v", n)
+	}
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { Bincount([]int64{1, -2}, 0) }, fmt.Sprintf(errStrings[3], "Bincount()", 1, int64(-2))},
+		{func() { Bincount([]int{1}, -1) }, fmt.Sprintf(errStrings[4], "Bincount()", -1)},
+		{func() { Bincount([]uint64{1, math.MaxUint64}, 0) }, fmt.Sprintf(errStrings[5], "Bincount()", 1, uint64(math.MaxUint64))},
+		{func() { Bincount([]int64{math.MaxInt64}, 0) }, fmt.Sprintf(errStrings[5], "Bincount()", 0, int64(math.MaxInt64))},
+	}
+	var wg sync.WaitGroup
+	for _, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				r := recover()
+				if r != test.expected {
+					t.Errorf("Expected %s, got %v", test.expected, r)
+				}
+				wg.Done()
+			}()
+			test.f()
+		}()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Package window generates the standard windows used to taper a signal ahead of
+spectral analysis, as []float64s of a given length.
+
+Each window w of length n is symmetric, such that w[i] == w[n-1-i], and peaks
+at its center. A window is applied to a signal by multiplying the two
+elementwise, for example with vec.Mul():
+
+	w := window.Hann(len(signal))
+	tapered := vec.Mul(signal, w)
+
+Tapering reduces the amplitude of the signal, and hence of the peaks in its
+spectrum, by the coherent gain of the window, which is the average value of
+its elements. Use window.Normalize() to scale a window to unit coherent gain
+so that the amplitudes of the peaks are preserved.
+
+As with the other packages in gocrunch, invalid input is treated as
+a critical error, and causes a panic with a message that names the
+offending function.
+*/
+package window
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	errStrings = []string{
+		"\ngocrunch/window error.\nIn window.%s, the length must be greater than 0, received %d.\n",
+		"\ngocrunch/window error.\nIn window.%s, the window has a coherent gain of 0.0, and cannot be normalized.\n",
+	}
+)
+
+/*
+Hann returns a Hann window of length n, whose elements are
+
+	w[i] = 0.5 - 0.5*cos(2*pi*i/(n-1))
+
+The first and last elements of the window are 0.0. n must be greater than 0,
+otherwise this function will panic.
+*/
+func Hann(n int) []float64 {
+	return cosineSum("Hann()", n, 0.5, 0.5, 0.0)
+}
+
+/*
+Hamming returns a Hamming window of length n, whose elements are
+
+	w[i] = 0.54 - 0.46*cos(2*pi*i/(n-1))
+
+n must be greater than 0, otherwise this function will panic.
+*/
+func Hamming(n int) []float64 {
+	return cosineSum("Hamming()", n, 0.54, 0.46, 0.0)
+}
+
+/*
+Blackman returns a Blackman window of length n, whose elements are
+
+	w[i] = 0.42 - 0.5*cos(2*pi*i/(n-1)) + 0.08*cos(4*pi*i/(n-1))
+
+n must be greater than 0, otherwise this function will panic.
+*/
+func Blackman(n int) []float64 {
+	w := cosineSum("Blackman()", n, 0.42, 0.5, 0.08)
+	// Remove the rounding errors around zero at the ends of the window,
+	// whose only element is 1.0 when n is 1.
+	if n > 1 {
+		w[0], w[n-1] = 0.0, 0.0
+	}
+	return w
+}
+
+/*
+Kaiser returns a Kaiser window of length n with the shape parameter beta,
+whose elements are
+
+	w[i] = I0(beta * sqrt(1 - (2*i/(n-1) - 1)^2)) / I0(beta)
+
+where I0 is the zeroth order modified Bessel function of the first kind. A
+beta of 0.0 gives a rectangular window, and larger values give narrower
+windows, trading a wider main lobe for lower side lobes. n must be greater
+than 0, otherwise this function will panic.
+*/
+func Kaiser(n int, beta float64) []float64 {
+	if n <= 0 {
+		panic(fmt.Sprintf(errStrings[0], "Kaiser()", n))
+	}
+	w := make([]float64, n)
+	if n == 1 {
+		w[0] = 1.0
+		return w
+	}
+	denom := besselI0(beta)
+	for i := range w {
+		r := 2.0*float64(i)/float64(n-1) - 1.0
+		w[i] = besselI0(beta*math.Sqrt(1.0-r*r)) / denom
+	}
+	return w
+}
+
+/*
+CoherentGain returns the coherent gain of the passed window, which is the
+average value of its elements. A rectangular window has a coherent gain of
+1.0, and the Hann window a coherent gain of about 0.5.
+*/
+func CoherentGain(w []float64) float64 {
+	sum := 0.0
+	for i := range w {
+		sum += w[i]
+	}
+	return sum / float64(len(w))
+}
+
+/*
+Normalize returns a copy of the passed window scaled to a coherent gain of
+1.0, such that tapering a signal with it preserves the amplitudes of the
+peaks in its spectrum. The coherent gain of the passed window cannot be 0.0,
+otherwise this function will panic. The passed window is not modified in
+this function.
+*/
+func Normalize(w []float64) []float64 {
+	g := CoherentGain(w)
+	if g == 0.0 || math.IsNaN(g) {
+		panic(fmt.Sprintf(errStrings[1], "Normalize()"))
+	}
+	c := make([]float64, len(w))
+	for i := range w {
+		c[i] = w[i] / g
+	}
+	return c
+}
+
+// cosineSum returns the window a0 - a1*cos(x) + a2*cos(2x) of length n, with
+// x going from 0 to 2*pi.
+func cosineSum(fn string, n int, a0, a1, a2 float64) []float64 {
+	if n <= 0 {
+		panic(fmt.Sprintf(errStrings[0], fn, n))
+	}
+	w := make([]float64, n)
+	if n == 1 {
+		w[0] = 1.0
+		return w
+	}
+	for i := range w {
+		x := 2.0 * math.Pi * float64(i) / float64(n-1)
+		w[i] = a0 - a1*math.Cos(x) + a2*math.Cos(2.0*x)
+	}
+	return w
+}
+
+// besselI0 returns the zeroth order modified Bessel function of the first
+// kind, computed from its power series.
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	q := x * x / 4.0
+	for k := 1; k < 500; k++ {
+		term *= q / float64(k*k)
+		sum += term
+		if term < sum*1e-17 {
+			break
+		}
+	}
+	return sum
+}
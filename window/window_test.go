@@ -0,0 +1,115 @@
+package window
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func checkSymmetric(t *testing.T, name string, w []float64) {
+	for i := range w {
+		if math.Abs(w[i]-w[len(w)-1-i]) > 1e-12 {
+			t.Errorf("%s is not symmetric at index %d: %f and %f", name, i, w[i], w[len(w)-1-i])
+		}
+	}
+}
+
+func TestHann(t *testing.T) {
+	w := Hann(5)
+	expected := []float64{0.0, 0.5, 1.0, 0.5, 0.0}
+	for i := range w {
+		if math.Abs(w[i]-expected[i]) > 1e-12 {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], w[i])
+		}
+	}
+	checkSymmetric(t, "Hann", Hann(64))
+	if w = Hann(1); len(w) != 1 || w[0] != 1.0 {
+		t.Errorf("expected {1.0} for a window of length 1, got %v", w)
+	}
+}
+
+func TestHamming(t *testing.T) {
+	w := Hamming(5)
+	if math.Abs(w[0]-0.08) > 1e-12 || math.Abs(w[2]-1.0) > 1e-12 {
+		t.Errorf("expected the ends at 0.08 and the center at 1.0, got %v", w)
+	}
+	checkSymmetric(t, "Hamming", Hamming(33))
+	if w = Hamming(1); len(w) != 1 || w[0] != 1.0 {
+		t.Errorf("expected {1.0} for a window of length 1, got %v", w)
+	}
+}
+
+func TestBlackman(t *testing.T) {
+	w := Blackman(5)
+	expected := []float64{0.0, 0.34, 1.0, 0.34, 0.0}
+	for i := range w {
+		if math.Abs(w[i]-expected[i]) > 1e-12 {
+			t.Errorf("at index %d, expected %f, got %f", i, expected[i], w[i])
+		}
+	}
+	checkSymmetric(t, "Blackman", Blackman(50))
+	if w = Blackman(1); len(w) != 1 || w[0] != 1.0 {
+		t.Errorf("expected {1.0} for a window of length 1, got %v", w)
+	}
+}
+
+func TestKaiser(t *testing.T) {
+	w := Kaiser(9, 0.0)
+	for i := range w {
+		if math.Abs(w[i]-1.0) > 1e-12 {
+			t.Errorf("expected a rectangular window for beta 0.0, got %v", w)
+			break
+		}
+	}
+	w = Kaiser(9, 8.6)
+	checkSymmetric(t, "Kaiser", w)
+	if math.Abs(w[4]-1.0) > 1e-12 {
+		t.Errorf("expected the center to be 1.0, got %f", w[4])
+	}
+	// The ends of a Kaiser window are 1/I0(beta).
+	if math.Abs(w[0]-1.0/besselI0(8.6)) > 1e-15 {
+		t.Errorf("expected the ends to be %e, got %e", 1.0/besselI0(8.6), w[0])
+	}
+	if math.Abs(besselI0(1.0)-1.2660658777520082) > 1e-15 {
+		t.Errorf("I0(1) is wrong: %f", besselI0(1.0))
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	w := Hann(1001)
+	if g := CoherentGain(w); math.Abs(g-0.5) > 1e-3 {
+		t.Errorf("expected a coherent gain of about 0.5, got %f", g)
+	}
+	n := Normalize(w)
+	if g := CoherentGain(n); math.Abs(g-1.0) > 1e-12 {
+		t.Errorf("expected a coherent gain of 1.0, got %f", g)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[1], "Normalize()")
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Normalize([]float64{0.0, 0.0})
+	}()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer func() {
+			r := recover()
+			expectedErr := fmt.Sprintf(errStrings[0], "Hann()", 0)
+			if r != expectedErr {
+				t.Errorf("Expected %s, got %v", expectedErr, r)
+			}
+			wg.Done()
+		}()
+		Hann(0)
+	}()
+	wg.Wait()
+}